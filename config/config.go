@@ -12,6 +12,11 @@ type Config struct {
 	AppConfig   AppConfig   `mapstructure:"AppConfig"`
 	TokenConfig TokenConfig `mapstructure:"TokenConfig"`
 	Database    Postgres    `mapstructure:"Database"`
+	Workspace   Workspace   `mapstructure:"Workspace"`
+	Idempotency Idempotency `mapstructure:"Idempotency"`
+	Storage     Storage     `mapstructure:"Storage"`
+	Import      Import      `mapstructure:"Import"`
+	Calendar    Calendar    `mapstructure:"Calendar"`
 }
 
 type AppConfig struct {
@@ -33,6 +38,42 @@ type TokenConfig struct {
 	AccessTokenDuration time.Duration `mapstructure:"ACCESS_TOKEN_DURATION"`
 }
 
+type Workspace struct {
+	InvitationDuration time.Duration `mapstructure:"INVITATION_DURATION"`
+}
+
+type Idempotency struct {
+	KeyTTL      time.Duration `mapstructure:"KEY_TTL"`
+	LockTimeout time.Duration `mapstructure:"LOCK_TIMEOUT"`
+}
+
+// Storage selects where uploaded files are kept: "local" or "s3"
+type Storage struct {
+	Driver       string        `mapstructure:"DRIVER"`
+	LocalDir     string        `mapstructure:"LOCAL_DIR"`
+	S3Endpoint   string        `mapstructure:"S3_ENDPOINT"`
+	S3Region     string        `mapstructure:"S3_REGION"`
+	S3Bucket     string        `mapstructure:"S3_BUCKET"`
+	S3AccessKey  string        `mapstructure:"S3_ACCESS_KEY"`
+	S3SecretKey  string        `mapstructure:"S3_SECRET_KEY"`
+	S3PathStyle  bool          `mapstructure:"S3_PATH_STYLE"`
+	UserQuota    int64         `mapstructure:"USER_QUOTA"`
+	MaxImageSize int64         `mapstructure:"MAX_IMAGE_SIZE"`
+	SignedURLTTL time.Duration `mapstructure:"SIGNED_URL_TTL"`
+	AllowedTypes []string      `mapstructure:"ALLOWED_TYPES"`
+}
+
+// Import limits the files accepted by task import
+type Import struct {
+	MaxFileSize int64 `mapstructure:"MAX_FILE_SIZE"`
+	MaxRows     int   `mapstructure:"MAX_ROWS"`
+}
+
+// Calendar sets how often calendar clients are asked to poll a feed
+type Calendar struct {
+	RefreshInterval time.Duration `mapstructure:"REFRESH_INTERVAL"`
+}
+
 func LoadConfig() (*Config, error) {
 
 	env := os.Getenv("ENV")
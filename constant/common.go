@@ -2,13 +2,190 @@ package constants
 
 type TaskStatus string
 
+type TaskHistoryAction string
+
+type TaskPermission string
+
+type WorkspaceRole string
+
+type NotificationType string
+
+type RevisionResourceType string
+
+type ViewGroupBy string
+
+type TaskBulkAction string
+
+type ImportFormat string
+
+type ImportJobStatus string
+
+type ExportFormat string
+
+type ExportImages string
+
+type CalendarComponent string
+
+type contextKey string
+
+const (
+	TaskStatusPending        TaskStatus = "IN_PROGRESS"
+	TaskStatusCompleted      TaskStatus = "COMPLETED"
+	Alphabet                            = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	AuthorizationHeaderKey              = "authorization"
+	AuthorizationTypeBearer             = "bearer"
+	AuthorizationPayloadKey             = "authorization_payload"
+	DefaultTimeZone                     = "Asia/Bangkok"
+	TimezoneHeaderKey                   = "X-Timezone"
+	TimezoneQueryKey                    = "tz"
+	TestAppEnv                          = "test"
+	WorkspaceHeaderKey                  = "X-Workspace-ID"
+	AssigneeFilterMe                    = "me"
+	AssigneeFilterUnassigned            = "unassigned"
+)
+
+// Request-scoped values set by middleware
+const (
+	WorkspaceIDKey   contextKey = "workspace_id"
+	WorkspaceRoleKey contextKey = "workspace_role"
+	TimeLocationKey  contextKey = "time_location"
+)
+
+const (
+	TaskHistoryActionCreate TaskHistoryAction = "CREATE"
+	TaskHistoryActionUpdate TaskHistoryAction = "UPDATE"
+	TaskHistoryActionDelete TaskHistoryAction = "DELETE"
+	TaskHistoryActionRevert TaskHistoryAction = "REVERT"
+)
+
+const (
+	NotificationTypeTaskAssigned   NotificationType = "TASK_ASSIGNED"
+	NotificationTypeTaskUnassigned NotificationType = "TASK_UNASSIGNED"
+)
+
+const (
+	RevisionResourceNote RevisionResourceType = "note"
+	RevisionResourceTask RevisionResourceType = "task"
+)
+
+const (
+	ViewGroupByNone     ViewGroupBy = "none"
+	ViewGroupByStatus   ViewGroupBy = "status"
+	ViewGroupByAssignee ViewGroupBy = "assignee"
+	ViewGroupByDue      ViewGroupBy = "due"
+)
+
+const (
+	TaskBulkActionSetStatus TaskBulkAction = "set_status"
+	TaskBulkActionAddTag    TaskBulkAction = "add_tag"
+	TaskBulkActionRemoveTag TaskBulkAction = "remove_tag"
+	TaskBulkActionArchive   TaskBulkAction = "archive"
+	TaskBulkActionUnarchive TaskBulkAction = "unarchive"
+	TaskBulkActionDelete    TaskBulkAction = "delete"
+)
+
+// Outcome of one task in a bulk operation
+const (
+	TaskBulkResultUpdated   = "updated"
+	TaskBulkResultDeleted   = "deleted"
+	TaskBulkResultUnchanged = "unchanged"
+	TaskBulkResultFailed    = "failed"
+)
+
+// MaxBulkTasks caps the tasks one bulk operation may touch
+const MaxBulkTasks = 500
+
+const (
+	ImportFormatCSV     ImportFormat = "csv"
+	ImportFormatJSON    ImportFormat = "json"
+	ImportFormatTodoist ImportFormat = "todoist"
+	ImportFormatTrello  ImportFormat = "trello"
+)
+
+const (
+	ImportJobStatusPending   ImportJobStatus = "PENDING"
+	ImportJobStatusRunning   ImportJobStatus = "RUNNING"
+	ImportJobStatusCompleted ImportJobStatus = "COMPLETED"
+	ImportJobStatusFailed    ImportJobStatus = "FAILED"
+)
+
+// ImportBatchSize is how many imported tasks are written per transaction
+const ImportBatchSize = 100
+
+const (
+	ExportFormatCSV      ExportFormat = "csv"
+	ExportFormatJSON     ExportFormat = "json"
+	ExportFormatMarkdown ExportFormat = "md"
+	ExportFormatICal     ExportFormat = "ics"
+)
+
+// How an export carries task images
 const (
-	TaskStatusPending       TaskStatus = "IN_PROGRESS"
-	TaskStatusCompleted     TaskStatus = "COMPLETED"
-	Alphabet                           = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ"
-	AuthorizationHeaderKey             = "authorization"
-	AuthorizationTypeBearer            = "bearer"
-	AuthorizationPayloadKey            = "authorization_payload"
-	CurrentTimeLocation                = "Asia/Bangkok"
-	TestAppEnv                         = "test"
+	ExportImagesNone  ExportImages = "none"
+	ExportImagesLink  ExportImages = "link"
+	ExportImagesEmbed ExportImages = "embed"
 )
+
+// ExportBatchSize is how many tasks an export reads per query
+const ExportBatchSize = 500
+
+// What a task becomes in a calendar feed
+const (
+	CalendarComponentEvent CalendarComponent = "event"
+	CalendarComponentTodo  CalendarComponent = "todo"
+)
+
+const (
+	TaskPermissionOwner  TaskPermission = "owner"
+	TaskPermissionEditor TaskPermission = "editor"
+	TaskPermissionViewer TaskPermission = "viewer"
+)
+
+// Allows reports whether p grants at least the required permission
+func (p TaskPermission) Allows(required TaskPermission) bool {
+	return p.level() >= required.level()
+}
+
+func (p TaskPermission) level() int {
+	switch p {
+	case TaskPermissionOwner:
+		return 3
+	case TaskPermissionEditor:
+		return 2
+	case TaskPermissionViewer:
+		return 1
+	default:
+		return 0
+	}
+}
+
+const (
+	WorkspaceRoleOwner  WorkspaceRole = "owner"
+	WorkspaceRoleAdmin  WorkspaceRole = "admin"
+	WorkspaceRoleMember WorkspaceRole = "member"
+)
+
+// Allows reports whether r grants at least the required role
+func (r WorkspaceRole) Allows(required WorkspaceRole) bool {
+	return r.level() >= required.level()
+}
+
+func (r WorkspaceRole) level() int {
+	switch r {
+	case WorkspaceRoleOwner:
+		return 3
+	case WorkspaceRoleAdmin:
+		return 2
+	case WorkspaceRoleMember:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// MIMEMergePatchJSON is the media type of a JSON Merge Patch (RFC 7396)
+const MIMEMergePatchJSON = "application/merge-patch+json"
+
+// ThumbnailSizes are the longest edges, in pixels, of the thumbnails made
+// for task images
+var ThumbnailSizes = []int{128, 512}
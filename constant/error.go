@@ -17,6 +17,7 @@ const (
 	CodeAuthHeaderMissingBearer ErrorType = 1006
 	CodeUserIdMismatch          ErrorType = 1007
 	CodeUnauthorized            ErrorType = 1008
+	CodeInsufficientPermission  ErrorType = 1009
 
 	// Input & validation
 	CodeInvalidRequestBody                ErrorType = 2001
@@ -30,16 +31,87 @@ const (
 	CodeOpenFileContext                   ErrorType = 2009
 	CodeUserIdDoesNotMatchWithYourAccount ErrorType = 2010
 	CodeOtherError                        ErrorType = 2012
+	CodeCannotShareWithSelf               ErrorType = 2013
+	CodeInvalidWorkspaceHeader            ErrorType = 2014
+	CodeAssigneeNoAccess                  ErrorType = 2015
+	CodeCannotDependOnSelf                ErrorType = 2016
+	CodeDependencyScopeMismatch           ErrorType = 2017
+	CodeInvalidMoveTarget                 ErrorType = 2018
+	CodeInvalidCursor                     ErrorType = 2019
+	CodeTooManyBulkTasks                  ErrorType = 2020
+	CodeTooManyTaskTags                   ErrorType = 2021
+	CodeUnsupportedMediaType              ErrorType = 2022
+	CodeInvalidIdempotencyKey             ErrorType = 2023
+	CodeContentLengthRequired             ErrorType = 2024
+	CodeRangeNotSatisfiable               ErrorType = 2025
+	CodeUnsupportedImageType              ErrorType = 2026
+	CodeInvalidImage                      ErrorType = 2027
+	CodeImageTooLarge                     ErrorType = 2028
+	CodeInvalidTimezone                   ErrorType = 2029
 
 	// Task Resource
-	CodeTaskNotFound      ErrorType = 3001
-	CodeTaskAlreadyExists ErrorType = 3002
+	CodeTaskNotFound                ErrorType = 3001
+	CodeTaskAlreadyExists           ErrorType = 3002
+	CodeTaskVersionNotFound         ErrorType = 3003
+	CodeTaskShareNotFound           ErrorType = 3004
+	CodeDependencyCycle             ErrorType = 3005
+	CodeTaskDependencyNotFound      ErrorType = 3006
+	CodeTaskDependencyAlreadyExists ErrorType = 3007
+	CodeTaskVersionMismatch         ErrorType = 3008
 
 	// User Resource
 	CodeUserNotFound      ErrorType = 4001
 	CodePasswordIncorrect ErrorType = 4002
 	CodeUserAlreadyExists ErrorType = 4003
 
+	// Comment Resource
+	CodeCommentNotFound  ErrorType = 6001
+	CodeNotCommentAuthor ErrorType = 6002
+
+	// Workspace Resource
+	CodeWorkspaceNotFound          ErrorType = 7001
+	CodeNotWorkspaceMember         ErrorType = 7002
+	CodeAlreadyWorkspaceMember     ErrorType = 7003
+	CodeCannotModifyWorkspaceOwner ErrorType = 7004
+	CodeInvitationNotFound         ErrorType = 7005
+	CodeInvitationExpired          ErrorType = 7006
+	CodeInvitationEmailMismatch    ErrorType = 7007
+	CodeWorkspaceTaskNotShareable  ErrorType = 7008
+
+	// Notification Resource
+	CodeNotificationNotFound ErrorType = 8001
+
+	// Note Resource
+	CodeNoteNotFound     ErrorType = 9001
+	CodeNotNoteAuthor    ErrorType = 9002
+	CodeRevisionNotFound ErrorType = 9003
+
+	// View Resource
+	CodeViewNotFound        ErrorType = 10001
+	CodeViewAlreadyExists   ErrorType = 10002
+	CodeBuiltInViewReadOnly ErrorType = 10003
+
+	// Idempotency
+	CodeIdempotencyKeyReused     ErrorType = 11001
+	CodeIdempotencyKeyInProgress ErrorType = 11002
+
+	// Attachment Resource
+	CodeAttachmentNotFound    ErrorType = 12001
+	CodeStorageQuotaExceeded  ErrorType = 12002
+	CodeNotAttachmentUploader ErrorType = 12003
+	CodeThumbnailNotAvailable ErrorType = 12004
+	CodeInvalidSignedURL      ErrorType = 12005
+	CodeSignedURLExpired      ErrorType = 12006
+
+	// Import Resource
+	CodeImportJobNotFound    ErrorType = 13001
+	CodeInvalidImportFile    ErrorType = 13002
+	CodeInvalidImportMapping ErrorType = 13003
+	CodeImportTooLarge       ErrorType = 13004
+
+	// Calendar Resource
+	CodeCalendarFeedNotFound ErrorType = 14001
+
 	// Internal
 	CodeInternalServerError       ErrorType = 5000
 	CodeServiceUnavailable        ErrorType = 5001
@@ -48,37 +120,109 @@ const (
 
 var (
 	// Token-related errors
-	ErrExpiredToken            = errors.New("token has expired")                                     // 1001
-	ErrInvalidToken            = errors.New("token is invalid")                                      // 1002
-	ErrFailedToVerifyToken     = errors.New("failed to verify token")                                // 1003
-	ErrAuthHeaderMissing       = errors.New("authorization header is not provided")                  // 1004
-	ErrAuthHeaderFormatInvalid = errors.New("invalid authorization header format")                   // 1005
-	ErrAuthHeaderMissingBearer = errors.New("authorization header must start with Bearer")           // 1006
-	ErrUserIdMismatch          = errors.New("user id of this task does not match with your account") // 1007
-	ErrUnauthorized            = errors.New("unauthorized: token payload is invalid")                // 1008
+	ErrExpiredToken            = errors.New("token has expired")                                             // 1001
+	ErrInvalidToken            = errors.New("token is invalid")                                              // 1002
+	ErrFailedToVerifyToken     = errors.New("failed to verify token")                                        // 1003
+	ErrAuthHeaderMissing       = errors.New("authorization header is not provided")                          // 1004
+	ErrAuthHeaderFormatInvalid = errors.New("invalid authorization header format")                           // 1005
+	ErrAuthHeaderMissingBearer = errors.New("authorization header must start with Bearer")                   // 1006
+	ErrUserIdMismatch          = errors.New("user id of this task does not match with your account")         // 1007
+	ErrUnauthorized            = errors.New("unauthorized: token payload is invalid")                        // 1008
+	ErrInsufficientPermission  = errors.New("you do not have permission to perform this action on the task") // 1009
 
 	// Input & validation
-	ErrInvalidRequestBody                = errors.New("invalid request body")                                  // 2001
-	ErrMissingRequiredFields             = errors.New("missing required fields")                               // 2002
-	ErrInvalidFieldFormat                = errors.New("invalid field format")                                  // 2003
-	ErrInvalidStatusTransition           = errors.New("invalid status transition")                             // 2004
-	ErrInvalidQueryRequestParam          = errors.New("invalid query request param")                           // 2005
-	ErrInvalidRequestParam               = errors.New("invalid request param")                                 // 2006
-	ErrHashPassword                      = errors.New("failed to hash password")                               // 2007
-	ErrConvertFileHeaderToBase64         = errors.New("failed to convert file header to base64")               // 2008
-	ErrOpenFileContext                   = errors.New("failed to open file context")                           // 2009
-	ErrUserIdDoesNotMatchWithYourAccount = errors.New("user id of this task does not match with your account") // 2010
-	ErrOtherError                        = errors.New("other error")                                           // 2012
+	ErrInvalidRequestBody                = errors.New("invalid request body")                                            // 2001
+	ErrMissingRequiredFields             = errors.New("missing required fields")                                         // 2002
+	ErrInvalidFieldFormat                = errors.New("invalid field format")                                            // 2003
+	ErrInvalidStatusTransition           = errors.New("invalid status transition")                                       // 2004
+	ErrInvalidQueryRequestParam          = errors.New("invalid query request param")                                     // 2005
+	ErrInvalidRequestParam               = errors.New("invalid request param")                                           // 2006
+	ErrHashPassword                      = errors.New("failed to hash password")                                         // 2007
+	ErrConvertFileHeaderToBase64         = errors.New("failed to convert file header to base64")                         // 2008
+	ErrOpenFileContext                   = errors.New("failed to open file context")                                     // 2009
+	ErrUserIdDoesNotMatchWithYourAccount = errors.New("user id of this task does not match with your account")           // 2010
+	ErrOtherError                        = errors.New("other error")                                                     // 2012
+	ErrCannotShareWithSelf               = errors.New("cannot share a task with yourself")                               // 2013
+	ErrInvalidWorkspaceHeader            = errors.New("invalid X-Workspace-ID header")                                   // 2014
+	ErrAssigneeNoAccess                  = errors.New("assignee does not have access to this task")                      // 2015
+	ErrCannotDependOnSelf                = errors.New("a task cannot depend on itself")                                  // 2016
+	ErrDependencyScopeMismatch           = errors.New("dependencies can only link tasks of the same owner or workspace") // 2017
+	ErrInvalidMoveTarget                 = errors.New("move target must be another task in the same column")             // 2018
+	ErrInvalidCursor                     = errors.New("cursor is invalid or does not match the query")                   // 2019
+	ErrTooManyBulkTasks                  = errors.New("too many tasks for one bulk operation")                           // 2020
+	ErrTooManyTaskTags                   = errors.New("a task can have at most 20 tags")                                 // 2021
+	ErrUnsupportedMediaType              = errors.New("unsupported content type")                                        // 2022
+	ErrInvalidIdempotencyKey             = errors.New("Idempotency-Key must be 1 to 255 characters")                     // 2023
+	ErrContentLengthRequired             = errors.New("content length is required")                                      // 2024
+	ErrRangeNotSatisfiable               = errors.New("requested range not satisfiable")                                 // 2025
+	ErrUnsupportedImageType              = errors.New("image must be a PNG, JPEG, WebP or GIF")                          // 2026
+	ErrInvalidImage                      = errors.New("image could not be decoded")                                      // 2027
+	ErrImageTooLarge                     = errors.New("image exceeds the maximum size")                                  // 2028
+	ErrInvalidTimezone                   = errors.New("timezone must be an IANA time zone name")                         // 2029
 
 	// Task Resource
-	ErrTaskNotFound      = errors.New("task not found")      // 3001
-	ErrTaskAlreadyExists = errors.New("task already exists") // 3002
+	ErrTaskNotFound                = errors.New("task not found")                     // 3001
+	ErrTaskAlreadyExists           = errors.New("task already exists")                // 3002
+	ErrTaskVersionNotFound         = errors.New("task version not found")             // 3003
+	ErrTaskShareNotFound           = errors.New("task share not found")               // 3004
+	ErrDependencyCycle             = errors.New("dependency would create a cycle")    // 3005
+	ErrTaskDependencyNotFound      = errors.New("task dependency not found")          // 3006
+	ErrTaskDependencyAlreadyExists = errors.New("task dependency already exists")     // 3007
+	ErrTaskVersionMismatch         = errors.New("task has changed since it was read") // 3008
 
 	// User Resource
 	ErrUserNotFound      = errors.New("user not found")        // 4001
 	ErrPasswordIncorrect = errors.New("password is incorrect") // 4002
 	ErrUserAlreadyExists = errors.New("user already exists")   // 4003
 
+	// Comment Resource
+	ErrCommentNotFound  = errors.New("comment not found")                     // 6001
+	ErrNotCommentAuthor = errors.New("only the author can edit this comment") // 6002
+
+	// Workspace Resource
+	ErrWorkspaceNotFound          = errors.New("workspace not found")                                     // 7001
+	ErrNotWorkspaceMember         = errors.New("you are not a member of this workspace")                  // 7002
+	ErrAlreadyWorkspaceMember     = errors.New("user is already a member of this workspace")              // 7003
+	ErrCannotModifyWorkspaceOwner = errors.New("the workspace owner cannot be changed or removed")        // 7004
+	ErrInvitationNotFound         = errors.New("invitation not found")                                    // 7005
+	ErrInvitationExpired          = errors.New("invitation has expired or was already used")              // 7006
+	ErrInvitationEmailMismatch    = errors.New("invitation was sent to a different email address")        // 7007
+	ErrWorkspaceTaskNotShareable  = errors.New("workspace tasks are shared through workspace membership") // 7008
+
+	// Notification Resource
+	ErrNotificationNotFound = errors.New("notification not found") // 8001
+
+	// Note Resource
+	ErrNoteNotFound     = errors.New("note not found")                                            // 9001
+	ErrNotNoteAuthor    = errors.New("only the author or a workspace admin can delete this note") // 9002
+	ErrRevisionNotFound = errors.New("revision not found")                                        // 9003
+
+	// View Resource
+	ErrViewNotFound        = errors.New("view not found")                       // 10001
+	ErrViewAlreadyExists   = errors.New("a view with this name already exists") // 10002
+	ErrBuiltInViewReadOnly = errors.New("built-in views cannot be changed")     // 10003
+
+	// Idempotency
+	ErrIdempotencyKeyReused     = errors.New("idempotency key was already used with a different request") // 11001
+	ErrIdempotencyKeyInProgress = errors.New("a request with this idempotency key is still in progress")  // 11002
+
+	// Attachment Resource
+	ErrAttachmentNotFound    = errors.New("attachment not found")                                           // 12001
+	ErrStorageQuotaExceeded  = errors.New("storage quota exceeded")                                         // 12002
+	ErrNotAttachmentUploader = errors.New("only the uploader or the task owner can delete this attachment") // 12003
+	ErrThumbnailNotAvailable = errors.New("attachment has no thumbnail")                                    // 12004
+	ErrInvalidSignedURL      = errors.New("download link is invalid")                                       // 12005
+	ErrSignedURLExpired      = errors.New("download link has expired")                                      // 12006
+
+	// Import Resource
+	ErrImportJobNotFound    = errors.New("import job not found")          // 13001
+	ErrInvalidImportFile    = errors.New("import file could not be read") // 13002
+	ErrInvalidImportMapping = errors.New("column mapping is invalid")     // 13003
+	ErrImportTooLarge       = errors.New("import file is too large")      // 13004
+
+	// Calendar Resource
+	ErrCalendarFeedNotFound = errors.New("calendar feed not found") // 14001
+
 	// Internal
 	ErrInternalServerError       = errors.New("internal server error")                   // 5001
 	ErrServiceUnavailable        = errors.New("service unavailable")                     // 5002
@@ -95,6 +239,7 @@ var ErrorMapWithCode = map[error]ErrorType{
 	ErrAuthHeaderMissingBearer:           CodeAuthHeaderMissingBearer,           // 1006
 	ErrUserIdDoesNotMatchWithYourAccount: CodeUserIdDoesNotMatchWithYourAccount, // 1007
 	ErrUnauthorized:                      CodeUnauthorized,                      // 1008
+	ErrInsufficientPermission:            CodeInsufficientPermission,            // 1009
 
 	// Input & validation
 	ErrInvalidRequestBody:                CodeInvalidRequestBody,                // 2001
@@ -108,16 +253,87 @@ var ErrorMapWithCode = map[error]ErrorType{
 	ErrOpenFileContext:                   CodeOpenFileContext,                   // 2009
 	ErrUserIdDoesNotMatchWithYourAccount: CodeUserIdDoesNotMatchWithYourAccount, // 2010
 	ErrOtherError:                        CodeOtherError,                        // 2012
+	ErrCannotShareWithSelf:               CodeCannotShareWithSelf,               // 2013
+	ErrInvalidWorkspaceHeader:            CodeInvalidWorkspaceHeader,            // 2014
+	ErrAssigneeNoAccess:                  CodeAssigneeNoAccess,                  // 2015
+	ErrCannotDependOnSelf:                CodeCannotDependOnSelf,                // 2016
+	ErrDependencyScopeMismatch:           CodeDependencyScopeMismatch,           // 2017
+	ErrInvalidMoveTarget:                 CodeInvalidMoveTarget,                 // 2018
+	ErrInvalidCursor:                     CodeInvalidCursor,                     // 2019
+	ErrTooManyBulkTasks:                  CodeTooManyBulkTasks,                  // 2020
+	ErrTooManyTaskTags:                   CodeTooManyTaskTags,                   // 2021
+	ErrUnsupportedMediaType:              CodeUnsupportedMediaType,              // 2022
+	ErrInvalidIdempotencyKey:             CodeInvalidIdempotencyKey,             // 2023
+	ErrContentLengthRequired:             CodeContentLengthRequired,             // 2024
+	ErrRangeNotSatisfiable:               CodeRangeNotSatisfiable,               // 2025
+	ErrUnsupportedImageType:              CodeUnsupportedImageType,              // 2026
+	ErrInvalidImage:                      CodeInvalidImage,                      // 2027
+	ErrImageTooLarge:                     CodeImageTooLarge,                     // 2028
+	ErrInvalidTimezone:                   CodeInvalidTimezone,                   // 2029
 
 	// Task Resource
-	ErrTaskNotFound:      CodeTaskNotFound,      // 3001
-	ErrTaskAlreadyExists: CodeTaskAlreadyExists, // 3002
+	ErrTaskNotFound:                CodeTaskNotFound,                // 3001
+	ErrTaskAlreadyExists:           CodeTaskAlreadyExists,           // 3002
+	ErrTaskVersionNotFound:         CodeTaskVersionNotFound,         // 3003
+	ErrTaskShareNotFound:           CodeTaskShareNotFound,           // 3004
+	ErrDependencyCycle:             CodeDependencyCycle,             // 3005
+	ErrTaskDependencyNotFound:      CodeTaskDependencyNotFound,      // 3006
+	ErrTaskDependencyAlreadyExists: CodeTaskDependencyAlreadyExists, // 3007
+	ErrTaskVersionMismatch:         CodeTaskVersionMismatch,         // 3008
 
 	// User Resource
 	ErrUserNotFound:      CodeUserNotFound,      // 4001
 	ErrPasswordIncorrect: CodePasswordIncorrect, // 4002
 	ErrUserAlreadyExists: CodeUserAlreadyExists, // 4003
 
+	// Comment Resource
+	ErrCommentNotFound:  CodeCommentNotFound,  // 6001
+	ErrNotCommentAuthor: CodeNotCommentAuthor, // 6002
+
+	// Workspace Resource
+	ErrWorkspaceNotFound:          CodeWorkspaceNotFound,          // 7001
+	ErrNotWorkspaceMember:         CodeNotWorkspaceMember,         // 7002
+	ErrAlreadyWorkspaceMember:     CodeAlreadyWorkspaceMember,     // 7003
+	ErrCannotModifyWorkspaceOwner: CodeCannotModifyWorkspaceOwner, // 7004
+	ErrInvitationNotFound:         CodeInvitationNotFound,         // 7005
+	ErrInvitationExpired:          CodeInvitationExpired,          // 7006
+	ErrInvitationEmailMismatch:    CodeInvitationEmailMismatch,    // 7007
+	ErrWorkspaceTaskNotShareable:  CodeWorkspaceTaskNotShareable,  // 7008
+
+	// Notification Resource
+	ErrNotificationNotFound: CodeNotificationNotFound, // 8001
+
+	// Note Resource
+	ErrNoteNotFound:     CodeNoteNotFound,     // 9001
+	ErrNotNoteAuthor:    CodeNotNoteAuthor,    // 9002
+	ErrRevisionNotFound: CodeRevisionNotFound, // 9003
+
+	// View Resource
+	ErrViewNotFound:        CodeViewNotFound,        // 10001
+	ErrViewAlreadyExists:   CodeViewAlreadyExists,   // 10002
+	ErrBuiltInViewReadOnly: CodeBuiltInViewReadOnly, // 10003
+
+	// Idempotency
+	ErrIdempotencyKeyReused:     CodeIdempotencyKeyReused,     // 11001
+	ErrIdempotencyKeyInProgress: CodeIdempotencyKeyInProgress, // 11002
+
+	// Attachment Resource
+	ErrAttachmentNotFound:    CodeAttachmentNotFound,    // 12001
+	ErrStorageQuotaExceeded:  CodeStorageQuotaExceeded,  // 12002
+	ErrNotAttachmentUploader: CodeNotAttachmentUploader, // 12003
+	ErrThumbnailNotAvailable: CodeThumbnailNotAvailable, // 12004
+	ErrInvalidSignedURL:      CodeInvalidSignedURL,      // 12005
+	ErrSignedURLExpired:      CodeSignedURLExpired,      // 12006
+
+	// Import Resource
+	ErrImportJobNotFound:    CodeImportJobNotFound,    // 13001
+	ErrInvalidImportFile:    CodeInvalidImportFile,    // 13002
+	ErrInvalidImportMapping: CodeInvalidImportMapping, // 13003
+	ErrImportTooLarge:       CodeImportTooLarge,       // 13004
+
+	// Calendar Resource
+	ErrCalendarFeedNotFound: CodeCalendarFeedNotFound, // 14001
+
 	// Internal
 	ErrInternalServerError:       CodeInternalServerError,       // 5001
 	ErrServiceUnavailable:        CodeServiceUnavailable,        // 5002
@@ -134,29 +350,101 @@ var ErrorMapWithStatusCode = map[error]int{
 	ErrAuthHeaderMissingBearer:           http.StatusUnauthorized, // 1006
 	ErrUserIdDoesNotMatchWithYourAccount: http.StatusUnauthorized, // 1007
 	ErrUnauthorized:                      http.StatusUnauthorized, // 1008
+	ErrInsufficientPermission:            http.StatusForbidden,    // 1009
 
 	// Input & validation
-	ErrInvalidRequestBody:                http.StatusBadRequest,   // 2001
-	ErrMissingRequiredFields:             http.StatusBadRequest,   // 2002
-	ErrInvalidFieldFormat:                http.StatusBadRequest,   // 2003
-	ErrInvalidStatusTransition:           http.StatusBadRequest,   // 2004
-	ErrInvalidQueryRequestParam:          http.StatusBadRequest,   // 2005
-	ErrInvalidRequestParam:               http.StatusBadRequest,   // 2006
-	ErrHashPassword:                      http.StatusBadRequest,   // 2007
-	ErrConvertFileHeaderToBase64:         http.StatusBadRequest,   // 2008
-	ErrOpenFileContext:                   http.StatusBadRequest,   // 2009
-	ErrUserIdDoesNotMatchWithYourAccount: http.StatusUnauthorized, // 2010
-	ErrOtherError:                        http.StatusBadRequest,   // 2012
+	ErrInvalidRequestBody:                http.StatusBadRequest,                   // 2001
+	ErrMissingRequiredFields:             http.StatusBadRequest,                   // 2002
+	ErrInvalidFieldFormat:                http.StatusBadRequest,                   // 2003
+	ErrInvalidStatusTransition:           http.StatusBadRequest,                   // 2004
+	ErrInvalidQueryRequestParam:          http.StatusBadRequest,                   // 2005
+	ErrInvalidRequestParam:               http.StatusBadRequest,                   // 2006
+	ErrHashPassword:                      http.StatusBadRequest,                   // 2007
+	ErrConvertFileHeaderToBase64:         http.StatusBadRequest,                   // 2008
+	ErrOpenFileContext:                   http.StatusBadRequest,                   // 2009
+	ErrUserIdDoesNotMatchWithYourAccount: http.StatusUnauthorized,                 // 2010
+	ErrOtherError:                        http.StatusBadRequest,                   // 2012
+	ErrCannotShareWithSelf:               http.StatusBadRequest,                   // 2013
+	ErrInvalidWorkspaceHeader:            http.StatusBadRequest,                   // 2014
+	ErrAssigneeNoAccess:                  http.StatusBadRequest,                   // 2015
+	ErrCannotDependOnSelf:                http.StatusBadRequest,                   // 2016
+	ErrDependencyScopeMismatch:           http.StatusBadRequest,                   // 2017
+	ErrInvalidMoveTarget:                 http.StatusBadRequest,                   // 2018
+	ErrInvalidCursor:                     http.StatusBadRequest,                   // 2019
+	ErrTooManyBulkTasks:                  http.StatusBadRequest,                   // 2020
+	ErrTooManyTaskTags:                   http.StatusBadRequest,                   // 2021
+	ErrUnsupportedMediaType:              http.StatusUnsupportedMediaType,         // 2022
+	ErrInvalidIdempotencyKey:             http.StatusBadRequest,                   // 2023
+	ErrContentLengthRequired:             http.StatusLengthRequired,               // 2024
+	ErrRangeNotSatisfiable:               http.StatusRequestedRangeNotSatisfiable, // 2025
+	ErrUnsupportedImageType:              http.StatusUnsupportedMediaType,         // 2026
+	ErrInvalidImage:                      http.StatusBadRequest,                   // 2027
+	ErrImageTooLarge:                     http.StatusRequestEntityTooLarge,        // 2028
+	ErrInvalidTimezone:                   http.StatusBadRequest,                   // 2029
 
 	// Task Resource
-	ErrTaskNotFound:      http.StatusNotFound, // 3001
-	ErrTaskAlreadyExists: http.StatusConflict, // 3002
+	ErrTaskNotFound:                http.StatusNotFound,           // 3001
+	ErrTaskAlreadyExists:           http.StatusConflict,           // 3002
+	ErrTaskVersionNotFound:         http.StatusNotFound,           // 3003
+	ErrTaskShareNotFound:           http.StatusNotFound,           // 3004
+	ErrDependencyCycle:             http.StatusConflict,           // 3005
+	ErrTaskDependencyNotFound:      http.StatusNotFound,           // 3006
+	ErrTaskDependencyAlreadyExists: http.StatusConflict,           // 3007
+	ErrTaskVersionMismatch:         http.StatusPreconditionFailed, // 3008
 
 	// User Resource
 	ErrUserNotFound:      http.StatusNotFound,     // 4001
 	ErrPasswordIncorrect: http.StatusUnauthorized, // 4002
 	ErrUserAlreadyExists: http.StatusConflict,     // 4003
 
+	// Comment Resource
+	ErrCommentNotFound:  http.StatusNotFound,  // 6001
+	ErrNotCommentAuthor: http.StatusForbidden, // 6002
+
+	// Workspace Resource
+	ErrWorkspaceNotFound:          http.StatusNotFound,   // 7001
+	ErrNotWorkspaceMember:         http.StatusForbidden,  // 7002
+	ErrAlreadyWorkspaceMember:     http.StatusConflict,   // 7003
+	ErrCannotModifyWorkspaceOwner: http.StatusBadRequest, // 7004
+	ErrInvitationNotFound:         http.StatusNotFound,   // 7005
+	ErrInvitationExpired:          http.StatusGone,       // 7006
+	ErrInvitationEmailMismatch:    http.StatusForbidden,  // 7007
+	ErrWorkspaceTaskNotShareable:  http.StatusBadRequest, // 7008
+
+	// Notification Resource
+	ErrNotificationNotFound: http.StatusNotFound, // 8001
+
+	// Note Resource
+	ErrNoteNotFound:     http.StatusNotFound,  // 9001
+	ErrNotNoteAuthor:    http.StatusForbidden, // 9002
+	ErrRevisionNotFound: http.StatusNotFound,  // 9003
+
+	// View Resource
+	ErrViewNotFound:        http.StatusNotFound,  // 10001
+	ErrViewAlreadyExists:   http.StatusConflict,  // 10002
+	ErrBuiltInViewReadOnly: http.StatusForbidden, // 10003
+
+	// Idempotency
+	ErrIdempotencyKeyReused:     http.StatusUnprocessableEntity, // 11001
+	ErrIdempotencyKeyInProgress: http.StatusConflict,            // 11002
+
+	// Attachment Resource
+	ErrAttachmentNotFound:    http.StatusNotFound,              // 12001
+	ErrStorageQuotaExceeded:  http.StatusRequestEntityTooLarge, // 12002
+	ErrNotAttachmentUploader: http.StatusForbidden,             // 12003
+	ErrThumbnailNotAvailable: http.StatusNotFound,              // 12004
+	ErrInvalidSignedURL:      http.StatusForbidden,             // 12005
+	ErrSignedURLExpired:      http.StatusForbidden,             // 12006
+
+	// Import Resource
+	ErrImportJobNotFound:    http.StatusNotFound,              // 13001
+	ErrInvalidImportFile:    http.StatusBadRequest,            // 13002
+	ErrInvalidImportMapping: http.StatusBadRequest,            // 13003
+	ErrImportTooLarge:       http.StatusRequestEntityTooLarge, // 13004
+
+	// Calendar Resource
+	ErrCalendarFeedNotFound: http.StatusNotFound, // 14001
+
 	// Internal
 	ErrInternalServerError:       http.StatusInternalServerError, // 5001
 	ErrServiceUnavailable:        http.StatusServiceUnavailable,  // 5002
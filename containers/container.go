@@ -1,8 +1,12 @@
 package containers
 
 import (
+	"context"
+
 	"github.com/guncv/tech-exam-software-engineering/config"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
 	"github.com/guncv/tech-exam-software-engineering/infras/server"
+	"github.com/guncv/tech-exam-software-engineering/services"
 	"go.uber.org/dig"
 )
 
@@ -23,6 +27,36 @@ func (c *Container) Configure() {
 }
 
 func (c *Container) Run() *Container {
+	// Move task images still stored in the database to the blob store
+	if err := c.Container.Invoke(func(attachmentService services.IAttachmentService, log *log.Logger) {
+		ctx := context.Background()
+		moved, err := attachmentService.MoveLegacyImages(ctx)
+		if err != nil {
+			log.ErrorWithID(ctx, "[Container: Run] Failed to move legacy task images", err)
+			return
+		}
+		if moved > 0 {
+			log.InfoWithID(ctx, "[Container: Run] Moved legacy task images to the blob store", moved)
+		}
+	}); err != nil {
+		panic(err)
+	}
+
+	// Fail the imports that were running when the server last stopped
+	if err := c.Container.Invoke(func(importService services.IImportService, log *log.Logger) {
+		ctx := context.Background()
+		failed, err := importService.FailInterruptedImports(ctx)
+		if err != nil {
+			log.ErrorWithID(ctx, "[Container: Run] Failed to fail interrupted imports", err)
+			return
+		}
+		if failed > 0 {
+			log.InfoWithID(ctx, "[Container: Run] Failed interrupted imports", failed)
+		}
+	}); err != nil {
+		panic(err)
+	}
+
 	if err := c.Container.Invoke(func(s *server.GinServer) {
 		if err := s.Start(); err != nil {
 			panic(err)
@@ -10,4 +10,52 @@ func (c *Container) ControllerProvider() {
 	if err := c.Container.Provide(controllers.NewUserController); err != nil {
 		c.Error = err
 	}
+
+	if err := c.Container.Provide(controllers.NewCommentController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewTaskShareController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewWorkspaceController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewNotificationController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewTaskDependencyController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewNoteController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewRevisionController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewViewController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewAttachmentController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewImportController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewExportController); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(controllers.NewCalendarController); err != nil {
+		c.Error = err
+	}
 }
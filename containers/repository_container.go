@@ -14,6 +14,54 @@ func (c *Container) RepositoryProvider() {
 		c.Error = err
 	}
 
+	if err := c.Container.Provide(repositories.NewCommentRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewTaskShareRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewWorkspaceRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewNotificationRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewTaskDependencyRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewNoteRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewRevisionRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewViewRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewIdempotencyRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewAttachmentRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewImportJobRepository); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(repositories.NewCalendarFeedRepository); err != nil {
+		c.Error = err
+	}
+
 	if err := c.Container.Provide(utils.NewPayloadConstruct); err != nil {
 		c.Error = err
 	}
@@ -14,7 +14,67 @@ func (c *Container) ServiceProvider() {
 		c.Error = err
 	}
 
+	if err := c.Container.Provide(services.NewCommentService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewTaskShareService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewWorkspaceService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewNotificationService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewTaskDependencyService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewNoteService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewRevisionService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewViewService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewAttachmentService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewImportService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewExportService); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(services.NewCalendarService); err != nil {
+		c.Error = err
+	}
+
 	if err := c.Container.Provide(utils.NewPasetoMaker); err != nil {
 		c.Error = err
 	}
+
+	if err := c.Container.Provide(utils.NewCursorCodec); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(utils.NewBlobStore); err != nil {
+		c.Error = err
+	}
+
+	if err := c.Container.Provide(utils.NewURLSigner); err != nil {
+		c.Error = err
+	}
 }
@@ -0,0 +1,384 @@
+package controllers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type AttachmentController struct {
+	service services.IAttachmentService
+	log     *log.Logger
+}
+
+func NewAttachmentController(service services.IAttachmentService, log *log.Logger) *AttachmentController {
+	return &AttachmentController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Attachments
+// @Summary Upload Attachment
+// @Description Upload a file to a task. The request body is the file itself, streamed to storage, so Content-Length is required. Counts towards the uploader's storage quota.
+// @Accept octet-stream
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param filename query string true "File name"
+// @Param file body string true "File contents"
+// @Security BearerAuth
+// @Success 201 {object} entities.AttachmentResponse "Attachment uploaded successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 411 {object} entities.ErrorResponse "Content-Length missing"
+// @Failure 413 {object} entities.ErrorResponse "Storage quota exceeded"
+// @Failure 415 {object} entities.ErrorResponse "File type not allowed"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/attachments [post]
+func (h *AttachmentController) UploadAttachment(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: UploadAttachment] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	var req entities.UploadAttachmentRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateUploadAttachmentInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: UploadAttachment]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	// The body is streamed to storage, so its size must be known up front
+	if c.Request.ContentLength < 0 {
+		h.log.ErrorWithID(ctx, "[Controller: UploadAttachment]: Missing Content-Length", constants.ErrContentLengthRequired)
+		utils.ErrorResponse(c, constants.ErrContentLengthRequired)
+		return
+	}
+	req.Body, req.Size = c.Request.Body, c.Request.ContentLength
+
+	response, err := h.service.UploadAttachment(ctx, taskId, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: UploadAttachment]: Failed to upload attachment", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: UploadAttachment]: Attachment uploaded successfully")
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Tags Attachments
+// @Summary Get Attachments
+// @Description List the attachments of a task, oldest first
+// @Produce json
+// @Param id path string true "Task ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetAttachmentsResponse "Attachments retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid path params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/attachments [get]
+func (h *AttachmentController) GetAttachments(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetAttachments] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	response, err := h.service.GetAttachments(ctx, taskId)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetAttachments]: Failed to get attachments", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetAttachments]: Attachments retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Attachments
+// @Summary Get Attachment Content
+// @Description Download the file of a task attachment. Supports a single byte range with Range and If-Range. Images are shown inline, other files are downloaded.
+// @Produce octet-stream
+// @Param id path string true "Task ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Param If-Range header string false "ETag the range applies to"
+// @Security BearerAuth
+// @Success 200 {file} file "Attachment content"
+// @Success 206 {file} file "Requested part of the attachment"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid path params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleAttachmentNotFound "Task or attachment not found"
+// @Failure 416 {object} entities.ErrorResponse "Range not satisfiable"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/attachments/{attachment_id}/content [get]
+func (h *AttachmentController) GetAttachmentContent(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetAttachmentContent] Called")
+
+	// Get task id and attachment id from path
+	taskId := c.Param("id")
+	id := c.Param("attachment_id")
+	if taskId == "" || id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	req := entities.GetAttachmentContentRequest{
+		Range:   c.GetHeader("Range"),
+		IfRange: c.GetHeader("If-Range"),
+	}
+	content, err := h.service.GetAttachmentContent(ctx, taskId, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetAttachmentContent]: Failed to get attachment", err)
+		if errors.Is(err, constants.ErrRangeNotSatisfiable) && content != nil {
+			c.Header("Content-Range", content.ContentRange)
+		}
+		utils.ErrorResponse(c, err)
+		return
+	}
+	defer content.Body.Close()
+
+	h.log.InfoWithID(ctx, "[Controller: GetAttachmentContent]: Attachment retrieved successfully")
+	writeAttachmentContent(c, content)
+}
+
+// @Tags Attachments
+// @Summary Get Attachment Thumbnail
+// @Description Download a thumbnail of an image attachment. Sizes are the longest edge in pixels: 128 or 512. JPEG images have JPEG thumbnails, other images PNG ones.
+// @Produce png,jpeg
+// @Param id path string true "Task ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Param size path int true "Thumbnail size" Enums(128, 512)
+// @Security BearerAuth
+// @Success 200 {file} file "Thumbnail"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid path params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleAttachmentNotFound "Task or attachment not found, or not an image"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/attachments/{attachment_id}/thumbnails/{size} [get]
+func (h *AttachmentController) GetAttachmentThumbnail(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetAttachmentThumbnail] Called")
+
+	// Get task id, attachment id and size from path
+	taskId := c.Param("id")
+	id := c.Param("attachment_id")
+	size, err := strconv.Atoi(c.Param("size"))
+	if taskId == "" || id == "" || err != nil {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	content, err := h.service.GetAttachmentThumbnail(ctx, taskId, id, size)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetAttachmentThumbnail]: Failed to get thumbnail", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+	defer content.Body.Close()
+
+	h.log.InfoWithID(ctx, "[Controller: GetAttachmentThumbnail]: Thumbnail retrieved successfully")
+	writeThumbnail(c, content)
+}
+
+// @Tags Attachments
+// @Summary Delete Attachment
+// @Description Delete an attachment and its file; the uploader or the task owner may delete
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Security BearerAuth
+// @Success 200 {object} nil "Attachment deleted successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid path params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not the uploader or task owner"
+// @Failure 404 {object} entities.ErrExampleAttachmentNotFound "Task or attachment not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/attachments/{attachment_id} [delete]
+func (h *AttachmentController) DeleteAttachment(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: DeleteAttachment] Called")
+
+	// Get task id and attachment id from path
+	taskId := c.Param("id")
+	id := c.Param("attachment_id")
+	if taskId == "" || id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	if err := h.service.DeleteAttachment(ctx, taskId, id); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: DeleteAttachment]: Failed to delete attachment", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: DeleteAttachment]: Attachment deleted successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+// @Tags Attachments
+// @Summary Get Attachment Content With Signed Link
+// @Description Download the file of a task attachment with a signed link from an attachment or task response, e.g. in an <img> tag. No access token is needed; the link expires. Supports Range like the authenticated download.
+// @Produce octet-stream
+// @Param id path string true "Task ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Param uid query string true "User the link was made for"
+// @Param perm query string true "Permission granted"
+// @Param exp query int true "Expiry, in Unix seconds"
+// @Param sig query string true "Signature"
+// @Param Range header string false "Byte range, e.g. bytes=0-1023"
+// @Param If-Range header string false "ETag the range applies to"
+// @Success 200 {file} file "Attachment content"
+// @Success 206 {file} file "Requested part of the attachment"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid path params"
+// @Failure 403 {object} entities.ErrorResponse "Link invalid or expired"
+// @Failure 404 {object} entities.ErrExampleAttachmentNotFound "Attachment not found"
+// @Failure 416 {object} entities.ErrorResponse "Range not satisfiable"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/files/tasks/{id}/attachments/{attachment_id}/content [get]
+func (h *AttachmentController) GetSignedAttachmentContent(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetSignedAttachmentContent] Called")
+
+	// Get task id and attachment id from path
+	taskId := c.Param("id")
+	id := c.Param("attachment_id")
+	if taskId == "" || id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// A link without its grant is not a valid link
+	var grant entities.SignedURLRequest
+	if err := c.ShouldBindQuery(&grant); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetSignedAttachmentContent]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidSignedURL)
+		return
+	}
+
+	req := entities.GetAttachmentContentRequest{
+		Range:   c.GetHeader("Range"),
+		IfRange: c.GetHeader("If-Range"),
+	}
+	content, err := h.service.GetSignedAttachmentContent(ctx, taskId, id, &grant, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetSignedAttachmentContent]: Failed to get attachment", err)
+		if errors.Is(err, constants.ErrRangeNotSatisfiable) && content != nil {
+			c.Header("Content-Range", content.ContentRange)
+		}
+		utils.ErrorResponse(c, err)
+		return
+	}
+	defer content.Body.Close()
+
+	h.log.InfoWithID(ctx, "[Controller: GetSignedAttachmentContent]: Attachment retrieved successfully")
+	writeAttachmentContent(c, content)
+}
+
+// @Tags Attachments
+// @Summary Get Attachment Thumbnail With Signed Link
+// @Description Download a thumbnail of an image attachment with a signed link from an attachment response. No access token is needed; the link expires.
+// @Produce png,jpeg
+// @Param id path string true "Task ID"
+// @Param attachment_id path string true "Attachment ID"
+// @Param size path int true "Thumbnail size" Enums(128, 512)
+// @Param uid query string true "User the link was made for"
+// @Param perm query string true "Permission granted"
+// @Param exp query int true "Expiry, in Unix seconds"
+// @Param sig query string true "Signature"
+// @Success 200 {file} file "Thumbnail"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid path params"
+// @Failure 403 {object} entities.ErrorResponse "Link invalid or expired"
+// @Failure 404 {object} entities.ErrExampleAttachmentNotFound "Attachment not found, or not an image"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/files/tasks/{id}/attachments/{attachment_id}/thumbnails/{size} [get]
+func (h *AttachmentController) GetSignedAttachmentThumbnail(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetSignedAttachmentThumbnail] Called")
+
+	// Get task id, attachment id and size from path
+	taskId := c.Param("id")
+	id := c.Param("attachment_id")
+	size, err := strconv.Atoi(c.Param("size"))
+	if taskId == "" || id == "" || err != nil {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// A link without its grant is not a valid link
+	var grant entities.SignedURLRequest
+	if err := c.ShouldBindQuery(&grant); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetSignedAttachmentThumbnail]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidSignedURL)
+		return
+	}
+
+	content, err := h.service.GetSignedAttachmentThumbnail(ctx, taskId, id, size, &grant)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetSignedAttachmentThumbnail]: Failed to get thumbnail", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+	defer content.Body.Close()
+
+	h.log.InfoWithID(ctx, "[Controller: GetSignedAttachmentThumbnail]: Thumbnail retrieved successfully")
+	writeThumbnail(c, content)
+}
+
+// writeAttachmentContent streams an attachment's file, or part of it, with
+// headers for downloading it
+func writeAttachmentContent(c *gin.Context, content *entities.AttachmentContent) {
+	// Only images are shown in the browser; anything else is a download
+	disposition := "attachment"
+	if strings.HasPrefix(content.ContentType, "image/") {
+		disposition = "inline"
+	}
+	headers := map[string]string{
+		"Accept-Ranges":          "bytes",
+		"Content-Disposition":    mime.FormatMediaType(disposition, map[string]string{"filename": content.Filename}),
+		"ETag":                   content.ETag,
+		"X-Content-Type-Options": "nosniff",
+	}
+	status := http.StatusOK
+	if content.ContentRange != "" {
+		status = http.StatusPartialContent
+		headers["Content-Range"] = content.ContentRange
+	}
+
+	c.DataFromReader(status, content.Length, content.ContentType, content.Body, headers)
+}
+
+// writeThumbnail streams a thumbnail to be shown in the browser
+func writeThumbnail(c *gin.Context, content *entities.AttachmentContent) {
+	headers := map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("inline", map[string]string{"filename": content.Filename}),
+		"ETag":                   content.ETag,
+		"X-Content-Type-Options": "nosniff",
+	}
+
+	c.DataFromReader(http.StatusOK, content.Length, content.ContentType, content.Body, headers)
+}
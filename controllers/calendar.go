@@ -0,0 +1,154 @@
+package controllers
+
+import (
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type CalendarController struct {
+	service services.ICalendarService
+	log     *log.Logger
+}
+
+func NewCalendarController(service services.ICalendarService, log *log.Logger) *CalendarController {
+	return &CalendarController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Calendar
+// @Summary Create Calendar Feed
+// @Description Generate the secret URL of the caller's calendar feed, to subscribe to in a calendar app. The URL is shown only once. Calling this again replaces the secret, and the old URL stops working.
+// @Produce json
+// @Security BearerAuth
+// @Success 201 {object} entities.CalendarFeedResponse "Calendar feed created successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/calendar/feed [post]
+func (h *CalendarController) CreateCalendarFeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: CreateCalendarFeed] Called")
+
+	response, err := h.service.CreateCalendarFeed(ctx)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: CreateCalendarFeed]: Failed to create calendar feed", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: CreateCalendarFeed]: Calendar feed created successfully")
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Tags Calendar
+// @Summary Get Calendar Feed
+// @Description Check whether the caller has a calendar feed, and since when. The URL is not returned again.
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entities.CalendarFeedResponse "Calendar feed retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Calendar feed not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/calendar/feed [get]
+func (h *CalendarController) GetCalendarFeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetCalendarFeed] Called")
+
+	response, err := h.service.GetCalendarFeed(ctx)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetCalendarFeed]: Failed to get calendar feed", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetCalendarFeed]: Calendar feed retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Calendar
+// @Summary Delete Calendar Feed
+// @Description Turn the caller's calendar feed off; its URL stops working
+// @Security BearerAuth
+// @Success 200 {object} nil "Calendar feed deleted successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Calendar feed not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/calendar/feed [delete]
+func (h *CalendarController) DeleteCalendarFeed(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: DeleteCalendarFeed] Called")
+
+	if err := h.service.DeleteCalendarFeed(ctx); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: DeleteCalendarFeed]: Failed to delete calendar feed", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: DeleteCalendarFeed]: Calendar feed deleted successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Calendar feed deleted successfully"})
+}
+
+// @Tags Calendar
+// @Summary Get Calendar Feed Content
+// @Description The caller's personal tasks that are not archived, as an iCalendar feed for calendar apps to subscribe to. The secret token in the path stands in for an access token. Tasks are events on their date by default, or to-dos due then with type=todo; tasks dated at midnight are all-day. The response carries an ETag and a Cache-Control max-age matching the refresh interval it asks clients to poll at.
+// @Produce text/calendar
+// @Param token path string true "Feed token, followed by .ics"
+// @Param type query string false "Entry type: event (default) or todo"
+// @Param If-None-Match header string false "ETag of a cached copy"
+// @Success 200 {file} file "Calendar"
+// @Success 304 {object} nil "Calendar not modified"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 404 {object} entities.ErrorResponse "Calendar feed not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/calendar/feeds/{token} [get]
+func (h *CalendarController) GetCalendarFeedContent(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetCalendarFeedContent] Called")
+
+	// Get token from path; the .ics suffix is for calendar apps
+	token := strings.TrimSuffix(c.Param("token"), ".ics")
+	if token == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	var req entities.GetCalendarFeedContentRequest
+	// Bind query
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateGetCalendarFeedContentInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: GetCalendarFeedContent]: Failed to bind query", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	content, err := h.service.GetCalendarFeedContent(ctx, token, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetCalendarFeedContent]: Failed to get calendar feed", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	// Clients poll on the refresh interval; an unchanged calendar is
+	// answered without a body
+	c.Header("ETag", content.ETag)
+	c.Header("Cache-Control", "private, max-age="+strconv.Itoa(int(content.MaxAge.Seconds())))
+	if utils.IfNoneMatch(c.GetHeader("If-None-Match"), content.ETag) {
+		h.log.InfoWithID(ctx, "[Controller: GetCalendarFeedContent]: Calendar feed not modified")
+		c.Status(http.StatusNotModified)
+		return
+	}
+
+	c.Header("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": "tasks.ics"}))
+	h.log.InfoWithID(ctx, "[Controller: GetCalendarFeedContent]: Calendar feed retrieved successfully")
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", content.Body)
+}
@@ -0,0 +1,200 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type CommentController struct {
+	service services.ICommentService
+	log     *log.Logger
+}
+
+func NewCommentController(service services.ICommentService, log *log.Logger) *CommentController {
+	return &CommentController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Comments
+// @Summary Create Comment
+// @Description Add a comment to a task
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param createCommentRequest body entities.CreateCommentRequest true "Create comment request"
+// @Security BearerAuth
+// @Success 200 {object} entities.CommentResponse "Comment created successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/comments [post]
+func (h *CommentController) CreateComment(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: CreateComment] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.CreateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateCommentInput(req.Body)
+		h.log.ErrorWithID(ctx, "[Controller: CreateComment]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Create comment
+	response, err := h.service.CreateComment(ctx, taskId, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: CreateComment]: Failed to create comment", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: CreateComment]: Comment created successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Comments
+// @Summary Get Comments
+// @Description List the comments of a task, oldest first
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param limit query int true "Number of items per page"
+// @Param offset query int true "Page number (starting at 1)"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetCommentsResponse "Comments retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/comments [get]
+func (h *CommentController) GetComments(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetComments] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	var req entities.GetCommentsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateGetCommentsInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: GetComments]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	response, err := h.service.GetComments(ctx, taskId, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetComments]: Failed to get comments", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetComments]: Comments retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Comments
+// @Summary Update Comment
+// @Description Edit a comment; only its author may edit and the comment is flagged as edited
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param comment_id path string true "Comment ID"
+// @Param updateCommentRequest body entities.UpdateCommentRequest true "Update comment request"
+// @Security BearerAuth
+// @Success 200 {object} entities.CommentResponse "Comment updated successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not the comment author"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task or comment not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/comments/{comment_id} [put]
+func (h *CommentController) UpdateComment(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: UpdateComment] Called")
+
+	// Get task id and comment id from path
+	taskId := c.Param("id")
+	id := c.Param("comment_id")
+	if taskId == "" || id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.UpdateCommentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateCommentInput(req.Body)
+		h.log.ErrorWithID(ctx, "[Controller: UpdateComment]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Update comment
+	response, err := h.service.UpdateComment(ctx, taskId, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: UpdateComment]: Failed to update comment", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: UpdateComment]: Comment updated successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Comments
+// @Summary Delete Comment
+// @Description Delete a comment; allowed for its author and the task owner
+// @Accept json
+// @Param id path string true "Task ID"
+// @Param comment_id path string true "Comment ID"
+// @Security BearerAuth
+// @Success 200 {object} nil "Comment deleted successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to delete this comment"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task or comment not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/comments/{comment_id} [delete]
+func (h *CommentController) DeleteComment(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: DeleteComment] Called")
+
+	// Get task id and comment id from path
+	taskId := c.Param("id")
+	id := c.Param("comment_id")
+	if taskId == "" || id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Delete comment
+	if err := h.service.DeleteComment(ctx, taskId, id); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: DeleteComment]: Failed to delete comment", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: DeleteComment]: Comment deleted successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
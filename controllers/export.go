@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"mime"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type ExportController struct {
+	service services.IExportService
+	log     *log.Logger
+}
+
+func NewExportController(service services.IExportService, log *log.Logger) *ExportController {
+	return &ExportController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Exports
+// @Summary Export Tasks
+// @Description Download every task the listing query matches, with the same filters as Get All Tasks, as CSV, JSON, Markdown or iCalendar. The export is streamed as it is read. Images are left out, linked with signed links that expire, or embedded in a ZIP bundle next to the document.
+// @Produce text/csv
+// @Produce json
+// @Produce text/markdown
+// @Produce text/calendar
+// @Produce application/zip
+// @Param format query string true "Export format: csv, json, md or ics"
+// @Param images query string false "Task images: none (default), link or embed"
+// @Param search query string false "Search by title or description"
+// @Param filter query string false "Filter expression, as for Get All Tasks"
+// @Param sort_by query string false "Sort by (title, created_at, status, rank or date)"
+// @Param order query string false "Order (asc or desc)"
+// @Param assignee query string false "Assignee (me, unassigned or a user id)"
+// @Param archived query bool false "Include archived tasks"
+// @Security BearerAuth
+// @Success 200 {file} file "Export document or ZIP bundle"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/export [get]
+func (h *ExportController) ExportTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: ExportTasks] Called")
+
+	var req entities.ExportTasksRequest
+	// Bind query
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateExportTasksInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: ExportTasks]: Failed to bind query", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	export, err := h.service.ExportTasks(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: ExportTasks]: Failed to export tasks", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Type", export.ContentType)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": export.Filename}))
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.Status(http.StatusOK)
+
+	// The status is sent with the first write, so a failure after it can
+	// only cut the download short
+	if err := export.Write(c.Writer); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: ExportTasks]: Export interrupted", err)
+		c.Abort()
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: ExportTasks]: Tasks exported successfully")
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type ImportController struct {
+	service services.IImportService
+	log     *log.Logger
+}
+
+func NewImportController(service services.IImportService, log *log.Logger) *ImportController {
+	return &ImportController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Imports
+// @Summary Import Tasks
+// @Description Import tasks from a CSV file, our own JSON format, a Todoist CSV export or a Trello JSON export. Every row is validated as a created task; rows that fail are skipped and reported by row number. A dry run only returns the report. Otherwise the valid rows are imported in the background and the response is the job to poll.
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "Import file"
+// @Param format formData string true "File format: csv, json, todoist or trello"
+// @Param mapping formData string false "CSV only: JSON object from task field (title, description, status, date, tags) to column name"
+// @Param dry_run formData bool false "Validate the file without importing it"
+// @Security BearerAuth
+// @Success 200 {object} entities.ImportJobResponse "Dry run report"
+// @Success 202 {object} entities.ImportJobResponse "Import job started"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body, file or mapping"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 413 {object} entities.ErrorResponse "File too large or too many rows"
+// @Failure 415 {object} entities.ErrorResponse "Unsupported content type"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/imports [post]
+func (h *ImportController) ImportTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: ImportTasks] Called")
+
+	if c.ContentType() != binding.MIMEMultipartPOSTForm {
+		h.log.ErrorWithID(ctx, "[Controller: ImportTasks]: Unsupported content type", constants.ErrUnsupportedMediaType)
+		utils.ErrorResponse(c, constants.ErrUnsupportedMediaType)
+		return
+	}
+
+	var req entities.ImportTasksRequest
+	// Bind request
+	if err := c.ShouldBind(&req); err != nil {
+		detail := utils.ValidateImportTasksInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: ImportTasks]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	response, err := h.service.ImportTasks(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: ImportTasks]: Failed to import tasks", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	if response.DryRun {
+		h.log.InfoWithID(ctx, "[Controller: ImportTasks]: Import file validated")
+		c.JSON(http.StatusOK, response)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: ImportTasks]: Import job started")
+	c.JSON(http.StatusAccepted, response)
+}
+
+// @Tags Imports
+// @Summary Get Import Job
+// @Description Get the progress of an import job: how many rows were imported so far, and why the skipped rows were skipped
+// @Produce json
+// @Param job_id path string true "Import job ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.ImportJobResponse "Import job retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid path params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Import job not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/imports/{job_id} [get]
+func (h *ImportController) GetImportJob(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetImportJob] Called")
+
+	// Get job id from path
+	jobId := c.Param("job_id")
+	if jobId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	response, err := h.service.GetImportJob(ctx, jobId)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetImportJob]: Failed to get import job", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetImportJob]: Import job retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,226 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type NoteController struct {
+	service services.INoteService
+	log     *log.Logger
+}
+
+func NewNoteController(service services.INoteService, log *log.Logger) *NoteController {
+	return &NoteController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Notes
+// @Summary Create Note
+// @Description Create a note with a Markdown body, optionally attached to a task. [[Title]] links in the body are recorded as backlinks.
+// @Accept json
+// @Produce json
+// @Param createNoteRequest body entities.CreateNoteRequest true "Create note request"
+// @Security BearerAuth
+// @Success 200 {object} entities.NoteResponse "Note created successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes [post]
+func (h *NoteController) CreateNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: CreateNote] Called")
+
+	// Bind request
+	var req entities.CreateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateCreateNoteInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: CreateNote]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Create note
+	response, err := h.service.CreateNote(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: CreateNote]: Failed to create note", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: CreateNote]: Note created successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Notes
+// @Summary Get Note
+// @Description Get a note with its backlinks; format=html adds the body rendered to sanitized HTML
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Param format query string false "markdown (default) or html"
+// @Security BearerAuth
+// @Success 200 {object} entities.NoteResponse "Note retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Note not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes/{id} [get]
+func (h *NoteController) GetNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetNote] Called")
+
+	// Get note id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	var req entities.GetNoteRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateGetNoteInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: GetNote]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	// Get note
+	response, err := h.service.GetNote(ctx, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetNote]: Failed to get note", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetNote]: Note retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Notes
+// @Summary Update Note
+// @Description Update a note; only sent fields change and an empty task_id detaches it from its task
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Param updateNoteRequest body entities.UpdateNoteRequest true "Update note request"
+// @Security BearerAuth
+// @Success 200 {object} entities.NoteResponse "Note updated successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Note or task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes/{id} [put]
+func (h *NoteController) UpdateNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: UpdateNote] Called")
+
+	// Get note id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.UpdateNoteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateUpdateNoteInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: UpdateNote]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Update note
+	response, err := h.service.UpdateNote(ctx, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: UpdateNote]: Failed to update note", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: UpdateNote]: Note updated successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Notes
+// @Summary Delete Note
+// @Description Delete a note; in a workspace only its author or an admin may delete it
+// @Accept json
+// @Param id path string true "Note ID"
+// @Security BearerAuth
+// @Success 200 {object} nil "Note deleted successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to delete this note"
+// @Failure 404 {object} entities.ErrorResponse "Note not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes/{id} [delete]
+func (h *NoteController) DeleteNote(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: DeleteNote] Called")
+
+	// Get note id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Delete note
+	if err := h.service.DeleteNote(ctx, id); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: DeleteNote]: Failed to delete note", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: DeleteNote]: Note deleted successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Note deleted successfully"})
+}
+
+// @Tags Notes
+// @Summary Get Notes
+// @Description List notes, pinned first then most recently edited, with optional search and filters
+// @Accept json
+// @Produce json
+// @Param search query string false "Search by title or body"
+// @Param pinned query bool false "Only pinned or unpinned notes"
+// @Param task_id query string false "Only notes attached to this task"
+// @Param limit query int true "Number of items per page"
+// @Param offset query int true "Page number"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetNotesResponse "Notes retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes [get]
+func (h *NoteController) GetNotes(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetNotes] Called")
+
+	var req entities.GetNotesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateGetNotesInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: GetNotes]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	response, err := h.service.GetNotes(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetNotes]: Failed to get notes", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetNotes]: Notes retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
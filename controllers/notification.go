@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type NotificationController struct {
+	service services.INotificationService
+	log     *log.Logger
+}
+
+func NewNotificationController(service services.INotificationService, log *log.Logger) *NotificationController {
+	return &NotificationController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Notifications
+// @Summary Get Notifications
+// @Description List the caller's notifications, newest first
+// @Accept json
+// @Produce json
+// @Param unread_only query bool false "Only unread notifications"
+// @Param limit query int true "Number of items per page"
+// @Param offset query int true "Page number (starting at 1)"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetNotificationsResponse "Notifications retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notifications [get]
+func (h *NotificationController) GetNotifications(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetNotifications] Called")
+
+	var req entities.GetNotificationsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateGetNotificationsInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: GetNotifications]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	response, err := h.service.GetNotifications(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetNotifications]: Failed to get notifications", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetNotifications]: Notifications retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Notifications
+// @Summary Mark Notification Read
+// @Description Mark one of the caller's notifications as read
+// @Accept json
+// @Param id path string true "Notification ID"
+// @Security BearerAuth
+// @Success 200 {object} nil "Notification marked read successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Notification not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notifications/{id}/read [post]
+func (h *NotificationController) MarkRead(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: MarkRead] Called")
+
+	// Get notification id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	if err := h.service.MarkRead(ctx, id); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: MarkRead]: Failed to mark notification read", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: MarkRead]: Notification marked read successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked read successfully"})
+}
+
+// @Tags Notifications
+// @Summary Mark All Notifications Read
+// @Description Mark every unread notification of the caller as read
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entities.MarkAllNotificationsReadResponse "Notifications marked read successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notifications/read-all [post]
+func (h *NotificationController) MarkAllRead(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: MarkAllRead] Called")
+
+	response, err := h.service.MarkAllRead(ctx)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: MarkAllRead]: Failed to mark notifications read", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: MarkAllRead]: Notifications marked read successfully")
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,210 @@
+package controllers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type RevisionController struct {
+	service services.IRevisionService
+	log     *log.Logger
+}
+
+func NewRevisionController(service services.IRevisionService, log *log.Logger) *RevisionController {
+	return &RevisionController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Revisions
+// @Summary Get Note Revisions
+// @Description List the saved revisions of a note, newest version first
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetRevisionsResponse "Revisions retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Note not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes/{id}/revisions [get]
+func (h *RevisionController) GetNoteRevisions(c *gin.Context) {
+	h.getRevisions(c, constants.RevisionResourceNote)
+}
+
+// @Tags Revisions
+// @Summary Diff Note Revisions
+// @Description Unified diff of a note body between two versions
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Param from query int true "Base version"
+// @Param to query int true "Target version"
+// @Security BearerAuth
+// @Success 200 {object} entities.DiffRevisionsResponse "Revisions diffed successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Note or revision not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes/{id}/revisions/diff [get]
+func (h *RevisionController) DiffNoteRevisions(c *gin.Context) {
+	h.diffRevisions(c, constants.RevisionResourceNote)
+}
+
+// @Tags Revisions
+// @Summary Restore Note Revision
+// @Description Restore a note's title and body from a previous version, saved as a new revision
+// @Accept json
+// @Produce json
+// @Param id path string true "Note ID"
+// @Param version path int true "Version to restore"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetRevisionsResponse "Revision restored successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Note or revision not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/notes/{id}/revisions/{version}/restore [post]
+func (h *RevisionController) RestoreNoteRevision(c *gin.Context) {
+	h.restoreRevision(c, constants.RevisionResourceNote)
+}
+
+// @Tags Revisions
+// @Summary Get Task Description Revisions
+// @Description List the saved revisions of a task description, newest version first
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetRevisionsResponse "Revisions retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/revisions [get]
+func (h *RevisionController) GetTaskRevisions(c *gin.Context) {
+	h.getRevisions(c, constants.RevisionResourceTask)
+}
+
+// @Tags Revisions
+// @Summary Diff Task Description Revisions
+// @Description Unified diff of a task description between two versions
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param from query int true "Base version"
+// @Param to query int true "Target version"
+// @Security BearerAuth
+// @Success 200 {object} entities.DiffRevisionsResponse "Revisions diffed successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "Task or revision not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/revisions/diff [get]
+func (h *RevisionController) DiffTaskRevisions(c *gin.Context) {
+	h.diffRevisions(c, constants.RevisionResourceTask)
+}
+
+// @Tags Revisions
+// @Summary Restore Task Description Revision
+// @Description Restore a task description from a previous version; recorded in the task history as a revert
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param version path int true "Version to restore"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetRevisionsResponse "Revision restored successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to edit this task"
+// @Failure 404 {object} entities.ErrorResponse "Task or revision not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/revisions/{version}/restore [post]
+func (h *RevisionController) RestoreTaskRevision(c *gin.Context) {
+	h.restoreRevision(c, constants.RevisionResourceTask)
+}
+
+func (h *RevisionController) getRevisions(c *gin.Context, resourceType constants.RevisionResourceType) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetRevisions] Called")
+
+	// Get resource id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Get revisions
+	response, err := h.service.GetRevisions(ctx, resourceType, id)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetRevisions]: Failed to get revisions", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetRevisions]: Revisions retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *RevisionController) diffRevisions(c *gin.Context, resourceType constants.RevisionResourceType) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: DiffRevisions] Called")
+
+	// Get resource id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	var req entities.DiffRevisionsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateDiffRevisionsInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: DiffRevisions]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	// Diff revisions
+	response, err := h.service.DiffRevisions(ctx, resourceType, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: DiffRevisions]: Failed to diff revisions", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: DiffRevisions]: Revisions diffed successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+func (h *RevisionController) restoreRevision(c *gin.Context, resourceType constants.RevisionResourceType) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: RestoreRevision] Called")
+
+	// Get resource id and version from path
+	id := c.Param("id")
+	version, err := strconv.Atoi(c.Param("version"))
+	if id == "" || err != nil || version < 1 {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Restore revision
+	response, err := h.service.RestoreRevision(ctx, resourceType, id, version)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: RestoreRevision]: Failed to restore revision", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: RestoreRevision]: Revision restored successfully")
+	c.JSON(http.StatusOK, response)
+}
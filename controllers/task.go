@@ -1,9 +1,13 @@
 package controllers
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 	constants "github.com/guncv/tech-exam-software-engineering/constant"
 	"github.com/guncv/tech-exam-software-engineering/entities"
 	"github.com/guncv/tech-exam-software-engineering/infras/log"
@@ -46,19 +50,26 @@ func (h *TaskController) HealthCheck(c *gin.Context) {
 
 // @Tags Tasks
 // @Summary Create Task
-// @Description Create a new task
-// @Accept multipart/form-data
+// @Description Create a new task. A retry sent with the same Idempotency-Key gets the first response back instead of creating another task.
+// @Accept multipart/form-data,application/x-www-form-urlencoded,json
 // @Param title formData string true "Title"
 // @Param description formData string false "Description"
 // @Param status formData string true "Status"
 // @Param date formData string true "Date (RFC3339 format)"
-// @Param image formData file false "Optional base64 image upload"
+// @Param image formData file false "Optional image: PNG, JPEG, WebP or GIF"
+// @Param image_id formData string false "Image attachment the caller uploaded, copied instead of an image file"
+// @Param tags formData []string false "Tags, repeated for each tag" collectionFormat(multi)
+// @Param Idempotency-Key header string false "Client-chosen key that makes retries safe, up to 255 characters"
 // @Security BearerAuth
 // @Success 200 {object} entities.CreateTaskResponse
 // @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
 // @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
 // @Failure 409 {object} entities.ErrExampleTaskAlreadyExists "Task already exists"
 // @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 409 {object} entities.ErrorResponse "A request with this idempotency key is still in progress"
+// @Failure 413 {object} entities.ErrorResponse "Image or storage quota too large"
+// @Failure 415 {object} entities.ErrorResponse "Unsupported content or image type"
+// @Failure 422 {object} entities.ErrorResponse "Idempotency key was used with a different request"
 // @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
 // @Router /api/v1/tasks [post]
 func (h *TaskController) CreateTask(c *gin.Context) {
@@ -67,13 +78,25 @@ func (h *TaskController) CreateTask(c *gin.Context) {
 
 	var req entities.CreateTaskRequest
 	// Bind request
-	if err := c.ShouldBind(&req); err != nil {
+	if err := bindTaskBody(c, &req); errors.Is(err, constants.ErrUnsupportedMediaType) {
+		h.log.ErrorWithID(ctx, "[Controller: CreateTask]: Unsupported content type", err)
+		utils.ErrorResponse(c, err)
+		return
+	} else if err != nil {
 		detail := utils.ValidateCreateTaskInput(req)
 		h.log.ErrorWithID(ctx, "[Controller: CreateTask]: Failed to bind request", err)
 		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
 		return
 	}
 
+	// Get idempotency key
+	req.IdempotencyKey = c.GetHeader("Idempotency-Key")
+	if len(req.IdempotencyKey) > 255 || (req.IdempotencyKey == "" && len(c.Request.Header.Values("Idempotency-Key")) > 0) {
+		h.log.ErrorWithID(ctx, "[Controller: CreateTask]: Invalid idempotency key", req.IdempotencyKey)
+		utils.ErrorResponse(c, constants.ErrInvalidIdempotencyKey)
+		return
+	}
+
 	// Create task
 	response, err := h.service.CreateTask(ctx, &req)
 	if err != nil {
@@ -88,11 +111,13 @@ func (h *TaskController) CreateTask(c *gin.Context) {
 
 // @Tags Tasks
 // @Summary Get Task
-// @Description Get a task by ID
+// @Description Get a task by ID. The response carries an ETag of its content; send it back in If-None-Match to get 304 while the response is unchanged. Updates take the task's version in If-Match instead.
 // @Accept json
 // @Param id path string true "Task ID"
+// @Param If-None-Match header string false "ETag of a cached copy"
 // @Security BearerAuth
 // @Success 200 {object} entities.GetTaskResponse
+// @Success 304 {object} nil "Task not modified"
 // @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
 // @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
 // @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
@@ -116,25 +141,50 @@ func (h *TaskController) GetTask(c *gin.Context) {
 		return
 	}
 
+	// The response changes with more than the task's version: its counts,
+	// its signed image link and dates in the caller's zone. It is tagged by
+	// its content, so a conditional read of an unchanged response gets no
+	// body.
+	body, err := json.Marshal(response)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetTask]: Failed to encode task", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+	etag := utils.ContentETag(body)
+	c.Header("ETag", etag)
+	c.Header("Vary", "Authorization, X-Workspace-ID, X-Timezone")
+	if utils.IfNoneMatch(c.GetHeader("If-None-Match"), etag) {
+		h.log.InfoWithID(ctx, "[Controller: GetTask]: Task not modified")
+		c.Status(http.StatusNotModified)
+		return
+	}
+
 	h.log.InfoWithID(ctx, "[Controller: GetTask]: Task retrieved successfully")
-	c.JSON(http.StatusOK, response)
+	c.Data(http.StatusOK, binding.MIMEJSON+"; charset=utf-8", body)
 }
 
 // @Tags Tasks
 // @Summary Update Task
-// @Description Update a task by ID
-// @Accept multipart/form-data
+// @Description Update a task by ID. Send the ETag from GetTask in If-Match to fail with 412 instead of overwriting a newer version.
+// @Accept multipart/form-data,application/x-www-form-urlencoded,json
 // @Param id path string true "Task ID"
 // @Param title formData string false "Title"
 // @Param description formData string false "Description"
 // @Param status formData string false "Status"
-// @Param image formData file false "Image"
+// @Param image formData file false "Image: PNG, JPEG, WebP or GIF"
+// @Param image_id formData string false "Image attachment of the task to use instead of an image file; empty removes the image"
+// @Param tags formData []string false "Tags, replacing the current ones; one empty value clears them" collectionFormat(multi)
+// @Param If-Match header string false "ETag the update is based on"
 // @Security BearerAuth
 // @Success 200 {object} entities.UpdateTaskResponse "Task updated successfully"
 // @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
 // @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
 // @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
 // @Failure 409 {object} entities.ErrExampleTaskAlreadyExists "Task already exists"
+// @Failure 412 {object} entities.ErrorResponse "Task has changed since it was read"
+// @Failure 413 {object} entities.ErrorResponse "Image or storage quota too large"
+// @Failure 415 {object} entities.ErrorResponse "Unsupported content or image type"
 // @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
 // @Router /api/v1/tasks/{id} [put]
 func (h *TaskController) UpdateTask(c *gin.Context) {
@@ -150,7 +200,11 @@ func (h *TaskController) UpdateTask(c *gin.Context) {
 
 	// Bind request
 	var req entities.UpdateTaskRequest
-	if err := c.ShouldBind(&req); err != nil {
+	if err := bindTaskBody(c, &req); errors.Is(err, constants.ErrUnsupportedMediaType) {
+		h.log.ErrorWithID(ctx, "[Controller: UpdateTask]: Unsupported content type", err)
+		utils.ErrorResponse(c, err)
+		return
+	} else if err != nil {
 		detail := utils.ValidateUpdateTaskInput(req)
 		h.log.ErrorWithID(ctx, "[Controller: UpdateTask]: Failed to bind request", err)
 		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
@@ -158,6 +212,7 @@ func (h *TaskController) UpdateTask(c *gin.Context) {
 	}
 
 	// Update task
+	req.IfMatch = c.GetHeader("If-Match")
 	response, err := h.service.UpdateTask(ctx, id, &req)
 	if err != nil {
 		h.log.ErrorWithID(ctx, "[Controller: UpdateTask]: Failed to update task", err)
@@ -166,6 +221,68 @@ func (h *TaskController) UpdateTask(c *gin.Context) {
 	}
 
 	h.log.InfoWithID(ctx, "[Controller: UpdateTask]: Task updated successfully")
+	c.Header("ETag", utils.VersionETag(response.Version))
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Tasks
+// @Summary Patch Task
+// @Description Partially update a task with a JSON Merge Patch (RFC 7396). Members left out are unchanged and members set to null are cleared; title, status and date cannot be cleared, and image can only be cleared. image_id picks one of the task's image attachments as its image.
+// @Accept application/merge-patch+json,json
+// @Param id path string true "Task ID"
+// @Param request body entities.PatchTaskRequest true "Patch Task Request"
+// @Param If-Match header string false "ETag the patch is based on"
+// @Security BearerAuth
+// @Success 200 {object} entities.UpdateTaskResponse "Task updated successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 412 {object} entities.ErrorResponse "Task has changed since it was read"
+// @Failure 415 {object} entities.ErrorResponse "Unsupported content or image type"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id} [patch]
+func (h *TaskController) PatchTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: PatchTask] Called")
+
+	// Get task id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Only JSON bodies can carry null members
+	if ct := c.ContentType(); ct != constants.MIMEMergePatchJSON && ct != binding.MIMEJSON {
+		h.log.ErrorWithID(ctx, "[Controller: PatchTask]: Unsupported content type", ct)
+		utils.ErrorResponse(c, constants.ErrUnsupportedMediaType)
+		return
+	}
+
+	// Bind request
+	var req entities.PatchTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: PatchTask]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody)
+		return
+	}
+	if detail := utils.ValidatePatchTaskInput(req); detail != nil {
+		h.log.ErrorWithID(ctx, "[Controller: PatchTask]: Invalid request", detail)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Patch task
+	req.IfMatch = c.GetHeader("If-Match")
+	response, err := h.service.PatchTask(ctx, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: PatchTask]: Failed to patch task", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: PatchTask]: Task patched successfully")
+	c.Header("ETag", utils.VersionETag(response.Version))
 	c.JSON(http.StatusOK, response)
 }
 
@@ -204,16 +321,21 @@ func (h *TaskController) DeleteTask(c *gin.Context) {
 
 // @Tags Tasks
 // @Summary Get All Tasks
-// @Description Get all tasks with optional search, sort, and pagination
+// @Description Get all tasks with optional full-text search, sort, and pagination; search results include search_rank and highlight. Pages link to each other through next_cursor and prev_cursor, also sent in a Link header.
 // @Accept json
 // @Produce json
-// @Param search query string false "Search by title or description"
-// @Param sort_by query string false "Sort by field: title, created_at, status"
+// @Param search query string false "Full-text search of title and description: quoted phrases, -exclude and OR"
+// @Param filter query string false "Filter expression, e.g. status:IN_PROGRESS due<2026-11-01 tag:work -tag:later"
+// @Param sort_by query string false "Sort by field: title, created_at, status, rank, date, relevance"
 // @Param order query string false "Order: asc or desc"
 // @Param limit query int true "Number of items per page"
-// @Param offset query int true "Offset"
+// @Param offset query int false "Page number, for the first page or page jumps"
+// @Param cursor query string false "next_cursor or prev_cursor of an earlier page of the same query"
+// @Param include_total query bool false "Count every matching task into total"
+// @Param archived query bool false "List archived tasks instead of active ones"
 // @Security BearerAuth
 // @Success 200 {object} entities.GetAllTasksResponse "Tasks retrieved successfully"
+// @Header 200 {string} Link "URLs of the next and previous pages, rel=next and rel=prev"
 // @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
 // @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
 // @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
@@ -237,6 +359,208 @@ func (h *TaskController) GetAllTasks(c *gin.Context) {
 		return
 	}
 
+	if links := utils.PageLinks(c.Request.URL, response.NextCursor, response.PrevCursor); links != "" {
+		c.Header("Link", links)
+	}
+
 	h.log.InfoWithID(ctx, "[Controller: GetAllTasks]: Tasks retrieved successfully")
 	c.JSON(http.StatusOK, response)
 }
+
+// @Tags Tasks
+// @Summary Get Task History
+// @Description Get the change history of a task, newest version first
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetTaskHistoryResponse "Task history retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/history [get]
+func (h *TaskController) GetTaskHistory(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetTaskHistory] Called")
+
+	// Get task id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Get task history
+	response, err := h.service.GetTaskHistory(ctx, id)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetTaskHistory]: Failed to get task history", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetTaskHistory]: Task history retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Tasks
+// @Summary Revert Task
+// @Description Revert a task to the state recorded at a previous version
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param version path int true "Version to revert to"
+// @Param If-Match header string false "ETag the revert is based on"
+// @Security BearerAuth
+// @Success 200 {object} entities.UpdateTaskResponse "Task reverted successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request param"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task or version not found"
+// @Failure 412 {object} entities.ErrorResponse "Task has changed since it was read"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/history/{version}/revert [post]
+func (h *TaskController) RevertTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: RevertTask] Called")
+
+	// Get task id and version from path
+	id := c.Param("id")
+	version, err := strconv.Atoi(c.Param("version"))
+	if id == "" || err != nil || version < 1 {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Revert task
+	req := entities.RevertTaskRequest{IfMatch: c.GetHeader("If-Match")}
+	response, err := h.service.RevertTask(ctx, id, version, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: RevertTask]: Failed to revert task", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: RevertTask]: Task reverted successfully")
+	c.Header("ETag", utils.VersionETag(response.Version))
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Tasks
+// @Summary Move Task
+// @Description Move a task within its board column, or to another column by setting status. The task is placed directly before before_id or after after_id, or at the end of the column when neither is set.
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param moveTaskRequest body entities.MoveTaskRequest true "Move task request"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetTaskResponse "Task moved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body or move target"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to edit the task"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/move [post]
+func (h *TaskController) MoveTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: MoveTask] Called")
+
+	// Get task id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.MoveTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateMoveTaskInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: MoveTask]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Move task
+	response, err := h.service.MoveTask(ctx, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: MoveTask]: Failed to move task", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: MoveTask]: Task moved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Tasks
+// @Summary Get Task Board
+// @Description Get the caller's tasks grouped by status, each column in rank order
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entities.GetTaskBoardResponse "Task board retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/board [get]
+func (h *TaskController) GetTaskBoard(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetTaskBoard] Called")
+
+	response, err := h.service.GetTaskBoard(ctx)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetTaskBoard]: Failed to get task board", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetTaskBoard]: Task board retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Tasks
+// @Summary Bulk Tasks
+// @Description Apply one action to many tasks in a single transaction: set_status, add_tag, remove_tag, archive, unarchive or delete. Tasks are given as ids or a filter. Each task is checked on its own, and the report gives a result per task; tasks that fail are skipped.
+// @Accept json
+// @Produce json
+// @Param bulkTaskRequest body entities.BulkTaskRequest true "Bulk task request"
+// @Security BearerAuth
+// @Success 200 {object} entities.BulkTaskResponse "Bulk operation completed"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body, or too many tasks"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/bulk [post]
+func (h *TaskController) BulkTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: BulkTasks] Called")
+
+	// Bind request
+	var req entities.BulkTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateBulkTaskInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: BulkTasks]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Apply bulk action
+	response, err := h.service.BulkTasks(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: BulkTasks]: Failed to apply bulk action", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: BulkTasks]: Bulk operation completed")
+	c.JSON(http.StatusOK, response)
+}
+
+// bindTaskBody binds a task sent either as JSON or as a form; only a form
+// can carry an image upload
+func bindTaskBody(c *gin.Context, req interface{}) error {
+	switch c.ContentType() {
+	case binding.MIMEJSON:
+		return c.ShouldBindJSON(req)
+	case binding.MIMEMultipartPOSTForm, binding.MIMEPOSTForm:
+		return c.ShouldBind(req)
+	}
+	return constants.ErrUnsupportedMediaType
+}
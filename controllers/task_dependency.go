@@ -0,0 +1,140 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type TaskDependencyController struct {
+	service services.ITaskDependencyService
+	log     *log.Logger
+}
+
+func NewTaskDependencyController(service services.ITaskDependencyService, log *log.Logger) *TaskDependencyController {
+	return &TaskDependencyController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Dependencies
+// @Summary Add Task Dependency
+// @Description Mark the task as blocked by another task. Rejected if it would create a cycle.
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param addTaskDependencyRequest body entities.AddTaskDependencyRequest true "Add dependency request"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetTaskDependenciesResponse "Dependency added successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to edit the task"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 409 {object} entities.ErrorResponse "Dependency exists or would create a cycle"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/dependencies [post]
+func (h *TaskDependencyController) AddDependency(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: AddDependency] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.AddTaskDependencyRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateAddTaskDependencyInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: AddDependency]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	response, err := h.service.AddDependency(ctx, taskId, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: AddDependency]: Failed to add dependency", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: AddDependency]: Dependency added successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Dependencies
+// @Summary Get Task Dependencies
+// @Description List the tasks blocking this task and the tasks it blocks
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetTaskDependenciesResponse "Dependencies retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/dependencies [get]
+func (h *TaskDependencyController) GetDependencies(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetDependencies] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	response, err := h.service.GetDependencies(ctx, taskId)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetDependencies]: Failed to get dependencies", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetDependencies]: Dependencies retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Dependencies
+// @Summary Remove Task Dependency
+// @Description Remove a blocked-by relation from the task
+// @Accept json
+// @Param id path string true "Task ID"
+// @Param blocked_by_id path string true "ID of the blocking task"
+// @Security BearerAuth
+// @Success 200 {object} nil "Dependency removed successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to edit the task"
+// @Failure 404 {object} entities.ErrorResponse "Task or dependency not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/dependencies/{blocked_by_id} [delete]
+func (h *TaskDependencyController) RemoveDependency(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: RemoveDependency] Called")
+
+	// Get task id and blocker id from path
+	taskId := c.Param("id")
+	blockedById := c.Param("blocked_by_id")
+	if taskId == "" || blockedById == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	if err := h.service.RemoveDependency(ctx, taskId, blockedById); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: RemoveDependency]: Failed to remove dependency", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: RemoveDependency]: Dependency removed successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Dependency removed successfully"})
+}
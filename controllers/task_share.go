@@ -0,0 +1,176 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type TaskShareController struct {
+	service services.ITaskShareService
+	log     *log.Logger
+}
+
+func NewTaskShareController(service services.ITaskShareService, log *log.Logger) *TaskShareController {
+	return &TaskShareController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Sharing
+// @Summary Share Task
+// @Description Share a task with another user by email as viewer or editor. Sharing again updates the permission.
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Param shareTaskRequest body entities.ShareTaskRequest true "Share task request"
+// @Security BearerAuth
+// @Success 200 {object} entities.TaskShareResponse "Task shared successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Only the owner may share"
+// @Failure 404 {object} entities.ErrExampleUserNotFound "Task or user not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/shares [post]
+func (h *TaskShareController) ShareTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: ShareTask] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.ShareTaskRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateShareTaskInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: ShareTask]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	response, err := h.service.ShareTask(ctx, taskId, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: ShareTask]: Failed to share task", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: ShareTask]: Task shared successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Sharing
+// @Summary Get Task Shares
+// @Description List the users a task is shared with
+// @Accept json
+// @Produce json
+// @Param id path string true "Task ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetTaskSharesResponse "Shares retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Only the owner may list shares"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/shares [get]
+func (h *TaskShareController) GetTaskShares(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetTaskShares] Called")
+
+	// Get task id from path
+	taskId := c.Param("id")
+	if taskId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	response, err := h.service.GetTaskShares(ctx, taskId)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetTaskShares]: Failed to get shares", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetTaskShares]: Shares retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Sharing
+// @Summary Revoke Task Share
+// @Description Revoke a user's access to a task. The owner may revoke anyone; a grantee may remove themselves.
+// @Accept json
+// @Param id path string true "Task ID"
+// @Param user_id path string true "User ID of the grantee"
+// @Security BearerAuth
+// @Success 200 {object} nil "Share revoked successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to revoke this share"
+// @Failure 404 {object} entities.ErrExampleTaskNotFound "Task or share not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/{id}/shares/{user_id} [delete]
+func (h *TaskShareController) RevokeShare(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: RevokeShare] Called")
+
+	// Get task id and user id from path
+	taskId := c.Param("id")
+	userId := c.Param("user_id")
+	if taskId == "" || userId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	if err := h.service.RevokeShare(ctx, taskId, userId); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: RevokeShare]: Failed to revoke share", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: RevokeShare]: Share revoked successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Share revoked successfully"})
+}
+
+// @Tags Sharing
+// @Summary Get Tasks Shared With Me
+// @Description List tasks other users have shared with the caller, newest share first
+// @Accept json
+// @Produce json
+// @Param limit query int true "Number of items per page"
+// @Param offset query int true "Page number (starting at 1)"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetAllTasksResponse "Shared tasks retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/tasks/shared [get]
+func (h *TaskShareController) GetSharedTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetSharedTasks] Called")
+
+	var req entities.GetSharedTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateGetSharedTasksInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: GetSharedTasks]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	response, err := h.service.GetSharedTasks(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetSharedTasks]: Failed to get shared tasks", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetSharedTasks]: Shared tasks retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskController_UnsupportedContentType(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lgr := log.Initialize(constants.TestAppEnv)
+
+	// The body is rejected before the service is reached
+	h := NewTaskController(nil, lgr)
+	router := gin.New()
+	router.POST("/api/v1/tasks", h.CreateTask)
+	router.PUT("/api/v1/tasks/:id", h.UpdateTask)
+
+	testCases := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "CreateTask_TextPlain", method: http.MethodPost, path: "/api/v1/tasks"},
+		{name: "UpdateTask_TextPlain", method: http.MethodPut, path: "/api/v1/tasks/550e8400-e29b-41d4-a716-446655440000"},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			req := httptest.NewRequest(tC.method, tC.path, strings.NewReader("title=Task 1"))
+			req.Header.Set("Content-Type", "text/plain")
+			w := httptest.NewRecorder()
+
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusUnsupportedMediaType, w.Code)
+			var body struct {
+				Error entities.ErrorResponse `json:"error"`
+			}
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+			assert.Equal(t, int(constants.CodeUnsupportedMediaType), body.Error.Code)
+		})
+	}
+}
+
+// stubTaskService answers GetTask with a fixed task
+type stubTaskService struct {
+	services.ITaskService
+	task *entities.GetTaskResponse
+}
+
+func (s *stubTaskService) GetTask(ctx context.Context, id string) (*entities.GetTaskResponse, error) {
+	return s.task, nil
+}
+
+func TestTaskController_GetTaskETag(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	lgr := log.Initialize(constants.TestAppEnv)
+
+	task := &entities.GetTaskResponse{ID: "550e8400-e29b-41d4-a716-446655440000", Title: "Task 1", Date: "2024-01-05T09:00:00+07:00", Version: 3}
+	service := &stubTaskService{task: task}
+	router := gin.New()
+	router.GET("/api/v1/tasks/:id", NewTaskController(service, lgr).GetTask)
+
+	get := func(ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/tasks/"+task.ID, nil)
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := get("")
+	require.Equal(t, http.StatusOK, first.Code)
+	etag := first.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	assert.NotEqual(t, `"3"`, etag)
+	assert.Contains(t, first.Header().Get("Vary"), "X-Timezone")
+
+	// An unchanged response is not sent again
+	notModified := get(etag)
+	assert.Equal(t, http.StatusNotModified, notModified.Code)
+	assert.Empty(t, notModified.Body.Bytes())
+
+	// A change that leaves the version as is still makes a new response
+	changes := []func(){
+		func() { task.CommentCount = 1 },
+		func() { task.Date = "2024-01-05T03:00:00+01:00" },
+		func() { image := "/api/v1/files/tasks/1/attachments/2/content?exp=1"; task.Image = &image },
+	}
+	for _, change := range changes {
+		change()
+		w := get(etag)
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.NotEqual(t, etag, w.Header().Get("ETag"))
+		etag = w.Header().Get("ETag")
+	}
+}
@@ -88,3 +88,61 @@ func (c *UserController) Login(ctx *gin.Context) {
 	c.log.InfoWithID(ctx, "[Controller: Login] Successfully logged in user")
 	ctx.JSON(http.StatusOK, resp)
 }
+
+// @Tags Users
+// @Summary Get preferences
+// @Description Get the caller's preferences. Timezone is the IANA time zone times are shown in, Asia/Bangkok unless the caller picked another.
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entities.UserPreferencesResponse "Preferences retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleUserNotFound "User not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/users/me/preferences [get]
+func (c *UserController) GetPreferences(ctx *gin.Context) {
+	c.log.DebugWithID(ctx, "[Controller: GetPreferences] Called")
+
+	resp, err := c.service.GetPreferences(ctx.Request.Context())
+	if err != nil {
+		c.log.ErrorWithID(ctx, "[Controller: GetPreferences] Failed to get preferences: ", err)
+		utils.ErrorResponse(ctx, err)
+		return
+	}
+
+	c.log.InfoWithID(ctx, "[Controller: GetPreferences] Successfully retrieved preferences")
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// @Tags Users
+// @Summary Update preferences
+// @Description Update the caller's preferences. Timezone is an IANA time zone name such as Europe/Berlin; null goes back to Asia/Bangkok.
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param updateUserPreferencesRequest body entities.UpdateUserPreferencesRequest true "Update preferences request"
+// @Success 200 {object} entities.UserPreferencesResponse "Preferences updated successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrExampleUserNotFound "User not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/users/me/preferences [put]
+func (c *UserController) UpdatePreferences(ctx *gin.Context) {
+	c.log.DebugWithID(ctx, "[Controller: UpdatePreferences] Called")
+	var req entities.UpdateUserPreferencesRequest
+	if err := ctx.ShouldBindJSON(&req); err != nil {
+		c.log.ErrorWithID(ctx, "[Controller: UpdatePreferences] Failed to bind request: ", err)
+		detail := utils.ValidateUpdateUserPreferencesInput(req)
+		utils.ErrorResponse(ctx, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	resp, err := c.service.UpdatePreferences(ctx.Request.Context(), &req)
+	if err != nil {
+		c.log.ErrorWithID(ctx, "[Controller: UpdatePreferences] Failed to update preferences: ", err)
+		utils.ErrorResponse(ctx, err)
+		return
+	}
+
+	c.log.InfoWithID(ctx, "[Controller: UpdatePreferences] Successfully updated preferences")
+	ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,251 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type ViewController struct {
+	service services.IViewService
+	log     *log.Logger
+}
+
+func NewViewController(service services.IViewService, log *log.Logger) *ViewController {
+	return &ViewController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Views
+// @Summary Create View
+// @Description Save a task filter, sort and grouping under a name
+// @Accept json
+// @Produce json
+// @Param createViewRequest body entities.CreateViewRequest true "Create view request"
+// @Security BearerAuth
+// @Success 200 {object} entities.ViewResponse "View created successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 409 {object} entities.ErrorResponse "A view with this name already exists"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/views [post]
+func (h *ViewController) CreateView(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: CreateView] Called")
+
+	// Bind request
+	var req entities.CreateViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateCreateViewInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: CreateView]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Create view
+	response, err := h.service.CreateView(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: CreateView]: Failed to create view", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: CreateView]: View created successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Views
+// @Summary Get Views
+// @Description List the built-in views followed by the caller's saved views
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entities.GetViewsResponse "Views retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/views [get]
+func (h *ViewController) GetViews(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetViews] Called")
+
+	// Get views
+	response, err := h.service.GetViews(ctx)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetViews]: Failed to get views", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetViews]: Views retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Views
+// @Summary Get View
+// @Description Get a saved or built-in view
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID, or today, overdue, next-7-days"
+// @Security BearerAuth
+// @Success 200 {object} entities.ViewResponse "View retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "View not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/views/{id} [get]
+func (h *ViewController) GetView(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetView] Called")
+
+	// Get view id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Get view
+	response, err := h.service.GetView(ctx, id)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetView]: Failed to get view", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetView]: View retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Views
+// @Summary Update View
+// @Description Update a saved view; only sent fields change and built-in views are read-only
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID"
+// @Param updateViewRequest body entities.UpdateViewRequest true "Update view request"
+// @Security BearerAuth
+// @Success 200 {object} entities.ViewResponse "View updated successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Built-in views cannot be changed"
+// @Failure 404 {object} entities.ErrorResponse "View not found"
+// @Failure 409 {object} entities.ErrorResponse "A view with this name already exists"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/views/{id} [put]
+func (h *ViewController) UpdateView(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: UpdateView] Called")
+
+	// Get view id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.UpdateViewRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateUpdateViewInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: UpdateView]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	// Update view
+	response, err := h.service.UpdateView(ctx, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: UpdateView]: Failed to update view", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: UpdateView]: View updated successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Views
+// @Summary Delete View
+// @Description Delete a saved view; built-in views cannot be deleted
+// @Accept json
+// @Param id path string true "View ID"
+// @Security BearerAuth
+// @Success 200 {object} nil "View deleted successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Built-in views cannot be changed"
+// @Failure 404 {object} entities.ErrorResponse "View not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/views/{id} [delete]
+func (h *ViewController) DeleteView(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: DeleteView] Called")
+
+	// Get view id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Delete view
+	if err := h.service.DeleteView(ctx, id); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: DeleteView]: Failed to delete view", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: DeleteView]: View deleted successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "View deleted successfully"})
+}
+
+// @Tags Views
+// @Summary Get View Tasks
+// @Description Run a view's stored filter and sort over the tasks of the current workspace, grouped when the view has a group_by
+// @Accept json
+// @Produce json
+// @Param id path string true "View ID, or today, overdue, next-7-days"
+// @Param limit query int true "Number of items per page"
+// @Param offset query int true "Page number"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetViewTasksResponse "View tasks retrieved successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid query params"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 404 {object} entities.ErrorResponse "View not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/views/{id}/tasks [get]
+func (h *ViewController) GetViewTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetViewTasks] Called")
+
+	// Get view id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	var req entities.GetViewTasksRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		detail := utils.ValidateGetViewTasksInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: GetViewTasks]: Invalid query params", err)
+		utils.ErrorResponse(c, constants.ErrInvalidQueryRequestParam, detail)
+		return
+	}
+
+	// Get view tasks
+	response, err := h.service.GetViewTasks(ctx, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetViewTasks]: Failed to get view tasks", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetViewTasks]: View tasks retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,393 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type WorkspaceController struct {
+	service services.IWorkspaceService
+	log     *log.Logger
+}
+
+func NewWorkspaceController(service services.IWorkspaceService, log *log.Logger) *WorkspaceController {
+	return &WorkspaceController{
+		service: service,
+		log:     log,
+	}
+}
+
+// @Tags Workspaces
+// @Summary Create Workspace
+// @Description Create a team workspace. The caller becomes its owner.
+// @Accept json
+// @Produce json
+// @Param createWorkspaceRequest body entities.CreateWorkspaceRequest true "Create workspace request"
+// @Security BearerAuth
+// @Success 201 {object} entities.WorkspaceResponse "Workspace created successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces [post]
+func (h *WorkspaceController) CreateWorkspace(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: CreateWorkspace] Called")
+
+	// Bind request
+	var req entities.CreateWorkspaceRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateCreateWorkspaceInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: CreateWorkspace]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	response, err := h.service.CreateWorkspace(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: CreateWorkspace]: Failed to create workspace", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: CreateWorkspace]: Workspace created successfully")
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Tags Workspaces
+// @Summary Get My Workspaces
+// @Description List the workspaces the caller belongs to with their role in each
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} entities.GetWorkspacesResponse "Workspaces retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces [get]
+func (h *WorkspaceController) GetWorkspaces(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetWorkspaces] Called")
+
+	response, err := h.service.GetWorkspaces(ctx)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetWorkspaces]: Failed to get workspaces", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetWorkspaces]: Workspaces retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Workspaces
+// @Summary Get Workspace
+// @Description Get a workspace the caller belongs to
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.WorkspaceResponse "Workspace retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not a member of the workspace"
+// @Failure 404 {object} entities.ErrorResponse "Workspace not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/{id} [get]
+func (h *WorkspaceController) GetWorkspace(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetWorkspace] Called")
+
+	// Get workspace id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	response, err := h.service.GetWorkspace(ctx, id)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetWorkspace]: Failed to get workspace", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetWorkspace]: Workspace retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Workspaces
+// @Summary Get Workspace Members
+// @Description List the members of a workspace and their roles
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetWorkspaceMembersResponse "Members retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not a member of the workspace"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/{id}/members [get]
+func (h *WorkspaceController) GetMembers(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetMembers] Called")
+
+	// Get workspace id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	response, err := h.service.GetMembers(ctx, id)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetMembers]: Failed to get members", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetMembers]: Members retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Workspaces
+// @Summary Update Member Role
+// @Description Change a member's role. Requires admin; the owner's role cannot be changed.
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param user_id path string true "User ID of the member"
+// @Param updateWorkspaceMemberRequest body entities.UpdateWorkspaceMemberRequest true "Update member request"
+// @Security BearerAuth
+// @Success 200 {object} entities.WorkspaceMemberResponse "Member role updated successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to manage members"
+// @Failure 404 {object} entities.ErrExampleUserNotFound "Member not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/{id}/members/{user_id} [put]
+func (h *WorkspaceController) UpdateMemberRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: UpdateMemberRole] Called")
+
+	// Get workspace id and user id from path
+	id := c.Param("id")
+	userId := c.Param("user_id")
+	if id == "" || userId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.UpdateWorkspaceMemberRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateUpdateWorkspaceMemberInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: UpdateMemberRole]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	response, err := h.service.UpdateMemberRole(ctx, id, userId, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: UpdateMemberRole]: Failed to update member role", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: UpdateMemberRole]: Member role updated successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Workspaces
+// @Summary Remove Member
+// @Description Remove a member from a workspace. Admins may remove anyone but the owner; members may leave.
+// @Accept json
+// @Param id path string true "Workspace ID"
+// @Param user_id path string true "User ID of the member"
+// @Security BearerAuth
+// @Success 200 {object} nil "Member removed successfully"
+// @Failure 400 {object} entities.ErrorResponse "The owner cannot be removed"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to manage members"
+// @Failure 404 {object} entities.ErrExampleUserNotFound "Member not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/{id}/members/{user_id} [delete]
+func (h *WorkspaceController) RemoveMember(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: RemoveMember] Called")
+
+	// Get workspace id and user id from path
+	id := c.Param("id")
+	userId := c.Param("user_id")
+	if id == "" || userId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	if err := h.service.RemoveMember(ctx, id, userId); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: RemoveMember]: Failed to remove member", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: RemoveMember]: Member removed successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Member removed successfully"})
+}
+
+// @Tags Workspaces
+// @Summary Invite Member
+// @Description Invite a user by email. The returned token is shown only once and expires after the configured duration.
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Param createInvitationRequest body entities.CreateInvitationRequest true "Create invitation request"
+// @Security BearerAuth
+// @Success 201 {object} entities.InvitationResponse "Invitation created successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to invite"
+// @Failure 409 {object} entities.ErrorResponse "User is already a member"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/{id}/invitations [post]
+func (h *WorkspaceController) CreateInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: CreateInvitation] Called")
+
+	// Get workspace id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	// Bind request
+	var req entities.CreateInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateCreateInvitationInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: CreateInvitation]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	response, err := h.service.CreateInvitation(ctx, id, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: CreateInvitation]: Failed to create invitation", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: CreateInvitation]: Invitation created successfully")
+	c.JSON(http.StatusCreated, response)
+}
+
+// @Tags Workspaces
+// @Summary Get Pending Invitations
+// @Description List invitations that have not been accepted and have not expired
+// @Accept json
+// @Produce json
+// @Param id path string true "Workspace ID"
+// @Security BearerAuth
+// @Success 200 {object} entities.GetInvitationsResponse "Invitations retrieved successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to manage invitations"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/{id}/invitations [get]
+func (h *WorkspaceController) GetInvitations(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: GetInvitations] Called")
+
+	// Get workspace id from path
+	id := c.Param("id")
+	if id == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	response, err := h.service.GetInvitations(ctx, id)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: GetInvitations]: Failed to get invitations", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: GetInvitations]: Invitations retrieved successfully")
+	c.JSON(http.StatusOK, response)
+}
+
+// @Tags Workspaces
+// @Summary Revoke Invitation
+// @Description Revoke a pending invitation
+// @Accept json
+// @Param id path string true "Workspace ID"
+// @Param invitation_id path string true "Invitation ID"
+// @Security BearerAuth
+// @Success 200 {object} nil "Invitation revoked successfully"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Not allowed to manage invitations"
+// @Failure 404 {object} entities.ErrorResponse "Invitation not found"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/{id}/invitations/{invitation_id} [delete]
+func (h *WorkspaceController) RevokeInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: RevokeInvitation] Called")
+
+	// Get workspace id and invitation id from path
+	id := c.Param("id")
+	invitationId := c.Param("invitation_id")
+	if id == "" || invitationId == "" {
+		utils.ErrorResponse(c, constants.ErrInvalidRequestParam)
+		return
+	}
+
+	if err := h.service.RevokeInvitation(ctx, id, invitationId); err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: RevokeInvitation]: Failed to revoke invitation", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: RevokeInvitation]: Invitation revoked successfully")
+	c.JSON(http.StatusOK, gin.H{"message": "Invitation revoked successfully"})
+}
+
+// @Tags Workspaces
+// @Summary Accept Invitation
+// @Description Join a workspace with an invitation token. The caller's email must match the invited email.
+// @Accept json
+// @Produce json
+// @Param acceptInvitationRequest body entities.AcceptInvitationRequest true "Accept invitation request"
+// @Security BearerAuth
+// @Success 200 {object} entities.WorkspaceResponse "Invitation accepted successfully"
+// @Failure 400 {object} entities.ErrExampleInvalidRequest "Invalid request body"
+// @Failure 401 {object} entities.ErrExampleUnauthorized "Unauthorized"
+// @Failure 403 {object} entities.ErrorResponse "Invitation was sent to a different email"
+// @Failure 404 {object} entities.ErrorResponse "Invitation not found"
+// @Failure 409 {object} entities.ErrorResponse "Already a member"
+// @Failure 410 {object} entities.ErrorResponse "Invitation expired or already used"
+// @Failure 500 {object} entities.ErrExampleInternalError "Internal server error"
+// @Router /api/v1/workspaces/invitations/accept [post]
+func (h *WorkspaceController) AcceptInvitation(c *gin.Context) {
+	ctx := c.Request.Context()
+	h.log.DebugWithID(ctx, "[Controller: AcceptInvitation] Called")
+
+	// Bind request
+	var req entities.AcceptInvitationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		detail := utils.ValidateAcceptInvitationInput(req)
+		h.log.ErrorWithID(ctx, "[Controller: AcceptInvitation]: Failed to bind request", err)
+		utils.ErrorResponse(c, constants.ErrInvalidRequestBody, detail)
+		return
+	}
+
+	response, err := h.service.AcceptInvitation(ctx, &req)
+	if err != nil {
+		h.log.ErrorWithID(ctx, "[Controller: AcceptInvitation]: Failed to accept invitation", err)
+		utils.ErrorResponse(c, err)
+		return
+	}
+
+	h.log.InfoWithID(ctx, "[Controller: AcceptInvitation]: Invitation accepted successfully")
+	c.JSON(http.StatusOK, response)
+}
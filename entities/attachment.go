@@ -0,0 +1,56 @@
+package entities
+
+import "io"
+
+type UploadAttachmentRequest struct {
+	Filename string `form:"filename" binding:"required,max=255,notblank" example:"report.pdf"`
+	// Set by the controller from the request body and its Content-Length
+	Body io.Reader `json:"-" form:"-" swaggerignore:"true"`
+	Size int64     `json:"-" form:"-" swaggerignore:"true"`
+}
+
+type GetAttachmentContentRequest struct {
+	// Set by the controller from the Range and If-Range headers
+	Range   string `json:"-" form:"-" swaggerignore:"true"`
+	IfRange string `json:"-" form:"-" swaggerignore:"true"`
+}
+
+// SignedURLRequest is the grant and signature carried in the query of a
+// signed download link
+type SignedURLRequest struct {
+	UserID     string `form:"uid" binding:"required"`
+	Permission string `form:"perm" binding:"required"`
+	Expires    int64  `form:"exp" binding:"required"`
+	Signature  string `form:"sig" binding:"required"`
+}
+
+type AttachmentResponse struct {
+	ID          string `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	TaskID      string `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID      string `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Filename    string `json:"filename" example:"report.pdf"`
+	ContentType string `json:"content_type" example:"application/pdf"`
+	Size        int64  `json:"size" example:"24576"`
+	URL         string `json:"url" example:"/api/v1/files/tasks/123e4567-e89b-12d3-a456-426614174000/attachments/550e8400-e29b-41d4-a716-446655440000/content?exp=1735689600&perm=viewer&sig=...&uid=1"`
+	// URL and the Thumbnails, by size, are signed links that work without an
+	// access token until they expire; only images have thumbnails
+	Thumbnails map[string]string `json:"thumbnails,omitempty"`
+	CreatedAt  string            `json:"created_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetAttachmentsResponse struct {
+	Attachments []AttachmentResponse `json:"attachments"`
+}
+
+// AttachmentContent is an attachment's file, or the requested part of it,
+// streamed from the blob store; the caller closes Body
+type AttachmentContent struct {
+	Filename    string
+	ContentType string
+	ETag        string
+	// Length is how many bytes Body holds. ContentRange is set when that is
+	// only part of the file.
+	Length       int64
+	ContentRange string
+	Body         io.ReadCloser
+}
@@ -0,0 +1,26 @@
+package entities
+
+import (
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+)
+
+type CalendarFeedResponse struct {
+	CreatedAt string `json:"created_at" example:"2021-09-01T00:00:00Z"`
+
+	// Only returned when the feed's token is generated
+	URL string `json:"url,omitempty" example:"/api/v1/calendar/feeds/q4Jm3X1cVQ2h0k8P9yT7wB5nR6sLdZfA1eGuHiOjKlM.ics"`
+}
+
+type GetCalendarFeedContentRequest struct {
+	Type constants.CalendarComponent `form:"type" binding:"omitempty,oneof=event todo" example:"event"`
+}
+
+// CalendarFeedContent is a rendered calendar feed, its entity tag and how
+// long clients may cache it
+type CalendarFeedContent struct {
+	Body   []byte
+	ETag   string
+	MaxAge time.Duration
+}
@@ -0,0 +1,29 @@
+package entities
+
+type CreateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=5000,notblank" example:"Blocked until the API keys arrive"`
+}
+
+type UpdateCommentRequest struct {
+	Body string `json:"body" binding:"required,max=5000,notblank" example:"Unblocked, keys arrived today"`
+}
+
+type CommentResponse struct {
+	ID        string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TaskID    string `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID    string `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Body      string `json:"body" example:"Blocked until the API keys arrive"`
+	Edited    bool   `json:"edited" example:"false"`
+	CreatedAt string `json:"created_at" example:"2021-09-01T00:00:00Z"`
+	UpdatedAt string `json:"updated_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetCommentsRequest struct {
+	Limit  int `form:"limit" binding:"min=1,max=100" example:"10"`
+	Offset int `form:"offset" binding:"min=1" example:"1"`
+}
+
+type GetCommentsResponse struct {
+	Total    int64             `json:"total" example:"1"`
+	Comments []CommentResponse `json:"comments"`
+}
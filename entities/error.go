@@ -64,3 +64,9 @@ type ErrExampleTaskAlreadyExists struct {
 	Code    int    `json:"code" example:"3002"`
 	Message string `json:"message" example:"task already exists"`
 }
+
+// ErrExampleAttachmentNotFound is used to show an example of a 404 Not Found error
+type ErrExampleAttachmentNotFound struct {
+	Code    int    `json:"code" example:"12001"`
+	Message string `json:"message" example:"attachment not found"`
+}
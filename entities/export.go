@@ -0,0 +1,49 @@
+package entities
+
+import (
+	"io"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+)
+
+// ExportTasksRequest picks the tasks to export with the filters of
+// GetAllTasksRequest
+type ExportTasksRequest struct {
+	Format   constants.ExportFormat `form:"format" binding:"required,oneof=csv json md ics" example:"csv"`
+	Images   constants.ExportImages `form:"images" binding:"omitempty,oneof=none link embed" example:"link"`
+	Search   string                 `form:"search" binding:"omitempty,max=100" example:"release -draft"`
+	Filter   string                 `form:"filter" binding:"omitempty,max=500,taskfilter" example:"status:IN_PROGRESS tag:work"`
+	SortBy   string                 `form:"sort_by" binding:"omitempty,oneof=title created_at status rank date" example:"date"`
+	Order    string                 `form:"order" binding:"omitempty,oneof=asc desc" example:"asc"`
+	Assignee string                 `form:"assignee" binding:"omitempty,assigneefilter" example:"me"`
+	Archived bool                   `form:"archived" example:"false"`
+}
+
+// ExportTask is a task as written to an export; the JSON export is the
+// format that import reads back
+type ExportTask struct {
+	ID          string    `json:"id"`
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	Status      string    `json:"status"`
+	Date        time.Time `json:"date"`
+	AllDay      bool      `json:"all_day,omitempty"`
+	Tags        []string  `json:"tags"`
+	AssigneeID  *string   `json:"assignee_id"`
+	Archived    bool      `json:"archived"`
+	CreatedAt   time.Time `json:"created_at"`
+	// Version and UpdatedAt tell calendar clients an entry has changed
+	Version   int       `json:"-"`
+	UpdatedAt time.Time `json:"-"`
+	// Image is a signed link to the task image, or its path in a ZIP bundle
+	Image string `json:"image,omitempty"`
+}
+
+// TaskExport is an export ready to be streamed: Write writes it to the
+// response once the headers are sent
+type TaskExport struct {
+	Filename    string
+	ContentType string
+	Write       func(w io.Writer) error
+}
@@ -0,0 +1,39 @@
+package entities
+
+import (
+	"mime/multipart"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+)
+
+type ImportTasksRequest struct {
+	File   *multipart.FileHeader  `form:"file" binding:"required" swaggerignore:"true"`
+	Format constants.ImportFormat `form:"format" binding:"required,oneof=csv json todoist trello" example:"csv"`
+	// Mapping is a JSON object from task field to CSV header name, for CSV
+	// files whose columns are not named after the fields
+	Mapping string `form:"mapping" binding:"omitempty" example:"{\"title\":\"Name\",\"date\":\"Due\"}"`
+	DryRun  bool   `form:"dry_run" binding:"omitempty" example:"false"`
+}
+
+// ImportRowError lists why one row of an import file is skipped
+type ImportRowError struct {
+	Row    int                 `json:"row" example:"3"`
+	Errors []map[string]string `json:"errors"`
+}
+
+// ImportJobResponse is the progress of an import, or the report of a dry
+// run, which has no ID and imports nothing
+type ImportJobResponse struct {
+	ID         string           `json:"id,omitempty" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Format     string           `json:"format" example:"csv"`
+	Status     string           `json:"status" example:"RUNNING"`
+	DryRun     bool             `json:"dry_run" example:"false"`
+	Total      int              `json:"total" example:"120"`
+	Valid      int              `json:"valid" example:"118"`
+	Imported   int              `json:"imported" example:"100"`
+	Failed     int              `json:"failed" example:"2"`
+	Errors     []ImportRowError `json:"errors"`
+	Error      *string          `json:"error,omitempty"`
+	CreatedAt  string           `json:"created_at,omitempty" example:"2021-09-01T00:00:00Z"`
+	FinishedAt *string          `json:"finished_at,omitempty" example:"2021-09-01T00:00:05Z"`
+}
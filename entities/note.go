@@ -0,0 +1,52 @@
+package entities
+
+type CreateNoteRequest struct {
+	Title  string  `json:"title" binding:"required,max=200,notblank" example:"Meeting notes"`
+	Body   string  `json:"body" example:"Discussed the [[Roadmap]]"`
+	Pinned bool    `json:"pinned" example:"false"`
+	TaskID *string `json:"task_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+type UpdateNoteRequest struct {
+	Title  string  `json:"title" binding:"omitempty,max=200,notblank" example:"Meeting notes"`
+	Body   *string `json:"body" example:"Discussed the [[Roadmap]]"`
+	Pinned *bool   `json:"pinned" example:"true"`
+	TaskID *string `json:"task_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+type GetNoteRequest struct {
+	Format string `form:"format" binding:"omitempty,oneof=markdown html" example:"html"`
+}
+
+type GetNotesRequest struct {
+	Search string `form:"search" binding:"max=100" example:"meeting"`
+	Pinned *bool  `form:"pinned" example:"true"`
+	TaskID string `form:"task_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Limit  int    `form:"limit" binding:"min=1,max=100" example:"10"`
+	Offset int    `form:"offset" binding:"min=1" example:"1"`
+}
+
+type NoteSummary struct {
+	ID    string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title string `json:"title" example:"Weekly sync"`
+}
+
+type NoteResponse struct {
+	ID          string        `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID      string        `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	WorkspaceID *string       `json:"workspace_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	TaskID      *string       `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title       string        `json:"title" example:"Meeting notes"`
+	Body        string        `json:"body" example:"Discussed the [[Roadmap]]"`
+	BodyHTML    *string       `json:"body_html,omitempty" example:"<p>Discussed the [[Roadmap]]</p>"`
+	Pinned      bool          `json:"pinned" example:"false"`
+	Links       []string      `json:"links"`
+	Backlinks   []NoteSummary `json:"backlinks,omitempty"`
+	CreatedAt   string        `json:"created_at" example:"2021-09-01T00:00:00Z"`
+	UpdatedAt   string        `json:"updated_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetNotesResponse struct {
+	Total int64          `json:"total" example:"1"`
+	Notes []NoteResponse `json:"notes"`
+}
@@ -0,0 +1,26 @@
+package entities
+
+type NotificationResponse struct {
+	ID        string  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Type      string  `json:"type" example:"TASK_ASSIGNED"`
+	TaskID    string  `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ActorID   string  `json:"actor_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Message   string  `json:"message" example:"You were assigned to \"Task 1\""`
+	ReadAt    *string `json:"read_at" example:"2021-09-01T00:00:00Z"`
+	CreatedAt string  `json:"created_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetNotificationsRequest struct {
+	UnreadOnly bool `form:"unread_only" example:"true"`
+	Limit      int  `form:"limit" binding:"min=1,max=100" example:"10"`
+	Offset     int  `form:"offset" binding:"min=1" example:"1"`
+}
+
+type GetNotificationsResponse struct {
+	Total         int64                  `json:"total" example:"1"`
+	Notifications []NotificationResponse `json:"notifications"`
+}
+
+type MarkAllNotificationsReadResponse struct {
+	Updated int64 `json:"updated" example:"3"`
+}
@@ -0,0 +1,27 @@
+package entities
+
+type RevisionResponse struct {
+	Version     int     `json:"version" example:"2"`
+	Title       *string `json:"title,omitempty" example:"Meeting notes"`
+	ActorID     string  `json:"actor_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ContentHash string  `json:"content_hash" example:"9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"`
+	Size        int     `json:"size" example:"1024"`
+	CreatedAt   string  `json:"created_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetRevisionsResponse struct {
+	ResourceID string             `json:"resource_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Total      int                `json:"total" example:"1"`
+	Revisions  []RevisionResponse `json:"revisions"`
+}
+
+type DiffRevisionsRequest struct {
+	From int `form:"from" binding:"required,min=1" example:"1"`
+	To   int `form:"to" binding:"required,min=1" example:"2"`
+}
+
+type DiffRevisionsResponse struct {
+	From int    `json:"from" example:"1"`
+	To   int    `json:"to" example:"2"`
+	Diff string `json:"diff" example:"--- v1\n+++ v2\n@@ -1 +1 @@\n-old\n+new\n"`
+}
@@ -1,6 +1,7 @@
 package entities
 
 import (
+	"encoding/json"
 	"mime/multipart"
 	"time"
 
@@ -11,63 +12,224 @@ type GetHealthUserResponse struct {
 	Status string `json:"status" example:"Healthy"`
 }
 
+// CreateTaskRequest is sent as JSON or as a form. A form can carry an image
+// file; either can instead name an image the caller uploaded in image_id,
+// which the task gets a copy of.
 type CreateTaskRequest struct {
-	Title       string                `form:"title" binding:"required,max=100" example:"Task 1"`
-	Description *string               `form:"description" binding:"omitempty" example:"Description of task 1"`
-	Status      constants.TaskStatus  `form:"status" binding:"required,taskstatus" example:"IN_PROGRESS"`
-	Date        time.Time             `form:"date" time_format:"2006-01-02T15:04:05Z07:00" binding:"required" example:"2021-09-01T00:00:00Z"`
-	Image       *multipart.FileHeader `form:"image" binding:"omitempty" example:"https://example.com/image.jpg"`
+	Title       string                `json:"title" form:"title" binding:"required,max=100" example:"Task 1"`
+	Description *string               `json:"description" form:"description" binding:"omitempty" example:"Description of task 1"`
+	Status      constants.TaskStatus  `json:"status" form:"status" binding:"required,taskstatus" example:"IN_PROGRESS"`
+	Date        time.Time             `json:"date" form:"date" time_format:"2006-01-02T15:04:05Z07:00" binding:"required" example:"2021-09-01T00:00:00Z"`
+	AllDay      bool                  `json:"all_day" form:"all_day" example:"false"`
+	Image       *multipart.FileHeader `json:"-" form:"image" binding:"omitempty" swaggerignore:"true"`
+	ImageID     *string               `json:"image_id" form:"image_id" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AssigneeID  *string               `json:"assignee_id" form:"assignee_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Tags        []string              `json:"tags" form:"tags" binding:"omitempty,max=20,dive,tasktag" example:"work"`
+
+	// IdempotencyKey is the request's Idempotency-Key header
+	IdempotencyKey string `json:"-" form:"-" swaggerignore:"true"`
 }
 
 type CreateTaskResponse struct {
-	ID          string    `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	UserID      string    `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Title       string    `json:"title" example:"Task 1"`
-	Status      string    `json:"status" example:"IN_PROGRESS"`
-	Description *string   `json:"description" example:"Description of task 1"`
-	Date        time.Time `json:"date" example:"2021-09-01T00:00:00Z"`
-	Image       *string   `json:"image" example:"fqfqf"`
+	ID          string   `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID      string   `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title       string   `json:"title" example:"Task 1"`
+	Status      string   `json:"status" example:"IN_PROGRESS"`
+	Description *string  `json:"description" example:"Description of task 1"`
+	Date        string   `json:"date" example:"2021-09-01T00:00:00Z"`
+	AllDay      bool     `json:"all_day" example:"false"`
+	Image       *string  `json:"image" example:"/api/v1/tasks/123e4567-e89b-12d3-a456-426614174000/attachments/550e8400-e29b-41d4-a716-446655440000/content"`
+	AssigneeID  *string  `json:"assignee_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Tags        []string `json:"tags" example:"work"`
+	Version     int      `json:"version" example:"1"`
 }
 
 type GetTaskResponse struct {
-	ID          string  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	UserID      string  `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Title       string  `json:"title" example:"Task 1"`
-	Status      string  `json:"status" example:"IN_PROGRESS"`
-	Description *string `json:"description" example:"Description of task 1"`
-	Date        string  `json:"date" example:"2021-09-01T00:00:00Z"`
-	Image       *string `json:"image" example:"fqfqf"`
-	CreatedAt   string  `json:"created_at" example:"2021-09-01T00:00:00Z"`
+	ID           string   `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID       string   `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	WorkspaceID  *string  `json:"workspace_id,omitempty" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title        string   `json:"title" example:"Task 1"`
+	Status       string   `json:"status" example:"IN_PROGRESS"`
+	Description  *string  `json:"description" example:"Description of task 1"`
+	Date         string   `json:"date" example:"2021-09-01T00:00:00Z"`
+	AllDay       bool     `json:"all_day" example:"false"`
+	Image        *string  `json:"image" example:"/api/v1/tasks/123e4567-e89b-12d3-a456-426614174000/attachments/550e8400-e29b-41d4-a716-446655440000/content"`
+	AssigneeID   *string  `json:"assignee_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CommentCount int64    `json:"comment_count" example:"3"`
+	Blocked      bool     `json:"blocked" example:"false"`
+	Rank         *string  `json:"rank" example:"V"`
+	Tags         []string `json:"tags" example:"work"`
+	Permission   string   `json:"permission,omitempty" example:"owner"`
+	ArchivedAt   *string  `json:"archived_at,omitempty" example:"2021-09-02T00:00:00Z"`
+	Version      int      `json:"version" example:"1"`
+	CreatedAt    string   `json:"created_at" example:"2021-09-01T00:00:00Z"`
+
+	// Set only when the list was searched
+	SearchRank *float64       `json:"search_rank,omitempty" example:"0.6079271"`
+	Highlight  *TaskHighlight `json:"highlight,omitempty"`
+}
+
+type TaskHighlight struct {
+	Title       string  `json:"title" example:"Plan the <mark>release</mark>"`
+	Description *string `json:"description,omitempty" example:"Draft notes for the <mark>release</mark> review"`
 }
 
+// UpdateTaskRequest is sent as JSON or as a form; fields left out are
+// unchanged. The image is a file in a form, or one of the task's image
+// attachments in image_id; an empty image_id removes it.
 type UpdateTaskRequest struct {
-	Title       string                `form:"title" binding:"omitempty,max=100,notblank" example:"Task 1"`
-	Description *string               `form:"description" binding:"omitempty" example:"Description of task 1"`
-	Status      constants.TaskStatus  `form:"status" binding:"omitempty,taskstatus" example:"IN_PROGRESS"`
-	Date        time.Time             `form:"date" time_format:"2006-01-02T15:04:05Z07:00" binding:"omitempty" example:"2021-09-01T00:00:00Z"`
-	Image       *multipart.FileHeader `form:"image" binding:"omitempty" example:"https://example.com/image.jpg"`
+	Title       string                `json:"title" form:"title" binding:"omitempty,max=100,notblank" example:"Task 1"`
+	Description *string               `json:"description" form:"description" binding:"omitempty" example:"Description of task 1"`
+	Status      constants.TaskStatus  `json:"status" form:"status" binding:"omitempty,taskstatus" example:"IN_PROGRESS"`
+	Date        time.Time             `json:"date" form:"date" time_format:"2006-01-02T15:04:05Z07:00" binding:"omitempty" example:"2021-09-01T00:00:00Z"`
+	AllDay      *bool                 `json:"all_day" form:"all_day" example:"false"`
+	Image       *multipart.FileHeader `json:"-" form:"image" binding:"omitempty" swaggerignore:"true"`
+	ImageID     *string               `json:"image_id" form:"image_id" binding:"omitempty,uuid" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AssigneeID  *string               `json:"assignee_id" form:"assignee_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Tags        []string              `json:"tags" form:"tags" binding:"omitempty,max=20,dive,tasktag" example:"work"`
+
+	// IfMatch is the request's If-Match header
+	IfMatch string `json:"-" form:"-" swaggerignore:"true"`
+}
+
+// PatchTaskRequest is a JSON Merge Patch (RFC 7396) of a task. A member left
+// out is unchanged and a member set to null is cleared; title, status and
+// date cannot be cleared. image_id picks one of the task's image attachments
+// as its image.
+type PatchTaskRequest struct {
+	Title       PatchField[string]               `json:"title" swaggertype:"string" example:"Task 1"`
+	Description PatchField[string]               `json:"description" swaggertype:"string" example:"Description of task 1"`
+	Status      PatchField[constants.TaskStatus] `json:"status" swaggertype:"string" example:"COMPLETED"`
+	Date        PatchField[time.Time]            `json:"date" swaggertype:"string" example:"2021-09-01T00:00:00Z"`
+	AllDay      PatchField[bool]                 `json:"all_day" swaggertype:"boolean" example:"false"`
+	Image       PatchField[string]               `json:"image" swaggertype:"string" example:"null"`
+	ImageID     PatchField[string]               `json:"image_id" swaggertype:"string" example:"550e8400-e29b-41d4-a716-446655440000"`
+	AssigneeID  PatchField[string]               `json:"assignee_id" swaggertype:"string" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Tags        PatchField[[]string]             `json:"tags" swaggertype:"array,string" example:"work"`
+
+	// IfMatch is the request's If-Match header
+	IfMatch string `json:"-" swaggerignore:"true"`
+}
+
+// PatchField is one member of a merge patch: Set when the member was sent,
+// and Null when it was sent as null
+type PatchField[T any] struct {
+	Set   bool
+	Null  bool
+	Value T
+}
+
+func (f *PatchField[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if string(data) == "null" {
+		f.Null = true
+		return nil
+	}
+	return json.Unmarshal(data, &f.Value)
 }
 
 type UpdateTaskResponse struct {
-	ID          string  `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	UserID      string  `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
-	Title       string  `json:"title" example:"Task 1"`
-	Status      string  `json:"status" example:"IN_PROGRESS"`
-	Description *string `json:"description" example:"Description of task 1"`
-	Date        string  `json:"date" example:"2021-09-01T00:00:00Z"`
-	Image       *string `json:"image" example:"fqfqf"`
-	CreatedAt   string  `json:"created_at" example:"2021-09-01T00:00:00Z"`
+	ID          string   `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID      string   `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title       string   `json:"title" example:"Task 1"`
+	Status      string   `json:"status" example:"IN_PROGRESS"`
+	Description *string  `json:"description" example:"Description of task 1"`
+	Date        string   `json:"date" example:"2021-09-01T00:00:00Z"`
+	AllDay      bool     `json:"all_day" example:"false"`
+	Image       *string  `json:"image" example:"/api/v1/tasks/123e4567-e89b-12d3-a456-426614174000/attachments/550e8400-e29b-41d4-a716-446655440000/content"`
+	AssigneeID  *string  `json:"assignee_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Tags        []string `json:"tags" example:"work"`
+	Version     int      `json:"version" example:"2"`
+	CreatedAt   string   `json:"created_at" example:"2021-09-01T00:00:00Z"`
 }
 
 type GetAllTasksRequest struct {
-	Search string `form:"search" example:"Task 1"`
-	SortBy string `form:"sort_by" binding:"omitempty,oneof=title created_at status" example:"title"`
-	Order  string `form:"order" binding:"omitempty,oneof=asc desc" example:"asc"`
-	Limit  int    `form:"limit" binding:"min=1,max=100" example:"10"`
-	Offset int    `form:"offset" binding:"min=1" example:"1"`
+	Search   string `form:"search" binding:"omitempty,max=100" example:"release -draft"`
+	Filter   string `form:"filter" binding:"omitempty,max=500,taskfilter" example:"status:IN_PROGRESS due<2026-11-01 tag:work -tag:later"`
+	SortBy   string `form:"sort_by" binding:"omitempty,oneof=title created_at status rank date relevance" example:"title"`
+	Order    string `form:"order" binding:"omitempty,oneof=asc desc" example:"asc"`
+	Assignee string `form:"assignee" binding:"omitempty,assigneefilter" example:"me"`
+	Limit    int    `form:"limit" binding:"min=1,max=100" example:"10"`
+	// Offset is a page number; a cursor takes its place when both are sent
+	Offset       int    `form:"offset" binding:"omitempty,min=1" example:"1"`
+	Cursor       string `form:"cursor" binding:"omitempty,max=1000" example:"eyJxIjoi...Q.b4c1..."`
+	IncludeTotal bool   `form:"include_total" example:"true"`
+	// Archived lists archived tasks instead of active ones
+	Archived bool `form:"archived" example:"false"`
 }
 
 type GetAllTasksResponse struct {
-	Total int               `json:"total" example:"1"`
-	Tasks []GetTaskResponse `json:"tasks"`
+	// Count is the number of tasks on this page; Total counts every match
+	// and is only sent when include_total is set
+	Count      int               `json:"count" example:"1"`
+	Total      *int64            `json:"total,omitempty" example:"42"`
+	NextCursor string            `json:"next_cursor,omitempty" example:"eyJxIjoi...Q.b4c1..."`
+	PrevCursor string            `json:"prev_cursor,omitempty" example:"eyJxIjoi...Q.9a0f..."`
+	Tasks      []GetTaskResponse `json:"tasks"`
+}
+
+type TaskFieldChange struct {
+	Field  string  `json:"field" example:"status"`
+	Before *string `json:"before" example:"IN_PROGRESS"`
+	After  *string `json:"after" example:"COMPLETED"`
+}
+
+type TaskHistoryEntry struct {
+	Version   int               `json:"version" example:"2"`
+	Action    string            `json:"action" example:"UPDATE"`
+	ActorID   string            `json:"actor_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Changes   []TaskFieldChange `json:"changes"`
+	CreatedAt string            `json:"created_at" example:"2021-09-01T00:00:00Z"`
+}
+
+// RevertTaskRequest carries the preconditions of a revert
+type RevertTaskRequest struct {
+	// IfMatch is the request's If-Match header
+	IfMatch string `json:"-" swaggerignore:"true"`
+}
+
+type GetTaskHistoryResponse struct {
+	TaskID  string             `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Total   int                `json:"total" example:"1"`
+	History []TaskHistoryEntry `json:"history"`
+}
+
+type MoveTaskRequest struct {
+	Status   constants.TaskStatus `json:"status" binding:"omitempty,taskstatus" example:"IN_PROGRESS"`
+	BeforeID string               `json:"before_id" binding:"omitempty,uuid,excluded_with=AfterID" example:"123e4567-e89b-12d3-a456-426614174000"`
+	AfterID  string               `json:"after_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+// BulkTaskRequest applies one action to the listed tasks, or to every task
+// matching the filter
+type BulkTaskRequest struct {
+	IDs    []string                 `json:"ids" binding:"required_without=Filter,max=500,dive,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Filter string                   `json:"filter" binding:"omitempty,max=500,taskfilter,excluded_with=IDs" example:"status:IN_PROGRESS tag:sprint-12"`
+	Action constants.TaskBulkAction `json:"action" binding:"required,oneof=set_status add_tag remove_tag archive unarchive delete" example:"set_status"`
+	Status constants.TaskStatus     `json:"status" binding:"omitempty,taskstatus" example:"COMPLETED"`
+	Tag    string                   `json:"tag" binding:"omitempty,tasktag" example:"sprint-12"`
+}
+
+type BulkTaskResult struct {
+	ID     string         `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Result string         `json:"result" example:"updated"`
+	Error  *ErrorResponse `json:"error,omitempty"`
+}
+
+type BulkTaskResponse struct {
+	Action    string           `json:"action" example:"set_status"`
+	Total     int              `json:"total" example:"1"`
+	Succeeded int              `json:"succeeded" example:"1"`
+	Failed    int              `json:"failed" example:"0"`
+	Results   []BulkTaskResult `json:"results"`
+}
+
+type TaskBoardColumn struct {
+	Status string            `json:"status" example:"IN_PROGRESS"`
+	Total  int               `json:"total" example:"1"`
+	Tasks  []GetTaskResponse `json:"tasks"`
+}
+
+type GetTaskBoardResponse struct {
+	Columns []TaskBoardColumn `json:"columns"`
 }
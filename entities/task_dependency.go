@@ -0,0 +1,19 @@
+package entities
+
+type AddTaskDependencyRequest struct {
+	BlockedByID string `json:"blocked_by_id" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+}
+
+type DependencyTaskResponse struct {
+	ID      string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Title   string `json:"title" example:"Task 1"`
+	Status  string `json:"status" example:"IN_PROGRESS"`
+	Blocked bool   `json:"blocked" example:"false"`
+}
+
+type GetTaskDependenciesResponse struct {
+	TaskID    string                   `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Blocked   bool                     `json:"blocked" example:"true"`
+	BlockedBy []DependencyTaskResponse `json:"blocked_by"`
+	Blocking  []DependencyTaskResponse `json:"blocking"`
+}
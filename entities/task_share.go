@@ -0,0 +1,25 @@
+package entities
+
+type ShareTaskRequest struct {
+	Email      string `json:"email" binding:"required,email" example:"jane.doe@example.com"`
+	Permission string `json:"permission" binding:"required,oneof=viewer editor" example:"viewer"`
+}
+
+type TaskShareResponse struct {
+	TaskID     string `json:"task_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	UserID     string `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Email      string `json:"email" example:"jane.doe@example.com"`
+	Permission string `json:"permission" example:"viewer"`
+	SharedBy   string `json:"shared_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	CreatedAt  string `json:"created_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetTaskSharesResponse struct {
+	Total  int                 `json:"total" example:"1"`
+	Shares []TaskShareResponse `json:"shares"`
+}
+
+type GetSharedTasksRequest struct {
+	Limit  int `form:"limit" binding:"min=1,max=100" example:"10"`
+	Offset int `form:"offset" binding:"min=1" example:"1"`
+}
@@ -0,0 +1,54 @@
+package entities
+
+import constants "github.com/guncv/tech-exam-software-engineering/constant"
+
+type CreateViewRequest struct {
+	Name    string                `json:"name" binding:"required,max=100,notblank" example:"Work this week"`
+	Filter  string                `json:"filter" binding:"omitempty,max=500,taskfilter" example:"tag:work due<=today+7d"`
+	SortBy  string                `json:"sort_by" binding:"omitempty,oneof=title created_at status rank date" example:"date"`
+	Order   string                `json:"order" binding:"omitempty,oneof=asc desc" example:"asc"`
+	GroupBy constants.ViewGroupBy `json:"group_by" binding:"omitempty,oneof=none status assignee due" example:"status"`
+}
+
+type UpdateViewRequest struct {
+	Name    string                `json:"name" binding:"omitempty,max=100,notblank" example:"Work this week"`
+	Filter  *string               `json:"filter" binding:"omitempty,max=500,taskfilter" example:"tag:work due<=today+7d"`
+	SortBy  string                `json:"sort_by" binding:"omitempty,oneof=title created_at status rank date" example:"date"`
+	Order   string                `json:"order" binding:"omitempty,oneof=asc desc" example:"asc"`
+	GroupBy constants.ViewGroupBy `json:"group_by" binding:"omitempty,oneof=none status assignee due" example:"status"`
+}
+
+type ViewResponse struct {
+	ID        string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name      string `json:"name" example:"Work this week"`
+	Filter    string `json:"filter" example:"tag:work due<=today+7d"`
+	SortBy    string `json:"sort_by" example:"date"`
+	Order     string `json:"order" example:"asc"`
+	GroupBy   string `json:"group_by" example:"status"`
+	BuiltIn   bool   `json:"built_in" example:"false"`
+	CreatedAt string `json:"created_at,omitempty" example:"2021-09-01T00:00:00Z"`
+	UpdatedAt string `json:"updated_at,omitempty" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetViewsResponse struct {
+	Total int            `json:"total" example:"4"`
+	Views []ViewResponse `json:"views"`
+}
+
+type GetViewTasksRequest struct {
+	Limit  int `form:"limit" binding:"min=1,max=100" example:"10"`
+	Offset int `form:"offset" binding:"min=1" example:"1"`
+}
+
+type ViewTaskGroup struct {
+	Key   string            `json:"key" example:"IN_PROGRESS"`
+	Total int               `json:"total" example:"1"`
+	Tasks []GetTaskResponse `json:"tasks"`
+}
+
+type GetViewTasksResponse struct {
+	View   ViewResponse      `json:"view"`
+	Total  int               `json:"total" example:"1"`
+	Tasks  []GetTaskResponse `json:"tasks,omitempty"`
+	Groups []ViewTaskGroup   `json:"groups,omitempty"`
+}
@@ -0,0 +1,61 @@
+package entities
+
+type CreateWorkspaceRequest struct {
+	Name string `json:"name" binding:"required,max=100,notblank" example:"Platform Team"`
+}
+
+type WorkspaceResponse struct {
+	ID        string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Name      string `json:"name" example:"Platform Team"`
+	OwnerID   string `json:"owner_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Role      string `json:"role" example:"owner"`
+	CreatedAt string `json:"created_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetWorkspacesResponse struct {
+	Total      int                 `json:"total" example:"1"`
+	Workspaces []WorkspaceResponse `json:"workspaces"`
+}
+
+type WorkspaceMemberResponse struct {
+	UserID    string `json:"user_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Email     string `json:"email" example:"jane.doe@example.com"`
+	Role      string `json:"role" example:"member"`
+	CreatedAt string `json:"created_at" example:"2021-09-01T00:00:00Z"`
+}
+
+type GetWorkspaceMembersResponse struct {
+	Total   int                       `json:"total" example:"1"`
+	Members []WorkspaceMemberResponse `json:"members"`
+}
+
+type UpdateWorkspaceMemberRequest struct {
+	Role string `json:"role" binding:"required,oneof=admin member" example:"admin"`
+}
+
+type CreateInvitationRequest struct {
+	Email string `json:"email" binding:"required,email" example:"jane.doe@example.com"`
+	Role  string `json:"role" binding:"required,oneof=admin member" example:"member"`
+}
+
+type InvitationResponse struct {
+	ID          string `json:"id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	WorkspaceID string `json:"workspace_id" example:"123e4567-e89b-12d3-a456-426614174000"`
+	Email       string `json:"email" example:"jane.doe@example.com"`
+	Role        string `json:"role" example:"member"`
+	InvitedBy   string `json:"invited_by" example:"123e4567-e89b-12d3-a456-426614174000"`
+	ExpiresAt   string `json:"expires_at" example:"2021-09-04T00:00:00Z"`
+	CreatedAt   string `json:"created_at" example:"2021-09-01T00:00:00Z"`
+
+	// Only returned once, when the invitation is created
+	Token string `json:"token,omitempty" example:"q4Jm3X1cVQ2h0k8P9yT7wB5nR6sLdZfA1eGuHiOjKlM"`
+}
+
+type GetInvitationsResponse struct {
+	Total       int                  `json:"total" example:"1"`
+	Invitations []InvitationResponse `json:"invitations"`
+}
+
+type AcceptInvitationRequest struct {
+	Token string `json:"token" binding:"required" example:"q4Jm3X1cVQ2h0k8P9yT7wB5nR6sLdZfA1eGuHiOjKlM"`
+}
@@ -84,8 +84,7 @@ func GetLogger() *Logger {
 func (l *Logger) ErrorWithID(ctx context.Context, args ...interface{}) {
 	// Create a new logger instance with caller skip set to 1 to point to the handler
 	loggerWithSkip := l.SugaredLogger.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
-	loc, _ := time.LoadLocation("Asia/Bangkok")
-	timestamp := time.Now().In(loc).Format(time.RFC3339)
+	timestamp := time.Now().UTC().Format(time.RFC3339)
 	loggerWithSkip.Errorf("%s | TimeStamp: %s", fmt.Sprint(args...), timestamp)
 }
 
@@ -93,15 +92,13 @@ func (l *Logger) ErrorWithID(ctx context.Context, args ...interface{}) {
 func (l *Logger) DebugWithID(ctx context.Context, args ...interface{}) {
 	// Create a new logger instance with caller skip set to 1 to point to the handler
 	loggerWithSkip := l.SugaredLogger.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
-	loc, _ := time.LoadLocation("Asia/Bangkok")
-	timestamp := time.Now().In(loc).Format(time.RFC3339)
+	timestamp := time.Now().UTC().Format(time.RFC3339)
 	loggerWithSkip.Debugf("%s | TimeStamp: %s", fmt.Sprint(args...), timestamp)
 }
 
 // InfoWithID logs an info message with custom context information, adjusting caller skip
 func (l *Logger) InfoWithID(ctx context.Context, args ...interface{}) {
 	loggerWithSkip := l.SugaredLogger.Desugar().WithOptions(zap.AddCallerSkip(1)).Sugar()
-	loc, _ := time.LoadLocation("Asia/Bangkok")
-	timestamp := time.Now().In(loc).Format(time.RFC3339)
+	timestamp := time.Now().UTC().Format(time.RFC3339)
 	loggerWithSkip.Infof("%s | TimeStamp: %s", fmt.Sprint(args...), timestamp)
 }
@@ -8,6 +8,7 @@ import (
 	"github.com/guncv/tech-exam-software-engineering/controllers"
 	"github.com/guncv/tech-exam-software-engineering/infras/log"
 	"github.com/guncv/tech-exam-software-engineering/middleware"
+	"github.com/guncv/tech-exam-software-engineering/services"
 	"github.com/guncv/tech-exam-software-engineering/utils"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -18,9 +19,9 @@ func RegisterRoutes(e *gin.Engine, c *dig.Container, tokenMaker utils.IPasetoMak
 
 	e.Use(cors.New(cors.Config{
 		AllowOrigins:     []string{"http://localhost:3000"},
-		AllowMethods:     []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization"},
-		ExposeHeaders:    []string{"Content-Length", "Authorization"},
+		AllowMethods:     []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowHeaders:     []string{"Origin", "Content-Type", "Accept", "Authorization", "X-Workspace-ID", "X-Timezone", "If-Match", "If-None-Match", "Idempotency-Key", "Range", "If-Range"},
+		ExposeHeaders:    []string{"Content-Length", "Authorization", "ETag", "Accept-Ranges", "Content-Range", "Content-Disposition"},
 		AllowCredentials: true,
 		MaxAge:           12 * time.Hour,
 	}))
@@ -30,16 +31,54 @@ func RegisterRoutes(e *gin.Engine, c *dig.Container, tokenMaker utils.IPasetoMak
 	if err := c.Invoke(func(
 		taskController *controllers.TaskController,
 		userController *controllers.UserController,
+		userService services.IUserService,
+		commentController *controllers.CommentController,
+		taskShareController *controllers.TaskShareController,
+		workspaceController *controllers.WorkspaceController,
+		workspaceService services.IWorkspaceService,
+		notificationController *controllers.NotificationController,
+		taskDependencyController *controllers.TaskDependencyController,
+		noteController *controllers.NoteController,
+		revisionController *controllers.RevisionController,
+		viewController *controllers.ViewController,
+		attachmentController *controllers.AttachmentController,
+		importController *controllers.ImportController,
+		exportController *controllers.ExportController,
+		calendarController *controllers.CalendarController,
 	) {
 		api := e.Group("/api/v1")
 		api.GET("/health", taskController.HealthCheck)
 
 		userRoutes(api, userController)
 
-		// Auth Middleware Routes
-		authRoutes := api.Group("/").Use(middleware.AuthMiddleware(tokenMaker, log))
+		// Signed links carry their own grant in place of an access token
+		fileRoutes(api, attachmentController)
+		calendarFeedRoutes(api, calendarController)
 
-		taskRoutes(authRoutes.(*gin.RouterGroup), taskController)
+		// Auth Middleware Routes; times are shown in the caller's time zone
+		authRoutes := api.Group("/").Use(
+			middleware.AuthMiddleware(tokenMaker, log),
+			middleware.TimezoneMiddleware(userService, log),
+		)
+
+		preferenceRoutes(authRoutes.(*gin.RouterGroup), userController)
+		workspaceRoutes(authRoutes.(*gin.RouterGroup), workspaceController)
+		notificationRoutes(authRoutes.(*gin.RouterGroup), notificationController)
+		calendarRoutes(authRoutes.(*gin.RouterGroup), calendarController)
+
+		// Task routes are scoped to the workspace in the X-Workspace-ID header
+		tenantRoutes := authRoutes.(*gin.RouterGroup).Group("/", middleware.WorkspaceMiddleware(workspaceService, log))
+
+		taskRoutes(tenantRoutes, taskController)
+		commentRoutes(tenantRoutes, commentController)
+		taskShareRoutes(tenantRoutes, taskShareController)
+		taskDependencyRoutes(tenantRoutes, taskDependencyController)
+		noteRoutes(tenantRoutes, noteController)
+		revisionRoutes(tenantRoutes, revisionController)
+		viewRoutes(tenantRoutes, viewController)
+		attachmentRoutes(tenantRoutes, attachmentController)
+		importRoutes(tenantRoutes, importController)
+		exportRoutes(tenantRoutes, exportController)
 	}); err != nil {
 		panic(err)
 	}
@@ -50,9 +89,141 @@ func taskRoutes(eg *gin.RouterGroup, taskController *controllers.TaskController)
 	tasks := eg.Group("/tasks")
 	tasks.POST("", taskController.CreateTask)
 	tasks.GET("", taskController.GetAllTasks)
+	tasks.GET("/board", taskController.GetTaskBoard)
+	tasks.POST("/bulk", taskController.BulkTasks)
 	tasks.GET("/:id", taskController.GetTask)
 	tasks.PUT("/:id", taskController.UpdateTask)
+	tasks.PATCH("/:id", taskController.PatchTask)
 	tasks.DELETE("/:id", taskController.DeleteTask)
+	tasks.GET("/:id/history", taskController.GetTaskHistory)
+	tasks.POST("/:id/history/:version/revert", taskController.RevertTask)
+	tasks.POST("/:id/move", taskController.MoveTask)
+}
+
+// Comment Routes
+func commentRoutes(eg *gin.RouterGroup, commentController *controllers.CommentController) {
+	comments := eg.Group("/tasks/:id/comments")
+	comments.POST("", commentController.CreateComment)
+	comments.GET("", commentController.GetComments)
+	comments.PUT("/:comment_id", commentController.UpdateComment)
+	comments.DELETE("/:comment_id", commentController.DeleteComment)
+}
+
+// Task Share Routes
+func taskShareRoutes(eg *gin.RouterGroup, taskShareController *controllers.TaskShareController) {
+	eg.GET("/tasks/shared", taskShareController.GetSharedTasks)
+
+	shares := eg.Group("/tasks/:id/shares")
+	shares.POST("", taskShareController.ShareTask)
+	shares.GET("", taskShareController.GetTaskShares)
+	shares.DELETE("/:user_id", taskShareController.RevokeShare)
+}
+
+// Task Dependency Routes
+func taskDependencyRoutes(eg *gin.RouterGroup, taskDependencyController *controllers.TaskDependencyController) {
+	dependencies := eg.Group("/tasks/:id/dependencies")
+	dependencies.POST("", taskDependencyController.AddDependency)
+	dependencies.GET("", taskDependencyController.GetDependencies)
+	dependencies.DELETE("/:blocked_by_id", taskDependencyController.RemoveDependency)
+}
+
+// Note Routes
+func noteRoutes(eg *gin.RouterGroup, noteController *controllers.NoteController) {
+	notes := eg.Group("/notes")
+	notes.POST("", noteController.CreateNote)
+	notes.GET("", noteController.GetNotes)
+	notes.GET("/:id", noteController.GetNote)
+	notes.PUT("/:id", noteController.UpdateNote)
+	notes.DELETE("/:id", noteController.DeleteNote)
+}
+
+// Revision Routes
+func revisionRoutes(eg *gin.RouterGroup, revisionController *controllers.RevisionController) {
+	noteRevisions := eg.Group("/notes/:id/revisions")
+	noteRevisions.GET("", revisionController.GetNoteRevisions)
+	noteRevisions.GET("/diff", revisionController.DiffNoteRevisions)
+	noteRevisions.POST("/:version/restore", revisionController.RestoreNoteRevision)
+
+	taskRevisions := eg.Group("/tasks/:id/revisions")
+	taskRevisions.GET("", revisionController.GetTaskRevisions)
+	taskRevisions.GET("/diff", revisionController.DiffTaskRevisions)
+	taskRevisions.POST("/:version/restore", revisionController.RestoreTaskRevision)
+}
+
+// View Routes
+func viewRoutes(eg *gin.RouterGroup, viewController *controllers.ViewController) {
+	views := eg.Group("/views")
+	views.POST("", viewController.CreateView)
+	views.GET("", viewController.GetViews)
+	views.GET("/:id", viewController.GetView)
+	views.PUT("/:id", viewController.UpdateView)
+	views.DELETE("/:id", viewController.DeleteView)
+	views.GET("/:id/tasks", viewController.GetViewTasks)
+}
+
+// File Routes
+func fileRoutes(eg *gin.RouterGroup, attachmentController *controllers.AttachmentController) {
+	files := eg.Group("/files/tasks/:id/attachments")
+	files.GET("/:attachment_id/content", attachmentController.GetSignedAttachmentContent)
+	files.GET("/:attachment_id/thumbnails/:size", attachmentController.GetSignedAttachmentThumbnail)
+}
+
+// Attachment Routes
+func attachmentRoutes(eg *gin.RouterGroup, attachmentController *controllers.AttachmentController) {
+	attachments := eg.Group("/tasks/:id/attachments")
+	attachments.POST("", attachmentController.UploadAttachment)
+	attachments.GET("", attachmentController.GetAttachments)
+	attachments.GET("/:attachment_id/content", attachmentController.GetAttachmentContent)
+	attachments.GET("/:attachment_id/thumbnails/:size", attachmentController.GetAttachmentThumbnail)
+	attachments.DELETE("/:attachment_id", attachmentController.DeleteAttachment)
+}
+
+// Import Routes
+func importRoutes(eg *gin.RouterGroup, importController *controllers.ImportController) {
+	imports := eg.Group("/tasks/imports")
+	imports.POST("", importController.ImportTasks)
+	imports.GET("/:job_id", importController.GetImportJob)
+}
+
+// Export Routes
+func exportRoutes(eg *gin.RouterGroup, exportController *controllers.ExportController) {
+	eg.GET("/tasks/export", exportController.ExportTasks)
+}
+
+// Workspace Routes
+func workspaceRoutes(eg *gin.RouterGroup, workspaceController *controllers.WorkspaceController) {
+	workspaces := eg.Group("/workspaces")
+	workspaces.POST("", workspaceController.CreateWorkspace)
+	workspaces.GET("", workspaceController.GetWorkspaces)
+	workspaces.POST("/invitations/accept", workspaceController.AcceptInvitation)
+	workspaces.GET("/:id", workspaceController.GetWorkspace)
+	workspaces.GET("/:id/members", workspaceController.GetMembers)
+	workspaces.PUT("/:id/members/:user_id", workspaceController.UpdateMemberRole)
+	workspaces.DELETE("/:id/members/:user_id", workspaceController.RemoveMember)
+	workspaces.POST("/:id/invitations", workspaceController.CreateInvitation)
+	workspaces.GET("/:id/invitations", workspaceController.GetInvitations)
+	workspaces.DELETE("/:id/invitations/:invitation_id", workspaceController.RevokeInvitation)
+}
+
+// Notification Routes
+func notificationRoutes(eg *gin.RouterGroup, notificationController *controllers.NotificationController) {
+	notifications := eg.Group("/notifications")
+	notifications.GET("", notificationController.GetNotifications)
+	notifications.POST("/read-all", notificationController.MarkAllRead)
+	notifications.POST("/:id/read", notificationController.MarkRead)
+}
+
+// Calendar Routes
+func calendarRoutes(eg *gin.RouterGroup, calendarController *controllers.CalendarController) {
+	feed := eg.Group("/calendar/feed")
+	feed.POST("", calendarController.CreateCalendarFeed)
+	feed.GET("", calendarController.GetCalendarFeed)
+	feed.DELETE("", calendarController.DeleteCalendarFeed)
+}
+
+// Calendar Feed Routes, authorized by the token in the URL
+func calendarFeedRoutes(eg *gin.RouterGroup, calendarController *controllers.CalendarController) {
+	eg.GET("/calendar/feeds/:token", calendarController.GetCalendarFeedContent)
 }
 
 // User Routes
@@ -61,3 +232,10 @@ func userRoutes(eg *gin.RouterGroup, userController *controllers.UserController)
 	users.POST("", userController.Register)
 	users.POST("/login", userController.Login)
 }
+
+// Preference Routes
+func preferenceRoutes(eg *gin.RouterGroup, userController *controllers.UserController) {
+	preferences := eg.Group("/users/me/preferences")
+	preferences.GET("", userController.GetPreferences)
+	preferences.PUT("", userController.UpdatePreferences)
+}
@@ -64,4 +64,33 @@ func RegisterCustomValidations() {
 			return strings.TrimSpace(fl.Field().String()) != ""
 		})
 	}
+
+	// Register custom validation for the assignee filter: me, unassigned or a user id
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("assigneefilter", func(fl validator.FieldLevel) bool {
+			return utils.IsValidAssigneeFilter(fl.Field().String())
+		})
+	}
+
+	// Register custom validation for task tags; empty clears the tags
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("tasktag", func(fl validator.FieldLevel) bool {
+			return utils.IsValidTagInput(fl.Field().String())
+		})
+	}
+
+	// Register custom validation for IANA time zone names
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("timezone", func(fl validator.FieldLevel) bool {
+			return utils.IsValidTimezone(fl.Field().String())
+		})
+	}
+
+	// Register custom validation for the task filter expression
+	if v, ok := binding.Validator.Engine().(*validator.Validate); ok {
+		v.RegisterValidation("taskfilter", func(fl validator.FieldLevel) bool {
+			_, err := utils.ParseTaskFilter(fl.Field().String())
+			return err == nil
+		})
+	}
 }
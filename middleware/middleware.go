@@ -3,10 +3,13 @@ package middleware
 import (
 	"context"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	constants "github.com/guncv/tech-exam-software-engineering/constant"
 	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/services"
 	"github.com/guncv/tech-exam-software-engineering/utils"
 )
 
@@ -50,3 +53,71 @@ func AuthMiddleware(tokenMaker utils.IPasetoMaker, log *log.Logger) gin.HandlerF
 		ctx.Next()
 	}
 }
+
+// WorkspaceMiddleware scopes the request to the workspace named by the
+// X-Workspace-ID header after checking the caller is a member. Requests
+// without the header stay in the caller's personal scope. It must run after
+// AuthMiddleware.
+func WorkspaceMiddleware(workspaceService services.IWorkspaceService, log *log.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		log.DebugWithID(ctx, "[Middleware: WorkspaceMiddleware] Called")
+		workspaceId := strings.TrimSpace(ctx.GetHeader(constants.WorkspaceHeaderKey))
+		if workspaceId == "" {
+			ctx.Next()
+			return
+		}
+
+		if _, err := uuid.Parse(workspaceId); err != nil {
+			log.ErrorWithID(ctx, "[Middleware: WorkspaceMiddleware] Invalid workspace id", err)
+			utils.AbortWithErrorResponse(ctx, constants.ErrInvalidWorkspaceHeader)
+			return
+		}
+
+		role, err := workspaceService.GetMembership(ctx.Request.Context(), workspaceId)
+		if err != nil {
+			log.ErrorWithID(ctx, "[Middleware: WorkspaceMiddleware] Failed to verify membership", err)
+			utils.ErrorResponse(ctx, err)
+			ctx.Abort()
+			return
+		}
+
+		newCtx := utils.WithWorkspace(ctx.Request.Context(), workspaceId, role)
+		ctx.Request = ctx.Request.WithContext(newCtx)
+		log.DebugWithID(ctx, "[Middleware: WorkspaceMiddleware] Request scoped to workspace", workspaceId)
+
+		ctx.Next()
+	}
+}
+
+// TimezoneMiddleware sets the time zone the request's times are shown and
+// read in: the X-Timezone header, else the tz query parameter, else the
+// caller's preference. It must run after AuthMiddleware.
+func TimezoneMiddleware(userService services.IUserService, log *log.Logger) gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		log.DebugWithID(ctx, "[Middleware: TimezoneMiddleware] Called")
+		name := strings.TrimSpace(ctx.GetHeader(constants.TimezoneHeaderKey))
+		if name == "" {
+			name = strings.TrimSpace(ctx.Query(constants.TimezoneQueryKey))
+		}
+
+		var loc *time.Location
+		var err error
+		if name != "" {
+			loc, err = utils.LoadTimezone(name)
+		} else {
+			loc, err = userService.GetLocation(ctx.Request.Context())
+		}
+		if err != nil {
+			log.ErrorWithID(ctx, "[Middleware: TimezoneMiddleware] Failed to resolve time zone", err)
+			utils.ErrorResponse(ctx, err)
+			ctx.Abort()
+			return
+		}
+
+		newCtx := utils.WithLocation(ctx.Request.Context(), loc)
+		ctx.Request = ctx.Request.WithContext(newCtx)
+		log.DebugWithID(ctx, "[Middleware: TimezoneMiddleware] Time zone set", loc.String())
+
+		ctx.Next()
+	}
+}
@@ -0,0 +1,2 @@
+-- Drop the task history table
+DROP TABLE IF EXISTS task_history;
@@ -0,0 +1,22 @@
+-- Create task history table
+CREATE TABLE task_history (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL,
+  actor_id UUID NOT NULL,
+  action VARCHAR(20) NOT NULL CHECK (action IN ('CREATE', 'UPDATE', 'DELETE', 'REVERT')),
+  version INTEGER NOT NULL,
+  changes JSONB NOT NULL DEFAULT '[]',
+  snapshot JSONB,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT uq_task_history_version UNIQUE (task_id, version)
+);
+
+-- Rows stay behind when their task is deleted, so task_id carries no foreign
+-- key; only the history of a task that still exists can be read
+CREATE INDEX idx_task_history_task_id ON task_history (task_id);
+CREATE INDEX idx_task_history_actor_id ON task_history (actor_id);
+
+COMMENT ON COLUMN task_history.actor_id IS 'User who performed the change';
+COMMENT ON COLUMN task_history.version IS 'Per-task version number, starting at 1 on create';
+COMMENT ON COLUMN task_history.changes IS 'Field-level before/after diff';
+COMMENT ON COLUMN task_history.snapshot IS 'Full task state after the change (before it, for DELETE)';
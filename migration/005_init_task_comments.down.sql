@@ -0,0 +1,2 @@
+-- Drop the task comments table
+DROP TABLE IF EXISTS task_comments;
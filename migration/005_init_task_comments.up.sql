@@ -0,0 +1,21 @@
+-- Create task comments table
+CREATE TABLE task_comments (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  body TEXT NOT NULL,
+  edited BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT fk_task_comments_task
+    FOREIGN KEY (task_id) REFERENCES tasks(id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_task_comments_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_task_comments_task_id_created_at ON task_comments (task_id, created_at);
+
+COMMENT ON COLUMN task_comments.body IS 'Comment text (required, max 5000 characters)';
+COMMENT ON COLUMN task_comments.edited IS 'True once the author has edited the comment';
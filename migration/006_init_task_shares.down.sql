@@ -0,0 +1,2 @@
+-- Drop the task shares table
+DROP TABLE IF EXISTS task_shares;
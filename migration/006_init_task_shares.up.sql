@@ -0,0 +1,23 @@
+-- Create task shares table
+CREATE TABLE task_shares (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  permission VARCHAR(20) NOT NULL CHECK (permission IN ('viewer', 'editor')),
+  shared_by UUID NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT uq_task_shares_task_user UNIQUE (task_id, user_id),
+  CONSTRAINT fk_task_shares_task
+    FOREIGN KEY (task_id) REFERENCES tasks(id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_task_shares_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+-- "Shared with me" lookups go by grantee
+CREATE INDEX idx_task_shares_user_id ON task_shares (user_id);
+
+COMMENT ON COLUMN task_shares.user_id IS 'User the task is shared with';
+COMMENT ON COLUMN task_shares.permission IS 'Only accepts viewer or editor; the owner is tasks.user_id';
+COMMENT ON COLUMN task_shares.shared_by IS 'Owner who granted the share';
@@ -0,0 +1,8 @@
+-- Detach tasks from workspaces
+DROP INDEX IF EXISTS idx_tasks_workspace_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS workspace_id;
+
+-- Drop the workspace tables
+DROP TABLE IF EXISTS workspace_invitations;
+DROP TABLE IF EXISTS workspace_members;
+DROP TABLE IF EXISTS workspaces;
@@ -0,0 +1,58 @@
+-- Create workspaces table
+CREATE TABLE workspaces (
+  id UUID PRIMARY KEY,
+  name VARCHAR(100) NOT NULL,
+  owner_id UUID NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT fk_workspaces_owner
+    FOREIGN KEY (owner_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+-- Create workspace members table
+CREATE TABLE workspace_members (
+  workspace_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  role VARCHAR(20) NOT NULL CHECK (role IN ('owner', 'admin', 'member')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (workspace_id, user_id),
+  CONSTRAINT fk_workspace_members_workspace
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_workspace_members_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+-- "My workspaces" lookups go by user
+CREATE INDEX idx_workspace_members_user_id ON workspace_members (user_id);
+
+-- Create workspace invitations table
+CREATE TABLE workspace_invitations (
+  id UUID PRIMARY KEY,
+  workspace_id UUID NOT NULL,
+  email VARCHAR(255) NOT NULL,
+  role VARCHAR(20) NOT NULL CHECK (role IN ('admin', 'member')),
+  token_hash CHAR(64) NOT NULL UNIQUE,
+  invited_by UUID NOT NULL,
+  expires_at TIMESTAMPTZ NOT NULL,
+  accepted_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT fk_workspace_invitations_workspace
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+    ON DELETE CASCADE
+);
+
+-- Tasks without a workspace stay personal to their owner
+ALTER TABLE tasks
+  ADD COLUMN workspace_id UUID,
+  ADD CONSTRAINT fk_tasks_workspace
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+    ON DELETE CASCADE;
+
+CREATE INDEX idx_tasks_workspace_id ON tasks (workspace_id);
+
+COMMENT ON COLUMN workspace_members.role IS 'Only accepts owner, admin or member; exactly one owner per workspace';
+COMMENT ON COLUMN workspace_invitations.token_hash IS 'SHA-256 hex of the invitation token; the token itself is never stored';
+COMMENT ON COLUMN workspace_invitations.accepted_at IS 'Set once the invitation is used; used invitations cannot be accepted again';
+COMMENT ON COLUMN tasks.workspace_id IS 'Workspace the task belongs to, NULL for personal tasks';
@@ -0,0 +1,6 @@
+-- Drop the notifications table
+DROP TABLE IF EXISTS notifications;
+
+-- Drop task assignment
+DROP INDEX IF EXISTS idx_tasks_assignee_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS assignee_id;
@@ -0,0 +1,35 @@
+-- Tasks can be assigned to one user with access to them
+ALTER TABLE tasks
+  ADD COLUMN assignee_id UUID,
+  ADD CONSTRAINT fk_tasks_assignee
+    FOREIGN KEY (assignee_id) REFERENCES users(id)
+    ON DELETE SET NULL;
+
+CREATE INDEX idx_tasks_assignee_id ON tasks (assignee_id);
+
+COMMENT ON COLUMN tasks.assignee_id IS 'User the task is assigned to, NULL when unassigned';
+
+-- Create notifications table
+CREATE TABLE notifications (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL,
+  type VARCHAR(30) NOT NULL,
+  task_id UUID NOT NULL,
+  actor_id UUID NOT NULL,
+  message TEXT NOT NULL,
+  read_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT fk_notifications_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_notifications_task
+    FOREIGN KEY (task_id) REFERENCES tasks(id)
+    ON DELETE CASCADE
+);
+
+-- Inbox lookups go by recipient, newest first
+CREATE INDEX idx_notifications_user_id_created_at ON notifications (user_id, created_at DESC);
+
+COMMENT ON COLUMN notifications.user_id IS 'Recipient of the notification';
+COMMENT ON COLUMN notifications.type IS 'TASK_ASSIGNED or TASK_UNASSIGNED';
+COMMENT ON COLUMN notifications.read_at IS 'Set when the recipient marks it read, NULL while unread';
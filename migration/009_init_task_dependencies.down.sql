@@ -0,0 +1,2 @@
+-- Drop the task dependencies table
+DROP TABLE IF EXISTS task_dependencies;
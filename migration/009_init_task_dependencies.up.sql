@@ -0,0 +1,21 @@
+-- Create task dependencies table
+CREATE TABLE task_dependencies (
+  task_id UUID NOT NULL,
+  blocked_by_id UUID NOT NULL,
+  created_by UUID NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (task_id, blocked_by_id),
+  CONSTRAINT chk_task_dependencies_not_self CHECK (task_id <> blocked_by_id),
+  CONSTRAINT fk_task_dependencies_task
+    FOREIGN KEY (task_id) REFERENCES tasks(id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_task_dependencies_blocked_by
+    FOREIGN KEY (blocked_by_id) REFERENCES tasks(id)
+    ON DELETE CASCADE
+);
+
+-- "What does this task block" lookups go by blocker
+CREATE INDEX idx_task_dependencies_blocked_by_id ON task_dependencies (blocked_by_id);
+
+COMMENT ON COLUMN task_dependencies.task_id IS 'Task that cannot be completed yet';
+COMMENT ON COLUMN task_dependencies.blocked_by_id IS 'Task that must be COMPLETED first';
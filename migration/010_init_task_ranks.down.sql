@@ -0,0 +1,6 @@
+-- Drop manual task ordering
+DROP INDEX IF EXISTS idx_tasks_workspace_id_status_rank;
+DROP INDEX IF EXISTS idx_tasks_user_id_status_rank;
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS rank;
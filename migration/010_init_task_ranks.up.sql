@@ -0,0 +1,9 @@
+-- Manual ordering of tasks within a board column (status)
+ALTER TABLE tasks
+  ADD COLUMN rank VARCHAR(64) COLLATE "C";
+
+-- Board columns are read per owner or workspace, in rank order
+CREATE INDEX idx_tasks_user_id_status_rank ON tasks (user_id, status, rank) WHERE workspace_id IS NULL;
+CREATE INDEX idx_tasks_workspace_id_status_rank ON tasks (workspace_id, status, rank) WHERE workspace_id IS NOT NULL;
+
+COMMENT ON COLUMN tasks.rank IS 'Base-62 fractional position within the status column, NULL until the column is first reordered';
@@ -0,0 +1,3 @@
+-- Drop the notes tables
+DROP TABLE IF EXISTS note_links;
+DROP TABLE IF EXISTS notes;
@@ -0,0 +1,44 @@
+-- Create notes table
+CREATE TABLE notes (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL,
+  workspace_id UUID,
+  task_id UUID,
+  title VARCHAR(200) NOT NULL,
+  body TEXT NOT NULL DEFAULT '',
+  pinned BOOLEAN NOT NULL DEFAULT false,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT fk_notes_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_notes_workspace
+    FOREIGN KEY (workspace_id) REFERENCES workspaces(id)
+    ON DELETE CASCADE,
+  CONSTRAINT fk_notes_task
+    FOREIGN KEY (task_id) REFERENCES tasks(id)
+    ON DELETE SET NULL
+);
+
+-- Listings go by owner or workspace, pinned first, most recently edited first
+CREATE INDEX idx_notes_user_id_updated_at ON notes (user_id, pinned DESC, updated_at DESC) WHERE workspace_id IS NULL;
+CREATE INDEX idx_notes_workspace_id_updated_at ON notes (workspace_id, pinned DESC, updated_at DESC) WHERE workspace_id IS NOT NULL;
+CREATE INDEX idx_notes_task_id ON notes (task_id);
+
+COMMENT ON COLUMN notes.body IS 'Markdown source';
+COMMENT ON COLUMN notes.task_id IS 'Task the note is attached to, NULL for standalone notes';
+
+-- Wiki-style [[title]] links, one row per distinct title linked from a note
+CREATE TABLE note_links (
+  note_id UUID NOT NULL,
+  target_title VARCHAR(200) NOT NULL,
+  PRIMARY KEY (note_id, target_title),
+  CONSTRAINT fk_note_links_note
+    FOREIGN KEY (note_id) REFERENCES notes(id)
+    ON DELETE CASCADE
+);
+
+-- Backlink lookups go by the linked title
+CREATE INDEX idx_note_links_target_title ON note_links (target_title);
+
+COMMENT ON COLUMN note_links.target_title IS 'Linked title, trimmed and lower-cased; resolved against note titles at read time';
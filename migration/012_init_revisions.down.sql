@@ -0,0 +1,3 @@
+-- Drop revisions and their content
+DROP TABLE IF EXISTS revisions;
+DROP TABLE IF EXISTS content_blobs;
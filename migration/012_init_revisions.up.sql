@@ -0,0 +1,41 @@
+-- Revision content, stored once per distinct text
+CREATE TABLE content_blobs (
+  hash CHAR(64) PRIMARY KEY,
+  content TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+COMMENT ON COLUMN content_blobs.hash IS 'Hex SHA-256 of content';
+
+-- Revisions of note bodies and task descriptions
+CREATE TABLE revisions (
+  id UUID PRIMARY KEY,
+  resource_type VARCHAR(10) NOT NULL CHECK (resource_type IN ('note', 'task')),
+  resource_id UUID NOT NULL,
+  version INT NOT NULL,
+  title VARCHAR(200),
+  content_hash CHAR(64) NOT NULL,
+  actor_id UUID NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT uq_revisions_resource_version UNIQUE (resource_type, resource_id, version),
+  CONSTRAINT fk_revisions_content
+    FOREIGN KEY (content_hash) REFERENCES content_blobs(hash)
+);
+
+COMMENT ON COLUMN revisions.resource_id IS 'Note or task id; rows are removed with the resource';
+COMMENT ON COLUMN revisions.title IS 'Note title at this revision, NULL for task descriptions';
+
+-- Existing note bodies and task descriptions become their first revision.
+-- Ids are derived from the resource so the backfill needs no extension.
+INSERT INTO content_blobs (hash, content)
+SELECT encode(sha256(convert_to(body, 'UTF8')), 'hex'), body FROM notes
+UNION
+SELECT encode(sha256(convert_to(description, 'UTF8')), 'hex'), description FROM tasks WHERE description IS NOT NULL
+ON CONFLICT (hash) DO NOTHING;
+
+INSERT INTO revisions (id, resource_type, resource_id, version, title, content_hash, actor_id, created_at)
+SELECT md5('note:' || id::text)::uuid, 'note', id, 1, title, encode(sha256(convert_to(body, 'UTF8')), 'hex'), user_id, updated_at FROM notes;
+
+INSERT INTO revisions (id, resource_type, resource_id, version, title, content_hash, actor_id, created_at)
+SELECT md5('task:' || id::text)::uuid, 'task', id, 1, NULL, encode(sha256(convert_to(description, 'UTF8')), 'hex'), user_id, created_at
+FROM tasks WHERE description IS NOT NULL;
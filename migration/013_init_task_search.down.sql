@@ -0,0 +1,5 @@
+-- Drop full-text search
+DROP INDEX IF EXISTS idx_tasks_search_vector;
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,10 @@
+-- Full-text search over task titles (weight A) and descriptions (weight B)
+ALTER TABLE tasks
+  ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B')
+  ) STORED;
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+
+COMMENT ON COLUMN tasks.search_vector IS 'English tsvector of title and description, maintained by Postgres';
@@ -0,0 +1,7 @@
+-- Free-form labels on tasks, matched by the task filter's tag: term
+ALTER TABLE tasks
+  ADD COLUMN tags TEXT[] NOT NULL DEFAULT '{}';
+
+CREATE INDEX idx_tasks_tags ON tasks USING GIN (tags);
+
+COMMENT ON COLUMN tasks.tags IS 'Lowercase tags of letters, digits, - and _; at most 20 per task';
@@ -0,0 +1,18 @@
+-- Create saved_views table: a user's named task filter, sort and grouping
+CREATE TABLE saved_views (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL,
+  name VARCHAR(100) NOT NULL,
+  filter TEXT NOT NULL DEFAULT '',
+  sort_by VARCHAR(20) NOT NULL DEFAULT 'created_at',
+  sort_order VARCHAR(4) NOT NULL DEFAULT 'desc' CHECK (sort_order IN ('asc', 'desc')),
+  group_by VARCHAR(20) NOT NULL DEFAULT 'none' CHECK (group_by IN ('none', 'status', 'assignee', 'due')),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT fk_saved_views_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE,
+  CONSTRAINT uq_saved_views_user_id_name UNIQUE (user_id, name)
+);
+
+COMMENT ON COLUMN saved_views.filter IS 'Task filter expression, parsed and compiled each time the view runs';
@@ -0,0 +1,5 @@
+-- Drop task archiving
+DROP INDEX IF EXISTS idx_tasks_archived_at;
+
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,7 @@
+-- Archived tasks are kept but left out of the task list and board
+ALTER TABLE tasks
+  ADD COLUMN archived_at TIMESTAMPTZ;
+
+CREATE INDEX idx_tasks_archived_at ON tasks (archived_at) WHERE archived_at IS NOT NULL;
+
+COMMENT ON COLUMN tasks.archived_at IS 'When the task was archived; NULL while it is active';
@@ -0,0 +1,3 @@
+-- Drop task versions
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS version;
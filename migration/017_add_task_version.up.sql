@@ -0,0 +1,5 @@
+-- Every update increments a task's version, which is served as its ETag
+ALTER TABLE tasks
+  ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+COMMENT ON COLUMN tasks.version IS 'Incremented on every update; updates only apply to the version they were read at';
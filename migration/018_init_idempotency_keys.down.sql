@@ -0,0 +1,2 @@
+-- Drop idempotency_keys table
+DROP TABLE IF EXISTS idempotency_keys;
@@ -0,0 +1,17 @@
+-- Create idempotency_keys table: the outcome of a request sent with an
+-- Idempotency-Key header, replayed when the request is retried
+CREATE TABLE idempotency_keys (
+  user_id UUID NOT NULL,
+  key VARCHAR(255) NOT NULL,
+  fingerprint VARCHAR(64) NOT NULL,
+  response JSONB,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  expires_at TIMESTAMPTZ NOT NULL,
+  PRIMARY KEY (user_id, key),
+  CONSTRAINT fk_idempotency_keys_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN idempotency_keys.fingerprint IS 'Digest of the request; a retry must match it';
+COMMENT ON COLUMN idempotency_keys.response IS 'Response body of the completed request; NULL while it is in progress';
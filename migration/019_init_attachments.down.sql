@@ -0,0 +1,5 @@
+-- Drop attachments; images already moved to blob storage are not restored
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS image_id;
+
+DROP TABLE IF EXISTS attachments;
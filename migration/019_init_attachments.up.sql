@@ -0,0 +1,29 @@
+-- Create attachments table: metadata of task files kept in blob storage
+CREATE TABLE attachments (
+  id UUID PRIMARY KEY,
+  task_id UUID NOT NULL,
+  user_id UUID NOT NULL,
+  storage_key TEXT NOT NULL UNIQUE,
+  filename VARCHAR(255) NOT NULL,
+  content_type VARCHAR(100) NOT NULL,
+  size BIGINT NOT NULL CHECK (size >= 0),
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  -- Deferred so a new task and its image can be inserted in one transaction
+  CONSTRAINT fk_attachments_task
+    FOREIGN KEY (task_id) REFERENCES tasks(id)
+    ON DELETE CASCADE
+    DEFERRABLE INITIALLY DEFERRED,
+  CONSTRAINT fk_attachments_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_attachments_task_id ON attachments (task_id);
+
+-- The task image is now one of its attachments. The old base64 column stays
+-- until the server has moved every image out of it on startup.
+ALTER TABLE tasks
+  ADD COLUMN image_id UUID REFERENCES attachments(id) ON DELETE SET NULL;
+
+COMMENT ON COLUMN attachments.storage_key IS 'Key of the file contents in the blob store';
+COMMENT ON COLUMN tasks.image IS 'Deprecated: base64 image, moved into attachments on startup';
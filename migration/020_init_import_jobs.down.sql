@@ -0,0 +1,2 @@
+-- Drop import_jobs table
+DROP TABLE IF EXISTS import_jobs;
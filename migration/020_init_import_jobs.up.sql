@@ -0,0 +1,25 @@
+-- Create import_jobs table: progress and outcome of a task import run in
+-- the background
+CREATE TABLE import_jobs (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL,
+  format VARCHAR(20) NOT NULL,
+  status VARCHAR(20) NOT NULL CHECK (status IN ('PENDING', 'RUNNING', 'COMPLETED', 'FAILED')),
+  total INTEGER NOT NULL DEFAULT 0,
+  imported INTEGER NOT NULL DEFAULT 0,
+  failed INTEGER NOT NULL DEFAULT 0,
+  errors JSONB NOT NULL DEFAULT '[]',
+  error TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  finished_at TIMESTAMPTZ,
+  CONSTRAINT fk_import_jobs_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+CREATE INDEX idx_import_jobs_user_id ON import_jobs (user_id);
+
+COMMENT ON COLUMN import_jobs.failed IS 'Rows skipped because they did not pass validation';
+COMMENT ON COLUMN import_jobs.errors IS 'Validation errors of the skipped rows, by row number';
+COMMENT ON COLUMN import_jobs.error IS 'Why the job stopped, when it failed';
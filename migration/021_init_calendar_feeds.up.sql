@@ -0,0 +1,13 @@
+-- Create calendar_feeds table: the secret behind each user's subscribable
+-- calendar of tasks
+CREATE TABLE calendar_feeds (
+  user_id UUID PRIMARY KEY,
+  token_hash CHAR(64) NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  CONSTRAINT fk_calendar_feeds_user
+    FOREIGN KEY (user_id) REFERENCES users(id)
+    ON DELETE CASCADE
+);
+
+COMMENT ON COLUMN calendar_feeds.token_hash IS 'SHA-256 hex of the feed token; the token itself is never stored';
+COMMENT ON COLUMN calendar_feeds.created_at IS 'When the token was last generated; older feed URLs stop working';
@@ -0,0 +1,3 @@
+-- Drop user time zones
+ALTER TABLE users
+  DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,5 @@
+-- Users pick the time zone their times are shown in
+ALTER TABLE users
+  ADD COLUMN timezone VARCHAR(64);
+
+COMMENT ON COLUMN users.timezone IS 'IANA time zone name, e.g. Europe/Berlin; NULL uses the default Asia/Bangkok';
@@ -0,0 +1,3 @@
+-- Drop all-day tasks
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS all_day;
@@ -0,0 +1,7 @@
+-- All-day tasks are due on a day rather than at an instant. Their date is
+-- stored as midnight UTC of that day, so it reads as the same day in every
+-- time zone. Existing tasks keep their time.
+ALTER TABLE tasks
+  ADD COLUMN all_day BOOLEAN NOT NULL DEFAULT false;
+
+COMMENT ON COLUMN tasks.all_day IS 'Whether the task is due on a day with no time; its date is then midnight UTC of that day';
@@ -0,0 +1,3 @@
+-- Drop task modification times
+ALTER TABLE tasks
+  DROP COLUMN IF EXISTS updated_at;
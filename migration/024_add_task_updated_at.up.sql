@@ -0,0 +1,8 @@
+-- Tasks record when they were last changed, for calendar clients to tell
+-- an edited entry from an unchanged one. Existing tasks were last changed
+-- by their latest history entry.
+ALTER TABLE tasks
+  ADD COLUMN updated_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+UPDATE tasks
+  SET updated_at = COALESCE((SELECT MAX(h.created_at) FROM task_history h WHERE h.task_id = tasks.id), tasks.created_at);
@@ -0,0 +1,11 @@
+-- A request holds its idempotency key only for a while, so a key whose
+-- request never finished can be claimed again by a retry. Keys left in
+-- progress before now are free to claim.
+ALTER TABLE idempotency_keys
+  ADD COLUMN locked_until TIMESTAMPTZ;
+
+UPDATE idempotency_keys
+  SET locked_until = created_at
+  WHERE response IS NULL;
+
+COMMENT ON COLUMN idempotency_keys.locked_until IS 'Until when the request in progress holds the key; NULL once it completes';
@@ -0,0 +1,479 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+
+	repositories "github.com/guncv/tech-exam-software-engineering/repositories"
+
+	uuid "github.com/google/uuid"
+)
+
+// MockIAttachmentRepository is an autogenerated mock type for the IAttachmentRepository type
+type MockIAttachmentRepository struct {
+	mock.Mock
+}
+
+type MockIAttachmentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIAttachmentRepository) EXPECT() *MockIAttachmentRepository_Expecter {
+	return &MockIAttachmentRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateAttachment provides a mock function with given fields: ctx, attachment, quota
+func (_m *MockIAttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error {
+	ret := _m.Called(ctx, attachment, quota)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Attachment, int64) error); ok {
+		r0 = rf(ctx, attachment, quota)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIAttachmentRepository_CreateAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAttachment'
+type MockIAttachmentRepository_CreateAttachment_Call struct {
+	*mock.Call
+}
+
+// CreateAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - attachment *models.Attachment
+//   - quota int64
+func (_e *MockIAttachmentRepository_Expecter) CreateAttachment(ctx interface{}, attachment interface{}, quota interface{}) *MockIAttachmentRepository_CreateAttachment_Call {
+	return &MockIAttachmentRepository_CreateAttachment_Call{Call: _e.mock.On("CreateAttachment", ctx, attachment, quota)}
+}
+
+func (_c *MockIAttachmentRepository_CreateAttachment_Call) Run(run func(ctx context.Context, attachment *models.Attachment, quota int64)) *MockIAttachmentRepository_CreateAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Attachment), args[2].(int64))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_CreateAttachment_Call) Return(_a0 error) *MockIAttachmentRepository_CreateAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_CreateAttachment_Call) RunAndReturn(run func(context.Context, *models.Attachment, int64) error) *MockIAttachmentRepository_CreateAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAttachment provides a mock function with given fields: ctx, id
+func (_m *MockIAttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAttachment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIAttachmentRepository_DeleteAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAttachment'
+type MockIAttachmentRepository_DeleteAttachment_Call struct {
+	*mock.Call
+}
+
+// DeleteAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIAttachmentRepository_Expecter) DeleteAttachment(ctx interface{}, id interface{}) *MockIAttachmentRepository_DeleteAttachment_Call {
+	return &MockIAttachmentRepository_DeleteAttachment_Call{Call: _e.mock.On("DeleteAttachment", ctx, id)}
+}
+
+func (_c *MockIAttachmentRepository_DeleteAttachment_Call) Run(run func(ctx context.Context, id string)) *MockIAttachmentRepository_DeleteAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_DeleteAttachment_Call) Return(_a0 error) *MockIAttachmentRepository_DeleteAttachment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_DeleteAttachment_Call) RunAndReturn(run func(context.Context, string) error) *MockIAttachmentRepository_DeleteAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAttachment provides a mock function with given fields: ctx, taskId, id
+func (_m *MockIAttachmentRepository) GetAttachment(ctx context.Context, taskId string, id string) (*models.Attachment, error) {
+	ret := _m.Called(ctx, taskId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAttachment")
+	}
+
+	var r0 *models.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Attachment, error)); ok {
+		return rf(ctx, taskId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Attachment); ok {
+		r0 = rf(ctx, taskId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIAttachmentRepository_GetAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAttachment'
+type MockIAttachmentRepository_GetAttachment_Call struct {
+	*mock.Call
+}
+
+// GetAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+//   - id string
+func (_e *MockIAttachmentRepository_Expecter) GetAttachment(ctx interface{}, taskId interface{}, id interface{}) *MockIAttachmentRepository_GetAttachment_Call {
+	return &MockIAttachmentRepository_GetAttachment_Call{Call: _e.mock.On("GetAttachment", ctx, taskId, id)}
+}
+
+func (_c *MockIAttachmentRepository_GetAttachment_Call) Run(run func(ctx context.Context, taskId string, id string)) *MockIAttachmentRepository_GetAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetAttachment_Call) Return(_a0 *models.Attachment, _a1 error) *MockIAttachmentRepository_GetAttachment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetAttachment_Call) RunAndReturn(run func(context.Context, string, string) (*models.Attachment, error)) *MockIAttachmentRepository_GetAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLegacyImages provides a mock function with given fields: ctx, limit
+func (_m *MockIAttachmentRepository) GetLegacyImages(ctx context.Context, limit int) (*[]repositories.LegacyTaskImage, error) {
+	ret := _m.Called(ctx, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLegacyImages")
+	}
+
+	var r0 *[]repositories.LegacyTaskImage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int) (*[]repositories.LegacyTaskImage, error)); ok {
+		return rf(ctx, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int) *[]repositories.LegacyTaskImage); ok {
+		r0 = rf(ctx, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]repositories.LegacyTaskImage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int) error); ok {
+		r1 = rf(ctx, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIAttachmentRepository_GetLegacyImages_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLegacyImages'
+type MockIAttachmentRepository_GetLegacyImages_Call struct {
+	*mock.Call
+}
+
+// GetLegacyImages is a helper method to define mock.On call
+//   - ctx context.Context
+//   - limit int
+func (_e *MockIAttachmentRepository_Expecter) GetLegacyImages(ctx interface{}, limit interface{}) *MockIAttachmentRepository_GetLegacyImages_Call {
+	return &MockIAttachmentRepository_GetLegacyImages_Call{Call: _e.mock.On("GetLegacyImages", ctx, limit)}
+}
+
+func (_c *MockIAttachmentRepository_GetLegacyImages_Call) Run(run func(ctx context.Context, limit int)) *MockIAttachmentRepository_GetLegacyImages_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(int))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetLegacyImages_Call) Return(_a0 *[]repositories.LegacyTaskImage, _a1 error) *MockIAttachmentRepository_GetLegacyImages_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetLegacyImages_Call) RunAndReturn(run func(context.Context, int) (*[]repositories.LegacyTaskImage, error)) *MockIAttachmentRepository_GetLegacyImages_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskAttachments provides a mock function with given fields: ctx, taskIds
+func (_m *MockIAttachmentRepository) GetTaskAttachments(ctx context.Context, taskIds []string) (*[]models.Attachment, error) {
+	ret := _m.Called(ctx, taskIds)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskAttachments")
+	}
+
+	var r0 *[]models.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (*[]models.Attachment, error)); ok {
+		return rf(ctx, taskIds)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) *[]models.Attachment); ok {
+		r0 = rf(ctx, taskIds)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, taskIds)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIAttachmentRepository_GetTaskAttachments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskAttachments'
+type MockIAttachmentRepository_GetTaskAttachments_Call struct {
+	*mock.Call
+}
+
+// GetTaskAttachments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskIds []string
+func (_e *MockIAttachmentRepository_Expecter) GetTaskAttachments(ctx interface{}, taskIds interface{}) *MockIAttachmentRepository_GetTaskAttachments_Call {
+	return &MockIAttachmentRepository_GetTaskAttachments_Call{Call: _e.mock.On("GetTaskAttachments", ctx, taskIds)}
+}
+
+func (_c *MockIAttachmentRepository_GetTaskAttachments_Call) Run(run func(ctx context.Context, taskIds []string)) *MockIAttachmentRepository_GetTaskAttachments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetTaskAttachments_Call) Return(_a0 *[]models.Attachment, _a1 error) *MockIAttachmentRepository_GetTaskAttachments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetTaskAttachments_Call) RunAndReturn(run func(context.Context, []string) (*[]models.Attachment, error)) *MockIAttachmentRepository_GetTaskAttachments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserAttachment provides a mock function with given fields: ctx, userId, id
+func (_m *MockIAttachmentRepository) GetUserAttachment(ctx context.Context, userId string, id string) (*models.Attachment, error) {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserAttachment")
+	}
+
+	var r0 *models.Attachment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Attachment, error)); ok {
+		return rf(ctx, userId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Attachment); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Attachment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIAttachmentRepository_GetUserAttachment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserAttachment'
+type MockIAttachmentRepository_GetUserAttachment_Call struct {
+	*mock.Call
+}
+
+// GetUserAttachment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *MockIAttachmentRepository_Expecter) GetUserAttachment(ctx interface{}, userId interface{}, id interface{}) *MockIAttachmentRepository_GetUserAttachment_Call {
+	return &MockIAttachmentRepository_GetUserAttachment_Call{Call: _e.mock.On("GetUserAttachment", ctx, userId, id)}
+}
+
+func (_c *MockIAttachmentRepository_GetUserAttachment_Call) Run(run func(ctx context.Context, userId string, id string)) *MockIAttachmentRepository_GetUserAttachment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetUserAttachment_Call) Return(_a0 *models.Attachment, _a1 error) *MockIAttachmentRepository_GetUserAttachment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetUserAttachment_Call) RunAndReturn(run func(context.Context, string, string) (*models.Attachment, error)) *MockIAttachmentRepository_GetUserAttachment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetUserStorageUsed provides a mock function with given fields: ctx, userId
+func (_m *MockIAttachmentRepository) GetUserStorageUsed(ctx context.Context, userId string) (int64, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserStorageUsed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIAttachmentRepository_GetUserStorageUsed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserStorageUsed'
+type MockIAttachmentRepository_GetUserStorageUsed_Call struct {
+	*mock.Call
+}
+
+// GetUserStorageUsed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockIAttachmentRepository_Expecter) GetUserStorageUsed(ctx interface{}, userId interface{}) *MockIAttachmentRepository_GetUserStorageUsed_Call {
+	return &MockIAttachmentRepository_GetUserStorageUsed_Call{Call: _e.mock.On("GetUserStorageUsed", ctx, userId)}
+}
+
+func (_c *MockIAttachmentRepository_GetUserStorageUsed_Call) Run(run func(ctx context.Context, userId string)) *MockIAttachmentRepository_GetUserStorageUsed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetUserStorageUsed_Call) Return(_a0 int64, _a1 error) *MockIAttachmentRepository_GetUserStorageUsed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_GetUserStorageUsed_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockIAttachmentRepository_GetUserStorageUsed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MoveLegacyImage provides a mock function with given fields: ctx, taskId, attachment
+func (_m *MockIAttachmentRepository) MoveLegacyImage(ctx context.Context, taskId uuid.UUID, attachment *models.Attachment) error {
+	ret := _m.Called(ctx, taskId, attachment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveLegacyImage")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *models.Attachment) error); ok {
+		r0 = rf(ctx, taskId, attachment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIAttachmentRepository_MoveLegacyImage_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveLegacyImage'
+type MockIAttachmentRepository_MoveLegacyImage_Call struct {
+	*mock.Call
+}
+
+// MoveLegacyImage is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId uuid.UUID
+//   - attachment *models.Attachment
+func (_e *MockIAttachmentRepository_Expecter) MoveLegacyImage(ctx interface{}, taskId interface{}, attachment interface{}) *MockIAttachmentRepository_MoveLegacyImage_Call {
+	return &MockIAttachmentRepository_MoveLegacyImage_Call{Call: _e.mock.On("MoveLegacyImage", ctx, taskId, attachment)}
+}
+
+func (_c *MockIAttachmentRepository_MoveLegacyImage_Call) Run(run func(ctx context.Context, taskId uuid.UUID, attachment *models.Attachment)) *MockIAttachmentRepository_MoveLegacyImage_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*models.Attachment))
+	})
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_MoveLegacyImage_Call) Return(_a0 error) *MockIAttachmentRepository_MoveLegacyImage_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIAttachmentRepository_MoveLegacyImage_Call) RunAndReturn(run func(context.Context, uuid.UUID, *models.Attachment) error) *MockIAttachmentRepository_MoveLegacyImage_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIAttachmentRepository creates a new instance of MockIAttachmentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIAttachmentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIAttachmentRepository {
+	mock := &MockIAttachmentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
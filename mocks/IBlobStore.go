@@ -0,0 +1,254 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+	io "io"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIBlobStore is an autogenerated mock type for the IBlobStore type
+type MockIBlobStore struct {
+	mock.Mock
+}
+
+type MockIBlobStore_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIBlobStore) EXPECT() *MockIBlobStore_Expecter {
+	return &MockIBlobStore_Expecter{mock: &_m.Mock}
+}
+
+// Delete provides a mock function with given fields: ctx, key
+func (_m *MockIBlobStore) Delete(ctx context.Context, key string) error {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Delete")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIBlobStore_Delete_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Delete'
+type MockIBlobStore_Delete_Call struct {
+	*mock.Call
+}
+
+// Delete is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIBlobStore_Expecter) Delete(ctx interface{}, key interface{}) *MockIBlobStore_Delete_Call {
+	return &MockIBlobStore_Delete_Call{Call: _e.mock.On("Delete", ctx, key)}
+}
+
+func (_c *MockIBlobStore_Delete_Call) Run(run func(ctx context.Context, key string)) *MockIBlobStore_Delete_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIBlobStore_Delete_Call) Return(_a0 error) *MockIBlobStore_Delete_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIBlobStore_Delete_Call) RunAndReturn(run func(context.Context, string) error) *MockIBlobStore_Delete_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Get provides a mock function with given fields: ctx, key
+func (_m *MockIBlobStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Get")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (io.ReadCloser, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) io.ReadCloser); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIBlobStore_Get_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Get'
+type MockIBlobStore_Get_Call struct {
+	*mock.Call
+}
+
+// Get is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+func (_e *MockIBlobStore_Expecter) Get(ctx interface{}, key interface{}) *MockIBlobStore_Get_Call {
+	return &MockIBlobStore_Get_Call{Call: _e.mock.On("Get", ctx, key)}
+}
+
+func (_c *MockIBlobStore_Get_Call) Run(run func(ctx context.Context, key string)) *MockIBlobStore_Get_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIBlobStore_Get_Call) Return(_a0 io.ReadCloser, _a1 error) *MockIBlobStore_Get_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIBlobStore_Get_Call) RunAndReturn(run func(context.Context, string) (io.ReadCloser, error)) *MockIBlobStore_Get_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRange provides a mock function with given fields: ctx, key, offset, length
+func (_m *MockIBlobStore) GetRange(ctx context.Context, key string, offset int64, length int64) (io.ReadCloser, error) {
+	ret := _m.Called(ctx, key, offset, length)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRange")
+	}
+
+	var r0 io.ReadCloser
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) (io.ReadCloser, error)); ok {
+		return rf(ctx, key, offset, length)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int64, int64) io.ReadCloser); ok {
+		r0 = rf(ctx, key, offset, length)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(io.ReadCloser)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int64, int64) error); ok {
+		r1 = rf(ctx, key, offset, length)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIBlobStore_GetRange_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRange'
+type MockIBlobStore_GetRange_Call struct {
+	*mock.Call
+}
+
+// GetRange is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - offset int64
+//   - length int64
+func (_e *MockIBlobStore_Expecter) GetRange(ctx interface{}, key interface{}, offset interface{}, length interface{}) *MockIBlobStore_GetRange_Call {
+	return &MockIBlobStore_GetRange_Call{Call: _e.mock.On("GetRange", ctx, key, offset, length)}
+}
+
+func (_c *MockIBlobStore_GetRange_Call) Run(run func(ctx context.Context, key string, offset int64, length int64)) *MockIBlobStore_GetRange_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int64), args[3].(int64))
+	})
+	return _c
+}
+
+func (_c *MockIBlobStore_GetRange_Call) Return(_a0 io.ReadCloser, _a1 error) *MockIBlobStore_GetRange_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIBlobStore_GetRange_Call) RunAndReturn(run func(context.Context, string, int64, int64) (io.ReadCloser, error)) *MockIBlobStore_GetRange_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Put provides a mock function with given fields: ctx, key, body, size, contentType
+func (_m *MockIBlobStore) Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error {
+	ret := _m.Called(ctx, key, body, size, contentType)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Put")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, int64, string) error); ok {
+		r0 = rf(ctx, key, body, size, contentType)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIBlobStore_Put_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Put'
+type MockIBlobStore_Put_Call struct {
+	*mock.Call
+}
+
+// Put is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - body io.Reader
+//   - size int64
+//   - contentType string
+func (_e *MockIBlobStore_Expecter) Put(ctx interface{}, key interface{}, body interface{}, size interface{}, contentType interface{}) *MockIBlobStore_Put_Call {
+	return &MockIBlobStore_Put_Call{Call: _e.mock.On("Put", ctx, key, body, size, contentType)}
+}
+
+func (_c *MockIBlobStore_Put_Call) Run(run func(ctx context.Context, key string, body io.Reader, size int64, contentType string)) *MockIBlobStore_Put_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(io.Reader), args[3].(int64), args[4].(string))
+	})
+	return _c
+}
+
+func (_c *MockIBlobStore_Put_Call) Return(_a0 error) *MockIBlobStore_Put_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIBlobStore_Put_Call) RunAndReturn(run func(context.Context, string, io.Reader, int64, string) error) *MockIBlobStore_Put_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIBlobStore creates a new instance of MockIBlobStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIBlobStore(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIBlobStore {
+	mock := &MockIBlobStore{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,259 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockICalendarFeedRepository is an autogenerated mock type for the ICalendarFeedRepository type
+type MockICalendarFeedRepository struct {
+	mock.Mock
+}
+
+type MockICalendarFeedRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockICalendarFeedRepository) EXPECT() *MockICalendarFeedRepository_Expecter {
+	return &MockICalendarFeedRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteCalendarFeed provides a mock function with given fields: ctx, userId
+func (_m *MockICalendarFeedRepository) DeleteCalendarFeed(ctx context.Context, userId string) (int64, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteCalendarFeed")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICalendarFeedRepository_DeleteCalendarFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteCalendarFeed'
+type MockICalendarFeedRepository_DeleteCalendarFeed_Call struct {
+	*mock.Call
+}
+
+// DeleteCalendarFeed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockICalendarFeedRepository_Expecter) DeleteCalendarFeed(ctx interface{}, userId interface{}) *MockICalendarFeedRepository_DeleteCalendarFeed_Call {
+	return &MockICalendarFeedRepository_DeleteCalendarFeed_Call{Call: _e.mock.On("DeleteCalendarFeed", ctx, userId)}
+}
+
+func (_c *MockICalendarFeedRepository_DeleteCalendarFeed_Call) Run(run func(ctx context.Context, userId string)) *MockICalendarFeedRepository_DeleteCalendarFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_DeleteCalendarFeed_Call) Return(_a0 int64, _a1 error) *MockICalendarFeedRepository_DeleteCalendarFeed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_DeleteCalendarFeed_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockICalendarFeedRepository_DeleteCalendarFeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCalendarFeed provides a mock function with given fields: ctx, userId
+func (_m *MockICalendarFeedRepository) GetCalendarFeed(ctx context.Context, userId string) (*models.CalendarFeed, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendarFeed")
+	}
+
+	var r0 *models.CalendarFeed
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CalendarFeed, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CalendarFeed); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CalendarFeed)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICalendarFeedRepository_GetCalendarFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCalendarFeed'
+type MockICalendarFeedRepository_GetCalendarFeed_Call struct {
+	*mock.Call
+}
+
+// GetCalendarFeed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockICalendarFeedRepository_Expecter) GetCalendarFeed(ctx interface{}, userId interface{}) *MockICalendarFeedRepository_GetCalendarFeed_Call {
+	return &MockICalendarFeedRepository_GetCalendarFeed_Call{Call: _e.mock.On("GetCalendarFeed", ctx, userId)}
+}
+
+func (_c *MockICalendarFeedRepository_GetCalendarFeed_Call) Run(run func(ctx context.Context, userId string)) *MockICalendarFeedRepository_GetCalendarFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_GetCalendarFeed_Call) Return(_a0 *models.CalendarFeed, _a1 error) *MockICalendarFeedRepository_GetCalendarFeed_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_GetCalendarFeed_Call) RunAndReturn(run func(context.Context, string) (*models.CalendarFeed, error)) *MockICalendarFeedRepository_GetCalendarFeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetCalendarFeedByToken provides a mock function with given fields: ctx, tokenHash
+func (_m *MockICalendarFeedRepository) GetCalendarFeedByToken(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetCalendarFeedByToken")
+	}
+
+	var r0 *models.CalendarFeed
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.CalendarFeed, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.CalendarFeed); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CalendarFeed)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICalendarFeedRepository_GetCalendarFeedByToken_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetCalendarFeedByToken'
+type MockICalendarFeedRepository_GetCalendarFeedByToken_Call struct {
+	*mock.Call
+}
+
+// GetCalendarFeedByToken is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockICalendarFeedRepository_Expecter) GetCalendarFeedByToken(ctx interface{}, tokenHash interface{}) *MockICalendarFeedRepository_GetCalendarFeedByToken_Call {
+	return &MockICalendarFeedRepository_GetCalendarFeedByToken_Call{Call: _e.mock.On("GetCalendarFeedByToken", ctx, tokenHash)}
+}
+
+func (_c *MockICalendarFeedRepository_GetCalendarFeedByToken_Call) Run(run func(ctx context.Context, tokenHash string)) *MockICalendarFeedRepository_GetCalendarFeedByToken_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_GetCalendarFeedByToken_Call) Return(_a0 *models.CalendarFeed, _a1 error) *MockICalendarFeedRepository_GetCalendarFeedByToken_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_GetCalendarFeedByToken_Call) RunAndReturn(run func(context.Context, string) (*models.CalendarFeed, error)) *MockICalendarFeedRepository_GetCalendarFeedByToken_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveCalendarFeed provides a mock function with given fields: ctx, feed
+func (_m *MockICalendarFeedRepository) SaveCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error {
+	ret := _m.Called(ctx, feed)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveCalendarFeed")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.CalendarFeed) error); ok {
+		r0 = rf(ctx, feed)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICalendarFeedRepository_SaveCalendarFeed_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveCalendarFeed'
+type MockICalendarFeedRepository_SaveCalendarFeed_Call struct {
+	*mock.Call
+}
+
+// SaveCalendarFeed is a helper method to define mock.On call
+//   - ctx context.Context
+//   - feed *models.CalendarFeed
+func (_e *MockICalendarFeedRepository_Expecter) SaveCalendarFeed(ctx interface{}, feed interface{}) *MockICalendarFeedRepository_SaveCalendarFeed_Call {
+	return &MockICalendarFeedRepository_SaveCalendarFeed_Call{Call: _e.mock.On("SaveCalendarFeed", ctx, feed)}
+}
+
+func (_c *MockICalendarFeedRepository_SaveCalendarFeed_Call) Run(run func(ctx context.Context, feed *models.CalendarFeed)) *MockICalendarFeedRepository_SaveCalendarFeed_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.CalendarFeed))
+	})
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_SaveCalendarFeed_Call) Return(_a0 error) *MockICalendarFeedRepository_SaveCalendarFeed_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICalendarFeedRepository_SaveCalendarFeed_Call) RunAndReturn(run func(context.Context, *models.CalendarFeed) error) *MockICalendarFeedRepository_SaveCalendarFeed_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockICalendarFeedRepository creates a new instance of MockICalendarFeedRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockICalendarFeedRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockICalendarFeedRepository {
+	mock := &MockICalendarFeedRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,306 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockICommentRepository is an autogenerated mock type for the ICommentRepository type
+type MockICommentRepository struct {
+	mock.Mock
+}
+
+type MockICommentRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockICommentRepository) EXPECT() *MockICommentRepository_Expecter {
+	return &MockICommentRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateComment provides a mock function with given fields: ctx, comment
+func (_m *MockICommentRepository) CreateComment(ctx context.Context, comment *models.Comment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICommentRepository_CreateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateComment'
+type MockICommentRepository_CreateComment_Call struct {
+	*mock.Call
+}
+
+// CreateComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *models.Comment
+func (_e *MockICommentRepository_Expecter) CreateComment(ctx interface{}, comment interface{}) *MockICommentRepository_CreateComment_Call {
+	return &MockICommentRepository_CreateComment_Call{Call: _e.mock.On("CreateComment", ctx, comment)}
+}
+
+func (_c *MockICommentRepository_CreateComment_Call) Run(run func(ctx context.Context, comment *models.Comment)) *MockICommentRepository_CreateComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *MockICommentRepository_CreateComment_Call) Return(_a0 error) *MockICommentRepository_CreateComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICommentRepository_CreateComment_Call) RunAndReturn(run func(context.Context, *models.Comment) error) *MockICommentRepository_CreateComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteComment provides a mock function with given fields: ctx, id
+func (_m *MockICommentRepository) DeleteComment(ctx context.Context, id string) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICommentRepository_DeleteComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteComment'
+type MockICommentRepository_DeleteComment_Call struct {
+	*mock.Call
+}
+
+// DeleteComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockICommentRepository_Expecter) DeleteComment(ctx interface{}, id interface{}) *MockICommentRepository_DeleteComment_Call {
+	return &MockICommentRepository_DeleteComment_Call{Call: _e.mock.On("DeleteComment", ctx, id)}
+}
+
+func (_c *MockICommentRepository_DeleteComment_Call) Run(run func(ctx context.Context, id string)) *MockICommentRepository_DeleteComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockICommentRepository_DeleteComment_Call) Return(_a0 error) *MockICommentRepository_DeleteComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICommentRepository_DeleteComment_Call) RunAndReturn(run func(context.Context, string) error) *MockICommentRepository_DeleteComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetComment provides a mock function with given fields: ctx, taskId, id
+func (_m *MockICommentRepository) GetComment(ctx context.Context, taskId string, id string) (*models.Comment, error) {
+	ret := _m.Called(ctx, taskId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComment")
+	}
+
+	var r0 *models.Comment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Comment, error)); ok {
+		return rf(ctx, taskId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Comment); ok {
+		r0 = rf(ctx, taskId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICommentRepository_GetComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComment'
+type MockICommentRepository_GetComment_Call struct {
+	*mock.Call
+}
+
+// GetComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+//   - id string
+func (_e *MockICommentRepository_Expecter) GetComment(ctx interface{}, taskId interface{}, id interface{}) *MockICommentRepository_GetComment_Call {
+	return &MockICommentRepository_GetComment_Call{Call: _e.mock.On("GetComment", ctx, taskId, id)}
+}
+
+func (_c *MockICommentRepository_GetComment_Call) Run(run func(ctx context.Context, taskId string, id string)) *MockICommentRepository_GetComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockICommentRepository_GetComment_Call) Return(_a0 *models.Comment, _a1 error) *MockICommentRepository_GetComment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICommentRepository_GetComment_Call) RunAndReturn(run func(context.Context, string, string) (*models.Comment, error)) *MockICommentRepository_GetComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetComments provides a mock function with given fields: ctx, taskId, limit, offset
+func (_m *MockICommentRepository) GetComments(ctx context.Context, taskId string, limit int, offset int) (*[]models.Comment, int64, error) {
+	ret := _m.Called(ctx, taskId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetComments")
+	}
+
+	var r0 *[]models.Comment
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*[]models.Comment, int64, error)); ok {
+		return rf(ctx, taskId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *[]models.Comment); ok {
+		r0 = rf(ctx, taskId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Comment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, taskId, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, taskId, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockICommentRepository_GetComments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetComments'
+type MockICommentRepository_GetComments_Call struct {
+	*mock.Call
+}
+
+// GetComments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+//   - limit int
+//   - offset int
+func (_e *MockICommentRepository_Expecter) GetComments(ctx interface{}, taskId interface{}, limit interface{}, offset interface{}) *MockICommentRepository_GetComments_Call {
+	return &MockICommentRepository_GetComments_Call{Call: _e.mock.On("GetComments", ctx, taskId, limit, offset)}
+}
+
+func (_c *MockICommentRepository_GetComments_Call) Run(run func(ctx context.Context, taskId string, limit int, offset int)) *MockICommentRepository_GetComments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockICommentRepository_GetComments_Call) Return(_a0 *[]models.Comment, _a1 int64, _a2 error) *MockICommentRepository_GetComments_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockICommentRepository_GetComments_Call) RunAndReturn(run func(context.Context, string, int, int) (*[]models.Comment, int64, error)) *MockICommentRepository_GetComments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateComment provides a mock function with given fields: ctx, comment
+func (_m *MockICommentRepository) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	ret := _m.Called(ctx, comment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateComment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Comment) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockICommentRepository_UpdateComment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateComment'
+type MockICommentRepository_UpdateComment_Call struct {
+	*mock.Call
+}
+
+// UpdateComment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - comment *models.Comment
+func (_e *MockICommentRepository_Expecter) UpdateComment(ctx interface{}, comment interface{}) *MockICommentRepository_UpdateComment_Call {
+	return &MockICommentRepository_UpdateComment_Call{Call: _e.mock.On("UpdateComment", ctx, comment)}
+}
+
+func (_c *MockICommentRepository_UpdateComment_Call) Run(run func(ctx context.Context, comment *models.Comment)) *MockICommentRepository_UpdateComment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Comment))
+	})
+	return _c
+}
+
+func (_c *MockICommentRepository_UpdateComment_Call) Return(_a0 error) *MockICommentRepository_UpdateComment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockICommentRepository_UpdateComment_Call) RunAndReturn(run func(context.Context, *models.Comment) error) *MockICommentRepository_UpdateComment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockICommentRepository creates a new instance of MockICommentRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockICommentRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockICommentRepository {
+	mock := &MockICommentRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
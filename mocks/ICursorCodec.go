@@ -0,0 +1,149 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	utils "github.com/guncv/tech-exam-software-engineering/utils"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockICursorCodec is an autogenerated mock type for the ICursorCodec type
+type MockICursorCodec struct {
+	mock.Mock
+}
+
+type MockICursorCodec_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockICursorCodec) EXPECT() *MockICursorCodec_Expecter {
+	return &MockICursorCodec_Expecter{mock: &_m.Mock}
+}
+
+// Decode provides a mock function with given fields: token
+func (_m *MockICursorCodec) Decode(token string) (*utils.Cursor, error) {
+	ret := _m.Called(token)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Decode")
+	}
+
+	var r0 *utils.Cursor
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string) (*utils.Cursor, error)); ok {
+		return rf(token)
+	}
+	if rf, ok := ret.Get(0).(func(string) *utils.Cursor); ok {
+		r0 = rf(token)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*utils.Cursor)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string) error); ok {
+		r1 = rf(token)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICursorCodec_Decode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Decode'
+type MockICursorCodec_Decode_Call struct {
+	*mock.Call
+}
+
+// Decode is a helper method to define mock.On call
+//   - token string
+func (_e *MockICursorCodec_Expecter) Decode(token interface{}) *MockICursorCodec_Decode_Call {
+	return &MockICursorCodec_Decode_Call{Call: _e.mock.On("Decode", token)}
+}
+
+func (_c *MockICursorCodec_Decode_Call) Run(run func(token string)) *MockICursorCodec_Decode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string))
+	})
+	return _c
+}
+
+func (_c *MockICursorCodec_Decode_Call) Return(_a0 *utils.Cursor, _a1 error) *MockICursorCodec_Decode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICursorCodec_Decode_Call) RunAndReturn(run func(string) (*utils.Cursor, error)) *MockICursorCodec_Decode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Encode provides a mock function with given fields: cursor
+func (_m *MockICursorCodec) Encode(cursor *utils.Cursor) (string, error) {
+	ret := _m.Called(cursor)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Encode")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(*utils.Cursor) (string, error)); ok {
+		return rf(cursor)
+	}
+	if rf, ok := ret.Get(0).(func(*utils.Cursor) string); ok {
+		r0 = rf(cursor)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(*utils.Cursor) error); ok {
+		r1 = rf(cursor)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockICursorCodec_Encode_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Encode'
+type MockICursorCodec_Encode_Call struct {
+	*mock.Call
+}
+
+// Encode is a helper method to define mock.On call
+//   - cursor *utils.Cursor
+func (_e *MockICursorCodec_Expecter) Encode(cursor interface{}) *MockICursorCodec_Encode_Call {
+	return &MockICursorCodec_Encode_Call{Call: _e.mock.On("Encode", cursor)}
+}
+
+func (_c *MockICursorCodec_Encode_Call) Run(run func(cursor *utils.Cursor)) *MockICursorCodec_Encode_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*utils.Cursor))
+	})
+	return _c
+}
+
+func (_c *MockICursorCodec_Encode_Call) Return(_a0 string, _a1 error) *MockICursorCodec_Encode_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockICursorCodec_Encode_Call) RunAndReturn(run func(*utils.Cursor) (string, error)) *MockICursorCodec_Encode_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockICursorCodec creates a new instance of MockICursorCodec. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockICursorCodec(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockICursorCodec {
+	mock := &MockICursorCodec{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,193 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIIdempotencyRepository is an autogenerated mock type for the IIdempotencyRepository type
+type MockIIdempotencyRepository struct {
+	mock.Mock
+}
+
+type MockIIdempotencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIIdempotencyRepository) EXPECT() *MockIIdempotencyRepository_Expecter {
+	return &MockIIdempotencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// ClaimKey provides a mock function with given fields: ctx, key
+func (_m *MockIIdempotencyRepository) ClaimKey(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	ret := _m.Called(ctx, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimKey")
+	}
+
+	var r0 *models.IdempotencyKey
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey) (*models.IdempotencyKey, error)); ok {
+		return rf(ctx, key)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.IdempotencyKey) *models.IdempotencyKey); ok {
+		r0 = rf(ctx, key)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.IdempotencyKey)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.IdempotencyKey) error); ok {
+		r1 = rf(ctx, key)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIIdempotencyRepository_ClaimKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimKey'
+type MockIIdempotencyRepository_ClaimKey_Call struct {
+	*mock.Call
+}
+
+// ClaimKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key *models.IdempotencyKey
+func (_e *MockIIdempotencyRepository_Expecter) ClaimKey(ctx interface{}, key interface{}) *MockIIdempotencyRepository_ClaimKey_Call {
+	return &MockIIdempotencyRepository_ClaimKey_Call{Call: _e.mock.On("ClaimKey", ctx, key)}
+}
+
+func (_c *MockIIdempotencyRepository_ClaimKey_Call) Run(run func(ctx context.Context, key *models.IdempotencyKey)) *MockIIdempotencyRepository_ClaimKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.IdempotencyKey))
+	})
+	return _c
+}
+
+func (_c *MockIIdempotencyRepository_ClaimKey_Call) Return(_a0 *models.IdempotencyKey, _a1 error) *MockIIdempotencyRepository_ClaimKey_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIIdempotencyRepository_ClaimKey_Call) RunAndReturn(run func(context.Context, *models.IdempotencyKey) (*models.IdempotencyKey, error)) *MockIIdempotencyRepository_ClaimKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReleaseKey provides a mock function with given fields: ctx, userId, key
+func (_m *MockIIdempotencyRepository) ReleaseKey(ctx context.Context, userId string, key string) error {
+	ret := _m.Called(ctx, userId, key)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseKey")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) error); ok {
+		r0 = rf(ctx, userId, key)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIIdempotencyRepository_ReleaseKey_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseKey'
+type MockIIdempotencyRepository_ReleaseKey_Call struct {
+	*mock.Call
+}
+
+// ReleaseKey is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - key string
+func (_e *MockIIdempotencyRepository_Expecter) ReleaseKey(ctx interface{}, userId interface{}, key interface{}) *MockIIdempotencyRepository_ReleaseKey_Call {
+	return &MockIIdempotencyRepository_ReleaseKey_Call{Call: _e.mock.On("ReleaseKey", ctx, userId, key)}
+}
+
+func (_c *MockIIdempotencyRepository_ReleaseKey_Call) Run(run func(ctx context.Context, userId string, key string)) *MockIIdempotencyRepository_ReleaseKey_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIIdempotencyRepository_ReleaseKey_Call) Return(_a0 error) *MockIIdempotencyRepository_ReleaseKey_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIIdempotencyRepository_ReleaseKey_Call) RunAndReturn(run func(context.Context, string, string) error) *MockIIdempotencyRepository_ReleaseKey_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SaveResponse provides a mock function with given fields: ctx, userId, key, response
+func (_m *MockIIdempotencyRepository) SaveResponse(ctx context.Context, userId string, key string, response []byte) error {
+	ret := _m.Called(ctx, userId, key, response)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveResponse")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, []byte) error); ok {
+		r0 = rf(ctx, userId, key, response)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIIdempotencyRepository_SaveResponse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SaveResponse'
+type MockIIdempotencyRepository_SaveResponse_Call struct {
+	*mock.Call
+}
+
+// SaveResponse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - key string
+//   - response []byte
+func (_e *MockIIdempotencyRepository_Expecter) SaveResponse(ctx interface{}, userId interface{}, key interface{}, response interface{}) *MockIIdempotencyRepository_SaveResponse_Call {
+	return &MockIIdempotencyRepository_SaveResponse_Call{Call: _e.mock.On("SaveResponse", ctx, userId, key, response)}
+}
+
+func (_c *MockIIdempotencyRepository_SaveResponse_Call) Run(run func(ctx context.Context, userId string, key string, response []byte)) *MockIIdempotencyRepository_SaveResponse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].([]byte))
+	})
+	return _c
+}
+
+func (_c *MockIIdempotencyRepository_SaveResponse_Call) Return(_a0 error) *MockIIdempotencyRepository_SaveResponse_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIIdempotencyRepository_SaveResponse_Call) RunAndReturn(run func(context.Context, string, string, []byte) error) *MockIIdempotencyRepository_SaveResponse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIIdempotencyRepository creates a new instance of MockIIdempotencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIIdempotencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIIdempotencyRepository {
+	mock := &MockIIdempotencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
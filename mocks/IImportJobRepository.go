@@ -0,0 +1,248 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIImportJobRepository is an autogenerated mock type for the IImportJobRepository type
+type MockIImportJobRepository struct {
+	mock.Mock
+}
+
+type MockIImportJobRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIImportJobRepository) EXPECT() *MockIImportJobRepository_Expecter {
+	return &MockIImportJobRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateImportJob provides a mock function with given fields: ctx, job
+func (_m *MockIImportJobRepository) CreateImportJob(ctx context.Context, job *models.ImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateImportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIImportJobRepository_CreateImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateImportJob'
+type MockIImportJobRepository_CreateImportJob_Call struct {
+	*mock.Call
+}
+
+// CreateImportJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *models.ImportJob
+func (_e *MockIImportJobRepository_Expecter) CreateImportJob(ctx interface{}, job interface{}) *MockIImportJobRepository_CreateImportJob_Call {
+	return &MockIImportJobRepository_CreateImportJob_Call{Call: _e.mock.On("CreateImportJob", ctx, job)}
+}
+
+func (_c *MockIImportJobRepository_CreateImportJob_Call) Run(run func(ctx context.Context, job *models.ImportJob)) *MockIImportJobRepository_CreateImportJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ImportJob))
+	})
+	return _c
+}
+
+func (_c *MockIImportJobRepository_CreateImportJob_Call) Return(_a0 error) *MockIImportJobRepository_CreateImportJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIImportJobRepository_CreateImportJob_Call) RunAndReturn(run func(context.Context, *models.ImportJob) error) *MockIImportJobRepository_CreateImportJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// FailInterruptedImportJobs provides a mock function with given fields: ctx, reason
+func (_m *MockIImportJobRepository) FailInterruptedImportJobs(ctx context.Context, reason string) (int64, error) {
+	ret := _m.Called(ctx, reason)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailInterruptedImportJobs")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, reason)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, reason)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, reason)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIImportJobRepository_FailInterruptedImportJobs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'FailInterruptedImportJobs'
+type MockIImportJobRepository_FailInterruptedImportJobs_Call struct {
+	*mock.Call
+}
+
+// FailInterruptedImportJobs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - reason string
+func (_e *MockIImportJobRepository_Expecter) FailInterruptedImportJobs(ctx interface{}, reason interface{}) *MockIImportJobRepository_FailInterruptedImportJobs_Call {
+	return &MockIImportJobRepository_FailInterruptedImportJobs_Call{Call: _e.mock.On("FailInterruptedImportJobs", ctx, reason)}
+}
+
+func (_c *MockIImportJobRepository_FailInterruptedImportJobs_Call) Run(run func(ctx context.Context, reason string)) *MockIImportJobRepository_FailInterruptedImportJobs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIImportJobRepository_FailInterruptedImportJobs_Call) Return(_a0 int64, _a1 error) *MockIImportJobRepository_FailInterruptedImportJobs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIImportJobRepository_FailInterruptedImportJobs_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockIImportJobRepository_FailInterruptedImportJobs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetImportJob provides a mock function with given fields: ctx, userId, id
+func (_m *MockIImportJobRepository) GetImportJob(ctx context.Context, userId string, id string) (*models.ImportJob, error) {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetImportJob")
+	}
+
+	var r0 *models.ImportJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.ImportJob, error)); ok {
+		return rf(ctx, userId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.ImportJob); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.ImportJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIImportJobRepository_GetImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetImportJob'
+type MockIImportJobRepository_GetImportJob_Call struct {
+	*mock.Call
+}
+
+// GetImportJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *MockIImportJobRepository_Expecter) GetImportJob(ctx interface{}, userId interface{}, id interface{}) *MockIImportJobRepository_GetImportJob_Call {
+	return &MockIImportJobRepository_GetImportJob_Call{Call: _e.mock.On("GetImportJob", ctx, userId, id)}
+}
+
+func (_c *MockIImportJobRepository_GetImportJob_Call) Run(run func(ctx context.Context, userId string, id string)) *MockIImportJobRepository_GetImportJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIImportJobRepository_GetImportJob_Call) Return(_a0 *models.ImportJob, _a1 error) *MockIImportJobRepository_GetImportJob_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIImportJobRepository_GetImportJob_Call) RunAndReturn(run func(context.Context, string, string) (*models.ImportJob, error)) *MockIImportJobRepository_GetImportJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateImportJob provides a mock function with given fields: ctx, job
+func (_m *MockIImportJobRepository) UpdateImportJob(ctx context.Context, job *models.ImportJob) error {
+	ret := _m.Called(ctx, job)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateImportJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.ImportJob) error); ok {
+		r0 = rf(ctx, job)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIImportJobRepository_UpdateImportJob_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateImportJob'
+type MockIImportJobRepository_UpdateImportJob_Call struct {
+	*mock.Call
+}
+
+// UpdateImportJob is a helper method to define mock.On call
+//   - ctx context.Context
+//   - job *models.ImportJob
+func (_e *MockIImportJobRepository_Expecter) UpdateImportJob(ctx interface{}, job interface{}) *MockIImportJobRepository_UpdateImportJob_Call {
+	return &MockIImportJobRepository_UpdateImportJob_Call{Call: _e.mock.On("UpdateImportJob", ctx, job)}
+}
+
+func (_c *MockIImportJobRepository_UpdateImportJob_Call) Run(run func(ctx context.Context, job *models.ImportJob)) *MockIImportJobRepository_UpdateImportJob_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.ImportJob))
+	})
+	return _c
+}
+
+func (_c *MockIImportJobRepository_UpdateImportJob_Call) Return(_a0 error) *MockIImportJobRepository_UpdateImportJob_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIImportJobRepository_UpdateImportJob_Call) RunAndReturn(run func(context.Context, *models.ImportJob) error) *MockIImportJobRepository_UpdateImportJob_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIImportJobRepository creates a new instance of MockIImportJobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIImportJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIImportJobRepository {
+	mock := &MockIImportJobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
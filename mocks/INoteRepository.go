@@ -0,0 +1,382 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	entities "github.com/guncv/tech-exam-software-engineering/entities"
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+)
+
+// MockINoteRepository is an autogenerated mock type for the INoteRepository type
+type MockINoteRepository struct {
+	mock.Mock
+}
+
+type MockINoteRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockINoteRepository) EXPECT() *MockINoteRepository_Expecter {
+	return &MockINoteRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateNote provides a mock function with given fields: ctx, note, links, revision
+func (_m *MockINoteRepository) CreateNote(ctx context.Context, note *models.Note, links []string, revision *models.Revision) error {
+	ret := _m.Called(ctx, note, links, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Note, []string, *models.Revision) error); ok {
+		r0 = rf(ctx, note, links, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockINoteRepository_CreateNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNote'
+type MockINoteRepository_CreateNote_Call struct {
+	*mock.Call
+}
+
+// CreateNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - note *models.Note
+//   - links []string
+//   - revision *models.Revision
+func (_e *MockINoteRepository_Expecter) CreateNote(ctx interface{}, note interface{}, links interface{}, revision interface{}) *MockINoteRepository_CreateNote_Call {
+	return &MockINoteRepository_CreateNote_Call{Call: _e.mock.On("CreateNote", ctx, note, links, revision)}
+}
+
+func (_c *MockINoteRepository_CreateNote_Call) Run(run func(ctx context.Context, note *models.Note, links []string, revision *models.Revision)) *MockINoteRepository_CreateNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Note), args[2].([]string), args[3].(*models.Revision))
+	})
+	return _c
+}
+
+func (_c *MockINoteRepository_CreateNote_Call) Return(_a0 error) *MockINoteRepository_CreateNote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockINoteRepository_CreateNote_Call) RunAndReturn(run func(context.Context, *models.Note, []string, *models.Revision) error) *MockINoteRepository_CreateNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteNote provides a mock function with given fields: ctx, id, userId
+func (_m *MockINoteRepository) DeleteNote(ctx context.Context, id string, userId string) (int64, error) {
+	ret := _m.Called(ctx, id, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteNote")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, id, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, id, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockINoteRepository_DeleteNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteNote'
+type MockINoteRepository_DeleteNote_Call struct {
+	*mock.Call
+}
+
+// DeleteNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userId string
+func (_e *MockINoteRepository_Expecter) DeleteNote(ctx interface{}, id interface{}, userId interface{}) *MockINoteRepository_DeleteNote_Call {
+	return &MockINoteRepository_DeleteNote_Call{Call: _e.mock.On("DeleteNote", ctx, id, userId)}
+}
+
+func (_c *MockINoteRepository_DeleteNote_Call) Run(run func(ctx context.Context, id string, userId string)) *MockINoteRepository_DeleteNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockINoteRepository_DeleteNote_Call) Return(_a0 int64, _a1 error) *MockINoteRepository_DeleteNote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockINoteRepository_DeleteNote_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockINoteRepository_DeleteNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBacklinks provides a mock function with given fields: ctx, note, userId
+func (_m *MockINoteRepository) GetBacklinks(ctx context.Context, note *models.Note, userId string) (*[]models.Note, error) {
+	ret := _m.Called(ctx, note, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBacklinks")
+	}
+
+	var r0 *[]models.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Note, string) (*[]models.Note, error)); ok {
+		return rf(ctx, note, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Note, string) *[]models.Note); ok {
+		r0 = rf(ctx, note, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Note, string) error); ok {
+		r1 = rf(ctx, note, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockINoteRepository_GetBacklinks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBacklinks'
+type MockINoteRepository_GetBacklinks_Call struct {
+	*mock.Call
+}
+
+// GetBacklinks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - note *models.Note
+//   - userId string
+func (_e *MockINoteRepository_Expecter) GetBacklinks(ctx interface{}, note interface{}, userId interface{}) *MockINoteRepository_GetBacklinks_Call {
+	return &MockINoteRepository_GetBacklinks_Call{Call: _e.mock.On("GetBacklinks", ctx, note, userId)}
+}
+
+func (_c *MockINoteRepository_GetBacklinks_Call) Run(run func(ctx context.Context, note *models.Note, userId string)) *MockINoteRepository_GetBacklinks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Note), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockINoteRepository_GetBacklinks_Call) Return(_a0 *[]models.Note, _a1 error) *MockINoteRepository_GetBacklinks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockINoteRepository_GetBacklinks_Call) RunAndReturn(run func(context.Context, *models.Note, string) (*[]models.Note, error)) *MockINoteRepository_GetBacklinks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNote provides a mock function with given fields: ctx, id, userId
+func (_m *MockINoteRepository) GetNote(ctx context.Context, id string, userId string) (*models.Note, error) {
+	ret := _m.Called(ctx, id, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNote")
+	}
+
+	var r0 *models.Note
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.Note, error)); ok {
+		return rf(ctx, id, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.Note); ok {
+		r0 = rf(ctx, id, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockINoteRepository_GetNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNote'
+type MockINoteRepository_GetNote_Call struct {
+	*mock.Call
+}
+
+// GetNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userId string
+func (_e *MockINoteRepository_Expecter) GetNote(ctx interface{}, id interface{}, userId interface{}) *MockINoteRepository_GetNote_Call {
+	return &MockINoteRepository_GetNote_Call{Call: _e.mock.On("GetNote", ctx, id, userId)}
+}
+
+func (_c *MockINoteRepository_GetNote_Call) Run(run func(ctx context.Context, id string, userId string)) *MockINoteRepository_GetNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockINoteRepository_GetNote_Call) Return(_a0 *models.Note, _a1 error) *MockINoteRepository_GetNote_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockINoteRepository_GetNote_Call) RunAndReturn(run func(context.Context, string, string) (*models.Note, error)) *MockINoteRepository_GetNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotes provides a mock function with given fields: ctx, req, userId
+func (_m *MockINoteRepository) GetNotes(ctx context.Context, req *entities.GetNotesRequest, userId string) (*[]models.Note, int64, error) {
+	ret := _m.Called(ctx, req, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotes")
+	}
+
+	var r0 *[]models.Note
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetNotesRequest, string) (*[]models.Note, int64, error)); ok {
+		return rf(ctx, req, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetNotesRequest, string) *[]models.Note); ok {
+		r0 = rf(ctx, req, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Note)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.GetNotesRequest, string) int64); ok {
+		r1 = rf(ctx, req, userId)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *entities.GetNotesRequest, string) error); ok {
+		r2 = rf(ctx, req, userId)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockINoteRepository_GetNotes_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotes'
+type MockINoteRepository_GetNotes_Call struct {
+	*mock.Call
+}
+
+// GetNotes is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *entities.GetNotesRequest
+//   - userId string
+func (_e *MockINoteRepository_Expecter) GetNotes(ctx interface{}, req interface{}, userId interface{}) *MockINoteRepository_GetNotes_Call {
+	return &MockINoteRepository_GetNotes_Call{Call: _e.mock.On("GetNotes", ctx, req, userId)}
+}
+
+func (_c *MockINoteRepository_GetNotes_Call) Run(run func(ctx context.Context, req *entities.GetNotesRequest, userId string)) *MockINoteRepository_GetNotes_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.GetNotesRequest), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockINoteRepository_GetNotes_Call) Return(_a0 *[]models.Note, _a1 int64, _a2 error) *MockINoteRepository_GetNotes_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockINoteRepository_GetNotes_Call) RunAndReturn(run func(context.Context, *entities.GetNotesRequest, string) (*[]models.Note, int64, error)) *MockINoteRepository_GetNotes_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateNote provides a mock function with given fields: ctx, note, links, revision
+func (_m *MockINoteRepository) UpdateNote(ctx context.Context, note *models.Note, links []string, revision *models.Revision) error {
+	ret := _m.Called(ctx, note, links, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateNote")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Note, []string, *models.Revision) error); ok {
+		r0 = rf(ctx, note, links, revision)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockINoteRepository_UpdateNote_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateNote'
+type MockINoteRepository_UpdateNote_Call struct {
+	*mock.Call
+}
+
+// UpdateNote is a helper method to define mock.On call
+//   - ctx context.Context
+//   - note *models.Note
+//   - links []string
+//   - revision *models.Revision
+func (_e *MockINoteRepository_Expecter) UpdateNote(ctx interface{}, note interface{}, links interface{}, revision interface{}) *MockINoteRepository_UpdateNote_Call {
+	return &MockINoteRepository_UpdateNote_Call{Call: _e.mock.On("UpdateNote", ctx, note, links, revision)}
+}
+
+func (_c *MockINoteRepository_UpdateNote_Call) Run(run func(ctx context.Context, note *models.Note, links []string, revision *models.Revision)) *MockINoteRepository_UpdateNote_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Note), args[2].([]string), args[3].(*models.Revision))
+	})
+	return _c
+}
+
+func (_c *MockINoteRepository_UpdateNote_Call) Return(_a0 error) *MockINoteRepository_UpdateNote_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockINoteRepository_UpdateNote_Call) RunAndReturn(run func(context.Context, *models.Note, []string, *models.Revision) error) *MockINoteRepository_UpdateNote_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockINoteRepository creates a new instance of MockINoteRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockINoteRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockINoteRepository {
+	mock := &MockINoteRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,268 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockINotificationRepository is an autogenerated mock type for the INotificationRepository type
+type MockINotificationRepository struct {
+	mock.Mock
+}
+
+type MockINotificationRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockINotificationRepository) EXPECT() *MockINotificationRepository_Expecter {
+	return &MockINotificationRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateNotifications provides a mock function with given fields: ctx, notifications
+func (_m *MockINotificationRepository) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	ret := _m.Called(ctx, notifications)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateNotifications")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Notification) error); ok {
+		r0 = rf(ctx, notifications)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockINotificationRepository_CreateNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateNotifications'
+type MockINotificationRepository_CreateNotifications_Call struct {
+	*mock.Call
+}
+
+// CreateNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - notifications []models.Notification
+func (_e *MockINotificationRepository_Expecter) CreateNotifications(ctx interface{}, notifications interface{}) *MockINotificationRepository_CreateNotifications_Call {
+	return &MockINotificationRepository_CreateNotifications_Call{Call: _e.mock.On("CreateNotifications", ctx, notifications)}
+}
+
+func (_c *MockINotificationRepository_CreateNotifications_Call) Run(run func(ctx context.Context, notifications []models.Notification)) *MockINotificationRepository_CreateNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.Notification))
+	})
+	return _c
+}
+
+func (_c *MockINotificationRepository_CreateNotifications_Call) Return(_a0 error) *MockINotificationRepository_CreateNotifications_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockINotificationRepository_CreateNotifications_Call) RunAndReturn(run func(context.Context, []models.Notification) error) *MockINotificationRepository_CreateNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetNotifications provides a mock function with given fields: ctx, userId, unreadOnly, limit, offset
+func (_m *MockINotificationRepository) GetNotifications(ctx context.Context, userId string, unreadOnly bool, limit int, offset int) (*[]models.Notification, int64, error) {
+	ret := _m.Called(ctx, userId, unreadOnly, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetNotifications")
+	}
+
+	var r0 *[]models.Notification
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, int, int) (*[]models.Notification, int64, error)); ok {
+		return rf(ctx, userId, unreadOnly, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, int, int) *[]models.Notification); ok {
+		r0 = rf(ctx, userId, unreadOnly, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Notification)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool, int, int) int64); ok {
+		r1 = rf(ctx, userId, unreadOnly, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, bool, int, int) error); ok {
+		r2 = rf(ctx, userId, unreadOnly, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockINotificationRepository_GetNotifications_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetNotifications'
+type MockINotificationRepository_GetNotifications_Call struct {
+	*mock.Call
+}
+
+// GetNotifications is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - unreadOnly bool
+//   - limit int
+//   - offset int
+func (_e *MockINotificationRepository_Expecter) GetNotifications(ctx interface{}, userId interface{}, unreadOnly interface{}, limit interface{}, offset interface{}) *MockINotificationRepository_GetNotifications_Call {
+	return &MockINotificationRepository_GetNotifications_Call{Call: _e.mock.On("GetNotifications", ctx, userId, unreadOnly, limit, offset)}
+}
+
+func (_c *MockINotificationRepository_GetNotifications_Call) Run(run func(ctx context.Context, userId string, unreadOnly bool, limit int, offset int)) *MockINotificationRepository_GetNotifications_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(bool), args[3].(int), args[4].(int))
+	})
+	return _c
+}
+
+func (_c *MockINotificationRepository_GetNotifications_Call) Return(_a0 *[]models.Notification, _a1 int64, _a2 error) *MockINotificationRepository_GetNotifications_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockINotificationRepository_GetNotifications_Call) RunAndReturn(run func(context.Context, string, bool, int, int) (*[]models.Notification, int64, error)) *MockINotificationRepository_GetNotifications_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkAllRead provides a mock function with given fields: ctx, userId
+func (_m *MockINotificationRepository) MarkAllRead(ctx context.Context, userId string) (int64, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkAllRead")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int64, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int64); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockINotificationRepository_MarkAllRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkAllRead'
+type MockINotificationRepository_MarkAllRead_Call struct {
+	*mock.Call
+}
+
+// MarkAllRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockINotificationRepository_Expecter) MarkAllRead(ctx interface{}, userId interface{}) *MockINotificationRepository_MarkAllRead_Call {
+	return &MockINotificationRepository_MarkAllRead_Call{Call: _e.mock.On("MarkAllRead", ctx, userId)}
+}
+
+func (_c *MockINotificationRepository_MarkAllRead_Call) Run(run func(ctx context.Context, userId string)) *MockINotificationRepository_MarkAllRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockINotificationRepository_MarkAllRead_Call) Return(_a0 int64, _a1 error) *MockINotificationRepository_MarkAllRead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockINotificationRepository_MarkAllRead_Call) RunAndReturn(run func(context.Context, string) (int64, error)) *MockINotificationRepository_MarkAllRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkRead provides a mock function with given fields: ctx, userId, id
+func (_m *MockINotificationRepository) MarkRead(ctx context.Context, userId string, id string) (int64, error) {
+	ret := _m.Called(ctx, userId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkRead")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, userId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, userId, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, userId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockINotificationRepository_MarkRead_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkRead'
+type MockINotificationRepository_MarkRead_Call struct {
+	*mock.Call
+}
+
+// MarkRead is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - id string
+func (_e *MockINotificationRepository_Expecter) MarkRead(ctx interface{}, userId interface{}, id interface{}) *MockINotificationRepository_MarkRead_Call {
+	return &MockINotificationRepository_MarkRead_Call{Call: _e.mock.On("MarkRead", ctx, userId, id)}
+}
+
+func (_c *MockINotificationRepository_MarkRead_Call) Run(run func(ctx context.Context, userId string, id string)) *MockINotificationRepository_MarkRead_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockINotificationRepository_MarkRead_Call) Return(_a0 int64, _a1 error) *MockINotificationRepository_MarkRead_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockINotificationRepository_MarkRead_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockINotificationRepository_MarkRead_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockINotificationRepository creates a new instance of MockINotificationRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockINotificationRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockINotificationRepository {
+	mock := &MockINotificationRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
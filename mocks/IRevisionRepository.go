@@ -0,0 +1,161 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+
+	mock "github.com/stretchr/testify/mock"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+)
+
+// MockIRevisionRepository is an autogenerated mock type for the IRevisionRepository type
+type MockIRevisionRepository struct {
+	mock.Mock
+}
+
+type MockIRevisionRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIRevisionRepository) EXPECT() *MockIRevisionRepository_Expecter {
+	return &MockIRevisionRepository_Expecter{mock: &_m.Mock}
+}
+
+// GetRevision provides a mock function with given fields: ctx, resourceType, resourceId, version
+func (_m *MockIRevisionRepository) GetRevision(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, version int) (*models.Revision, error) {
+	ret := _m.Called(ctx, resourceType, resourceId, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, constants.RevisionResourceType, string, int) (*models.Revision, error)); ok {
+		return rf(ctx, resourceType, resourceId, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, constants.RevisionResourceType, string, int) *models.Revision); ok {
+		r0 = rf(ctx, resourceType, resourceId, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, constants.RevisionResourceType, string, int) error); ok {
+		r1 = rf(ctx, resourceType, resourceId, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRevisionRepository_GetRevision_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevision'
+type MockIRevisionRepository_GetRevision_Call struct {
+	*mock.Call
+}
+
+// GetRevision is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resourceType constants.RevisionResourceType
+//   - resourceId string
+//   - version int
+func (_e *MockIRevisionRepository_Expecter) GetRevision(ctx interface{}, resourceType interface{}, resourceId interface{}, version interface{}) *MockIRevisionRepository_GetRevision_Call {
+	return &MockIRevisionRepository_GetRevision_Call{Call: _e.mock.On("GetRevision", ctx, resourceType, resourceId, version)}
+}
+
+func (_c *MockIRevisionRepository_GetRevision_Call) Run(run func(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, version int)) *MockIRevisionRepository_GetRevision_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(constants.RevisionResourceType), args[2].(string), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockIRevisionRepository_GetRevision_Call) Return(_a0 *models.Revision, _a1 error) *MockIRevisionRepository_GetRevision_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRevisionRepository_GetRevision_Call) RunAndReturn(run func(context.Context, constants.RevisionResourceType, string, int) (*models.Revision, error)) *MockIRevisionRepository_GetRevision_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRevisions provides a mock function with given fields: ctx, resourceType, resourceId
+func (_m *MockIRevisionRepository) GetRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string) (*[]models.Revision, error) {
+	ret := _m.Called(ctx, resourceType, resourceId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 *[]models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, constants.RevisionResourceType, string) (*[]models.Revision, error)); ok {
+		return rf(ctx, resourceType, resourceId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, constants.RevisionResourceType, string) *[]models.Revision); ok {
+		r0 = rf(ctx, resourceType, resourceId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, constants.RevisionResourceType, string) error); ok {
+		r1 = rf(ctx, resourceType, resourceId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIRevisionRepository_GetRevisions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRevisions'
+type MockIRevisionRepository_GetRevisions_Call struct {
+	*mock.Call
+}
+
+// GetRevisions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - resourceType constants.RevisionResourceType
+//   - resourceId string
+func (_e *MockIRevisionRepository_Expecter) GetRevisions(ctx interface{}, resourceType interface{}, resourceId interface{}) *MockIRevisionRepository_GetRevisions_Call {
+	return &MockIRevisionRepository_GetRevisions_Call{Call: _e.mock.On("GetRevisions", ctx, resourceType, resourceId)}
+}
+
+func (_c *MockIRevisionRepository_GetRevisions_Call) Run(run func(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string)) *MockIRevisionRepository_GetRevisions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(constants.RevisionResourceType), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIRevisionRepository_GetRevisions_Call) Return(_a0 *[]models.Revision, _a1 error) *MockIRevisionRepository_GetRevisions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIRevisionRepository_GetRevisions_Call) RunAndReturn(run func(context.Context, constants.RevisionResourceType, string) (*[]models.Revision, error)) *MockIRevisionRepository_GetRevisions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIRevisionRepository creates a new instance of MockIRevisionRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIRevisionRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIRevisionRepository {
+	mock := &MockIRevisionRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
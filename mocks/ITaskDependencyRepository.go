@@ -0,0 +1,260 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockITaskDependencyRepository is an autogenerated mock type for the ITaskDependencyRepository type
+type MockITaskDependencyRepository struct {
+	mock.Mock
+}
+
+type MockITaskDependencyRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockITaskDependencyRepository) EXPECT() *MockITaskDependencyRepository_Expecter {
+	return &MockITaskDependencyRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateDependency provides a mock function with given fields: ctx, dependency
+func (_m *MockITaskDependencyRepository) CreateDependency(ctx context.Context, dependency *models.TaskDependency) error {
+	ret := _m.Called(ctx, dependency)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateDependency")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaskDependency) error); ok {
+		r0 = rf(ctx, dependency)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITaskDependencyRepository_CreateDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateDependency'
+type MockITaskDependencyRepository_CreateDependency_Call struct {
+	*mock.Call
+}
+
+// CreateDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - dependency *models.TaskDependency
+func (_e *MockITaskDependencyRepository_Expecter) CreateDependency(ctx interface{}, dependency interface{}) *MockITaskDependencyRepository_CreateDependency_Call {
+	return &MockITaskDependencyRepository_CreateDependency_Call{Call: _e.mock.On("CreateDependency", ctx, dependency)}
+}
+
+func (_c *MockITaskDependencyRepository_CreateDependency_Call) Run(run func(ctx context.Context, dependency *models.TaskDependency)) *MockITaskDependencyRepository_CreateDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.TaskDependency))
+	})
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_CreateDependency_Call) Return(_a0 error) *MockITaskDependencyRepository_CreateDependency_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_CreateDependency_Call) RunAndReturn(run func(context.Context, *models.TaskDependency) error) *MockITaskDependencyRepository_CreateDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDependency provides a mock function with given fields: ctx, taskId, blockedById
+func (_m *MockITaskDependencyRepository) DeleteDependency(ctx context.Context, taskId string, blockedById string) (int64, error) {
+	ret := _m.Called(ctx, taskId, blockedById)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDependency")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, taskId, blockedById)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, taskId, blockedById)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, blockedById)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskDependencyRepository_DeleteDependency_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDependency'
+type MockITaskDependencyRepository_DeleteDependency_Call struct {
+	*mock.Call
+}
+
+// DeleteDependency is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+//   - blockedById string
+func (_e *MockITaskDependencyRepository_Expecter) DeleteDependency(ctx interface{}, taskId interface{}, blockedById interface{}) *MockITaskDependencyRepository_DeleteDependency_Call {
+	return &MockITaskDependencyRepository_DeleteDependency_Call{Call: _e.mock.On("DeleteDependency", ctx, taskId, blockedById)}
+}
+
+func (_c *MockITaskDependencyRepository_DeleteDependency_Call) Run(run func(ctx context.Context, taskId string, blockedById string)) *MockITaskDependencyRepository_DeleteDependency_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_DeleteDependency_Call) Return(_a0 int64, _a1 error) *MockITaskDependencyRepository_DeleteDependency_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_DeleteDependency_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockITaskDependencyRepository_DeleteDependency_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlockers provides a mock function with given fields: ctx, taskId
+func (_m *MockITaskDependencyRepository) GetBlockers(ctx context.Context, taskId string) (*[]models.Task, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlockers")
+	}
+
+	var r0 *[]models.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.Task, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.Task); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskDependencyRepository_GetBlockers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlockers'
+type MockITaskDependencyRepository_GetBlockers_Call struct {
+	*mock.Call
+}
+
+// GetBlockers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+func (_e *MockITaskDependencyRepository_Expecter) GetBlockers(ctx interface{}, taskId interface{}) *MockITaskDependencyRepository_GetBlockers_Call {
+	return &MockITaskDependencyRepository_GetBlockers_Call{Call: _e.mock.On("GetBlockers", ctx, taskId)}
+}
+
+func (_c *MockITaskDependencyRepository_GetBlockers_Call) Run(run func(ctx context.Context, taskId string)) *MockITaskDependencyRepository_GetBlockers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_GetBlockers_Call) Return(_a0 *[]models.Task, _a1 error) *MockITaskDependencyRepository_GetBlockers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_GetBlockers_Call) RunAndReturn(run func(context.Context, string) (*[]models.Task, error)) *MockITaskDependencyRepository_GetBlockers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBlocking provides a mock function with given fields: ctx, taskId
+func (_m *MockITaskDependencyRepository) GetBlocking(ctx context.Context, taskId string) (*[]models.Task, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBlocking")
+	}
+
+	var r0 *[]models.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.Task, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.Task); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskDependencyRepository_GetBlocking_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBlocking'
+type MockITaskDependencyRepository_GetBlocking_Call struct {
+	*mock.Call
+}
+
+// GetBlocking is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+func (_e *MockITaskDependencyRepository_Expecter) GetBlocking(ctx interface{}, taskId interface{}) *MockITaskDependencyRepository_GetBlocking_Call {
+	return &MockITaskDependencyRepository_GetBlocking_Call{Call: _e.mock.On("GetBlocking", ctx, taskId)}
+}
+
+func (_c *MockITaskDependencyRepository_GetBlocking_Call) Run(run func(ctx context.Context, taskId string)) *MockITaskDependencyRepository_GetBlocking_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_GetBlocking_Call) Return(_a0 *[]models.Task, _a1 error) *MockITaskDependencyRepository_GetBlocking_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskDependencyRepository_GetBlocking_Call) RunAndReturn(run func(context.Context, string) (*[]models.Task, error)) *MockITaskDependencyRepository_GetBlocking_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockITaskDependencyRepository creates a new instance of MockITaskDependencyRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockITaskDependencyRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockITaskDependencyRepository {
+	mock := &MockITaskDependencyRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
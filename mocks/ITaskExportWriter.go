@@ -0,0 +1,126 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	entities "github.com/guncv/tech-exam-software-engineering/entities"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockITaskExportWriter is an autogenerated mock type for the ITaskExportWriter type
+type MockITaskExportWriter struct {
+	mock.Mock
+}
+
+type MockITaskExportWriter_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockITaskExportWriter) EXPECT() *MockITaskExportWriter_Expecter {
+	return &MockITaskExportWriter_Expecter{mock: &_m.Mock}
+}
+
+// Close provides a mock function with no fields
+func (_m *MockITaskExportWriter) Close() error {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for Close")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITaskExportWriter_Close_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Close'
+type MockITaskExportWriter_Close_Call struct {
+	*mock.Call
+}
+
+// Close is a helper method to define mock.On call
+func (_e *MockITaskExportWriter_Expecter) Close() *MockITaskExportWriter_Close_Call {
+	return &MockITaskExportWriter_Close_Call{Call: _e.mock.On("Close")}
+}
+
+func (_c *MockITaskExportWriter_Close_Call) Run(run func()) *MockITaskExportWriter_Close_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run()
+	})
+	return _c
+}
+
+func (_c *MockITaskExportWriter_Close_Call) Return(_a0 error) *MockITaskExportWriter_Close_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITaskExportWriter_Close_Call) RunAndReturn(run func() error) *MockITaskExportWriter_Close_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// WriteTask provides a mock function with given fields: task
+func (_m *MockITaskExportWriter) WriteTask(task *entities.ExportTask) error {
+	ret := _m.Called(task)
+
+	if len(ret) == 0 {
+		panic("no return value specified for WriteTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(*entities.ExportTask) error); ok {
+		r0 = rf(task)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITaskExportWriter_WriteTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'WriteTask'
+type MockITaskExportWriter_WriteTask_Call struct {
+	*mock.Call
+}
+
+// WriteTask is a helper method to define mock.On call
+//   - task *entities.ExportTask
+func (_e *MockITaskExportWriter_Expecter) WriteTask(task interface{}) *MockITaskExportWriter_WriteTask_Call {
+	return &MockITaskExportWriter_WriteTask_Call{Call: _e.mock.On("WriteTask", task)}
+}
+
+func (_c *MockITaskExportWriter_WriteTask_Call) Run(run func(task *entities.ExportTask)) *MockITaskExportWriter_WriteTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(*entities.ExportTask))
+	})
+	return _c
+}
+
+func (_c *MockITaskExportWriter_WriteTask_Call) Return(_a0 error) *MockITaskExportWriter_WriteTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITaskExportWriter_WriteTask_Call) RunAndReturn(run func(*entities.ExportTask) error) *MockITaskExportWriter_WriteTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockITaskExportWriter creates a new instance of MockITaskExportWriter. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockITaskExportWriter(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockITaskExportWriter {
+	mock := &MockITaskExportWriter{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
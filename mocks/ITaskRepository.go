@@ -9,6 +9,10 @@ import (
 	mock "github.com/stretchr/testify/mock"
 
 	models "github.com/guncv/tech-exam-software-engineering/models"
+
+	repositories "github.com/guncv/tech-exam-software-engineering/repositories"
+
+	utils "github.com/guncv/tech-exam-software-engineering/utils"
 )
 
 // MockITaskRepository is an autogenerated mock type for the ITaskRepository type
@@ -24,17 +28,123 @@ func (_m *MockITaskRepository) EXPECT() *MockITaskRepository_Expecter {
 	return &MockITaskRepository_Expecter{mock: &_m.Mock}
 }
 
-// CreateTask provides a mock function with given fields: ctx, task
-func (_m *MockITaskRepository) CreateTask(ctx context.Context, task *models.Task) error {
-	ret := _m.Called(ctx, task)
+// ApplyTaskChanges provides a mock function with given fields: ctx, changes
+func (_m *MockITaskRepository) ApplyTaskChanges(ctx context.Context, changes []repositories.TaskChange) error {
+	ret := _m.Called(ctx, changes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ApplyTaskChanges")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []repositories.TaskChange) error); ok {
+		r0 = rf(ctx, changes)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITaskRepository_ApplyTaskChanges_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ApplyTaskChanges'
+type MockITaskRepository_ApplyTaskChanges_Call struct {
+	*mock.Call
+}
+
+// ApplyTaskChanges is a helper method to define mock.On call
+//   - ctx context.Context
+//   - changes []repositories.TaskChange
+func (_e *MockITaskRepository_Expecter) ApplyTaskChanges(ctx interface{}, changes interface{}) *MockITaskRepository_ApplyTaskChanges_Call {
+	return &MockITaskRepository_ApplyTaskChanges_Call{Call: _e.mock.On("ApplyTaskChanges", ctx, changes)}
+}
+
+func (_c *MockITaskRepository_ApplyTaskChanges_Call) Run(run func(ctx context.Context, changes []repositories.TaskChange)) *MockITaskRepository_ApplyTaskChanges_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]repositories.TaskChange))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_ApplyTaskChanges_Call) Return(_a0 error) *MockITaskRepository_ApplyTaskChanges_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITaskRepository_ApplyTaskChanges_Call) RunAndReturn(run func(context.Context, []repositories.TaskChange) error) *MockITaskRepository_ApplyTaskChanges_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CountTasks provides a mock function with given fields: ctx, req, filter, userId
+func (_m *MockITaskRepository) CountTasks(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, userId string) (int64, error) {
+	ret := _m.Called(ctx, req, filter, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTasks")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, string) (int64, error)); ok {
+		return rf(ctx, req, filter, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, string) int64); ok {
+		r0 = rf(ctx, req, filter, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, string) error); ok {
+		r1 = rf(ctx, req, filter, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskRepository_CountTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CountTasks'
+type MockITaskRepository_CountTasks_Call struct {
+	*mock.Call
+}
+
+// CountTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - req *entities.GetAllTasksRequest
+//   - filter utils.FilterNode
+//   - userId string
+func (_e *MockITaskRepository_Expecter) CountTasks(ctx interface{}, req interface{}, filter interface{}, userId interface{}) *MockITaskRepository_CountTasks_Call {
+	return &MockITaskRepository_CountTasks_Call{Call: _e.mock.On("CountTasks", ctx, req, filter, userId)}
+}
+
+func (_c *MockITaskRepository_CountTasks_Call) Run(run func(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, userId string)) *MockITaskRepository_CountTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*entities.GetAllTasksRequest), args[2].(utils.FilterNode), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_CountTasks_Call) Return(_a0 int64, _a1 error) *MockITaskRepository_CountTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskRepository_CountTasks_Call) RunAndReturn(run func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, string) (int64, error)) *MockITaskRepository_CountTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTask provides a mock function with given fields: ctx, task, image, quota, history
+func (_m *MockITaskRepository) CreateTask(ctx context.Context, task *models.Task, image *models.Attachment, quota int64, history *models.TaskHistory) error {
+	ret := _m.Called(ctx, task, image, quota, history)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
-		r0 = rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Task, *models.Attachment, int64, *models.TaskHistory) error); ok {
+		r0 = rf(ctx, task, image, quota, history)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -50,13 +160,16 @@ type MockITaskRepository_CreateTask_Call struct {
 // CreateTask is a helper method to define mock.On call
 //   - ctx context.Context
 //   - task *models.Task
-func (_e *MockITaskRepository_Expecter) CreateTask(ctx interface{}, task interface{}) *MockITaskRepository_CreateTask_Call {
-	return &MockITaskRepository_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, task)}
+//   - image *models.Attachment
+//   - quota int64
+//   - history *models.TaskHistory
+func (_e *MockITaskRepository_Expecter) CreateTask(ctx interface{}, task interface{}, image interface{}, quota interface{}, history interface{}) *MockITaskRepository_CreateTask_Call {
+	return &MockITaskRepository_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, task, image, quota, history)}
 }
 
-func (_c *MockITaskRepository_CreateTask_Call) Run(run func(ctx context.Context, task *models.Task)) *MockITaskRepository_CreateTask_Call {
+func (_c *MockITaskRepository_CreateTask_Call) Run(run func(ctx context.Context, task *models.Task, image *models.Attachment, quota int64, history *models.TaskHistory)) *MockITaskRepository_CreateTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.Task))
+		run(args[0].(context.Context), args[1].(*models.Task), args[2].(*models.Attachment), args[3].(int64), args[4].(*models.TaskHistory))
 	})
 	return _c
 }
@@ -66,22 +179,70 @@ func (_c *MockITaskRepository_CreateTask_Call) Return(_a0 error) *MockITaskRepos
 	return _c
 }
 
-func (_c *MockITaskRepository_CreateTask_Call) RunAndReturn(run func(context.Context, *models.Task) error) *MockITaskRepository_CreateTask_Call {
+func (_c *MockITaskRepository_CreateTask_Call) RunAndReturn(run func(context.Context, *models.Task, *models.Attachment, int64, *models.TaskHistory) error) *MockITaskRepository_CreateTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTask provides a mock function with given fields: ctx, id
-func (_m *MockITaskRepository) DeleteTask(ctx context.Context, id string) error {
-	ret := _m.Called(ctx, id)
+// CreateTasks provides a mock function with given fields: ctx, tasks, histories
+func (_m *MockITaskRepository) CreateTasks(ctx context.Context, tasks []models.Task, histories []models.TaskHistory) error {
+	ret := _m.Called(ctx, tasks, histories)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.Task, []models.TaskHistory) error); ok {
+		r0 = rf(ctx, tasks, histories)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITaskRepository_CreateTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTasks'
+type MockITaskRepository_CreateTasks_Call struct {
+	*mock.Call
+}
+
+// CreateTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tasks []models.Task
+//   - histories []models.TaskHistory
+func (_e *MockITaskRepository_Expecter) CreateTasks(ctx interface{}, tasks interface{}, histories interface{}) *MockITaskRepository_CreateTasks_Call {
+	return &MockITaskRepository_CreateTasks_Call{Call: _e.mock.On("CreateTasks", ctx, tasks, histories)}
+}
+
+func (_c *MockITaskRepository_CreateTasks_Call) Run(run func(ctx context.Context, tasks []models.Task, histories []models.TaskHistory)) *MockITaskRepository_CreateTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]models.Task), args[2].([]models.TaskHistory))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_CreateTasks_Call) Return(_a0 error) *MockITaskRepository_CreateTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITaskRepository_CreateTasks_Call) RunAndReturn(run func(context.Context, []models.Task, []models.TaskHistory) error) *MockITaskRepository_CreateTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTask provides a mock function with given fields: ctx, id, history
+func (_m *MockITaskRepository) DeleteTask(ctx context.Context, id string, history *models.TaskHistory) error {
+	ret := _m.Called(ctx, id, history)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.TaskHistory) error); ok {
+		r0 = rf(ctx, id, history)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -97,13 +258,14 @@ type MockITaskRepository_DeleteTask_Call struct {
 // DeleteTask is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-func (_e *MockITaskRepository_Expecter) DeleteTask(ctx interface{}, id interface{}) *MockITaskRepository_DeleteTask_Call {
-	return &MockITaskRepository_DeleteTask_Call{Call: _e.mock.On("DeleteTask", ctx, id)}
+//   - history *models.TaskHistory
+func (_e *MockITaskRepository_Expecter) DeleteTask(ctx interface{}, id interface{}, history interface{}) *MockITaskRepository_DeleteTask_Call {
+	return &MockITaskRepository_DeleteTask_Call{Call: _e.mock.On("DeleteTask", ctx, id, history)}
 }
 
-func (_c *MockITaskRepository_DeleteTask_Call) Run(run func(ctx context.Context, id string)) *MockITaskRepository_DeleteTask_Call {
+func (_c *MockITaskRepository_DeleteTask_Call) Run(run func(ctx context.Context, id string, history *models.TaskHistory)) *MockITaskRepository_DeleteTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(string), args[2].(*models.TaskHistory))
 	})
 	return _c
 }
@@ -113,14 +275,14 @@ func (_c *MockITaskRepository_DeleteTask_Call) Return(_a0 error) *MockITaskRepos
 	return _c
 }
 
-func (_c *MockITaskRepository_DeleteTask_Call) RunAndReturn(run func(context.Context, string) error) *MockITaskRepository_DeleteTask_Call {
+func (_c *MockITaskRepository_DeleteTask_Call) RunAndReturn(run func(context.Context, string, *models.TaskHistory) error) *MockITaskRepository_DeleteTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetAllTasks provides a mock function with given fields: ctx, req, userId
-func (_m *MockITaskRepository) GetAllTasks(ctx context.Context, req *entities.GetAllTasksRequest, userId string) (*[]models.Task, error) {
-	ret := _m.Called(ctx, req, userId)
+// GetAllTasks provides a mock function with given fields: ctx, req, filter, cursor, userId
+func (_m *MockITaskRepository) GetAllTasks(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, cursor *utils.Cursor, userId string) (*[]models.Task, error) {
+	ret := _m.Called(ctx, req, filter, cursor, userId)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetAllTasks")
@@ -128,19 +290,19 @@ func (_m *MockITaskRepository) GetAllTasks(ctx context.Context, req *entities.Ge
 
 	var r0 *[]models.Task
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetAllTasksRequest, string) (*[]models.Task, error)); ok {
-		return rf(ctx, req, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, *utils.Cursor, string) (*[]models.Task, error)); ok {
+		return rf(ctx, req, filter, cursor, userId)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetAllTasksRequest, string) *[]models.Task); ok {
-		r0 = rf(ctx, req, userId)
+	if rf, ok := ret.Get(0).(func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, *utils.Cursor, string) *[]models.Task); ok {
+		r0 = rf(ctx, req, filter, cursor, userId)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*[]models.Task)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *entities.GetAllTasksRequest, string) error); ok {
-		r1 = rf(ctx, req, userId)
+	if rf, ok := ret.Get(1).(func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, *utils.Cursor, string) error); ok {
+		r1 = rf(ctx, req, filter, cursor, userId)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -156,14 +318,16 @@ type MockITaskRepository_GetAllTasks_Call struct {
 // GetAllTasks is a helper method to define mock.On call
 //   - ctx context.Context
 //   - req *entities.GetAllTasksRequest
+//   - filter utils.FilterNode
+//   - cursor *utils.Cursor
 //   - userId string
-func (_e *MockITaskRepository_Expecter) GetAllTasks(ctx interface{}, req interface{}, userId interface{}) *MockITaskRepository_GetAllTasks_Call {
-	return &MockITaskRepository_GetAllTasks_Call{Call: _e.mock.On("GetAllTasks", ctx, req, userId)}
+func (_e *MockITaskRepository_Expecter) GetAllTasks(ctx interface{}, req interface{}, filter interface{}, cursor interface{}, userId interface{}) *MockITaskRepository_GetAllTasks_Call {
+	return &MockITaskRepository_GetAllTasks_Call{Call: _e.mock.On("GetAllTasks", ctx, req, filter, cursor, userId)}
 }
 
-func (_c *MockITaskRepository_GetAllTasks_Call) Run(run func(ctx context.Context, req *entities.GetAllTasksRequest, userId string)) *MockITaskRepository_GetAllTasks_Call {
+func (_c *MockITaskRepository_GetAllTasks_Call) Run(run func(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, cursor *utils.Cursor, userId string)) *MockITaskRepository_GetAllTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*entities.GetAllTasksRequest), args[2].(string))
+		run(args[0].(context.Context), args[1].(*entities.GetAllTasksRequest), args[2].(utils.FilterNode), args[3].(*utils.Cursor), args[4].(string))
 	})
 	return _c
 }
@@ -173,7 +337,124 @@ func (_c *MockITaskRepository_GetAllTasks_Call) Return(_a0 *[]models.Task, _a1 e
 	return _c
 }
 
-func (_c *MockITaskRepository_GetAllTasks_Call) RunAndReturn(run func(context.Context, *entities.GetAllTasksRequest, string) (*[]models.Task, error)) *MockITaskRepository_GetAllTasks_Call {
+func (_c *MockITaskRepository_GetAllTasks_Call) RunAndReturn(run func(context.Context, *entities.GetAllTasksRequest, utils.FilterNode, *utils.Cursor, string) (*[]models.Task, error)) *MockITaskRepository_GetAllTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetBoardTasks provides a mock function with given fields: ctx, userId
+func (_m *MockITaskRepository) GetBoardTasks(ctx context.Context, userId string) (*[]models.Task, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetBoardTasks")
+	}
+
+	var r0 *[]models.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.Task, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.Task); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskRepository_GetBoardTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetBoardTasks'
+type MockITaskRepository_GetBoardTasks_Call struct {
+	*mock.Call
+}
+
+// GetBoardTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockITaskRepository_Expecter) GetBoardTasks(ctx interface{}, userId interface{}) *MockITaskRepository_GetBoardTasks_Call {
+	return &MockITaskRepository_GetBoardTasks_Call{Call: _e.mock.On("GetBoardTasks", ctx, userId)}
+}
+
+func (_c *MockITaskRepository_GetBoardTasks_Call) Run(run func(ctx context.Context, userId string)) *MockITaskRepository_GetBoardTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_GetBoardTasks_Call) Return(_a0 *[]models.Task, _a1 error) *MockITaskRepository_GetBoardTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskRepository_GetBoardTasks_Call) RunAndReturn(run func(context.Context, string) (*[]models.Task, error)) *MockITaskRepository_GetBoardTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSharePermission provides a mock function with given fields: ctx, taskId, userId
+func (_m *MockITaskRepository) GetSharePermission(ctx context.Context, taskId string, userId string) (string, error) {
+	ret := _m.Called(ctx, taskId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharePermission")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (string, error)); ok {
+		return rf(ctx, taskId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) string); ok {
+		r0 = rf(ctx, taskId, userId)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskRepository_GetSharePermission_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSharePermission'
+type MockITaskRepository_GetSharePermission_Call struct {
+	*mock.Call
+}
+
+// GetSharePermission is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+//   - userId string
+func (_e *MockITaskRepository_Expecter) GetSharePermission(ctx interface{}, taskId interface{}, userId interface{}) *MockITaskRepository_GetSharePermission_Call {
+	return &MockITaskRepository_GetSharePermission_Call{Call: _e.mock.On("GetSharePermission", ctx, taskId, userId)}
+}
+
+func (_c *MockITaskRepository_GetSharePermission_Call) Run(run func(ctx context.Context, taskId string, userId string)) *MockITaskRepository_GetSharePermission_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_GetSharePermission_Call) Return(_a0 string, _a1 error) *MockITaskRepository_GetSharePermission_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskRepository_GetSharePermission_Call) RunAndReturn(run func(context.Context, string, string) (string, error)) *MockITaskRepository_GetSharePermission_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -237,6 +518,125 @@ func (_c *MockITaskRepository_GetTask_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// GetTaskHistory provides a mock function with given fields: ctx, taskId
+func (_m *MockITaskRepository) GetTaskHistory(ctx context.Context, taskId string) (*[]models.TaskHistory, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskHistory")
+	}
+
+	var r0 *[]models.TaskHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.TaskHistory, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.TaskHistory); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.TaskHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskRepository_GetTaskHistory_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskHistory'
+type MockITaskRepository_GetTaskHistory_Call struct {
+	*mock.Call
+}
+
+// GetTaskHistory is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+func (_e *MockITaskRepository_Expecter) GetTaskHistory(ctx interface{}, taskId interface{}) *MockITaskRepository_GetTaskHistory_Call {
+	return &MockITaskRepository_GetTaskHistory_Call{Call: _e.mock.On("GetTaskHistory", ctx, taskId)}
+}
+
+func (_c *MockITaskRepository_GetTaskHistory_Call) Run(run func(ctx context.Context, taskId string)) *MockITaskRepository_GetTaskHistory_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_GetTaskHistory_Call) Return(_a0 *[]models.TaskHistory, _a1 error) *MockITaskRepository_GetTaskHistory_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskRepository_GetTaskHistory_Call) RunAndReturn(run func(context.Context, string) (*[]models.TaskHistory, error)) *MockITaskRepository_GetTaskHistory_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskHistoryVersion provides a mock function with given fields: ctx, taskId, version
+func (_m *MockITaskRepository) GetTaskHistoryVersion(ctx context.Context, taskId string, version int) (*models.TaskHistory, error) {
+	ret := _m.Called(ctx, taskId, version)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskHistoryVersion")
+	}
+
+	var r0 *models.TaskHistory
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*models.TaskHistory, error)); ok {
+		return rf(ctx, taskId, version)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *models.TaskHistory); ok {
+		r0 = rf(ctx, taskId, version)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.TaskHistory)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, taskId, version)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskRepository_GetTaskHistoryVersion_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskHistoryVersion'
+type MockITaskRepository_GetTaskHistoryVersion_Call struct {
+	*mock.Call
+}
+
+// GetTaskHistoryVersion is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+//   - version int
+func (_e *MockITaskRepository_Expecter) GetTaskHistoryVersion(ctx interface{}, taskId interface{}, version interface{}) *MockITaskRepository_GetTaskHistoryVersion_Call {
+	return &MockITaskRepository_GetTaskHistoryVersion_Call{Call: _e.mock.On("GetTaskHistoryVersion", ctx, taskId, version)}
+}
+
+func (_c *MockITaskRepository_GetTaskHistoryVersion_Call) Run(run func(ctx context.Context, taskId string, version int)) *MockITaskRepository_GetTaskHistoryVersion_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_GetTaskHistoryVersion_Call) Return(_a0 *models.TaskHistory, _a1 error) *MockITaskRepository_GetTaskHistoryVersion_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskRepository_GetTaskHistoryVersion_Call) RunAndReturn(run func(context.Context, string, int) (*models.TaskHistory, error)) *MockITaskRepository_GetTaskHistoryVersion_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // HealthCheck provides a mock function with given fields: ctx
 func (_m *MockITaskRepository) HealthCheck(ctx context.Context) (string, error) {
 	ret := _m.Called(ctx)
@@ -293,17 +693,66 @@ func (_c *MockITaskRepository_HealthCheck_Call) RunAndReturn(run func(context.Co
 	return _c
 }
 
-// UpdateTask provides a mock function with given fields: ctx, task
-func (_m *MockITaskRepository) UpdateTask(ctx context.Context, task *models.Task) error {
-	ret := _m.Called(ctx, task)
+// MoveTask provides a mock function with given fields: ctx, task, ranks, history
+func (_m *MockITaskRepository) MoveTask(ctx context.Context, task *models.Task, ranks map[string]string, history *models.TaskHistory) error {
+	ret := _m.Called(ctx, task, ranks, history)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MoveTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Task, map[string]string, *models.TaskHistory) error); ok {
+		r0 = rf(ctx, task, ranks, history)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITaskRepository_MoveTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MoveTask'
+type MockITaskRepository_MoveTask_Call struct {
+	*mock.Call
+}
+
+// MoveTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - task *models.Task
+//   - ranks map[string]string
+//   - history *models.TaskHistory
+func (_e *MockITaskRepository_Expecter) MoveTask(ctx interface{}, task interface{}, ranks interface{}, history interface{}) *MockITaskRepository_MoveTask_Call {
+	return &MockITaskRepository_MoveTask_Call{Call: _e.mock.On("MoveTask", ctx, task, ranks, history)}
+}
+
+func (_c *MockITaskRepository_MoveTask_Call) Run(run func(ctx context.Context, task *models.Task, ranks map[string]string, history *models.TaskHistory)) *MockITaskRepository_MoveTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Task), args[2].(map[string]string), args[3].(*models.TaskHistory))
+	})
+	return _c
+}
+
+func (_c *MockITaskRepository_MoveTask_Call) Return(_a0 error) *MockITaskRepository_MoveTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITaskRepository_MoveTask_Call) RunAndReturn(run func(context.Context, *models.Task, map[string]string, *models.TaskHistory) error) *MockITaskRepository_MoveTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateTask provides a mock function with given fields: ctx, task, history
+func (_m *MockITaskRepository) UpdateTask(ctx context.Context, task *models.Task, history *models.TaskHistory) error {
+	ret := _m.Called(ctx, task, history)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateTask")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *models.Task) error); ok {
-		r0 = rf(ctx, task)
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Task, *models.TaskHistory) error); ok {
+		r0 = rf(ctx, task, history)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -319,13 +768,14 @@ type MockITaskRepository_UpdateTask_Call struct {
 // UpdateTask is a helper method to define mock.On call
 //   - ctx context.Context
 //   - task *models.Task
-func (_e *MockITaskRepository_Expecter) UpdateTask(ctx interface{}, task interface{}) *MockITaskRepository_UpdateTask_Call {
-	return &MockITaskRepository_UpdateTask_Call{Call: _e.mock.On("UpdateTask", ctx, task)}
+//   - history *models.TaskHistory
+func (_e *MockITaskRepository_Expecter) UpdateTask(ctx interface{}, task interface{}, history interface{}) *MockITaskRepository_UpdateTask_Call {
+	return &MockITaskRepository_UpdateTask_Call{Call: _e.mock.On("UpdateTask", ctx, task, history)}
 }
 
-func (_c *MockITaskRepository_UpdateTask_Call) Run(run func(ctx context.Context, task *models.Task)) *MockITaskRepository_UpdateTask_Call {
+func (_c *MockITaskRepository_UpdateTask_Call) Run(run func(ctx context.Context, task *models.Task, history *models.TaskHistory)) *MockITaskRepository_UpdateTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*models.Task))
+		run(args[0].(context.Context), args[1].(*models.Task), args[2].(*models.TaskHistory))
 	})
 	return _c
 }
@@ -335,7 +785,7 @@ func (_c *MockITaskRepository_UpdateTask_Call) Return(_a0 error) *MockITaskRepos
 	return _c
 }
 
-func (_c *MockITaskRepository_UpdateTask_Call) RunAndReturn(run func(context.Context, *models.Task) error) *MockITaskRepository_UpdateTask_Call {
+func (_c *MockITaskRepository_UpdateTask_Call) RunAndReturn(run func(context.Context, *models.Task, *models.TaskHistory) error) *MockITaskRepository_UpdateTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
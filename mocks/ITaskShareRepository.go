@@ -0,0 +1,269 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockITaskShareRepository is an autogenerated mock type for the ITaskShareRepository type
+type MockITaskShareRepository struct {
+	mock.Mock
+}
+
+type MockITaskShareRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockITaskShareRepository) EXPECT() *MockITaskShareRepository_Expecter {
+	return &MockITaskShareRepository_Expecter{mock: &_m.Mock}
+}
+
+// DeleteShare provides a mock function with given fields: ctx, taskId, userId
+func (_m *MockITaskShareRepository) DeleteShare(ctx context.Context, taskId string, userId string) (int64, error) {
+	ret := _m.Called(ctx, taskId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteShare")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, taskId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, taskId, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, taskId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskShareRepository_DeleteShare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteShare'
+type MockITaskShareRepository_DeleteShare_Call struct {
+	*mock.Call
+}
+
+// DeleteShare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+//   - userId string
+func (_e *MockITaskShareRepository_Expecter) DeleteShare(ctx interface{}, taskId interface{}, userId interface{}) *MockITaskShareRepository_DeleteShare_Call {
+	return &MockITaskShareRepository_DeleteShare_Call{Call: _e.mock.On("DeleteShare", ctx, taskId, userId)}
+}
+
+func (_c *MockITaskShareRepository_DeleteShare_Call) Run(run func(ctx context.Context, taskId string, userId string)) *MockITaskShareRepository_DeleteShare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskShareRepository_DeleteShare_Call) Return(_a0 int64, _a1 error) *MockITaskShareRepository_DeleteShare_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskShareRepository_DeleteShare_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockITaskShareRepository_DeleteShare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetSharedTasks provides a mock function with given fields: ctx, userId, limit, offset
+func (_m *MockITaskShareRepository) GetSharedTasks(ctx context.Context, userId string, limit int, offset int) (*[]models.Task, int64, error) {
+	ret := _m.Called(ctx, userId, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetSharedTasks")
+	}
+
+	var r0 *[]models.Task
+	var r1 int64
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*[]models.Task, int64, error)); ok {
+		return rf(ctx, userId, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *[]models.Task); ok {
+		r0 = rf(ctx, userId, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) int64); ok {
+		r1 = rf(ctx, userId, limit, offset)
+	} else {
+		r1 = ret.Get(1).(int64)
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, string, int, int) error); ok {
+		r2 = rf(ctx, userId, limit, offset)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// MockITaskShareRepository_GetSharedTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetSharedTasks'
+type MockITaskShareRepository_GetSharedTasks_Call struct {
+	*mock.Call
+}
+
+// GetSharedTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+//   - limit int
+//   - offset int
+func (_e *MockITaskShareRepository_Expecter) GetSharedTasks(ctx interface{}, userId interface{}, limit interface{}, offset interface{}) *MockITaskShareRepository_GetSharedTasks_Call {
+	return &MockITaskShareRepository_GetSharedTasks_Call{Call: _e.mock.On("GetSharedTasks", ctx, userId, limit, offset)}
+}
+
+func (_c *MockITaskShareRepository_GetSharedTasks_Call) Run(run func(ctx context.Context, userId string, limit int, offset int)) *MockITaskShareRepository_GetSharedTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(int), args[3].(int))
+	})
+	return _c
+}
+
+func (_c *MockITaskShareRepository_GetSharedTasks_Call) Return(_a0 *[]models.Task, _a1 int64, _a2 error) *MockITaskShareRepository_GetSharedTasks_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *MockITaskShareRepository_GetSharedTasks_Call) RunAndReturn(run func(context.Context, string, int, int) (*[]models.Task, int64, error)) *MockITaskShareRepository_GetSharedTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetShares provides a mock function with given fields: ctx, taskId
+func (_m *MockITaskShareRepository) GetShares(ctx context.Context, taskId string) (*[]models.TaskShare, error) {
+	ret := _m.Called(ctx, taskId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetShares")
+	}
+
+	var r0 *[]models.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.TaskShare, error)); ok {
+		return rf(ctx, taskId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.TaskShare); ok {
+		r0 = rf(ctx, taskId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.TaskShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockITaskShareRepository_GetShares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetShares'
+type MockITaskShareRepository_GetShares_Call struct {
+	*mock.Call
+}
+
+// GetShares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskId string
+func (_e *MockITaskShareRepository_Expecter) GetShares(ctx interface{}, taskId interface{}) *MockITaskShareRepository_GetShares_Call {
+	return &MockITaskShareRepository_GetShares_Call{Call: _e.mock.On("GetShares", ctx, taskId)}
+}
+
+func (_c *MockITaskShareRepository_GetShares_Call) Run(run func(ctx context.Context, taskId string)) *MockITaskShareRepository_GetShares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockITaskShareRepository_GetShares_Call) Return(_a0 *[]models.TaskShare, _a1 error) *MockITaskShareRepository_GetShares_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockITaskShareRepository_GetShares_Call) RunAndReturn(run func(context.Context, string) (*[]models.TaskShare, error)) *MockITaskShareRepository_GetShares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpsertShare provides a mock function with given fields: ctx, share
+func (_m *MockITaskShareRepository) UpsertShare(ctx context.Context, share *models.TaskShare) error {
+	ret := _m.Called(ctx, share)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpsertShare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.TaskShare) error); ok {
+		r0 = rf(ctx, share)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockITaskShareRepository_UpsertShare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpsertShare'
+type MockITaskShareRepository_UpsertShare_Call struct {
+	*mock.Call
+}
+
+// UpsertShare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - share *models.TaskShare
+func (_e *MockITaskShareRepository_Expecter) UpsertShare(ctx interface{}, share interface{}) *MockITaskShareRepository_UpsertShare_Call {
+	return &MockITaskShareRepository_UpsertShare_Call{Call: _e.mock.On("UpsertShare", ctx, share)}
+}
+
+func (_c *MockITaskShareRepository_UpsertShare_Call) Run(run func(ctx context.Context, share *models.TaskShare)) *MockITaskShareRepository_UpsertShare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.TaskShare))
+	})
+	return _c
+}
+
+func (_c *MockITaskShareRepository_UpsertShare_Call) Return(_a0 error) *MockITaskShareRepository_UpsertShare_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockITaskShareRepository_UpsertShare_Call) RunAndReturn(run func(context.Context, *models.TaskShare) error) *MockITaskShareRepository_UpsertShare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockITaskShareRepository creates a new instance of MockITaskShareRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockITaskShareRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockITaskShareRepository {
+	mock := &MockITaskShareRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,132 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	mock "github.com/stretchr/testify/mock"
+
+	utils "github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+// MockIURLSigner is an autogenerated mock type for the IURLSigner type
+type MockIURLSigner struct {
+	mock.Mock
+}
+
+type MockIURLSigner_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIURLSigner) EXPECT() *MockIURLSigner_Expecter {
+	return &MockIURLSigner_Expecter{mock: &_m.Mock}
+}
+
+// Sign provides a mock function with given fields: path, userId, permission
+func (_m *MockIURLSigner) Sign(path string, userId string, permission constants.TaskPermission) string {
+	ret := _m.Called(path, userId, permission)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Sign")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(string, string, constants.TaskPermission) string); ok {
+		r0 = rf(path, userId, permission)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// MockIURLSigner_Sign_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Sign'
+type MockIURLSigner_Sign_Call struct {
+	*mock.Call
+}
+
+// Sign is a helper method to define mock.On call
+//   - path string
+//   - userId string
+//   - permission constants.TaskPermission
+func (_e *MockIURLSigner_Expecter) Sign(path interface{}, userId interface{}, permission interface{}) *MockIURLSigner_Sign_Call {
+	return &MockIURLSigner_Sign_Call{Call: _e.mock.On("Sign", path, userId, permission)}
+}
+
+func (_c *MockIURLSigner_Sign_Call) Run(run func(path string, userId string, permission constants.TaskPermission)) *MockIURLSigner_Sign_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(string), args[2].(constants.TaskPermission))
+	})
+	return _c
+}
+
+func (_c *MockIURLSigner_Sign_Call) Return(_a0 string) *MockIURLSigner_Sign_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIURLSigner_Sign_Call) RunAndReturn(run func(string, string, constants.TaskPermission) string) *MockIURLSigner_Sign_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Verify provides a mock function with given fields: path, grant
+func (_m *MockIURLSigner) Verify(path string, grant *utils.URLGrant) error {
+	ret := _m.Called(path, grant)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Verify")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, *utils.URLGrant) error); ok {
+		r0 = rf(path, grant)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIURLSigner_Verify_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Verify'
+type MockIURLSigner_Verify_Call struct {
+	*mock.Call
+}
+
+// Verify is a helper method to define mock.On call
+//   - path string
+//   - grant *utils.URLGrant
+func (_e *MockIURLSigner_Expecter) Verify(path interface{}, grant interface{}) *MockIURLSigner_Verify_Call {
+	return &MockIURLSigner_Verify_Call{Call: _e.mock.On("Verify", path, grant)}
+}
+
+func (_c *MockIURLSigner_Verify_Call) Run(run func(path string, grant *utils.URLGrant)) *MockIURLSigner_Verify_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(string), args[1].(*utils.URLGrant))
+	})
+	return _c
+}
+
+func (_c *MockIURLSigner_Verify_Call) Return(_a0 error) *MockIURLSigner_Verify_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIURLSigner_Verify_Call) RunAndReturn(run func(string, *utils.URLGrant) error) *MockIURLSigner_Verify_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIURLSigner creates a new instance of MockIURLSigner. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIURLSigner(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIURLSigner {
+	mock := &MockIURLSigner{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -81,6 +81,65 @@ func (_c *MockIUserRepository_GetUser_Call) RunAndReturn(run func(context.Contex
 	return _c
 }
 
+// GetUserByID provides a mock function with given fields: ctx, id
+func (_m *MockIUserRepository) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUserByID")
+	}
+
+	var r0 *models.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.User, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.User); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIUserRepository_GetUserByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetUserByID'
+type MockIUserRepository_GetUserByID_Call struct {
+	*mock.Call
+}
+
+// GetUserByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIUserRepository_Expecter) GetUserByID(ctx interface{}, id interface{}) *MockIUserRepository_GetUserByID_Call {
+	return &MockIUserRepository_GetUserByID_Call{Call: _e.mock.On("GetUserByID", ctx, id)}
+}
+
+func (_c *MockIUserRepository_GetUserByID_Call) Run(run func(ctx context.Context, id string)) *MockIUserRepository_GetUserByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIUserRepository_GetUserByID_Call) Return(_a0 *models.User, _a1 error) *MockIUserRepository_GetUserByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIUserRepository_GetUserByID_Call) RunAndReturn(run func(context.Context, string) (*models.User, error)) *MockIUserRepository_GetUserByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // RegisterUser provides a mock function with given fields: ctx, user
 func (_m *MockIUserRepository) RegisterUser(ctx context.Context, user *models.User) error {
 	ret := _m.Called(ctx, user)
@@ -128,6 +187,54 @@ func (_c *MockIUserRepository_RegisterUser_Call) RunAndReturn(run func(context.C
 	return _c
 }
 
+// UpdateUserTimezone provides a mock function with given fields: ctx, id, timezone
+func (_m *MockIUserRepository) UpdateUserTimezone(ctx context.Context, id string, timezone *string) error {
+	ret := _m.Called(ctx, id, timezone)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateUserTimezone")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *string) error); ok {
+		r0 = rf(ctx, id, timezone)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIUserRepository_UpdateUserTimezone_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateUserTimezone'
+type MockIUserRepository_UpdateUserTimezone_Call struct {
+	*mock.Call
+}
+
+// UpdateUserTimezone is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - timezone *string
+func (_e *MockIUserRepository_Expecter) UpdateUserTimezone(ctx interface{}, id interface{}, timezone interface{}) *MockIUserRepository_UpdateUserTimezone_Call {
+	return &MockIUserRepository_UpdateUserTimezone_Call{Call: _e.mock.On("UpdateUserTimezone", ctx, id, timezone)}
+}
+
+func (_c *MockIUserRepository_UpdateUserTimezone_Call) Run(run func(ctx context.Context, id string, timezone *string)) *MockIUserRepository_UpdateUserTimezone_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*string))
+	})
+	return _c
+}
+
+func (_c *MockIUserRepository_UpdateUserTimezone_Call) Return(_a0 error) *MockIUserRepository_UpdateUserTimezone_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIUserRepository_UpdateUserTimezone_Call) RunAndReturn(run func(context.Context, string, *string) error) *MockIUserRepository_UpdateUserTimezone_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewMockIUserRepository creates a new instance of MockIUserRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewMockIUserRepository(t interface {
@@ -0,0 +1,308 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIViewRepository is an autogenerated mock type for the IViewRepository type
+type MockIViewRepository struct {
+	mock.Mock
+}
+
+type MockIViewRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIViewRepository) EXPECT() *MockIViewRepository_Expecter {
+	return &MockIViewRepository_Expecter{mock: &_m.Mock}
+}
+
+// CreateView provides a mock function with given fields: ctx, view
+func (_m *MockIViewRepository) CreateView(ctx context.Context, view *models.SavedView) error {
+	ret := _m.Called(ctx, view)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SavedView) error); ok {
+		r0 = rf(ctx, view)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIViewRepository_CreateView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateView'
+type MockIViewRepository_CreateView_Call struct {
+	*mock.Call
+}
+
+// CreateView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - view *models.SavedView
+func (_e *MockIViewRepository_Expecter) CreateView(ctx interface{}, view interface{}) *MockIViewRepository_CreateView_Call {
+	return &MockIViewRepository_CreateView_Call{Call: _e.mock.On("CreateView", ctx, view)}
+}
+
+func (_c *MockIViewRepository_CreateView_Call) Run(run func(ctx context.Context, view *models.SavedView)) *MockIViewRepository_CreateView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SavedView))
+	})
+	return _c
+}
+
+func (_c *MockIViewRepository_CreateView_Call) Return(_a0 error) *MockIViewRepository_CreateView_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIViewRepository_CreateView_Call) RunAndReturn(run func(context.Context, *models.SavedView) error) *MockIViewRepository_CreateView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteView provides a mock function with given fields: ctx, id, userId
+func (_m *MockIViewRepository) DeleteView(ctx context.Context, id string, userId string) (int64, error) {
+	ret := _m.Called(ctx, id, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteView")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, id, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, id, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIViewRepository_DeleteView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteView'
+type MockIViewRepository_DeleteView_Call struct {
+	*mock.Call
+}
+
+// DeleteView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userId string
+func (_e *MockIViewRepository_Expecter) DeleteView(ctx interface{}, id interface{}, userId interface{}) *MockIViewRepository_DeleteView_Call {
+	return &MockIViewRepository_DeleteView_Call{Call: _e.mock.On("DeleteView", ctx, id, userId)}
+}
+
+func (_c *MockIViewRepository_DeleteView_Call) Run(run func(ctx context.Context, id string, userId string)) *MockIViewRepository_DeleteView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIViewRepository_DeleteView_Call) Return(_a0 int64, _a1 error) *MockIViewRepository_DeleteView_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIViewRepository_DeleteView_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockIViewRepository_DeleteView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetView provides a mock function with given fields: ctx, id, userId
+func (_m *MockIViewRepository) GetView(ctx context.Context, id string, userId string) (*models.SavedView, error) {
+	ret := _m.Called(ctx, id, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetView")
+	}
+
+	var r0 *models.SavedView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.SavedView, error)); ok {
+		return rf(ctx, id, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.SavedView); ok {
+		r0 = rf(ctx, id, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.SavedView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, id, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIViewRepository_GetView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetView'
+type MockIViewRepository_GetView_Call struct {
+	*mock.Call
+}
+
+// GetView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - userId string
+func (_e *MockIViewRepository_Expecter) GetView(ctx interface{}, id interface{}, userId interface{}) *MockIViewRepository_GetView_Call {
+	return &MockIViewRepository_GetView_Call{Call: _e.mock.On("GetView", ctx, id, userId)}
+}
+
+func (_c *MockIViewRepository_GetView_Call) Run(run func(ctx context.Context, id string, userId string)) *MockIViewRepository_GetView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIViewRepository_GetView_Call) Return(_a0 *models.SavedView, _a1 error) *MockIViewRepository_GetView_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIViewRepository_GetView_Call) RunAndReturn(run func(context.Context, string, string) (*models.SavedView, error)) *MockIViewRepository_GetView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetViews provides a mock function with given fields: ctx, userId
+func (_m *MockIViewRepository) GetViews(ctx context.Context, userId string) (*[]models.SavedView, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetViews")
+	}
+
+	var r0 *[]models.SavedView
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.SavedView, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.SavedView); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.SavedView)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIViewRepository_GetViews_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetViews'
+type MockIViewRepository_GetViews_Call struct {
+	*mock.Call
+}
+
+// GetViews is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockIViewRepository_Expecter) GetViews(ctx interface{}, userId interface{}) *MockIViewRepository_GetViews_Call {
+	return &MockIViewRepository_GetViews_Call{Call: _e.mock.On("GetViews", ctx, userId)}
+}
+
+func (_c *MockIViewRepository_GetViews_Call) Run(run func(ctx context.Context, userId string)) *MockIViewRepository_GetViews_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIViewRepository_GetViews_Call) Return(_a0 *[]models.SavedView, _a1 error) *MockIViewRepository_GetViews_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIViewRepository_GetViews_Call) RunAndReturn(run func(context.Context, string) (*[]models.SavedView, error)) *MockIViewRepository_GetViews_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateView provides a mock function with given fields: ctx, view
+func (_m *MockIViewRepository) UpdateView(ctx context.Context, view *models.SavedView) error {
+	ret := _m.Called(ctx, view)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateView")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.SavedView) error); ok {
+		r0 = rf(ctx, view)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIViewRepository_UpdateView_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateView'
+type MockIViewRepository_UpdateView_Call struct {
+	*mock.Call
+}
+
+// UpdateView is a helper method to define mock.On call
+//   - ctx context.Context
+//   - view *models.SavedView
+func (_e *MockIViewRepository_Expecter) UpdateView(ctx interface{}, view interface{}) *MockIViewRepository_UpdateView_Call {
+	return &MockIViewRepository_UpdateView_Call{Call: _e.mock.On("UpdateView", ctx, view)}
+}
+
+func (_c *MockIViewRepository_UpdateView_Call) Run(run func(ctx context.Context, view *models.SavedView)) *MockIViewRepository_UpdateView_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.SavedView))
+	})
+	return _c
+}
+
+func (_c *MockIViewRepository_UpdateView_Call) Return(_a0 error) *MockIViewRepository_UpdateView_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIViewRepository_UpdateView_Call) RunAndReturn(run func(context.Context, *models.SavedView) error) *MockIViewRepository_UpdateView_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIViewRepository creates a new instance of MockIViewRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIViewRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIViewRepository {
+	mock := &MockIViewRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
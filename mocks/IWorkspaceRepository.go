@@ -0,0 +1,710 @@
+// Code generated by mockery v2.53.3. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/guncv/tech-exam-software-engineering/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MockIWorkspaceRepository is an autogenerated mock type for the IWorkspaceRepository type
+type MockIWorkspaceRepository struct {
+	mock.Mock
+}
+
+type MockIWorkspaceRepository_Expecter struct {
+	mock *mock.Mock
+}
+
+func (_m *MockIWorkspaceRepository) EXPECT() *MockIWorkspaceRepository_Expecter {
+	return &MockIWorkspaceRepository_Expecter{mock: &_m.Mock}
+}
+
+// AcceptInvitation provides a mock function with given fields: ctx, invitation, member
+func (_m *MockIWorkspaceRepository) AcceptInvitation(ctx context.Context, invitation *models.WorkspaceInvitation, member *models.WorkspaceMember) error {
+	ret := _m.Called(ctx, invitation, member)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AcceptInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WorkspaceInvitation, *models.WorkspaceMember) error); ok {
+		r0 = rf(ctx, invitation, member)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIWorkspaceRepository_AcceptInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AcceptInvitation'
+type MockIWorkspaceRepository_AcceptInvitation_Call struct {
+	*mock.Call
+}
+
+// AcceptInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitation *models.WorkspaceInvitation
+//   - member *models.WorkspaceMember
+func (_e *MockIWorkspaceRepository_Expecter) AcceptInvitation(ctx interface{}, invitation interface{}, member interface{}) *MockIWorkspaceRepository_AcceptInvitation_Call {
+	return &MockIWorkspaceRepository_AcceptInvitation_Call{Call: _e.mock.On("AcceptInvitation", ctx, invitation, member)}
+}
+
+func (_c *MockIWorkspaceRepository_AcceptInvitation_Call) Run(run func(ctx context.Context, invitation *models.WorkspaceInvitation, member *models.WorkspaceMember)) *MockIWorkspaceRepository_AcceptInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WorkspaceInvitation), args[2].(*models.WorkspaceMember))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_AcceptInvitation_Call) Return(_a0 error) *MockIWorkspaceRepository_AcceptInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_AcceptInvitation_Call) RunAndReturn(run func(context.Context, *models.WorkspaceInvitation, *models.WorkspaceMember) error) *MockIWorkspaceRepository_AcceptInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateInvitation provides a mock function with given fields: ctx, invitation
+func (_m *MockIWorkspaceRepository) CreateInvitation(ctx context.Context, invitation *models.WorkspaceInvitation) error {
+	ret := _m.Called(ctx, invitation)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateInvitation")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.WorkspaceInvitation) error); ok {
+		r0 = rf(ctx, invitation)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIWorkspaceRepository_CreateInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateInvitation'
+type MockIWorkspaceRepository_CreateInvitation_Call struct {
+	*mock.Call
+}
+
+// CreateInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - invitation *models.WorkspaceInvitation
+func (_e *MockIWorkspaceRepository_Expecter) CreateInvitation(ctx interface{}, invitation interface{}) *MockIWorkspaceRepository_CreateInvitation_Call {
+	return &MockIWorkspaceRepository_CreateInvitation_Call{Call: _e.mock.On("CreateInvitation", ctx, invitation)}
+}
+
+func (_c *MockIWorkspaceRepository_CreateInvitation_Call) Run(run func(ctx context.Context, invitation *models.WorkspaceInvitation)) *MockIWorkspaceRepository_CreateInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.WorkspaceInvitation))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_CreateInvitation_Call) Return(_a0 error) *MockIWorkspaceRepository_CreateInvitation_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_CreateInvitation_Call) RunAndReturn(run func(context.Context, *models.WorkspaceInvitation) error) *MockIWorkspaceRepository_CreateInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateWorkspace provides a mock function with given fields: ctx, workspace, owner
+func (_m *MockIWorkspaceRepository) CreateWorkspace(ctx context.Context, workspace *models.Workspace, owner *models.WorkspaceMember) error {
+	ret := _m.Called(ctx, workspace, owner)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateWorkspace")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Workspace, *models.WorkspaceMember) error); ok {
+		r0 = rf(ctx, workspace, owner)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockIWorkspaceRepository_CreateWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateWorkspace'
+type MockIWorkspaceRepository_CreateWorkspace_Call struct {
+	*mock.Call
+}
+
+// CreateWorkspace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workspace *models.Workspace
+//   - owner *models.WorkspaceMember
+func (_e *MockIWorkspaceRepository_Expecter) CreateWorkspace(ctx interface{}, workspace interface{}, owner interface{}) *MockIWorkspaceRepository_CreateWorkspace_Call {
+	return &MockIWorkspaceRepository_CreateWorkspace_Call{Call: _e.mock.On("CreateWorkspace", ctx, workspace, owner)}
+}
+
+func (_c *MockIWorkspaceRepository_CreateWorkspace_Call) Run(run func(ctx context.Context, workspace *models.Workspace, owner *models.WorkspaceMember)) *MockIWorkspaceRepository_CreateWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*models.Workspace), args[2].(*models.WorkspaceMember))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_CreateWorkspace_Call) Return(_a0 error) *MockIWorkspaceRepository_CreateWorkspace_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_CreateWorkspace_Call) RunAndReturn(run func(context.Context, *models.Workspace, *models.WorkspaceMember) error) *MockIWorkspaceRepository_CreateWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteInvitation provides a mock function with given fields: ctx, workspaceId, id
+func (_m *MockIWorkspaceRepository) DeleteInvitation(ctx context.Context, workspaceId string, id string) (int64, error) {
+	ret := _m.Called(ctx, workspaceId, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteInvitation")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, workspaceId, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, workspaceId, id)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, workspaceId, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_DeleteInvitation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteInvitation'
+type MockIWorkspaceRepository_DeleteInvitation_Call struct {
+	*mock.Call
+}
+
+// DeleteInvitation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workspaceId string
+//   - id string
+func (_e *MockIWorkspaceRepository_Expecter) DeleteInvitation(ctx interface{}, workspaceId interface{}, id interface{}) *MockIWorkspaceRepository_DeleteInvitation_Call {
+	return &MockIWorkspaceRepository_DeleteInvitation_Call{Call: _e.mock.On("DeleteInvitation", ctx, workspaceId, id)}
+}
+
+func (_c *MockIWorkspaceRepository_DeleteInvitation_Call) Run(run func(ctx context.Context, workspaceId string, id string)) *MockIWorkspaceRepository_DeleteInvitation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_DeleteInvitation_Call) Return(_a0 int64, _a1 error) *MockIWorkspaceRepository_DeleteInvitation_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_DeleteInvitation_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockIWorkspaceRepository_DeleteInvitation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteMember provides a mock function with given fields: ctx, workspaceId, userId
+func (_m *MockIWorkspaceRepository) DeleteMember(ctx context.Context, workspaceId string, userId string) (int64, error) {
+	ret := _m.Called(ctx, workspaceId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMember")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (int64, error)); ok {
+		return rf(ctx, workspaceId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) int64); ok {
+		r0 = rf(ctx, workspaceId, userId)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, workspaceId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_DeleteMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteMember'
+type MockIWorkspaceRepository_DeleteMember_Call struct {
+	*mock.Call
+}
+
+// DeleteMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workspaceId string
+//   - userId string
+func (_e *MockIWorkspaceRepository_Expecter) DeleteMember(ctx interface{}, workspaceId interface{}, userId interface{}) *MockIWorkspaceRepository_DeleteMember_Call {
+	return &MockIWorkspaceRepository_DeleteMember_Call{Call: _e.mock.On("DeleteMember", ctx, workspaceId, userId)}
+}
+
+func (_c *MockIWorkspaceRepository_DeleteMember_Call) Run(run func(ctx context.Context, workspaceId string, userId string)) *MockIWorkspaceRepository_DeleteMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_DeleteMember_Call) Return(_a0 int64, _a1 error) *MockIWorkspaceRepository_DeleteMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_DeleteMember_Call) RunAndReturn(run func(context.Context, string, string) (int64, error)) *MockIWorkspaceRepository_DeleteMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitationByTokenHash provides a mock function with given fields: ctx, tokenHash
+func (_m *MockIWorkspaceRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.WorkspaceInvitation, error) {
+	ret := _m.Called(ctx, tokenHash)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitationByTokenHash")
+	}
+
+	var r0 *models.WorkspaceInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.WorkspaceInvitation, error)); ok {
+		return rf(ctx, tokenHash)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.WorkspaceInvitation); ok {
+		r0 = rf(ctx, tokenHash)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WorkspaceInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, tokenHash)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_GetInvitationByTokenHash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitationByTokenHash'
+type MockIWorkspaceRepository_GetInvitationByTokenHash_Call struct {
+	*mock.Call
+}
+
+// GetInvitationByTokenHash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tokenHash string
+func (_e *MockIWorkspaceRepository_Expecter) GetInvitationByTokenHash(ctx interface{}, tokenHash interface{}) *MockIWorkspaceRepository_GetInvitationByTokenHash_Call {
+	return &MockIWorkspaceRepository_GetInvitationByTokenHash_Call{Call: _e.mock.On("GetInvitationByTokenHash", ctx, tokenHash)}
+}
+
+func (_c *MockIWorkspaceRepository_GetInvitationByTokenHash_Call) Run(run func(ctx context.Context, tokenHash string)) *MockIWorkspaceRepository_GetInvitationByTokenHash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetInvitationByTokenHash_Call) Return(_a0 *models.WorkspaceInvitation, _a1 error) *MockIWorkspaceRepository_GetInvitationByTokenHash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetInvitationByTokenHash_Call) RunAndReturn(run func(context.Context, string) (*models.WorkspaceInvitation, error)) *MockIWorkspaceRepository_GetInvitationByTokenHash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetInvitations provides a mock function with given fields: ctx, workspaceId
+func (_m *MockIWorkspaceRepository) GetInvitations(ctx context.Context, workspaceId string) (*[]models.WorkspaceInvitation, error) {
+	ret := _m.Called(ctx, workspaceId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetInvitations")
+	}
+
+	var r0 *[]models.WorkspaceInvitation
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.WorkspaceInvitation, error)); ok {
+		return rf(ctx, workspaceId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.WorkspaceInvitation); ok {
+		r0 = rf(ctx, workspaceId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.WorkspaceInvitation)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, workspaceId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_GetInvitations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetInvitations'
+type MockIWorkspaceRepository_GetInvitations_Call struct {
+	*mock.Call
+}
+
+// GetInvitations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workspaceId string
+func (_e *MockIWorkspaceRepository_Expecter) GetInvitations(ctx interface{}, workspaceId interface{}) *MockIWorkspaceRepository_GetInvitations_Call {
+	return &MockIWorkspaceRepository_GetInvitations_Call{Call: _e.mock.On("GetInvitations", ctx, workspaceId)}
+}
+
+func (_c *MockIWorkspaceRepository_GetInvitations_Call) Run(run func(ctx context.Context, workspaceId string)) *MockIWorkspaceRepository_GetInvitations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetInvitations_Call) Return(_a0 *[]models.WorkspaceInvitation, _a1 error) *MockIWorkspaceRepository_GetInvitations_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetInvitations_Call) RunAndReturn(run func(context.Context, string) (*[]models.WorkspaceInvitation, error)) *MockIWorkspaceRepository_GetInvitations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMember provides a mock function with given fields: ctx, workspaceId, userId
+func (_m *MockIWorkspaceRepository) GetMember(ctx context.Context, workspaceId string, userId string) (*models.WorkspaceMember, error) {
+	ret := _m.Called(ctx, workspaceId, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMember")
+	}
+
+	var r0 *models.WorkspaceMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.WorkspaceMember, error)); ok {
+		return rf(ctx, workspaceId, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.WorkspaceMember); ok {
+		r0 = rf(ctx, workspaceId, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.WorkspaceMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, workspaceId, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_GetMember_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMember'
+type MockIWorkspaceRepository_GetMember_Call struct {
+	*mock.Call
+}
+
+// GetMember is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workspaceId string
+//   - userId string
+func (_e *MockIWorkspaceRepository_Expecter) GetMember(ctx interface{}, workspaceId interface{}, userId interface{}) *MockIWorkspaceRepository_GetMember_Call {
+	return &MockIWorkspaceRepository_GetMember_Call{Call: _e.mock.On("GetMember", ctx, workspaceId, userId)}
+}
+
+func (_c *MockIWorkspaceRepository_GetMember_Call) Run(run func(ctx context.Context, workspaceId string, userId string)) *MockIWorkspaceRepository_GetMember_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetMember_Call) Return(_a0 *models.WorkspaceMember, _a1 error) *MockIWorkspaceRepository_GetMember_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetMember_Call) RunAndReturn(run func(context.Context, string, string) (*models.WorkspaceMember, error)) *MockIWorkspaceRepository_GetMember_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMembers provides a mock function with given fields: ctx, workspaceId
+func (_m *MockIWorkspaceRepository) GetMembers(ctx context.Context, workspaceId string) (*[]models.WorkspaceMember, error) {
+	ret := _m.Called(ctx, workspaceId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMembers")
+	}
+
+	var r0 *[]models.WorkspaceMember
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.WorkspaceMember, error)); ok {
+		return rf(ctx, workspaceId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.WorkspaceMember); ok {
+		r0 = rf(ctx, workspaceId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.WorkspaceMember)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, workspaceId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_GetMembers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMembers'
+type MockIWorkspaceRepository_GetMembers_Call struct {
+	*mock.Call
+}
+
+// GetMembers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workspaceId string
+func (_e *MockIWorkspaceRepository_Expecter) GetMembers(ctx interface{}, workspaceId interface{}) *MockIWorkspaceRepository_GetMembers_Call {
+	return &MockIWorkspaceRepository_GetMembers_Call{Call: _e.mock.On("GetMembers", ctx, workspaceId)}
+}
+
+func (_c *MockIWorkspaceRepository_GetMembers_Call) Run(run func(ctx context.Context, workspaceId string)) *MockIWorkspaceRepository_GetMembers_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetMembers_Call) Return(_a0 *[]models.WorkspaceMember, _a1 error) *MockIWorkspaceRepository_GetMembers_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetMembers_Call) RunAndReturn(run func(context.Context, string) (*[]models.WorkspaceMember, error)) *MockIWorkspaceRepository_GetMembers_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspace provides a mock function with given fields: ctx, id
+func (_m *MockIWorkspaceRepository) GetWorkspace(ctx context.Context, id string) (*models.Workspace, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspace")
+	}
+
+	var r0 *models.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Workspace, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Workspace); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_GetWorkspace_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspace'
+type MockIWorkspaceRepository_GetWorkspace_Call struct {
+	*mock.Call
+}
+
+// GetWorkspace is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *MockIWorkspaceRepository_Expecter) GetWorkspace(ctx interface{}, id interface{}) *MockIWorkspaceRepository_GetWorkspace_Call {
+	return &MockIWorkspaceRepository_GetWorkspace_Call{Call: _e.mock.On("GetWorkspace", ctx, id)}
+}
+
+func (_c *MockIWorkspaceRepository_GetWorkspace_Call) Run(run func(ctx context.Context, id string)) *MockIWorkspaceRepository_GetWorkspace_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetWorkspace_Call) Return(_a0 *models.Workspace, _a1 error) *MockIWorkspaceRepository_GetWorkspace_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetWorkspace_Call) RunAndReturn(run func(context.Context, string) (*models.Workspace, error)) *MockIWorkspaceRepository_GetWorkspace_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetWorkspaces provides a mock function with given fields: ctx, userId
+func (_m *MockIWorkspaceRepository) GetWorkspaces(ctx context.Context, userId string) (*[]models.Workspace, error) {
+	ret := _m.Called(ctx, userId)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetWorkspaces")
+	}
+
+	var r0 *[]models.Workspace
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*[]models.Workspace, error)); ok {
+		return rf(ctx, userId)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *[]models.Workspace); ok {
+		r0 = rf(ctx, userId)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*[]models.Workspace)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, userId)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_GetWorkspaces_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetWorkspaces'
+type MockIWorkspaceRepository_GetWorkspaces_Call struct {
+	*mock.Call
+}
+
+// GetWorkspaces is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userId string
+func (_e *MockIWorkspaceRepository_Expecter) GetWorkspaces(ctx interface{}, userId interface{}) *MockIWorkspaceRepository_GetWorkspaces_Call {
+	return &MockIWorkspaceRepository_GetWorkspaces_Call{Call: _e.mock.On("GetWorkspaces", ctx, userId)}
+}
+
+func (_c *MockIWorkspaceRepository_GetWorkspaces_Call) Run(run func(ctx context.Context, userId string)) *MockIWorkspaceRepository_GetWorkspaces_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetWorkspaces_Call) Return(_a0 *[]models.Workspace, _a1 error) *MockIWorkspaceRepository_GetWorkspaces_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_GetWorkspaces_Call) RunAndReturn(run func(context.Context, string) (*[]models.Workspace, error)) *MockIWorkspaceRepository_GetWorkspaces_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateMemberRole provides a mock function with given fields: ctx, workspaceId, userId, role
+func (_m *MockIWorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceId string, userId string, role string) (int64, error) {
+	ret := _m.Called(ctx, workspaceId, userId, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMemberRole")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) (int64, error)); ok {
+		return rf(ctx, workspaceId, userId, role)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, string) int64); ok {
+		r0 = rf(ctx, workspaceId, userId, role)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, string) error); ok {
+		r1 = rf(ctx, workspaceId, userId, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MockIWorkspaceRepository_UpdateMemberRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateMemberRole'
+type MockIWorkspaceRepository_UpdateMemberRole_Call struct {
+	*mock.Call
+}
+
+// UpdateMemberRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - workspaceId string
+//   - userId string
+//   - role string
+func (_e *MockIWorkspaceRepository_Expecter) UpdateMemberRole(ctx interface{}, workspaceId interface{}, userId interface{}, role interface{}) *MockIWorkspaceRepository_UpdateMemberRole_Call {
+	return &MockIWorkspaceRepository_UpdateMemberRole_Call{Call: _e.mock.On("UpdateMemberRole", ctx, workspaceId, userId, role)}
+}
+
+func (_c *MockIWorkspaceRepository_UpdateMemberRole_Call) Run(run func(ctx context.Context, workspaceId string, userId string, role string)) *MockIWorkspaceRepository_UpdateMemberRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(string), args[3].(string))
+	})
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_UpdateMemberRole_Call) Return(_a0 int64, _a1 error) *MockIWorkspaceRepository_UpdateMemberRole_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *MockIWorkspaceRepository_UpdateMemberRole_Call) RunAndReturn(run func(context.Context, string, string, string) (int64, error)) *MockIWorkspaceRepository_UpdateMemberRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// NewMockIWorkspaceRepository creates a new instance of MockIWorkspaceRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMockIWorkspaceRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MockIWorkspaceRepository {
+	mock := &MockIWorkspaceRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,25 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Attachment describes a file of a task; its contents live in the blob store
+// under StorageKey
+type Attachment struct {
+	ID          uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	TaskID      uuid.UUID `gorm:"type:uuid;column:task_id;not null" json:"task_id"`
+	UserID      string    `gorm:"type:uuid;column:user_id;not null" json:"user_id"`
+	StorageKey  string    `gorm:"column:storage_key;type:text;not null" json:"-"`
+	Filename    string    `gorm:"column:filename;type:varchar(255);not null" json:"filename"`
+	ContentType string    `gorm:"column:content_type;type:varchar(100);not null" json:"content_type"`
+	Size        int64     `gorm:"column:size;not null" json:"size"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (Attachment) TableName() string {
+	return "attachments"
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// CalendarFeed is a user's subscribable calendar of tasks, found by the
+// hash of the secret token in its URL
+type CalendarFeed struct {
+	UserID    string    `gorm:"type:uuid;column:user_id;primaryKey" json:"user_id"`
+	TokenHash string    `gorm:"column:token_hash;type:char(64);unique;not null" json:"-"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (CalendarFeed) TableName() string {
+	return "calendar_feeds"
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Comment struct {
+	ID        uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	TaskID    uuid.UUID `gorm:"type:uuid;column:task_id;not null" json:"task_id"`
+	UserID    string    `gorm:"type:uuid;column:user_id;not null" json:"user_id"`
+	Body      string    `gorm:"column:body;type:text;not null" json:"body"`
+	Edited    bool      `gorm:"column:edited;not null;default:false" json:"edited"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null;default:now()" json:"updated_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (Comment) TableName() string {
+	return "task_comments"
+}
@@ -0,0 +1,20 @@
+package models
+
+import "time"
+
+// IdempotencyKey holds the outcome of a request sent with an Idempotency-Key
+// header, so that a retry of it is answered without running it again
+type IdempotencyKey struct {
+	UserID      string     `gorm:"type:uuid;column:user_id;primaryKey" json:"user_id"`
+	Key         string     `gorm:"column:key;type:varchar(255);primaryKey" json:"key"`
+	Fingerprint string     `gorm:"column:fingerprint;type:varchar(64);not null" json:"fingerprint"`
+	Response    []byte     `gorm:"column:response;type:jsonb" json:"response,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at;type:timestamptz;not null" json:"expires_at"`
+	LockedUntil *time.Time `gorm:"column:locked_until;type:timestamptz" json:"locked_until,omitempty"`
+}
+
+// TableName overrides the default table name used by GORM
+func (IdempotencyKey) TableName() string {
+	return "idempotency_keys"
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImportJob tracks a task import that runs in the background
+type ImportJob struct {
+	ID         uuid.UUID       `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	UserID     string          `gorm:"type:uuid;column:user_id;not null" json:"user_id"`
+	Format     string          `gorm:"column:format;type:varchar(20);not null" json:"format"`
+	Status     string          `gorm:"column:status;type:varchar(20);not null" json:"status"`
+	Total      int             `gorm:"column:total;not null" json:"total"`
+	Imported   int             `gorm:"column:imported;not null" json:"imported"`
+	Failed     int             `gorm:"column:failed;not null" json:"failed"`
+	Errors     ImportRowErrors `gorm:"column:errors;type:jsonb;not null" json:"errors"`
+	Error      *string         `gorm:"column:error;type:text" json:"error"`
+	CreatedAt  time.Time       `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+	UpdatedAt  time.Time       `gorm:"column:updated_at;type:timestamptz;not null;default:now()" json:"updated_at"`
+	FinishedAt *time.Time      `gorm:"column:finished_at;type:timestamptz" json:"finished_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (ImportJob) TableName() string {
+	return "import_jobs"
+}
+
+// ImportRowError lists why one row of an import file was skipped
+type ImportRowError struct {
+	Row    int                 `json:"row"`
+	Errors []map[string]string `json:"errors"`
+}
+
+type ImportRowErrors []ImportRowError
+
+func (e ImportRowErrors) Value() (driver.Value, error) {
+	if e == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(e)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (e *ImportRowErrors) Scan(value interface{}) error {
+	return scanJSON(value, e)
+}
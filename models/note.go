@@ -0,0 +1,34 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Note struct {
+	ID          uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	UserID      string    `gorm:"type:uuid;column:user_id;not null" json:"user_id"`
+	WorkspaceID *string   `gorm:"type:uuid;column:workspace_id" json:"workspace_id,omitempty"`
+	TaskID      *string   `gorm:"type:uuid;column:task_id" json:"task_id,omitempty"`
+	Title       string    `gorm:"column:title;type:varchar(200);not null" json:"title"`
+	Body        string    `gorm:"column:body;type:text;not null;default:''" json:"body"`
+	Pinned      bool      `gorm:"column:pinned;not null;default:false" json:"pinned"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;type:timestamptz;not null;default:now()" json:"updated_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (Note) TableName() string {
+	return "notes"
+}
+
+type NoteLink struct {
+	NoteID      uuid.UUID `gorm:"type:uuid;column:note_id;primaryKey" json:"note_id"`
+	TargetTitle string    `gorm:"column:target_title;type:varchar(200);primaryKey" json:"target_title"`
+}
+
+// TableName overrides the default table name used by GORM
+func (NoteLink) TableName() string {
+	return "note_links"
+}
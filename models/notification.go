@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Notification struct {
+	ID        uuid.UUID  `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	UserID    string     `gorm:"type:uuid;column:user_id;not null" json:"user_id"`
+	Type      string     `gorm:"column:type;type:varchar(30);not null" json:"type"`
+	TaskID    uuid.UUID  `gorm:"type:uuid;column:task_id;not null" json:"task_id"`
+	ActorID   string     `gorm:"type:uuid;column:actor_id;not null" json:"actor_id"`
+	Message   string     `gorm:"column:message;type:text;not null" json:"message"`
+	ReadAt    *time.Time `gorm:"column:read_at;type:timestamptz" json:"read_at,omitempty"`
+	CreatedAt time.Time  `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (Notification) TableName() string {
+	return "notifications"
+}
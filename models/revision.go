@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Revision struct {
+	ID           uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	ResourceType string    `gorm:"column:resource_type;type:varchar(10);not null" json:"resource_type"`
+	ResourceID   uuid.UUID `gorm:"type:uuid;column:resource_id;not null" json:"resource_id"`
+	Version      int       `gorm:"column:version;not null" json:"version"`
+	Title        *string   `gorm:"column:title;type:varchar(200)" json:"title,omitempty"`
+	ContentHash  string    `gorm:"column:content_hash;type:char(64);not null" json:"content_hash"`
+	ActorID      string    `gorm:"type:uuid;column:actor_id;not null" json:"actor_id"`
+	CreatedAt    time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+
+	// Content lives in content_blobs; it is set on write and joined in on read
+	Content string `gorm:"->;column:content" json:"content"`
+	Size    int    `gorm:"->;column:size" json:"size"`
+}
+
+// TableName overrides the default table name used by GORM
+func (Revision) TableName() string {
+	return "revisions"
+}
+
+type ContentBlob struct {
+	Hash      string    `gorm:"column:hash;type:char(64);primaryKey" json:"hash"`
+	Content   string    `gorm:"column:content;type:text;not null" json:"content"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (ContentBlob) TableName() string {
+	return "content_blobs"
+}
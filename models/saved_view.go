@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type SavedView struct {
+	ID        uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	UserID    string    `gorm:"type:uuid;column:user_id;not null" json:"user_id"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	Filter    string    `gorm:"column:filter;type:text;not null;default:''" json:"filter"`
+	SortBy    string    `gorm:"column:sort_by;type:varchar(20);not null;default:'created_at'" json:"sort_by"`
+	SortOrder string    `gorm:"column:sort_order;type:varchar(4);not null;default:'desc'" json:"sort_order"`
+	GroupBy   string    `gorm:"column:group_by;type:varchar(20);not null;default:'none'" json:"group_by"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;type:timestamptz;not null;default:now()" json:"updated_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (SavedView) TableName() string {
+	return "saved_views"
+}
@@ -1,23 +1,62 @@
 package models
 
 import (
+	"database/sql/driver"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 )
 
 type Task struct {
-	ID          uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
-	UserID      string    `gorm:"type:uuid;column:user_id;not null" validate:"required" json:"user_id"`
-	Title       string    `gorm:"column:title;type:varchar(100);not null" validate:"required" json:"title"`
-	Description *string   `gorm:"column:description;type:text" json:"description,omitempty"`
-	Date        time.Time `gorm:"column:date;type:timestamptz;not null" json:"date"`
-	Image       *string   `gorm:"column:image;type:text" json:"image,omitempty"`
-	Status      string    `gorm:"column:status;type:varchar(20);not null;check:status IN ('IN_PROGRESS','COMPLETED')" json:"status"`
-	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+	ID          uuid.UUID  `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	UserID      string     `gorm:"type:uuid;column:user_id;not null" validate:"required" json:"user_id"`
+	WorkspaceID *string    `gorm:"type:uuid;column:workspace_id" json:"workspace_id,omitempty"`
+	AssigneeID  *string    `gorm:"type:uuid;column:assignee_id" json:"assignee_id,omitempty"`
+	Title       string     `gorm:"column:title;type:varchar(100);not null" validate:"required" json:"title"`
+	Description *string    `gorm:"column:description;type:text" json:"description,omitempty"`
+	Date        time.Time  `gorm:"column:date;type:timestamptz;not null" json:"date"`
+	AllDay      bool       `gorm:"column:all_day;not null;default:false" json:"all_day"`
+	ImageID     *string    `gorm:"type:uuid;column:image_id" json:"image_id,omitempty"`
+	Status      string     `gorm:"column:status;type:varchar(20);not null;check:status IN ('IN_PROGRESS','COMPLETED')" json:"status"`
+	Rank        *string    `gorm:"column:rank;type:varchar(64)" json:"rank,omitempty"`
+	Tags        TaskTags   `gorm:"column:tags;type:text[];not null;default:'{}'" json:"tags"`
+	ArchivedAt  *time.Time `gorm:"column:archived_at;type:timestamptz" json:"archived_at,omitempty"`
+	Version     int        `gorm:"column:version;not null;default:1" json:"version"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+	UpdatedAt   time.Time  `gorm:"column:updated_at;type:timestamptz;not null;default:now()" json:"updated_at"`
+
+	// Read-only aggregates, populated by the repository's task select
+	CommentCount int64  `gorm:"->;column:comment_count" json:"comment_count"`
+	Blocked      bool   `gorm:"->;column:blocked" json:"blocked"`
+	Permission   string `gorm:"->;column:permission" json:"permission,omitempty"`
+
+	// Search results only, populated when listing tasks with a search term
+	SearchRank           float64 `gorm:"->;column:search_rank" json:"-"`
+	TitleHighlight       *string `gorm:"->;column:title_highlight" json:"-"`
+	DescriptionHighlight *string `gorm:"->;column:description_highlight" json:"-"`
 }
 
 // TableName overrides the default table name used by GORM
 func (Task) TableName() string {
 	return "tasks"
 }
+
+// TaskTags is the text[] tags column; an empty list is stored as '{}', never NULL
+type TaskTags []string
+
+func (t TaskTags) Value() (driver.Value, error) {
+	if t == nil {
+		return pq.StringArray{}.Value()
+	}
+	return pq.StringArray(t).Value()
+}
+
+func (t *TaskTags) Scan(value interface{}) error {
+	var a pq.StringArray
+	if err := a.Scan(value); err != nil {
+		return err
+	}
+	*t = TaskTags(a)
+	return nil
+}
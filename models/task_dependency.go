@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TaskDependency records that TaskID cannot be completed until BlockedByID is
+type TaskDependency struct {
+	TaskID      uuid.UUID `gorm:"type:uuid;column:task_id;primaryKey" json:"task_id"`
+	BlockedByID uuid.UUID `gorm:"type:uuid;column:blocked_by_id;primaryKey" json:"blocked_by_id"`
+	CreatedBy   string    `gorm:"type:uuid;column:created_by;not null" json:"created_by"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (TaskDependency) TableName() string {
+	return "task_dependencies"
+}
@@ -0,0 +1,116 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskHistory struct {
+	ID        uuid.UUID     `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	TaskID    uuid.UUID     `gorm:"type:uuid;column:task_id;not null" json:"task_id"`
+	ActorID   string        `gorm:"type:uuid;column:actor_id;not null" json:"actor_id"`
+	Action    string        `gorm:"column:action;type:varchar(20);not null" json:"action"`
+	Version   int           `gorm:"column:version;not null" json:"version"`
+	Changes   FieldChanges  `gorm:"column:changes;type:jsonb;not null" json:"changes"`
+	Snapshot  *TaskSnapshot `gorm:"column:snapshot;type:jsonb" json:"snapshot"`
+	CreatedAt time.Time     `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (TaskHistory) TableName() string {
+	return "task_history"
+}
+
+// FieldChange is a single field-level before/after pair of a task history entry
+type FieldChange struct {
+	Field  string  `json:"field"`
+	Before *string `json:"before"`
+	After  *string `json:"after"`
+}
+
+type FieldChanges []FieldChange
+
+func (f FieldChanges) Value() (driver.Value, error) {
+	if f == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal(f)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (f *FieldChanges) Scan(value interface{}) error {
+	return scanJSON(value, f)
+}
+
+// TaskSnapshot is the full user-editable state of a task at a given version
+type TaskSnapshot struct {
+	Title       string    `json:"title"`
+	Description *string   `json:"description"`
+	Status      string    `json:"status"`
+	Date        time.Time `json:"date"`
+	AllDay      bool      `json:"all_day,omitempty"`
+	ImageID     *string   `json:"image_id,omitempty"`
+	AssigneeID  *string   `json:"assignee_id,omitempty"`
+	Tags        []string  `json:"tags,omitempty"`
+	Archived    bool      `json:"archived,omitempty"`
+}
+
+func (s TaskSnapshot) Value() (driver.Value, error) {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+func (s *TaskSnapshot) Scan(value interface{}) error {
+	return scanJSON(value, s)
+}
+
+// Snapshot captures the current state of the task for the history trail
+func (t *Task) Snapshot() *TaskSnapshot {
+	return &TaskSnapshot{
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Date:        t.Date,
+		AllDay:      t.AllDay,
+		ImageID:     t.ImageID,
+		AssigneeID:  t.AssigneeID,
+		Tags:        t.Tags,
+		Archived:    t.ArchivedAt != nil,
+	}
+}
+
+// Restore applies a snapshot back onto the task. The assignee is left as is:
+// assignment is access-checked and notified, so it is never reverted implicitly.
+// Archiving is not part of a task's content and is not reverted either.
+func (t *Task) Restore(s *TaskSnapshot) {
+	t.Title = s.Title
+	t.Description = s.Description
+	t.Status = s.Status
+	t.Date = s.Date
+	t.AllDay = s.AllDay
+	t.ImageID = s.ImageID
+	t.Tags = s.Tags
+}
+
+func scanJSON(value interface{}, dest interface{}) error {
+	switch v := value.(type) {
+	case []byte:
+		return json.Unmarshal(v, dest)
+	case string:
+		return json.Unmarshal([]byte(v), dest)
+	case nil:
+		return nil
+	default:
+		return errors.New("unsupported type for jsonb column")
+	}
+}
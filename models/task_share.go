@@ -0,0 +1,24 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type TaskShare struct {
+	ID         uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	TaskID     uuid.UUID `gorm:"type:uuid;column:task_id;not null" json:"task_id"`
+	UserID     string    `gorm:"type:uuid;column:user_id;not null" json:"user_id"`
+	Permission string    `gorm:"column:permission;type:varchar(20);not null;check:permission IN ('viewer','editor')" json:"permission"`
+	SharedBy   string    `gorm:"type:uuid;column:shared_by;not null" json:"shared_by"`
+	CreatedAt  time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+
+	// Read-only, joined from users by the repository
+	Email string `gorm:"->;column:email" json:"email"`
+}
+
+// TableName overrides the default table name used by GORM
+func (TaskShare) TableName() string {
+	return "task_shares"
+}
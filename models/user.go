@@ -12,6 +12,7 @@ type User struct {
 	Password  string    `gorm:"column:password_hash;type:text;not null" json:"-"`
 	FirstName string    `gorm:"column:first_name;type:varchar(100)" json:"first_name"`
 	LastName  string    `gorm:"column:last_name;type:varchar(100)" json:"last_name"`
+	Timezone  *string   `gorm:"column:timezone;type:varchar(64)" json:"timezone"`
 	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;default:now()" json:"created_at"`
 }
 
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Workspace struct {
+	ID        uuid.UUID `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	Name      string    `gorm:"column:name;type:varchar(100);not null" json:"name"`
+	OwnerID   string    `gorm:"type:uuid;column:owner_id;not null" json:"owner_id"`
+	CreatedAt time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+
+	// Read-only, the caller's role joined from workspace_members
+	Role string `gorm:"->;column:role" json:"role,omitempty"`
+}
+
+// TableName overrides the default table name used by GORM
+func (Workspace) TableName() string {
+	return "workspaces"
+}
+
+type WorkspaceMember struct {
+	WorkspaceID uuid.UUID `gorm:"type:uuid;column:workspace_id;primaryKey" json:"workspace_id"`
+	UserID      string    `gorm:"type:uuid;column:user_id;primaryKey" json:"user_id"`
+	Role        string    `gorm:"column:role;type:varchar(20);not null;check:role IN ('owner','admin','member')" json:"role"`
+	CreatedAt   time.Time `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+
+	// Read-only, joined from users by the repository
+	Email string `gorm:"->;column:email" json:"email"`
+}
+
+// TableName overrides the default table name used by GORM
+func (WorkspaceMember) TableName() string {
+	return "workspace_members"
+}
+
+type WorkspaceInvitation struct {
+	ID          uuid.UUID  `gorm:"type:uuid;column:id;primaryKey" json:"id"`
+	WorkspaceID uuid.UUID  `gorm:"type:uuid;column:workspace_id;not null" json:"workspace_id"`
+	Email       string     `gorm:"column:email;type:varchar(255);not null" json:"email"`
+	Role        string     `gorm:"column:role;type:varchar(20);not null;check:role IN ('admin','member')" json:"role"`
+	TokenHash   string     `gorm:"column:token_hash;type:char(64);unique;not null" json:"-"`
+	InvitedBy   string     `gorm:"type:uuid;column:invited_by;not null" json:"invited_by"`
+	ExpiresAt   time.Time  `gorm:"column:expires_at;type:timestamptz;not null" json:"expires_at"`
+	AcceptedAt  *time.Time `gorm:"column:accepted_at;type:timestamptz" json:"accepted_at,omitempty"`
+	CreatedAt   time.Time  `gorm:"column:created_at;type:timestamptz;not null;default:now()" json:"created_at"`
+}
+
+// TableName overrides the default table name used by GORM
+func (WorkspaceInvitation) TableName() string {
+	return "workspace_invitations"
+}
+
+// Pending reports whether the invitation can still be accepted at now
+func (i *WorkspaceInvitation) Pending(now time.Time) bool {
+	return i.AcceptedAt == nil && now.Before(i.ExpiresAt)
+}
@@ -0,0 +1,189 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+)
+
+type IAttachmentRepository interface {
+	CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error
+	GetAttachment(ctx context.Context, taskId string, id string) (*models.Attachment, error)
+	GetUserAttachment(ctx context.Context, userId string, id string) (*models.Attachment, error)
+	GetTaskAttachments(ctx context.Context, taskIds []string) (*[]models.Attachment, error)
+	DeleteAttachment(ctx context.Context, id string) error
+	GetUserStorageUsed(ctx context.Context, userId string) (int64, error)
+	GetLegacyImages(ctx context.Context, limit int) (*[]LegacyTaskImage, error)
+	MoveLegacyImage(ctx context.Context, taskId uuid.UUID, attachment *models.Attachment) error
+}
+
+type AttachmentRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewAttachmentRepository(db *gorm.DB, log *log.Logger) IAttachmentRepository {
+	return &AttachmentRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// CreateAttachment stores an attachment, failing with
+// ErrStorageQuotaExceeded if it would take its user's uploads over quota bytes
+func (r *AttachmentRepository) CreateAttachment(ctx context.Context, attachment *models.Attachment, quota int64) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateAttachment] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return createAttachmentWithinQuota(tx, attachment, quota)
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateAttachment] Failed to create attachment", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepository) GetAttachment(ctx context.Context, taskId string, id string) (*models.Attachment, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetAttachment] Called")
+
+	var attachment models.Attachment
+	if err := r.db.Where("id = ? AND task_id = ?", id, taskId).First(&attachment).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetAttachment] Failed to get attachment", err)
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetUserAttachment returns an attachment the user uploaded to a task of the
+// request's tenant
+func (r *AttachmentRepository) GetUserAttachment(ctx context.Context, userId string, id string) (*models.Attachment, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetUserAttachment] Called")
+
+	tenantTasks := r.db.Model(&models.Task{}).Select("tasks.id").Scopes(tenantScope(ctx))
+	var attachment models.Attachment
+	if err := r.db.Where("id = ? AND user_id = ? AND task_id IN (?)", id, userId, tenantTasks).First(&attachment).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetUserAttachment] Failed to get attachment", err)
+		return nil, err
+	}
+
+	return &attachment, nil
+}
+
+// GetTaskAttachments returns every attachment of the tasks, oldest first
+func (r *AttachmentRepository) GetTaskAttachments(ctx context.Context, taskIds []string) (*[]models.Attachment, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetTaskAttachments] Called")
+
+	attachments := []models.Attachment{}
+	if len(taskIds) == 0 {
+		return &attachments, nil
+	}
+
+	if err := r.db.Where("task_id IN ?", taskIds).Order("created_at asc, id asc").Find(&attachments).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetTaskAttachments] Failed to get attachments", err)
+		return nil, err
+	}
+
+	return &attachments, nil
+}
+
+func (r *AttachmentRepository) DeleteAttachment(ctx context.Context, id string) error {
+	r.log.DebugWithID(ctx, "[Repository: DeleteAttachment] Called")
+
+	result := r.db.Where("id = ?", id).Delete(&models.Attachment{})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteAttachment] Failed to delete attachment", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
+
+// GetUserStorageUsed returns how many bytes the user's uploads take, across
+// all workspaces
+func (r *AttachmentRepository) GetUserStorageUsed(ctx context.Context, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetUserStorageUsed] Called")
+
+	var used int64
+	if err := r.db.Model(&models.Attachment{}).Select("COALESCE(SUM(size), 0)").
+		Where("user_id = ?", userId).Scan(&used).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetUserStorageUsed] Failed to sum attachment sizes", err)
+		return 0, err
+	}
+
+	return used, nil
+}
+
+// createAttachmentWithinQuota stores an attachment unless it would take its
+// user's uploads over quota bytes. It runs in a transaction, whose lock on
+// the user makes concurrent uploads of the same user count one at a time.
+func createAttachmentWithinQuota(tx *gorm.DB, attachment *models.Attachment, quota int64) error {
+	if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "attachments:"+attachment.UserID).Error; err != nil {
+		return err
+	}
+
+	var used int64
+	if err := tx.Model(&models.Attachment{}).Select("COALESCE(SUM(size), 0)").
+		Where("user_id = ?", attachment.UserID).Scan(&used).Error; err != nil {
+		return err
+	}
+	if used+attachment.Size > quota {
+		return constants.ErrStorageQuotaExceeded
+	}
+
+	return tx.Create(attachment).Error
+}
+
+// LegacyTaskImage is a task image still stored base64-encoded in tasks.image
+type LegacyTaskImage struct {
+	ID     uuid.UUID
+	UserID string
+	Image  string
+}
+
+// GetLegacyImages returns tasks whose image has not been moved to blob
+// storage yet. It reads across all tenants and is only meant for the move.
+func (r *AttachmentRepository) GetLegacyImages(ctx context.Context, limit int) (*[]LegacyTaskImage, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetLegacyImages] Called")
+
+	images := []LegacyTaskImage{}
+	if err := r.db.Model(&models.Task{}).Select("id, user_id, image").
+		Where("image IS NOT NULL").Order("id").Limit(limit).Scan(&images).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetLegacyImages] Failed to get legacy images", err)
+		return nil, err
+	}
+
+	return &images, nil
+}
+
+// MoveLegacyImage records the attachment now holding a task's legacy image
+// and clears the base64 column. A nil attachment only clears it, for empty
+// or unreadable images.
+func (r *AttachmentRepository) MoveLegacyImage(ctx context.Context, taskId uuid.UUID, attachment *models.Attachment) error {
+	r.log.DebugWithID(ctx, "[Repository: MoveLegacyImage] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		updates := map[string]interface{}{"image": nil}
+		if attachment != nil {
+			if err := tx.Create(attachment).Error; err != nil {
+				return err
+			}
+			updates["image_id"] = attachment.ID
+			updates["version"] = gorm.Expr("version + 1")
+		}
+		return tx.Model(&models.Task{}).Where("id = ?", taskId).Updates(updates).Error
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: MoveLegacyImage] Failed to move legacy image", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,37 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttachmentRepository_CreateAttachment_Quota(t *testing.T) {
+	db := testDB(t)
+	repo := NewAttachmentRepository(db, log.Initialize(constants.TestAppEnv))
+	ctx := context.Background()
+	userId := createTestUser(t, db)
+
+	task := models.Task{ID: uuid.New(), UserID: userId, Title: "Task", Status: string(constants.TaskStatusPending), Date: time.Now(), Version: 1, CreatedAt: time.Now()}
+	require.NoError(t, db.Create(&task).Error)
+	newAttachment := func(size int64) *models.Attachment {
+		id := uuid.New()
+		return &models.Attachment{ID: id, TaskID: task.ID, UserID: userId, StorageKey: "tasks/" + task.ID.String() + "/" + id.String(), Filename: "file", ContentType: "text/plain", Size: size, CreatedAt: time.Now()}
+	}
+
+	// The second upload is counted against what the first left of the quota
+	require.NoError(t, repo.CreateAttachment(ctx, newAttachment(600), 1000))
+	assert.Equal(t, constants.ErrStorageQuotaExceeded, repo.CreateAttachment(ctx, newAttachment(600), 1000))
+	assert.NoError(t, repo.CreateAttachment(ctx, newAttachment(400), 1000))
+
+	used, err := repo.GetUserStorageUsed(ctx, userId)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), used)
+}
@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ICalendarFeedRepository interface {
+	GetCalendarFeed(ctx context.Context, userId string) (*models.CalendarFeed, error)
+	GetCalendarFeedByToken(ctx context.Context, tokenHash string) (*models.CalendarFeed, error)
+	SaveCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error
+	DeleteCalendarFeed(ctx context.Context, userId string) (int64, error)
+}
+
+type CalendarFeedRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewCalendarFeedRepository(db *gorm.DB, log *log.Logger) ICalendarFeedRepository {
+	return &CalendarFeedRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *CalendarFeedRepository) GetCalendarFeed(ctx context.Context, userId string) (*models.CalendarFeed, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetCalendarFeed] Called")
+
+	var feed models.CalendarFeed
+	if err := r.db.Where("user_id = ?", userId).First(&feed).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetCalendarFeed] Failed to get calendar feed", err)
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+func (r *CalendarFeedRepository) GetCalendarFeedByToken(ctx context.Context, tokenHash string) (*models.CalendarFeed, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetCalendarFeedByToken] Called")
+
+	var feed models.CalendarFeed
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&feed).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetCalendarFeedByToken] Failed to get calendar feed", err)
+		return nil, err
+	}
+
+	return &feed, nil
+}
+
+// SaveCalendarFeed creates the user's feed, or replaces the token of the one
+// they have
+func (r *CalendarFeedRepository) SaveCalendarFeed(ctx context.Context, feed *models.CalendarFeed) error {
+	r.log.DebugWithID(ctx, "[Repository: SaveCalendarFeed] Called")
+
+	err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"token_hash", "created_at"}),
+	}).Create(feed).Error
+	if err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: SaveCalendarFeed] Failed to save calendar feed", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CalendarFeedRepository) DeleteCalendarFeed(ctx context.Context, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: DeleteCalendarFeed] Called")
+
+	result := r.db.Where("user_id = ?", userId).Delete(&models.CalendarFeed{})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteCalendarFeed] Failed to delete calendar feed", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
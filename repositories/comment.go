@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+)
+
+type ICommentRepository interface {
+	CreateComment(ctx context.Context, comment *models.Comment) error
+	GetComment(ctx context.Context, taskId string, id string) (*models.Comment, error)
+	UpdateComment(ctx context.Context, comment *models.Comment) error
+	DeleteComment(ctx context.Context, id string) error
+	GetComments(ctx context.Context, taskId string, limit int, offset int) (*[]models.Comment, int64, error)
+}
+
+type CommentRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewCommentRepository(db *gorm.DB, log *log.Logger) ICommentRepository {
+	return &CommentRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *CommentRepository) CreateComment(ctx context.Context, comment *models.Comment) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateComment] Called")
+
+	if err := r.db.Create(comment).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateComment] Failed to create comment", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentRepository) GetComment(ctx context.Context, taskId string, id string) (*models.Comment, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetComment] Called")
+
+	var comment models.Comment
+	if err := r.db.Where("id = ? AND task_id = ?", id, taskId).First(&comment).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetComment] Failed to get comment", err)
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (r *CommentRepository) UpdateComment(ctx context.Context, comment *models.Comment) error {
+	r.log.DebugWithID(ctx, "[Repository: UpdateComment] Called")
+
+	if err := r.db.Model(&models.Comment{}).Where("id = ?", comment.ID).
+		Updates(map[string]interface{}{
+			"body":       comment.Body,
+			"edited":     comment.Edited,
+			"updated_at": comment.UpdatedAt,
+		}).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: UpdateComment] Failed to update comment", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentRepository) DeleteComment(ctx context.Context, id string) error {
+	r.log.DebugWithID(ctx, "[Repository: DeleteComment] Called")
+
+	if err := r.db.Where("id = ?", id).Delete(&models.Comment{}).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteComment] Failed to delete comment", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *CommentRepository) GetComments(ctx context.Context, taskId string, limit int, offset int) (*[]models.Comment, int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetComments] Called")
+
+	var total int64
+	if err := r.db.Model(&models.Comment{}).Where("task_id = ?", taskId).Count(&total).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetComments] Failed to count comments", err)
+		return nil, 0, err
+	}
+
+	var comments []models.Comment
+	if err := r.db.Where("task_id = ?", taskId).
+		Order("created_at asc").
+		Limit(limit).
+		Offset(offset).
+		Find(&comments).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetComments] Failed to get comments", err)
+		return nil, 0, err
+	}
+
+	return &comments, total, nil
+}
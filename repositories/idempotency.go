@@ -0,0 +1,99 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IIdempotencyRepository interface {
+	ClaimKey(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error)
+	SaveResponse(ctx context.Context, userId string, key string, response []byte) error
+	ReleaseKey(ctx context.Context, userId string, key string) error
+}
+
+type IdempotencyRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewIdempotencyRepository(db *gorm.DB, log *log.Logger) IIdempotencyRepository {
+	return &IdempotencyRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// ClaimKey stores a new key for the request about to run. When the user
+// already holds the key, nothing is stored and the existing key is returned
+// instead, unless the request holding it has not completed by its
+// locked_until: the same request then takes the key over. The user's expired
+// keys are dropped first, so a key can be used again once its window has
+// passed.
+func (r *IdempotencyRepository) ClaimKey(ctx context.Context, key *models.IdempotencyKey) (*models.IdempotencyKey, error) {
+	r.log.DebugWithID(ctx, "[Repository: ClaimKey] Called")
+
+	var existing *models.IdempotencyKey
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ? AND expires_at <= ?", key.UserID, time.Now()).Delete(&models.IdempotencyKey{}).Error; err != nil {
+			return err
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(key)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+
+		// A request that held the key too long is taken to have died
+		result = tx.Model(&models.IdempotencyKey{}).
+			Where("user_id = ? AND key = ? AND fingerprint = ? AND response IS NULL AND locked_until <= ?", key.UserID, key.Key, key.Fingerprint, time.Now()).
+			Updates(map[string]interface{}{"created_at": key.CreatedAt, "expires_at": key.ExpiresAt, "locked_until": key.LockedUntil})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			return nil
+		}
+
+		existing = &models.IdempotencyKey{}
+		return tx.Where("user_id = ? AND key = ?", key.UserID, key.Key).First(existing).Error
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: ClaimKey] Failed to claim key", err)
+		return nil, err
+	}
+
+	return existing, nil
+}
+
+// SaveResponse completes a claimed key with the response of its request
+func (r *IdempotencyRepository) SaveResponse(ctx context.Context, userId string, key string, response []byte) error {
+	r.log.DebugWithID(ctx, "[Repository: SaveResponse] Called")
+
+	if err := r.db.Model(&models.IdempotencyKey{}).
+		Where("user_id = ? AND key = ?", userId, key).
+		Updates(map[string]interface{}{"response": response, "locked_until": nil}).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: SaveResponse] Failed to save response", err)
+		return err
+	}
+
+	return nil
+}
+
+// ReleaseKey deletes a claimed key whose request failed, so a retry runs it
+func (r *IdempotencyRepository) ReleaseKey(ctx context.Context, userId string, key string) error {
+	r.log.DebugWithID(ctx, "[Repository: ReleaseKey] Called")
+
+	if err := r.db.Where("user_id = ? AND key = ?", userId, key).Delete(&models.IdempotencyKey{}).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: ReleaseKey] Failed to release key", err)
+		return err
+	}
+
+	return nil
+}
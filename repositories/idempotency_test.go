@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIdempotencyRepository_ClaimKey_Lock(t *testing.T) {
+	db := testDB(t)
+	repo := NewIdempotencyRepository(db, log.Initialize(constants.TestAppEnv))
+	ctx := context.Background()
+	userId := createTestUser(t, db)
+
+	newKey := func(fingerprint string, lockedUntil time.Time) *models.IdempotencyKey {
+		now := time.Now()
+		return &models.IdempotencyKey{UserID: userId, Key: "create-1", Fingerprint: fingerprint, CreatedAt: now, ExpiresAt: now.Add(time.Hour), LockedUntil: &lockedUntil}
+	}
+
+	// The first request's process dies before it saves a response
+	existing, err := repo.ClaimKey(ctx, newKey("a", time.Now().Add(-time.Second)))
+	require.NoError(t, err)
+	require.Nil(t, existing)
+
+	// Another request cannot take the key over, but a retry of the first can
+	existing, err = repo.ClaimKey(ctx, newKey("b", time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.Equal(t, "a", existing.Fingerprint)
+
+	existing, err = repo.ClaimKey(ctx, newKey("a", time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+	assert.Nil(t, existing)
+
+	// While the retry holds the key, the next retry finds it in progress
+	existing, err = repo.ClaimKey(ctx, newKey("a", time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.Nil(t, existing.Response)
+
+	// A completed key is replayed however old its lock
+	require.NoError(t, repo.SaveResponse(ctx, userId, "create-1", []byte(`{"id":"1"}`)))
+	existing, err = repo.ClaimKey(ctx, newKey("a", time.Now().Add(time.Minute)))
+	require.NoError(t, err)
+	require.NotNil(t, existing)
+	assert.JSONEq(t, `{"id":"1"}`, string(existing.Response))
+	assert.Nil(t, existing.LockedUntil)
+}
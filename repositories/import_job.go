@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+)
+
+type IImportJobRepository interface {
+	CreateImportJob(ctx context.Context, job *models.ImportJob) error
+	GetImportJob(ctx context.Context, userId string, id string) (*models.ImportJob, error)
+	UpdateImportJob(ctx context.Context, job *models.ImportJob) error
+	FailInterruptedImportJobs(ctx context.Context, reason string) (int64, error)
+}
+
+type ImportJobRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewImportJobRepository(db *gorm.DB, log *log.Logger) IImportJobRepository {
+	return &ImportJobRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *ImportJobRepository) CreateImportJob(ctx context.Context, job *models.ImportJob) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateImportJob] Called")
+
+	if err := r.db.Create(job).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateImportJob] Failed to create import job", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ImportJobRepository) GetImportJob(ctx context.Context, userId string, id string) (*models.ImportJob, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetImportJob] Called")
+
+	var job models.ImportJob
+	if err := r.db.Where("id = ? AND user_id = ?", id, userId).First(&job).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetImportJob] Failed to get import job", err)
+		return nil, err
+	}
+
+	return &job, nil
+}
+
+// UpdateImportJob saves the progress and outcome of the job
+func (r *ImportJobRepository) UpdateImportJob(ctx context.Context, job *models.ImportJob) error {
+	r.log.DebugWithID(ctx, "[Repository: UpdateImportJob] Called")
+
+	job.UpdatedAt = time.Now()
+	if err := r.db.Model(job).Select("status", "imported", "error", "updated_at", "finished_at").Updates(job).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: UpdateImportJob] Failed to update import job", err)
+		return err
+	}
+
+	return nil
+}
+
+// FailInterruptedImportJobs fails the jobs left unfinished by a previous run
+// of the server; their rows were only held in its memory
+func (r *ImportJobRepository) FailInterruptedImportJobs(ctx context.Context, reason string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: FailInterruptedImportJobs] Called")
+
+	now := time.Now()
+	result := r.db.Model(&models.ImportJob{}).
+		Where("status IN ?", []constants.ImportJobStatus{constants.ImportJobStatusPending, constants.ImportJobStatusRunning}).
+		Updates(map[string]interface{}{
+			"status":      constants.ImportJobStatusFailed,
+			"error":       reason,
+			"updated_at":  now,
+			"finished_at": now,
+		})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: FailInterruptedImportJobs] Failed to fail import jobs", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,197 @@
+package repositories
+
+import (
+	"context"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+type INoteRepository interface {
+	CreateNote(ctx context.Context, note *models.Note, links []string, revision *models.Revision) error
+	GetNote(ctx context.Context, id string, userId string) (*models.Note, error)
+	UpdateNote(ctx context.Context, note *models.Note, links []string, revision *models.Revision) error
+	DeleteNote(ctx context.Context, id string, userId string) (int64, error)
+	GetNotes(ctx context.Context, req *entities.GetNotesRequest, userId string) (*[]models.Note, int64, error)
+	GetBacklinks(ctx context.Context, note *models.Note, userId string) (*[]models.Note, error)
+}
+
+type NoteRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewNoteRepository(db *gorm.DB, log *log.Logger) INoteRepository {
+	return &NoteRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *NoteRepository) CreateNote(ctx context.Context, note *models.Note, links []string, revision *models.Revision) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateNote] Called")
+
+	// The tenant always comes from the request, never from the caller
+	note.WorkspaceID = nil
+	if workspaceId, _, ok := utils.WorkspaceFromContext(ctx); ok {
+		note.WorkspaceID = &workspaceId
+	}
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(note).Error; err != nil {
+			return err
+		}
+		if err := replaceNoteLinks(tx, note, links); err != nil {
+			return err
+		}
+		return createRevision(tx, revision)
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateNote] Failed to create note", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *NoteRepository) GetNote(ctx context.Context, id string, userId string) (*models.Note, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetNote] Called")
+
+	var note models.Note
+	if err := r.db.Scopes(noteScope(ctx, userId)).Where("id = ?", id).First(&note).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetNote] Failed to get note", err)
+		return nil, err
+	}
+
+	return &note, nil
+}
+
+func (r *NoteRepository) UpdateNote(ctx context.Context, note *models.Note, links []string, revision *models.Revision) error {
+	r.log.DebugWithID(ctx, "[Repository: UpdateNote] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&models.Note{}).Scopes(noteScope(ctx, note.UserID)).Where("id = ?", note.ID).
+			Updates(map[string]interface{}{
+				"title":      note.Title,
+				"body":       note.Body,
+				"pinned":     note.Pinned,
+				"task_id":    note.TaskID,
+				"updated_at": note.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		if err := replaceNoteLinks(tx, note, links); err != nil {
+			return err
+		}
+		return createRevision(tx, revision)
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: UpdateNote] Failed to update note", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *NoteRepository) DeleteNote(ctx context.Context, id string, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: DeleteNote] Called")
+
+	var deleted int64
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Scopes(noteScope(ctx, userId)).Where("id = ?", id).Delete(&models.Note{})
+		if result.Error != nil {
+			return result.Error
+		}
+		deleted = result.RowsAffected
+		if deleted == 0 {
+			return nil
+		}
+		return deleteRevisions(tx, constants.RevisionResourceNote, id)
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteNote] Failed to delete note", err)
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+func (r *NoteRepository) GetNotes(ctx context.Context, req *entities.GetNotesRequest, userId string) (*[]models.Note, int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetNotes] Called")
+
+	query := r.db.Model(&models.Note{}).Scopes(noteScope(ctx, userId))
+	if req.Search != "" {
+		query = query.Where("(title ILIKE ? OR body ILIKE ?)", "%"+req.Search+"%", "%"+req.Search+"%")
+	}
+	if req.Pinned != nil {
+		query = query.Where("pinned = ?", *req.Pinned)
+	}
+	if req.TaskID != "" {
+		query = query.Where("task_id = ?", req.TaskID)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetNotes] Failed to count notes", err)
+		return nil, 0, err
+	}
+
+	var notes []models.Note
+	if err := query.Order("pinned desc, updated_at desc").Limit(req.Limit).Offset(req.Offset).Find(&notes).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetNotes] Failed to get notes", err)
+		return nil, 0, err
+	}
+
+	return &notes, total, nil
+}
+
+// GetBacklinks returns the notes visible to the user that link to the note by
+// its current title
+func (r *NoteRepository) GetBacklinks(ctx context.Context, note *models.Note, userId string) (*[]models.Note, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetBacklinks] Called")
+
+	var notes []models.Note
+	if err := r.db.Scopes(noteScope(ctx, userId)).
+		Joins("JOIN note_links l ON l.note_id = notes.id").
+		Where("l.target_title = ? AND notes.id <> ?", utils.NormalizeNoteTitle(note.Title), note.ID).
+		Order("notes.updated_at desc").
+		Find(&notes).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetBacklinks] Failed to get backlinks", err)
+		return nil, err
+	}
+
+	return &notes, nil
+}
+
+// noteScope restricts a notes query to the workspace of the request, or to the
+// user's own personal notes when the request is not scoped to a workspace
+func noteScope(ctx context.Context, userId string) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if workspaceId, _, ok := utils.WorkspaceFromContext(ctx); ok {
+			return db.Where("notes.workspace_id = ?", workspaceId)
+		}
+		return db.Where("notes.workspace_id IS NULL AND notes.user_id = ?", userId)
+	}
+}
+
+// replaceNoteLinks stores the note's outgoing links inside the caller's
+// transaction, replacing the previous set
+func replaceNoteLinks(tx *gorm.DB, note *models.Note, links []string) error {
+	if err := tx.Where("note_id = ?", note.ID).Delete(&models.NoteLink{}).Error; err != nil {
+		return err
+	}
+	if len(links) == 0 {
+		return nil
+	}
+
+	rows := make([]models.NoteLink, 0, len(links))
+	for _, title := range links {
+		rows = append(rows, models.NoteLink{NoteID: note.ID, TargetTitle: title})
+	}
+	return tx.Create(&rows).Error
+}
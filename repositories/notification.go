@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+)
+
+type INotificationRepository interface {
+	CreateNotifications(ctx context.Context, notifications []models.Notification) error
+	GetNotifications(ctx context.Context, userId string, unreadOnly bool, limit int, offset int) (*[]models.Notification, int64, error)
+	MarkRead(ctx context.Context, userId string, id string) (int64, error)
+	MarkAllRead(ctx context.Context, userId string) (int64, error)
+}
+
+type NotificationRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewNotificationRepository(db *gorm.DB, log *log.Logger) INotificationRepository {
+	return &NotificationRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *NotificationRepository) CreateNotifications(ctx context.Context, notifications []models.Notification) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateNotifications] Called")
+
+	if len(notifications) == 0 {
+		return nil
+	}
+
+	if err := r.db.Create(&notifications).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateNotifications] Failed to create notifications", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *NotificationRepository) GetNotifications(ctx context.Context, userId string, unreadOnly bool, limit int, offset int) (*[]models.Notification, int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetNotifications] Called")
+
+	query := r.db.Model(&models.Notification{}).Where("user_id = ?", userId)
+	if unreadOnly {
+		query = query.Where("read_at IS NULL")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetNotifications] Failed to count notifications", err)
+		return nil, 0, err
+	}
+
+	var notifications []models.Notification
+	if err := query.Order("created_at desc").Limit(limit).Offset(offset).Find(&notifications).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetNotifications] Failed to get notifications", err)
+		return nil, 0, err
+	}
+
+	return &notifications, total, nil
+}
+
+func (r *NotificationRepository) MarkRead(ctx context.Context, userId string, id string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: MarkRead] Called")
+
+	// Marking an already read notification keeps its original read time
+	result := r.db.Model(&models.Notification{}).
+		Where("id = ? AND user_id = ?", id, userId).
+		Update("read_at", gorm.Expr("COALESCE(read_at, ?)", time.Now()))
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: MarkRead] Failed to mark notification read", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *NotificationRepository) MarkAllRead(ctx context.Context, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: MarkAllRead] Called")
+
+	result := r.db.Model(&models.Notification{}).
+		Where("user_id = ? AND read_at IS NULL", userId).
+		Update("read_at", time.Now())
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: MarkAllRead] Failed to mark notifications read", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
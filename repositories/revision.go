@@ -0,0 +1,101 @@
+package repositories
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRevisionRepository interface {
+	GetRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string) (*[]models.Revision, error)
+	GetRevision(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, version int) (*models.Revision, error)
+}
+
+type RevisionRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewRevisionRepository(db *gorm.DB, log *log.Logger) IRevisionRepository {
+	return &RevisionRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+// GetRevisions lists the revisions of a resource, newest first, with the size
+// of each revision's content but not the content itself
+func (r *RevisionRepository) GetRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string) (*[]models.Revision, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetRevisions] Called")
+
+	var revisions []models.Revision
+	if err := r.db.Select("revisions.*, octet_length(b.content) AS size").
+		Joins("JOIN content_blobs b ON b.hash = revisions.content_hash").
+		Where("revisions.resource_type = ? AND revisions.resource_id = ?", resourceType, resourceId).
+		Order("revisions.version desc").
+		Find(&revisions).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetRevisions] Failed to get revisions", err)
+		return nil, err
+	}
+
+	return &revisions, nil
+}
+
+func (r *RevisionRepository) GetRevision(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, version int) (*models.Revision, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetRevision] Called")
+
+	var revision models.Revision
+	if err := r.db.Select("revisions.*, b.content, octet_length(b.content) AS size").
+		Joins("JOIN content_blobs b ON b.hash = revisions.content_hash").
+		Where("revisions.resource_type = ? AND revisions.resource_id = ? AND revisions.version = ?", resourceType, resourceId, version).
+		First(&revision).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetRevision] Failed to get revision", err)
+		return nil, err
+	}
+
+	return &revision, nil
+}
+
+// createRevision appends a revision inside the caller's transaction, assigning
+// the next per-resource version. The content is stored in content_blobs keyed
+// by its hash, so an unchanged or restored text is never stored twice.
+func createRevision(tx *gorm.DB, revision *models.Revision) error {
+	if revision == nil {
+		return nil
+	}
+
+	sum := sha256.Sum256([]byte(revision.Content))
+	revision.ContentHash = hex.EncodeToString(sum[:])
+
+	blob := &models.ContentBlob{
+		Hash:      revision.ContentHash,
+		Content:   revision.Content,
+		CreatedAt: revision.CreatedAt,
+	}
+	if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(blob).Error; err != nil {
+		return err
+	}
+
+	var version int
+	if err := tx.Model(&models.Revision{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("resource_type = ? AND resource_id = ?", revision.ResourceType, revision.ResourceID).
+		Scan(&version).Error; err != nil {
+		return err
+	}
+	revision.Version = version + 1
+
+	return tx.Create(revision).Error
+}
+
+// deleteRevisions removes a deleted resource's revisions inside the caller's
+// transaction. Their content stays, as other revisions may share it.
+func deleteRevisions(tx *gorm.DB, resourceType constants.RevisionResourceType, resourceId interface{}) error {
+	return tx.Where("resource_type = ? AND resource_id = ?", resourceType, resourceId).Delete(&models.Revision{}).Error
+}
@@ -2,22 +2,49 @@ package repositories
 
 import (
 	"context"
+	"time"
 
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
 	"github.com/guncv/tech-exam-software-engineering/entities"
 	"github.com/guncv/tech-exam-software-engineering/infras/log"
 	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
 	"gorm.io/gorm"
 )
 
 type ITaskRepository interface {
 	HealthCheck(ctx context.Context) (string, error)
-	CreateTask(ctx context.Context, task *models.Task) error
+	CreateTask(ctx context.Context, task *models.Task, image *models.Attachment, quota int64, history *models.TaskHistory) error
+	CreateTasks(ctx context.Context, tasks []models.Task, histories []models.TaskHistory) error
 	GetTask(ctx context.Context, id string) (*models.Task, error)
-	UpdateTask(ctx context.Context, task *models.Task) error
-	DeleteTask(ctx context.Context, id string) error
-	GetAllTasks(ctx context.Context, req *entities.GetAllTasksRequest, userId string) (*[]models.Task, error)
+	UpdateTask(ctx context.Context, task *models.Task, history *models.TaskHistory) error
+	DeleteTask(ctx context.Context, id string, history *models.TaskHistory) error
+	GetAllTasks(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, cursor *utils.Cursor, userId string) (*[]models.Task, error)
+	CountTasks(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, userId string) (int64, error)
+	GetTaskHistory(ctx context.Context, taskId string) (*[]models.TaskHistory, error)
+	GetTaskHistoryVersion(ctx context.Context, taskId string, version int) (*models.TaskHistory, error)
+	GetSharePermission(ctx context.Context, taskId string, userId string) (string, error)
+	GetBoardTasks(ctx context.Context, userId string) (*[]models.Task, error)
+	MoveTask(ctx context.Context, task *models.Task, ranks map[string]string, history *models.TaskHistory) error
+	ApplyTaskChanges(ctx context.Context, changes []TaskChange) error
 }
 
+// taskColumns selects every task column plus its read-only aggregates
+const taskColumns = "tasks.*, " +
+	"(SELECT COUNT(*) FROM task_comments c WHERE c.task_id = tasks.id) AS comment_count, " +
+	taskBlockedCondition + " AS blocked"
+
+// taskBlockedCondition holds while any blocker of the task is still open
+const taskBlockedCondition = "EXISTS (SELECT 1 FROM task_dependencies d JOIN tasks b ON b.id = d.blocked_by_id " +
+	"WHERE d.task_id = tasks.id AND b.status <> 'COMPLETED')"
+
+// taskSearchColumns ranks and highlights tasks against the search_query
+// joined in by GetAllTasks; the two arguments are the headline options
+const taskSearchColumns = ", " + taskSearchRank + " AS search_rank, " +
+	"ts_headline('english', tasks.title, search_query, ?) AS title_highlight, " +
+	"ts_headline('english', coalesce(tasks.description, ''), search_query, ?) AS description_highlight"
+
 type TaskRepository struct {
 	db  *gorm.DB
 	log *log.Logger
@@ -38,10 +65,30 @@ func (r *TaskRepository) HealthCheck(ctx context.Context) (string, error) {
 	return "Healthy", nil
 }
 
-func (r *TaskRepository) CreateTask(ctx context.Context, task *models.Task) error {
+// CreateTask stores a task with its history and image, if any; the image
+// must fit in its user's quota of bytes
+func (r *TaskRepository) CreateTask(ctx context.Context, task *models.Task, image *models.Attachment, quota int64, history *models.TaskHistory) error {
 	r.log.DebugWithID(ctx, "[Repository: CreateTask] Called")
 
-	if err := r.db.Create(task).Error; err != nil {
+	// The tenant always comes from the request, never from the caller
+	task.WorkspaceID = nil
+	if workspaceId, _, ok := utils.WorkspaceFromContext(ctx); ok {
+		task.WorkspaceID = &workspaceId
+	}
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// The image goes first for the task to refer to; its own reference
+		// to the task is only checked on commit
+		if image != nil {
+			if err := createAttachmentWithinQuota(tx, image, quota); err != nil {
+				return err
+			}
+		}
+		if err := tx.Create(task).Error; err != nil {
+			return err
+		}
+		return createTaskHistory(tx, history)
+	}); err != nil {
 		r.log.ErrorWithID(ctx, "[Repository: CreateTask] Failed to create task", err)
 		return err
 	}
@@ -49,11 +96,53 @@ func (r *TaskRepository) CreateTask(ctx context.Context, task *models.Task) erro
 	return nil
 }
 
+// CreateTasks stores new tasks, their history and the revisions of their
+// descriptions in one transaction; either all of them are created or none
+func (r *TaskRepository) CreateTasks(ctx context.Context, tasks []models.Task, histories []models.TaskHistory) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateTasks] Called")
+
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	// The tenant always comes from the request, never from the caller
+	var workspaceId *string
+	if id, _, ok := utils.WorkspaceFromContext(ctx); ok {
+		workspaceId = &id
+	}
+	for i := range tasks {
+		tasks[i].WorkspaceID = workspaceId
+	}
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(&tasks).Error; err != nil {
+			return err
+		}
+		if len(histories) == 0 {
+			return nil
+		}
+		if err := tx.Create(&histories).Error; err != nil {
+			return err
+		}
+		for i := range histories {
+			if err := createRevision(tx, descriptionRevision(&histories[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateTasks] Failed to create tasks", err)
+		return err
+	}
+
+	return nil
+}
+
 func (r *TaskRepository) GetTask(ctx context.Context, id string) (*models.Task, error) {
 	r.log.DebugWithID(ctx, "[Repository: GetTask] Called")
 
 	var task models.Task
-	if err := r.db.Where("id = ?", id).First(&task).Error; err != nil {
+	if err := r.db.Select(taskColumns).Scopes(tenantScope(ctx)).Where("id = ?", id).First(&task).Error; err != nil {
 		r.log.ErrorWithID(ctx, "[Repository: GetTask] Failed to get task", err)
 		return nil, err
 	}
@@ -61,21 +150,25 @@ func (r *TaskRepository) GetTask(ctx context.Context, id string) (*models.Task,
 	return &task, nil
 }
 
-func (r *TaskRepository) UpdateTask(ctx context.Context, task *models.Task) error {
+func (r *TaskRepository) UpdateTask(ctx context.Context, task *models.Task, history *models.TaskHistory) error {
 	r.log.DebugWithID(ctx, "[Repository: UpdateTask] Called")
 
-	if err := r.db.Model(&models.Task{}).Where("id = ?", task.ID).Updates(task).Error; err != nil {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return updateTask(ctx, tx, task, history)
+	}); err != nil {
 		r.log.ErrorWithID(ctx, "[Repository: UpdateTask] Failed to update task", err)
 		return err
 	}
 
-	return r.db.Save(task).Error
+	return nil
 }
 
-func (r *TaskRepository) DeleteTask(ctx context.Context, id string) error {
+func (r *TaskRepository) DeleteTask(ctx context.Context, id string, history *models.TaskHistory) error {
 	r.log.DebugWithID(ctx, "[Repository: DeleteTask] Called")
 
-	if err := r.db.Where("id = ?", id).Delete(&models.Task{}).Error; err != nil {
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		return deleteTask(ctx, tx, id, history)
+	}); err != nil {
 		r.log.ErrorWithID(ctx, "[Repository: DeleteTask] Failed to delete task", err)
 		return err
 	}
@@ -83,16 +176,337 @@ func (r *TaskRepository) DeleteTask(ctx context.Context, id string) error {
 	return nil
 }
 
-func (r *TaskRepository) GetAllTasks(ctx context.Context, req *entities.GetAllTasksRequest, userId string) (*[]models.Task, error) {
+// TaskChange is one write of a bulk operation: an update of Task, or its
+// deletion when Delete is set
+type TaskChange struct {
+	Task    *models.Task
+	Delete  bool
+	History *models.TaskHistory
+}
+
+// ApplyTaskChanges writes every change in a single transaction; if any one
+// fails, none is applied
+func (r *TaskRepository) ApplyTaskChanges(ctx context.Context, changes []TaskChange) error {
+	r.log.DebugWithID(ctx, "[Repository: ApplyTaskChanges] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		for _, c := range changes {
+			if c.Delete {
+				if err := deleteTask(ctx, tx, c.Task.ID.String(), c.History); err != nil {
+					return err
+				}
+				continue
+			}
+			if err := updateTask(ctx, tx, c.Task, c.History); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: ApplyTaskChanges] Failed to apply task changes", err)
+		return err
+	}
+
+	return nil
+}
+
+// GetAllTasks returns a page of the tasks matching the request. With a cursor
+// the page starts after the cursor's row; a Before cursor walks backwards, so
+// its rows come nearest-first and the caller restores the listing order.
+func (r *TaskRepository) GetAllTasks(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, cursor *utils.Cursor, userId string) (*[]models.Task, error) {
 	r.log.DebugWithID(ctx, "[Repository: GetAllTasks] Called")
 
 	var tasks []models.Task
-	query := r.db.Where("(title LIKE ? OR description LIKE ?) AND user_id = ?", "%"+req.Search+"%", "%"+req.Search+"%", userId)
+	query := r.listTasksQuery(ctx, req, filter, userId)
+
+	if req.Search != "" {
+		query = query.Select(taskColumns+taskSearchColumns, utils.TitleHighlightOptions, utils.DescriptionHighlightOptions)
+	} else {
+		query = query.Select(taskColumns)
+	}
+
+	backward := cursor != nil && cursor.Before
+	if cursor != nil {
+		keyset, err := taskKeyset(req.SortBy, req.Order, backward, cursor.Values)
+		if err != nil {
+			r.log.ErrorWithID(ctx, "[Repository: GetAllTasks] Invalid cursor", err)
+			return nil, err
+		}
+		query = query.Where(keyset)
+	} else {
+		query = query.Offset(req.Offset)
+	}
 
-	if err := query.Order(req.SortBy + " " + req.Order).Limit(req.Limit).Offset(req.Offset).Find(&tasks).Error; err != nil {
+	if err := query.Order(taskOrder(req.SortBy, req.Order, backward)).Limit(req.Limit).Find(&tasks).Error; err != nil {
 		r.log.ErrorWithID(ctx, "[Repository: GetAllTasks] Failed to get all tasks", err)
 		return nil, err
 	}
 
 	return &tasks, nil
 }
+
+// CountTasks returns how many tasks match the request, ignoring pagination
+func (r *TaskRepository) CountTasks(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: CountTasks] Called")
+
+	var total int64
+	if err := r.listTasksQuery(ctx, req, filter, userId).Model(&models.Task{}).Count(&total).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CountTasks] Failed to count tasks", err)
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// listTasksQuery scopes and filters a task listing, without its select,
+// order or pagination
+func (r *TaskRepository) listTasksQuery(ctx context.Context, req *entities.GetAllTasksRequest, filter utils.FilterNode, userId string) *gorm.DB {
+	query := r.db.Scopes(tenantScope(ctx))
+
+	if req.Archived {
+		query = query.Where("tasks.archived_at IS NOT NULL")
+	} else {
+		query = query.Where("tasks.archived_at IS NULL")
+	}
+
+	// websearch_to_tsquery accepts "quoted phrases", -exclusions and OR
+	if req.Search != "" {
+		query = query.Joins("CROSS JOIN websearch_to_tsquery('english', ?) search_query", req.Search).
+			Where("tasks.search_vector @@ search_query")
+	}
+
+	// A workspace lists every task of the team; personal scope only the caller's
+	// own, plus tasks assigned to them when filtering on themselves. Tasks
+	// shared with the caller are listed by TaskShareRepository.GetSharedTasks.
+	if _, _, ok := utils.WorkspaceFromContext(ctx); !ok {
+		if req.Assignee == userId {
+			query = query.Where("(user_id = ? OR assignee_id = ?)", userId, userId)
+		} else {
+			query = query.Where("user_id = ?", userId)
+		}
+	}
+
+	switch req.Assignee {
+	case "":
+	case constants.AssigneeFilterUnassigned:
+		query = query.Where("assignee_id IS NULL")
+	default:
+		query = query.Where("assignee_id = ?", req.Assignee)
+	}
+
+	if filter != nil {
+		query = query.Where(compileTaskFilter(filter, userId, time.Now(), utils.LocationFromContext(ctx)))
+	}
+
+	return query
+}
+
+func (r *TaskRepository) GetTaskHistory(ctx context.Context, taskId string) (*[]models.TaskHistory, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetTaskHistory] Called")
+
+	var history []models.TaskHistory
+	if err := r.db.Where("task_id = ? AND task_id IN (?)", taskId, r.tenantTaskIDs(ctx)).Order("version desc").Find(&history).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetTaskHistory] Failed to get task history", err)
+		return nil, err
+	}
+
+	return &history, nil
+}
+
+func (r *TaskRepository) GetTaskHistoryVersion(ctx context.Context, taskId string, version int) (*models.TaskHistory, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetTaskHistoryVersion] Called")
+
+	var history models.TaskHistory
+	if err := r.db.Where("task_id = ? AND version = ? AND task_id IN (?)", taskId, version, r.tenantTaskIDs(ctx)).First(&history).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetTaskHistoryVersion] Failed to get task history version", err)
+		return nil, err
+	}
+
+	return &history, nil
+}
+
+// GetSharePermission returns the permission the task is shared with the user,
+// or an empty string when it is not shared with them
+func (r *TaskRepository) GetSharePermission(ctx context.Context, taskId string, userId string) (string, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetSharePermission] Called")
+
+	var shares []models.TaskShare
+	if err := r.db.Where("task_id = ? AND user_id = ? AND task_id IN (?)", taskId, userId, r.tenantTaskIDs(ctx)).Limit(1).Find(&shares).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetSharePermission] Failed to get share permission", err)
+		return "", err
+	}
+
+	if len(shares) == 0 {
+		return "", nil
+	}
+	return shares[0].Permission, nil
+}
+
+// GetBoardTasks returns the board the user sees: every task of the request's
+// workspace, or the user's own personal tasks, ordered by column and rank.
+// Tasks without a rank follow the ranked ones in creation order.
+func (r *TaskRepository) GetBoardTasks(ctx context.Context, userId string) (*[]models.Task, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetBoardTasks] Called")
+
+	query := r.db.Select(taskColumns).Scopes(tenantScope(ctx)).Where("tasks.archived_at IS NULL")
+	if _, _, ok := utils.WorkspaceFromContext(ctx); !ok {
+		query = query.Where("user_id = ?", userId)
+	}
+
+	var tasks []models.Task
+	if err := query.Order("status asc, rank asc NULLS LAST, created_at asc").Find(&tasks).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetBoardTasks] Failed to get board tasks", err)
+		return nil, err
+	}
+
+	return &tasks, nil
+}
+
+// MoveTask writes the task's new status and rank, along with any ranks
+// reassigned to the rest of its column, in one transaction. Only the board
+// columns are written so a concurrent edit of the task's content is kept;
+// every task written still gets a new version.
+func (r *TaskRepository) MoveTask(ctx context.Context, task *models.Task, ranks map[string]string, history *models.TaskHistory) error {
+	r.log.DebugWithID(ctx, "[Repository: MoveTask] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		for id, rank := range ranks {
+			if err := tx.Model(&models.Task{}).Scopes(tenantScope(ctx)).Where("id = ?", id).
+				Updates(map[string]interface{}{"rank": rank, "version": gorm.Expr("version + 1")}).Error; err != nil {
+				return err
+			}
+		}
+
+		result := tx.Model(&models.Task{}).Scopes(tenantScope(ctx)).Where("id = ?", task.ID).
+			Updates(map[string]interface{}{"status": task.Status, "rank": task.Rank, "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		task.Version++
+		return createTaskHistory(tx, history)
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: MoveTask] Failed to move task", err)
+		return err
+	}
+
+	return nil
+}
+
+// updateTask saves the task inside the caller's transaction and advances its
+// version. The write only applies while the row is still at the version the
+// task was read at, so a concurrent update is never silently overwritten.
+func updateTask(ctx context.Context, tx *gorm.DB, task *models.Task, history *models.TaskHistory) error {
+	result := tx.Model(&models.Task{}).Scopes(tenantScope(ctx)).Where("id = ? AND version = ?", task.ID, task.Version).Updates(task)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return taskUpdateConflict(ctx, tx, task.ID)
+	}
+	task.Version++
+	if err := tx.Save(task).Error; err != nil {
+		return err
+	}
+	return createTaskHistory(tx, history)
+}
+
+// taskUpdateConflict explains why a versioned update matched no row: the task
+// is gone, or it has been updated since it was read
+func taskUpdateConflict(ctx context.Context, tx *gorm.DB, id uuid.UUID) error {
+	var count int64
+	if err := tx.Model(&models.Task{}).Scopes(tenantScope(ctx)).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return constants.ErrTaskVersionMismatch
+}
+
+// deleteTask deletes the task and its revisions inside the caller's transaction
+func deleteTask(ctx context.Context, tx *gorm.DB, id string, history *models.TaskHistory) error {
+	result := tx.Scopes(tenantScope(ctx)).Where("id = ?", id).Delete(&models.Task{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	if err := deleteRevisions(tx, constants.RevisionResourceTask, id); err != nil {
+		return err
+	}
+	return createTaskHistory(tx, history)
+}
+
+// tenantScope restricts a tasks query to the workspace of the request, or to
+// personal tasks when the request is not scoped to a workspace. Every task
+// query goes through it so one tenant can never reach another's tasks.
+func tenantScope(ctx context.Context) func(*gorm.DB) *gorm.DB {
+	return func(db *gorm.DB) *gorm.DB {
+		if workspaceId, _, ok := utils.WorkspaceFromContext(ctx); ok {
+			return db.Where("tasks.workspace_id = ?", workspaceId)
+		}
+		return db.Where("tasks.workspace_id IS NULL")
+	}
+}
+
+// tenantTaskIDs is a subquery of the task ids visible to the request, used to
+// scope tables that reference tasks
+func (r *TaskRepository) tenantTaskIDs(ctx context.Context) *gorm.DB {
+	return r.db.Model(&models.Task{}).Select("tasks.id").Scopes(tenantScope(ctx))
+}
+
+// createTaskHistory appends a history entry inside the caller's transaction,
+// assigning the next per-task version number. A change to the description
+// also records a description revision.
+func createTaskHistory(tx *gorm.DB, history *models.TaskHistory) error {
+	if history == nil {
+		return nil
+	}
+
+	var version int
+	if err := tx.Model(&models.TaskHistory{}).
+		Select("COALESCE(MAX(version), 0)").
+		Where("task_id = ?", history.TaskID).
+		Scan(&version).Error; err != nil {
+		return err
+	}
+	history.Version = version + 1
+
+	if err := tx.Create(history).Error; err != nil {
+		return err
+	}
+	return createRevision(tx, descriptionRevision(history))
+}
+
+// descriptionRevision returns the revision for the description a history
+// entry changed, or nil when it left the description as is
+func descriptionRevision(history *models.TaskHistory) *models.Revision {
+	if history.Action == string(constants.TaskHistoryActionDelete) || history.Snapshot == nil {
+		return nil
+	}
+
+	for _, c := range history.Changes {
+		if c.Field != "description" {
+			continue
+		}
+
+		content := ""
+		if history.Snapshot.Description != nil {
+			content = *history.Snapshot.Description
+		}
+		return &models.Revision{
+			ID:           uuid.New(),
+			ResourceType: string(constants.RevisionResourceTask),
+			ResourceID:   history.TaskID,
+			ActorID:      history.ActorID,
+			Content:      content,
+			CreatedAt:    history.CreatedAt,
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm/clause"
+)
+
+// taskSortColumn is one column of a task listing's sort key. The key always
+// ends with the task id so that every row has a distinct position.
+type taskSortColumn struct {
+	expr string
+	// dir fixes the direction regardless of the requested order
+	dir   string
+	value func(t *models.Task) interface{}
+	parse func(v interface{}) (interface{}, bool)
+}
+
+var (
+	taskTitleColumn     = taskSortColumn{expr: "tasks.title", value: func(t *models.Task) interface{} { return t.Title }, parse: parseCursorString}
+	taskStatusColumn    = taskSortColumn{expr: "tasks.status", value: func(t *models.Task) interface{} { return t.Status }, parse: parseCursorString}
+	taskCreatedAtColumn = taskSortColumn{expr: "tasks.created_at", value: func(t *models.Task) interface{} { return formatCursorTime(t.CreatedAt) }, parse: parseCursorTime}
+	taskDateColumn      = taskSortColumn{expr: "tasks.date", value: func(t *models.Task) interface{} { return formatCursorTime(t.Date) }, parse: parseCursorTime}
+	taskIDColumn        = taskSortColumn{expr: "tasks.id", value: func(t *models.Task) interface{} { return t.ID.String() }, parse: parseCursorUUID}
+
+	// Tasks never placed on the board sort after the ranked ones, oldest first
+	taskUnrankedColumn = taskSortColumn{expr: "(tasks.rank IS NULL)", dir: "asc", value: func(t *models.Task) interface{} { return t.Rank == nil }, parse: parseCursorBool}
+	taskRankColumn     = taskSortColumn{expr: "COALESCE(tasks.rank, '')", value: func(t *models.Task) interface{} {
+		if t.Rank == nil {
+			return ""
+		}
+		return *t.Rank
+	}, parse: parseCursorString}
+
+	// The rank of a search result, repeated from taskSearchColumns since an
+	// alias cannot be used in WHERE
+	taskSearchRankColumn = taskSortColumn{expr: taskSearchRank, value: func(t *models.Task) interface{} { return t.SearchRank }, parse: parseCursorFloat}
+)
+
+// taskSearchRank is the rank of a search result. ts_rank is a float4, whose
+// text form reads back into a float64 as a different number; as a float8 the
+// rank selected, the cursor made from it and the rank it is compared with in
+// the keyset are all the same value.
+const taskSearchRank = "ts_rank(tasks.search_vector, search_query)::float8"
+
+// taskSortColumns returns the sort key of a task listing
+func taskSortColumns(sortBy string) []taskSortColumn {
+	switch sortBy {
+	case "title":
+		return []taskSortColumn{taskTitleColumn, taskIDColumn}
+	case "status":
+		return []taskSortColumn{taskStatusColumn, taskIDColumn}
+	case "date":
+		return []taskSortColumn{taskDateColumn, taskIDColumn}
+	case "rank":
+		created := taskCreatedAtColumn
+		created.dir = "asc"
+		return []taskSortColumn{taskUnrankedColumn, taskRankColumn, created, taskIDColumn}
+	case "relevance":
+		created := taskCreatedAtColumn
+		created.dir = "desc"
+		return []taskSortColumn{taskSearchRankColumn, created, taskIDColumn}
+	}
+	return []taskSortColumn{taskCreatedAtColumn, taskIDColumn}
+}
+
+// TaskCursorValues returns the sort key of a listed task, for a cursor that
+// continues the listing after (or before) it
+func TaskCursorValues(sortBy string, task *models.Task) []interface{} {
+	columns := taskSortColumns(sortBy)
+	values := make([]interface{}, len(columns))
+	for i, c := range columns {
+		values[i] = c.value(task)
+	}
+	return values
+}
+
+// taskOrder returns the ORDER BY of a listing; backward reverses every column
+func taskOrder(sortBy string, order string, backward bool) string {
+	columns := taskSortColumns(sortBy)
+	parts := make([]string, len(columns))
+	for i, c := range columns {
+		parts[i] = c.expr + " " + columnDirection(c, order, backward)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// taskKeyset returns a WHERE expression matching the rows that come after the
+// cursor values in the listing's order (before them when backward). For a
+// key (a, b, id) that is a > ? OR (a = ? AND b > ?) OR (a = ? AND b = ? AND
+// id > ?), with < for descending columns.
+func taskKeyset(sortBy string, order string, backward bool, values []interface{}) (clause.Expr, error) {
+	columns := taskSortColumns(sortBy)
+	if len(values) != len(columns) {
+		return clause.Expr{}, constants.ErrInvalidCursor
+	}
+
+	args := make([]interface{}, len(values))
+	for i, c := range columns {
+		v, ok := c.parse(values[i])
+		if !ok {
+			return clause.Expr{}, constants.ErrInvalidCursor
+		}
+		args[i] = v
+	}
+
+	var sql strings.Builder
+	var vars []interface{}
+	sql.WriteString("(")
+	for i, c := range columns {
+		if i > 0 {
+			sql.WriteString(" OR ")
+		}
+		sql.WriteString("(")
+		for j := 0; j < i; j++ {
+			sql.WriteString(columns[j].expr + " = ? AND ")
+			vars = append(vars, args[j])
+		}
+		op := " > ?"
+		if columnDirection(c, order, backward) == "desc" {
+			op = " < ?"
+		}
+		sql.WriteString(c.expr + op + ")")
+		vars = append(vars, args[i])
+	}
+	sql.WriteString(")")
+
+	return clause.Expr{SQL: sql.String(), Vars: vars}, nil
+}
+
+func columnDirection(c taskSortColumn, order string, backward bool) string {
+	dir := order
+	if c.dir != "" {
+		dir = c.dir
+	}
+	if backward {
+		if dir == "asc" {
+			return "desc"
+		}
+		return "asc"
+	}
+	return dir
+}
+
+// Cursor values travel as JSON, so times are RFC 3339 strings and numbers
+// come back as float64
+
+func formatCursorTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339Nano)
+}
+
+func parseCursorTime(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	return t, err == nil
+}
+
+func parseCursorString(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	return s, ok
+}
+
+func parseCursorUUID(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, false
+	}
+	_, err := uuid.Parse(s)
+	return s, err == nil
+}
+
+func parseCursorBool(v interface{}) (interface{}, bool) {
+	b, ok := v.(bool)
+	return b, ok
+}
+
+func parseCursorFloat(v interface{}) (interface{}, bool) {
+	f, ok := v.(float64)
+	return f, ok
+}
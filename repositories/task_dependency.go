@@ -0,0 +1,118 @@
+package repositories
+
+import (
+	"context"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITaskDependencyRepository interface {
+	CreateDependency(ctx context.Context, dependency *models.TaskDependency) error
+	DeleteDependency(ctx context.Context, taskId string, blockedById string) (int64, error)
+	GetBlockers(ctx context.Context, taskId string) (*[]models.Task, error)
+	GetBlocking(ctx context.Context, taskId string) (*[]models.Task, error)
+}
+
+// dependencyCycleQuery reports whether the first task is reachable from the
+// second by following blocked-by edges, i.e. whether adding "second is
+// blocked by first" would close a cycle
+const dependencyCycleQuery = `
+WITH RECURSIVE chain AS (
+  SELECT blocked_by_id FROM task_dependencies WHERE task_id = ?
+  UNION
+  SELECT d.blocked_by_id FROM task_dependencies d JOIN chain c ON d.task_id = c.blocked_by_id
+)
+SELECT EXISTS (SELECT 1 FROM chain WHERE blocked_by_id = ?)`
+
+type TaskDependencyRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewTaskDependencyRepository(db *gorm.DB, log *log.Logger) ITaskDependencyRepository {
+	return &TaskDependencyRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *TaskDependencyRepository) CreateDependency(ctx context.Context, dependency *models.TaskDependency) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateDependency] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Serialize dependency inserts so two concurrent edges cannot form a cycle together
+		if err := tx.Exec("SELECT pg_advisory_xact_lock(hashtext('task_dependencies'))").Error; err != nil {
+			return err
+		}
+
+		var cycle bool
+		if err := tx.Raw(dependencyCycleQuery, dependency.BlockedByID, dependency.TaskID).Scan(&cycle).Error; err != nil {
+			return err
+		}
+		if cycle {
+			return constants.ErrDependencyCycle
+		}
+
+		result := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(dependency)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return constants.ErrTaskDependencyAlreadyExists
+		}
+		return nil
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateDependency] Failed to create dependency", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *TaskDependencyRepository) DeleteDependency(ctx context.Context, taskId string, blockedById string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: DeleteDependency] Called")
+
+	result := r.db.Where("task_id = ? AND blocked_by_id = ?", taskId, blockedById).Delete(&models.TaskDependency{})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteDependency] Failed to delete dependency", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *TaskDependencyRepository) GetBlockers(ctx context.Context, taskId string) (*[]models.Task, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetBlockers] Called")
+
+	var tasks []models.Task
+	if err := r.db.Select(taskColumns).
+		Joins("JOIN task_dependencies dep ON dep.blocked_by_id = tasks.id").
+		Where("dep.task_id = ?", taskId).
+		Order("dep.created_at asc").
+		Find(&tasks).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetBlockers] Failed to get blockers", err)
+		return nil, err
+	}
+
+	return &tasks, nil
+}
+
+func (r *TaskDependencyRepository) GetBlocking(ctx context.Context, taskId string) (*[]models.Task, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetBlocking] Called")
+
+	var tasks []models.Task
+	if err := r.db.Select(taskColumns).
+		Joins("JOIN task_dependencies dep ON dep.task_id = tasks.id").
+		Where("dep.blocked_by_id = ?", taskId).
+		Order("dep.created_at asc").
+		Find(&tasks).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetBlocking] Failed to get blocked tasks", err)
+		return nil, err
+	}
+
+	return &tasks, nil
+}
@@ -0,0 +1,134 @@
+package repositories
+
+import (
+	"strings"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm/clause"
+)
+
+// compileTaskFilter turns a parsed filter into a WHERE expression. Column
+// names come from this file only; every value is a bound parameter. Days are
+// resolved against now in the caller's time zone.
+func compileTaskFilter(node utils.FilterNode, userId string, now time.Time, loc *time.Location) clause.Expr {
+	c := &taskFilterCompiler{userId: userId, now: now, loc: loc}
+	var sql strings.Builder
+	c.compile(&sql, node)
+	return clause.Expr{SQL: sql.String(), Vars: c.vars}
+}
+
+type taskFilterCompiler struct {
+	userId string
+	now    time.Time
+	loc    *time.Location
+	vars   []interface{}
+}
+
+func (c *taskFilterCompiler) compile(sql *strings.Builder, node utils.FilterNode) {
+	switch n := node.(type) {
+	case *utils.FilterAnd:
+		c.binary(sql, n.Left, " AND ", n.Right)
+	case *utils.FilterOr:
+		c.binary(sql, n.Left, " OR ", n.Right)
+	case *utils.FilterNot:
+		sql.WriteString("NOT (")
+		c.compile(sql, n.Operand)
+		sql.WriteString(")")
+	case *utils.FilterTerm:
+		if n.Op == "!=" {
+			sql.WriteString("NOT (")
+			c.term(sql, n)
+			sql.WriteString(")")
+			return
+		}
+		c.term(sql, n)
+	}
+}
+
+func (c *taskFilterCompiler) binary(sql *strings.Builder, left utils.FilterNode, op string, right utils.FilterNode) {
+	sql.WriteString("(")
+	c.compile(sql, left)
+	sql.WriteString(op)
+	c.compile(sql, right)
+	sql.WriteString(")")
+}
+
+// term writes the positive form of a term; != is negated by the caller.
+// Every condition is NULL-safe so that negating it never drops rows.
+func (c *taskFilterCompiler) term(sql *strings.Builder, t *utils.FilterTerm) {
+	switch t.Field {
+	case utils.FilterFieldStatus:
+		c.write(sql, "tasks.status = ?", t.Value)
+	case utils.FilterFieldTag:
+		c.write(sql, "tasks.tags @> ARRAY[?]::text[]", t.Value)
+	case utils.FilterFieldAssignee:
+		switch t.Value {
+		case constants.AssigneeFilterUnassigned:
+			sql.WriteString("tasks.assignee_id IS NULL")
+		case constants.AssigneeFilterMe:
+			c.write(sql, "tasks.assignee_id IS NOT DISTINCT FROM ?", c.userId)
+		default:
+			c.write(sql, "tasks.assignee_id IS NOT DISTINCT FROM ?", t.Value)
+		}
+	case utils.FilterFieldTitle:
+		c.write(sql, "tasks.title ILIKE ?", "%"+escapeLike(t.Value)+"%")
+	case utils.FilterFieldBlocked:
+		if t.Value == "true" {
+			sql.WriteString(taskBlockedCondition)
+		} else {
+			sql.WriteString("NOT " + taskBlockedCondition)
+		}
+	case utils.FilterFieldDue:
+		c.dueDay(sql, t)
+	case utils.FilterFieldCreated:
+		c.day(sql, "tasks.created_at", t)
+	}
+}
+
+// day compares a timestamp column with a whole day: due<D is before D
+// starts, due<=D before the next day starts, and so on
+func (c *taskFilterCompiler) day(sql *strings.Builder, column string, t *utils.FilterTerm) {
+	start, _ := utils.FilterDate(t.Value, c.now, c.loc)
+	c.dayBounds(sql, column, t.Op, start, start.AddDate(0, 0, 1))
+}
+
+// dueDay compares task dates with a whole day. All-day dates are midnight
+// UTC of their day, so they are compared with the day's UTC midnight rather
+// than its start in the caller's time zone.
+func (c *taskFilterCompiler) dueDay(sql *strings.Builder, t *utils.FilterTerm) {
+	start, _ := utils.FilterDate(t.Value, c.now, c.loc)
+	allDayStart := utils.AllDayDate(start)
+
+	sql.WriteString("((tasks.all_day AND ")
+	c.dayBounds(sql, "tasks.date", t.Op, allDayStart, allDayStart.AddDate(0, 0, 1))
+	sql.WriteString(") OR (NOT tasks.all_day AND ")
+	c.dayBounds(sql, "tasks.date", t.Op, start, start.AddDate(0, 0, 1))
+	sql.WriteString("))")
+}
+
+func (c *taskFilterCompiler) dayBounds(sql *strings.Builder, column string, op string, start time.Time, next time.Time) {
+	switch op {
+	case "<":
+		c.write(sql, column+" < ?", start)
+	case "<=":
+		c.write(sql, column+" < ?", next)
+	case ">":
+		c.write(sql, column+" >= ?", next)
+	case ">=":
+		c.write(sql, column+" >= ?", start)
+	default:
+		c.write(sql, "("+column+" >= ? AND "+column+" < ?)", start, next)
+	}
+}
+
+func (c *taskFilterCompiler) write(sql *strings.Builder, condition string, vars ...interface{}) {
+	sql.WriteString(condition)
+	c.vars = append(c.vars, vars...)
+}
+
+// escapeLike makes LIKE wildcards in user input match literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITaskShareRepository interface {
+	UpsertShare(ctx context.Context, share *models.TaskShare) error
+	GetShares(ctx context.Context, taskId string) (*[]models.TaskShare, error)
+	DeleteShare(ctx context.Context, taskId string, userId string) (int64, error)
+	GetSharedTasks(ctx context.Context, userId string, limit int, offset int) (*[]models.Task, int64, error)
+}
+
+type TaskShareRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewTaskShareRepository(db *gorm.DB, log *log.Logger) ITaskShareRepository {
+	return &TaskShareRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *TaskShareRepository) UpsertShare(ctx context.Context, share *models.TaskShare) error {
+	r.log.DebugWithID(ctx, "[Repository: UpsertShare] Called")
+
+	// Sharing again with the same user only changes the permission
+	if err := r.db.Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "task_id"}, {Name: "user_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"permission", "shared_by"}),
+	}).Create(share).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: UpsertShare] Failed to upsert share", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *TaskShareRepository) GetShares(ctx context.Context, taskId string) (*[]models.TaskShare, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetShares] Called")
+
+	var shares []models.TaskShare
+	if err := r.db.Select("task_shares.*, users.email").
+		Joins("JOIN users ON users.id = task_shares.user_id").
+		Where("task_shares.task_id = ?", taskId).
+		Order("task_shares.created_at asc").
+		Find(&shares).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetShares] Failed to get shares", err)
+		return nil, err
+	}
+
+	return &shares, nil
+}
+
+func (r *TaskShareRepository) DeleteShare(ctx context.Context, taskId string, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: DeleteShare] Called")
+
+	result := r.db.Where("task_id = ? AND user_id = ?", taskId, userId).Delete(&models.TaskShare{})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteShare] Failed to delete share", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *TaskShareRepository) GetSharedTasks(ctx context.Context, userId string, limit int, offset int) (*[]models.Task, int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetSharedTasks] Called")
+
+	query := r.db.Model(&models.Task{}).
+		Joins("JOIN task_shares s ON s.task_id = tasks.id").
+		Where("s.user_id = ? AND tasks.workspace_id IS NULL", userId)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetSharedTasks] Failed to count shared tasks", err)
+		return nil, 0, err
+	}
+
+	var tasks []models.Task
+	if err := query.Select(taskColumns + ", s.permission").
+		Order("s.created_at desc").
+		Limit(limit).
+		Offset(offset).
+		Find(&tasks).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetSharedTasks] Failed to get shared tasks", err)
+		return nil, 0, err
+	}
+
+	return &tasks, total, nil
+}
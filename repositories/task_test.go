@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskRepository_GetAllTasks_SearchTies(t *testing.T) {
+	db := testDB(t)
+	repo := NewTaskRepository(db, log.Initialize(constants.TestAppEnv))
+	ctx := context.Background()
+	userId := createTestUser(t, db)
+
+	// Tasks with the same text and creation time rank the same, so only
+	// their ids tell them apart
+	created := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	var want []string
+	for i := 0; i < 5; i++ {
+		task := models.Task{ID: uuid.New(), UserID: userId, Title: "Release notes", Status: string(constants.TaskStatusPending), Date: created, Version: 1, CreatedAt: created}
+		require.NoError(t, db.Create(&task).Error)
+		want = append(want, task.ID.String())
+	}
+
+	req := &entities.GetAllTasksRequest{Search: "release", SortBy: "relevance", Order: "desc", Limit: 2}
+	var got []string
+	var cursor *utils.Cursor
+	for page := 0; page < len(want); page++ {
+		tasks, err := repo.GetAllTasks(ctx, req, nil, cursor, userId)
+		require.NoError(t, err)
+		if len(*tasks) == 0 {
+			break
+		}
+		for _, task := range *tasks {
+			got = append(got, task.ID.String())
+		}
+
+		// Cursor values travel as JSON, as they do in a page link
+		last := (*tasks)[len(*tasks)-1]
+		encoded, err := json.Marshal(TaskCursorValues(req.SortBy, &last))
+		require.NoError(t, err)
+		cursor = &utils.Cursor{}
+		require.NoError(t, json.Unmarshal(encoded, &cursor.Values))
+	}
+
+	assert.ElementsMatch(t, want, got)
+}
@@ -11,6 +11,8 @@ import (
 type IUserRepository interface {
 	RegisterUser(ctx context.Context, user *models.User) error
 	GetUser(ctx context.Context, email string) (*models.User, error)
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	UpdateUserTimezone(ctx context.Context, id string, timezone *string) error
 }
 
 type UserRepository struct {
@@ -46,3 +48,28 @@ func (r *UserRepository) GetUser(ctx context.Context, email string) (*models.Use
 
 	return &user, nil
 }
+
+func (r *UserRepository) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetUserByID] Called")
+	var user models.User
+	if err := r.db.Where("id = ?", id).First(&user).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetUserByID] Failed to get user", err)
+		return nil, err
+	}
+
+	return &user, nil
+}
+
+func (r *UserRepository) UpdateUserTimezone(ctx context.Context, id string, timezone *string) error {
+	r.log.DebugWithID(ctx, "[Repository: UpdateUserTimezone] Called")
+	result := r.db.Model(&models.User{}).Where("id = ?", id).Update("timezone", timezone)
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: UpdateUserTimezone] Failed to update timezone", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+
+	return nil
+}
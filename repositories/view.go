@@ -0,0 +1,121 @@
+package repositories
+
+import (
+	"context"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IViewRepository interface {
+	CreateView(ctx context.Context, view *models.SavedView) error
+	GetView(ctx context.Context, id string, userId string) (*models.SavedView, error)
+	GetViews(ctx context.Context, userId string) (*[]models.SavedView, error)
+	UpdateView(ctx context.Context, view *models.SavedView) error
+	DeleteView(ctx context.Context, id string, userId string) (int64, error)
+}
+
+type ViewRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewViewRepository(db *gorm.DB, log *log.Logger) IViewRepository {
+	return &ViewRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *ViewRepository) CreateView(ctx context.Context, view *models.SavedView) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateView] Called")
+
+	// The only other unique key is (user_id, name)
+	result := r.db.Clauses(clause.OnConflict{DoNothing: true}).Create(view)
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateView] Failed to create view", result.Error)
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		r.log.ErrorWithID(ctx, "[Repository: CreateView] View name taken", constants.ErrViewAlreadyExists)
+		return constants.ErrViewAlreadyExists
+	}
+
+	return nil
+}
+
+func (r *ViewRepository) GetView(ctx context.Context, id string, userId string) (*models.SavedView, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetView] Called")
+
+	var view models.SavedView
+	if err := r.db.Where("id = ? AND user_id = ?", id, userId).First(&view).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetView] Failed to get view", err)
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+func (r *ViewRepository) GetViews(ctx context.Context, userId string) (*[]models.SavedView, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetViews] Called")
+
+	var views []models.SavedView
+	if err := r.db.Where("user_id = ?", userId).Order("name asc").Find(&views).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetViews] Failed to get views", err)
+		return nil, err
+	}
+
+	return &views, nil
+}
+
+func (r *ViewRepository) UpdateView(ctx context.Context, view *models.SavedView) error {
+	r.log.DebugWithID(ctx, "[Repository: UpdateView] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		var taken bool
+		if err := tx.Raw("SELECT EXISTS (SELECT 1 FROM saved_views WHERE user_id = ? AND name = ? AND id <> ?)",
+			view.UserID, view.Name, view.ID).Scan(&taken).Error; err != nil {
+			return err
+		}
+		if taken {
+			return constants.ErrViewAlreadyExists
+		}
+
+		result := tx.Model(&models.SavedView{}).Where("id = ? AND user_id = ?", view.ID, view.UserID).
+			Updates(map[string]interface{}{
+				"name":       view.Name,
+				"filter":     view.Filter,
+				"sort_by":    view.SortBy,
+				"sort_order": view.SortOrder,
+				"group_by":   view.GroupBy,
+				"updated_at": view.UpdatedAt,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return nil
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: UpdateView] Failed to update view", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *ViewRepository) DeleteView(ctx context.Context, id string, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: DeleteView] Called")
+
+	result := r.db.Where("id = ? AND user_id = ?", id, userId).Delete(&models.SavedView{})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteView] Failed to delete view", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
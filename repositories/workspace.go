@@ -0,0 +1,211 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"gorm.io/gorm"
+)
+
+type IWorkspaceRepository interface {
+	CreateWorkspace(ctx context.Context, workspace *models.Workspace, owner *models.WorkspaceMember) error
+	GetWorkspace(ctx context.Context, id string) (*models.Workspace, error)
+	GetWorkspaces(ctx context.Context, userId string) (*[]models.Workspace, error)
+	GetMember(ctx context.Context, workspaceId string, userId string) (*models.WorkspaceMember, error)
+	GetMembers(ctx context.Context, workspaceId string) (*[]models.WorkspaceMember, error)
+	UpdateMemberRole(ctx context.Context, workspaceId string, userId string, role string) (int64, error)
+	DeleteMember(ctx context.Context, workspaceId string, userId string) (int64, error)
+	CreateInvitation(ctx context.Context, invitation *models.WorkspaceInvitation) error
+	GetInvitations(ctx context.Context, workspaceId string) (*[]models.WorkspaceInvitation, error)
+	GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.WorkspaceInvitation, error)
+	DeleteInvitation(ctx context.Context, workspaceId string, id string) (int64, error)
+	AcceptInvitation(ctx context.Context, invitation *models.WorkspaceInvitation, member *models.WorkspaceMember) error
+}
+
+type WorkspaceRepository struct {
+	db  *gorm.DB
+	log *log.Logger
+}
+
+func NewWorkspaceRepository(db *gorm.DB, log *log.Logger) IWorkspaceRepository {
+	return &WorkspaceRepository{
+		db:  db,
+		log: log,
+	}
+}
+
+func (r *WorkspaceRepository) CreateWorkspace(ctx context.Context, workspace *models.Workspace, owner *models.WorkspaceMember) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateWorkspace] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(workspace).Error; err != nil {
+			return err
+		}
+		return tx.Create(owner).Error
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateWorkspace] Failed to create workspace", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkspaceRepository) GetWorkspace(ctx context.Context, id string) (*models.Workspace, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetWorkspace] Called")
+
+	var workspace models.Workspace
+	if err := r.db.Where("id = ?", id).First(&workspace).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetWorkspace] Failed to get workspace", err)
+		return nil, err
+	}
+
+	return &workspace, nil
+}
+
+func (r *WorkspaceRepository) GetWorkspaces(ctx context.Context, userId string) (*[]models.Workspace, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetWorkspaces] Called")
+
+	var workspaces []models.Workspace
+	if err := r.db.Select("workspaces.*, m.role").
+		Joins("JOIN workspace_members m ON m.workspace_id = workspaces.id").
+		Where("m.user_id = ?", userId).
+		Order("workspaces.created_at asc").
+		Find(&workspaces).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetWorkspaces] Failed to get workspaces", err)
+		return nil, err
+	}
+
+	return &workspaces, nil
+}
+
+func (r *WorkspaceRepository) GetMember(ctx context.Context, workspaceId string, userId string) (*models.WorkspaceMember, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetMember] Called")
+
+	var member models.WorkspaceMember
+	if err := r.db.Select("workspace_members.*, users.email").
+		Joins("JOIN users ON users.id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ? AND workspace_members.user_id = ?", workspaceId, userId).
+		First(&member).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetMember] Failed to get member", err)
+		return nil, err
+	}
+
+	return &member, nil
+}
+
+func (r *WorkspaceRepository) GetMembers(ctx context.Context, workspaceId string) (*[]models.WorkspaceMember, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetMembers] Called")
+
+	var members []models.WorkspaceMember
+	if err := r.db.Select("workspace_members.*, users.email").
+		Joins("JOIN users ON users.id = workspace_members.user_id").
+		Where("workspace_members.workspace_id = ?", workspaceId).
+		Order("workspace_members.created_at asc").
+		Find(&members).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetMembers] Failed to get members", err)
+		return nil, err
+	}
+
+	return &members, nil
+}
+
+func (r *WorkspaceRepository) UpdateMemberRole(ctx context.Context, workspaceId string, userId string, role string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: UpdateMemberRole] Called")
+
+	result := r.db.Model(&models.WorkspaceMember{}).
+		Where("workspace_id = ? AND user_id = ?", workspaceId, userId).
+		Update("role", role)
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: UpdateMemberRole] Failed to update member role", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *WorkspaceRepository) DeleteMember(ctx context.Context, workspaceId string, userId string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: DeleteMember] Called")
+
+	result := r.db.Where("workspace_id = ? AND user_id = ?", workspaceId, userId).Delete(&models.WorkspaceMember{})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteMember] Failed to delete member", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *WorkspaceRepository) CreateInvitation(ctx context.Context, invitation *models.WorkspaceInvitation) error {
+	r.log.DebugWithID(ctx, "[Repository: CreateInvitation] Called")
+
+	if err := r.db.Create(invitation).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: CreateInvitation] Failed to create invitation", err)
+		return err
+	}
+
+	return nil
+}
+
+func (r *WorkspaceRepository) GetInvitations(ctx context.Context, workspaceId string) (*[]models.WorkspaceInvitation, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetInvitations] Called")
+
+	// Only invitations that can still be accepted
+	var invitations []models.WorkspaceInvitation
+	if err := r.db.Where("workspace_id = ? AND accepted_at IS NULL AND expires_at > ?", workspaceId, time.Now()).
+		Order("created_at desc").
+		Find(&invitations).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetInvitations] Failed to get invitations", err)
+		return nil, err
+	}
+
+	return &invitations, nil
+}
+
+func (r *WorkspaceRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*models.WorkspaceInvitation, error) {
+	r.log.DebugWithID(ctx, "[Repository: GetInvitationByTokenHash] Called")
+
+	var invitation models.WorkspaceInvitation
+	if err := r.db.Where("token_hash = ?", tokenHash).First(&invitation).Error; err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: GetInvitationByTokenHash] Failed to get invitation", err)
+		return nil, err
+	}
+
+	return &invitation, nil
+}
+
+func (r *WorkspaceRepository) DeleteInvitation(ctx context.Context, workspaceId string, id string) (int64, error) {
+	r.log.DebugWithID(ctx, "[Repository: DeleteInvitation] Called")
+
+	result := r.db.Where("workspace_id = ? AND id = ?", workspaceId, id).Delete(&models.WorkspaceInvitation{})
+	if result.Error != nil {
+		r.log.ErrorWithID(ctx, "[Repository: DeleteInvitation] Failed to delete invitation", result.Error)
+		return 0, result.Error
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *WorkspaceRepository) AcceptInvitation(ctx context.Context, invitation *models.WorkspaceInvitation, member *models.WorkspaceMember) error {
+	r.log.DebugWithID(ctx, "[Repository: AcceptInvitation] Called")
+
+	if err := r.db.Transaction(func(tx *gorm.DB) error {
+		// Claim the invitation first so it cannot be used twice concurrently
+		result := tx.Model(&models.WorkspaceInvitation{}).
+			Where("id = ? AND accepted_at IS NULL", invitation.ID).
+			Update("accepted_at", invitation.AcceptedAt)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		return tx.Create(member).Error
+	}); err != nil {
+		r.log.ErrorWithID(ctx, "[Repository: AcceptInvitation] Failed to accept invitation", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,776 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"image"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+// legacyImageBatchSize is how many base64 images are moved per query
+const legacyImageBatchSize = 50
+
+// defaultStorageQuota applies when the config does not set one
+const defaultStorageQuota int64 = 100 << 20
+
+// defaultMaxImageSize applies when the config does not set one
+const defaultMaxImageSize int64 = 10 << 20
+
+// defaultAllowedFileTypes applies when the config does not set any
+var defaultAllowedFileTypes = []string{
+	"image/png", "image/jpeg", "image/gif", "image/webp",
+	"application/pdf", "application/zip", "text/plain",
+}
+
+type IAttachmentService interface {
+	UploadAttachment(ctx context.Context, taskId string, req *entities.UploadAttachmentRequest) (*entities.AttachmentResponse, error)
+	GetAttachments(ctx context.Context, taskId string) (*entities.GetAttachmentsResponse, error)
+	GetAttachmentContent(ctx context.Context, taskId string, id string, req *entities.GetAttachmentContentRequest) (*entities.AttachmentContent, error)
+	GetAttachmentThumbnail(ctx context.Context, taskId string, id string, size int) (*entities.AttachmentContent, error)
+	GetSignedAttachmentContent(ctx context.Context, taskId string, id string, grant *entities.SignedURLRequest, req *entities.GetAttachmentContentRequest) (*entities.AttachmentContent, error)
+	GetSignedAttachmentThumbnail(ctx context.Context, taskId string, id string, size int, grant *entities.SignedURLRequest) (*entities.AttachmentContent, error)
+	DeleteAttachment(ctx context.Context, taskId string, id string) error
+	MoveLegacyImages(ctx context.Context) (int, error)
+}
+
+type AttachmentService struct {
+	repo     repositories.IAttachmentRepository
+	taskRepo repositories.ITaskRepository
+	blobs    utils.IBlobStore
+	signer   utils.IURLSigner
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+	config   *config.Config
+}
+
+func NewAttachmentService(
+	repo repositories.IAttachmentRepository,
+	taskRepo repositories.ITaskRepository,
+	blobs utils.IBlobStore,
+	signer utils.IURLSigner,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+	config *config.Config,
+) IAttachmentService {
+	return &AttachmentService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		blobs:    blobs,
+		signer:   signer,
+		log:      log,
+		payload:  payload,
+		config:   config,
+	}
+}
+
+func (s *AttachmentService) UploadAttachment(ctx context.Context, taskId string, req *entities.UploadAttachmentRequest) (*entities.AttachmentResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: UploadAttachment] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UploadAttachment] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the task
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionEditor)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UploadAttachment] Failed to get task", err)
+		return nil, err
+	}
+
+	// Check the quota before reading the body; saving the attachment checks
+	// it again, as other uploads of the user may finish in the meantime
+	if err := checkStorageQuota(ctx, s.repo, authPayload.UserId, req.Size, storageQuota(s.config)); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UploadAttachment] Storage quota check failed", err)
+		return nil, err
+	}
+
+	// Stream the body to the blob store
+	filename := path.Base(strings.ReplaceAll(req.Filename, "\\", "/"))
+	attachment, err := storeFile(ctx, s.blobs, allowedFileTypes(s.config), task.ID, authPayload.UserId, filename, req.Body, req.Size)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UploadAttachment] Failed to store file", err)
+		return nil, err
+	}
+
+	// Create attachment
+	if err := s.repo.CreateAttachment(ctx, attachment, storageQuota(s.config)); err != nil {
+		deleteBlobs(ctx, s.blobs, s.log, []models.Attachment{*attachment})
+		s.log.ErrorWithID(ctx, "[Service: UploadAttachment] Failed to create attachment", err)
+		return nil, err
+	}
+
+	resp := toAttachmentResponse(ctx, s.signer, authPayload.UserId, attachment)
+	s.log.DebugWithID(ctx, "[Service: UploadAttachment] Attachment uploaded successfully", resp)
+	return resp, nil
+}
+
+func (s *AttachmentService) GetAttachments(ctx context.Context, taskId string) (*entities.GetAttachmentsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetAttachments] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachments] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the task
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachments] Failed to get task", err)
+		return nil, err
+	}
+
+	attachments, err := s.repo.GetTaskAttachments(ctx, []string{taskId})
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachments] Failed to get attachments", err)
+		return nil, err
+	}
+
+	resp := &entities.GetAttachmentsResponse{Attachments: []entities.AttachmentResponse{}}
+	for i := range *attachments {
+		resp.Attachments = append(resp.Attachments, *toAttachmentResponse(ctx, s.signer, authPayload.UserId, &(*attachments)[i]))
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetAttachments] Attachments retrieved successfully", len(resp.Attachments))
+	return resp, nil
+}
+
+// GetAttachmentContent opens the attachment's file, or the part of it asked
+// for by a Range header. A range past the end fails with
+// ErrRangeNotSatisfiable, returned with a content that has no Body but
+// reports the file size in ContentRange.
+func (s *AttachmentService) GetAttachmentContent(ctx context.Context, taskId string, id string, req *entities.GetAttachmentContentRequest) (*entities.AttachmentContent, error) {
+	s.log.DebugWithID(ctx, "[Service: GetAttachmentContent] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachmentContent] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the task
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachmentContent] Failed to get task", err)
+		return nil, err
+	}
+
+	content, err := s.openContent(ctx, taskId, id, req)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachmentContent] Failed to open attachment", err)
+		return content, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetAttachmentContent] Attachment opened successfully", id)
+	return content, nil
+}
+
+// GetAttachmentThumbnail opens a thumbnail of an image attachment. Sizes
+// are those of constants.ThumbnailSizes. Thumbnails missing from the blob
+// store, such as those of images uploaded as plain attachments, are made
+// from the original and stored for next time.
+func (s *AttachmentService) GetAttachmentThumbnail(ctx context.Context, taskId string, id string, size int) (*entities.AttachmentContent, error) {
+	s.log.DebugWithID(ctx, "[Service: GetAttachmentThumbnail] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachmentThumbnail] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the task
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachmentThumbnail] Failed to get task", err)
+		return nil, err
+	}
+
+	content, err := s.openThumbnail(ctx, taskId, id, size)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAttachmentThumbnail] Failed to open thumbnail", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetAttachmentThumbnail] Thumbnail opened successfully", id)
+	return content, nil
+}
+
+// openContent opens an attachment's file, or the part of it asked for. A
+// range past the end is returned as an empty content with the error.
+func (s *AttachmentService) openContent(ctx context.Context, taskId string, id string, req *entities.GetAttachmentContentRequest) (*entities.AttachmentContent, error) {
+	attachment, err := s.getAttachment(ctx, taskId, id)
+	if err != nil {
+		return nil, err
+	}
+	content := &entities.AttachmentContent{
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		ETag:        attachmentETag(attachment),
+		Length:      attachment.Size,
+	}
+
+	// Pick the part to send
+	byteRange, err := utils.ParseRange(req.Range, req.IfRange, content.ETag, attachment.Size)
+	if err != nil {
+		content.Length, content.ContentRange = 0, "bytes */"+strconv.FormatInt(attachment.Size, 10)
+		return content, err
+	}
+
+	// Open its contents
+	var body io.ReadCloser
+	if byteRange != nil {
+		content.Length, content.ContentRange = byteRange.Length, byteRange.ContentRange()
+		body, err = s.blobs.GetRange(ctx, attachment.StorageKey, byteRange.Start, byteRange.Length)
+	} else {
+		body, err = s.blobs.Get(ctx, attachment.StorageKey)
+	}
+	if err != nil {
+		if errors.Is(err, utils.ErrBlobNotFound) {
+			s.log.ErrorWithID(ctx, "[Service: openContent] Attachment blob is missing: ", err)
+			return nil, constants.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	content.Body = body
+
+	return content, nil
+}
+
+// openThumbnail opens a thumbnail of an image attachment, making it if it
+// is not stored yet
+func (s *AttachmentService) openThumbnail(ctx context.Context, taskId string, id string, size int) (*entities.AttachmentContent, error) {
+	if !slices.Contains(constants.ThumbnailSizes, size) {
+		return nil, constants.ErrInvalidRequestParam
+	}
+
+	attachment, err := s.getAttachment(ctx, taskId, id)
+	if err != nil {
+		return nil, err
+	}
+	if !hasThumbnails(attachment) {
+		return nil, constants.ErrThumbnailNotAvailable
+	}
+	content := &entities.AttachmentContent{
+		Filename:    attachment.Filename,
+		ContentType: utils.ThumbnailContentType(attachment.ContentType),
+		ETag:        `"` + attachment.ID.String() + "-" + strconv.Itoa(size) + `"`,
+		Length:      -1,
+	}
+
+	// Open the stored thumbnail
+	body, err := s.blobs.Get(ctx, thumbnailKey(attachment, size))
+	if err == nil {
+		content.Body = body
+		return content, nil
+	}
+	if !errors.Is(err, utils.ErrBlobNotFound) {
+		return nil, err
+	}
+
+	// Make it from the original
+	data, err := s.makeThumbnail(ctx, attachment, size)
+	if err != nil {
+		return nil, err
+	}
+	content.Length, content.Body = int64(len(data)), io.NopCloser(bytes.NewReader(data))
+
+	return content, nil
+}
+
+// GetSignedAttachmentContent is GetAttachmentContent for a signed link: the
+// link's grant stands in for the access token and the task permission check
+func (s *AttachmentService) GetSignedAttachmentContent(ctx context.Context, taskId string, id string, grant *entities.SignedURLRequest, req *entities.GetAttachmentContentRequest) (*entities.AttachmentContent, error) {
+	s.log.DebugWithID(ctx, "[Service: GetSignedAttachmentContent] Called")
+
+	// Check the link
+	if err := s.verifySignedURL(signedContentPath(taskId, id), grant); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetSignedAttachmentContent] Invalid signed link", err)
+		return nil, err
+	}
+
+	content, err := s.openContent(ctx, taskId, id, req)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetSignedAttachmentContent] Failed to open attachment", err)
+		return content, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetSignedAttachmentContent] Attachment opened for user", grant.UserID, id)
+	return content, nil
+}
+
+// GetSignedAttachmentThumbnail is GetAttachmentThumbnail for a signed link
+func (s *AttachmentService) GetSignedAttachmentThumbnail(ctx context.Context, taskId string, id string, size int, grant *entities.SignedURLRequest) (*entities.AttachmentContent, error) {
+	s.log.DebugWithID(ctx, "[Service: GetSignedAttachmentThumbnail] Called")
+
+	// Check the link
+	if err := s.verifySignedURL(signedThumbnailPath(taskId, id, size), grant); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetSignedAttachmentThumbnail] Invalid signed link", err)
+		return nil, err
+	}
+
+	content, err := s.openThumbnail(ctx, taskId, id, size)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetSignedAttachmentThumbnail] Failed to open thumbnail", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetSignedAttachmentThumbnail] Thumbnail opened for user", grant.UserID, id)
+	return content, nil
+}
+
+// verifySignedURL checks that a link was signed for path, has not expired
+// and grants at least viewing
+func (s *AttachmentService) verifySignedURL(path string, grant *entities.SignedURLRequest) error {
+	permission := constants.TaskPermission(grant.Permission)
+	if err := s.signer.Verify(path, &utils.URLGrant{
+		UserID:     grant.UserID,
+		Permission: permission,
+		Expires:    grant.Expires,
+		Signature:  grant.Signature,
+	}); err != nil {
+		return err
+	}
+	if !permission.Allows(constants.TaskPermissionViewer) {
+		return constants.ErrInvalidSignedURL
+	}
+	return nil
+}
+
+// makeThumbnail decodes an image attachment and stores a thumbnail of it.
+// Originals that cannot be decoded have no thumbnail.
+func (s *AttachmentService) makeThumbnail(ctx context.Context, attachment *models.Attachment, size int) ([]byte, error) {
+	body, err := s.blobs.Get(ctx, attachment.StorageKey)
+	if err != nil {
+		if errors.Is(err, utils.ErrBlobNotFound) {
+			return nil, constants.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, err
+	}
+	img, err := utils.DecodeImage(data)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: makeThumbnail] Failed to decode image", attachment.ID, err)
+		return nil, constants.ErrThumbnailNotAvailable
+	}
+
+	return storeThumbnail(ctx, s.blobs, attachment, img, size)
+}
+
+func (s *AttachmentService) DeleteAttachment(ctx context.Context, taskId string, id string) error {
+	s.log.DebugWithID(ctx, "[Service: DeleteAttachment] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteAttachment] Failed to get auth payload", err)
+		return err
+	}
+
+	// Check access to the task
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionEditor)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteAttachment] Failed to get task", err)
+		return err
+	}
+
+	// Get existing attachment
+	attachment, err := s.getAttachment(ctx, taskId, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteAttachment] Failed to get attachment", err)
+		return err
+	}
+
+	// The uploader or the task owner may delete
+	if attachment.UserID != authPayload.UserId && task.UserID != authPayload.UserId {
+		s.log.ErrorWithID(ctx, "[Service: DeleteAttachment] User may not delete this attachment", constants.ErrNotAttachmentUploader)
+		return constants.ErrNotAttachmentUploader
+	}
+
+	// Removing the task's image is a change to the task
+	if task.ImageID != nil && *task.ImageID == id {
+		before := task.Snapshot()
+		task.ImageID = nil
+		history := newTaskHistory(task, authPayload.UserId, constants.TaskHistoryActionUpdate, utils.DiffTaskSnapshots(before, task.Snapshot()))
+		if err := s.taskRepo.UpdateTask(ctx, task, history); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: DeleteAttachment] Failed to remove task image", err)
+			return err
+		}
+	}
+
+	if err := s.repo.DeleteAttachment(ctx, id); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteAttachment] Failed to delete attachment", err)
+		return err
+	}
+	deleteBlobs(ctx, s.blobs, s.log, []models.Attachment{*attachment})
+
+	s.log.DebugWithID(ctx, "[Service: DeleteAttachment] Attachment deleted successfully")
+	return nil
+}
+
+// getAttachment returns an attachment of the task, mapping a missing one to
+// ErrAttachmentNotFound
+func (s *AttachmentService) getAttachment(ctx context.Context, taskId string, id string) (*models.Attachment, error) {
+	attachment, err := s.repo.GetAttachment(ctx, taskId, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrAttachmentNotFound
+		}
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// MoveLegacyImages moves task images still stored as base64 in the tasks
+// table into the blob store, returning how many tasks it updated. It is safe
+// to run repeatedly; tasks already moved are not selected again.
+func (s *AttachmentService) MoveLegacyImages(ctx context.Context) (int, error) {
+	s.log.DebugWithID(ctx, "[Service: MoveLegacyImages] Called")
+
+	moved := 0
+	for {
+		images, err := s.repo.GetLegacyImages(ctx, legacyImageBatchSize)
+		if err != nil {
+			s.log.ErrorWithID(ctx, "[Service: MoveLegacyImages] Failed to get legacy images", err)
+			return moved, err
+		}
+		if len(*images) == 0 {
+			break
+		}
+
+		for _, image := range *images {
+			if err := s.moveLegacyImage(ctx, image); err != nil {
+				s.log.ErrorWithID(ctx, "[Service: MoveLegacyImages] Failed to move image of task", image.ID, err)
+				return moved, err
+			}
+			moved++
+		}
+	}
+
+	s.log.DebugWithID(ctx, "[Service: MoveLegacyImages] Legacy images moved", moved)
+	return moved, nil
+}
+
+// moveLegacyImage stores one base64 image as an attachment of its task.
+// Empty or undecodable images are dropped. Images are sanitized like new
+// uploads; ones that predate the checks and fail them are kept as they are.
+func (s *AttachmentService) moveLegacyImage(ctx context.Context, image repositories.LegacyTaskImage) error {
+	data, err := base64.StdEncoding.DecodeString(image.Image)
+	if err != nil || len(data) == 0 {
+		return s.repo.MoveLegacyImage(ctx, image.ID, nil)
+	}
+
+	var attachment *models.Attachment
+	if img, err := utils.SanitizeImage(data); err == nil {
+		if attachment, err = storeImage(ctx, s.blobs, s.log, image.ID, image.UserID, "image", img); err != nil {
+			return err
+		}
+	} else {
+		attachment = newAttachment(image.ID, image.UserID, "image", http.DetectContentType(data), int64(len(data)))
+		if err := s.blobs.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.Size, attachment.ContentType); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.MoveLegacyImage(ctx, image.ID, attachment); err != nil {
+		deleteBlobs(ctx, s.blobs, s.log, []models.Attachment{*attachment})
+		return err
+	}
+
+	return nil
+}
+
+// newAttachment describes a file of the task with a fresh storage key
+func newAttachment(taskId uuid.UUID, userId, filename, contentType string, size int64) *models.Attachment {
+	id := uuid.New()
+	return &models.Attachment{
+		ID:          id,
+		TaskID:      taskId,
+		UserID:      userId,
+		StorageKey:  "tasks/" + taskId.String() + "/" + id.String(),
+		Filename:    filename,
+		ContentType: contentType,
+		Size:        size,
+		CreatedAt:   time.Now(),
+	}
+}
+
+// readImage reads an uploaded task image and sanitizes it, refusing files
+// over maxSize bytes
+func readImage(file *multipart.FileHeader, maxSize int64) (*utils.SanitizedImage, error) {
+	if file.Size > maxSize {
+		return nil, constants.ErrImageTooLarge
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, constants.ErrOpenFileContext
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, constants.ErrOpenFileContext
+	}
+	if int64(len(data)) > maxSize {
+		return nil, constants.ErrImageTooLarge
+	}
+
+	return utils.SanitizeImage(data)
+}
+
+// readStoredImage reads an image attachment back from the blob store and
+// sanitizes it, refusing files over maxSize bytes
+func readStoredImage(ctx context.Context, blobs utils.IBlobStore, attachment *models.Attachment, maxSize int64) (*utils.SanitizedImage, error) {
+	if attachment.Size > maxSize {
+		return nil, constants.ErrImageTooLarge
+	}
+
+	body, err := blobs.Get(ctx, attachment.StorageKey)
+	if errors.Is(err, utils.ErrBlobNotFound) {
+		return nil, constants.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, maxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > maxSize {
+		return nil, constants.ErrImageTooLarge
+	}
+
+	return utils.SanitizeImage(data)
+}
+
+// storeImage writes a sanitized task image and its thumbnails to the blob
+// store and returns the attachment describing it; the caller saves the
+// attachment
+func storeImage(ctx context.Context, blobs utils.IBlobStore, log *log.Logger, taskId uuid.UUID, userId string, filename string, img *utils.SanitizedImage) (*models.Attachment, error) {
+	attachment := newAttachment(taskId, userId, filename, img.ContentType, int64(len(img.Data)))
+	if err := blobs.Put(ctx, attachment.StorageKey, bytes.NewReader(img.Data), attachment.Size, attachment.ContentType); err != nil {
+		return nil, err
+	}
+
+	for _, size := range constants.ThumbnailSizes {
+		if _, err := storeThumbnail(ctx, blobs, attachment, img.Image, size); err != nil {
+			deleteBlobs(ctx, blobs, log, []models.Attachment{*attachment})
+			return nil, err
+		}
+	}
+
+	return attachment, nil
+}
+
+// storeThumbnail scales an image attachment down to size and stores the
+// result under its thumbnail key, returning the encoded thumbnail
+func storeThumbnail(ctx context.Context, blobs utils.IBlobStore, attachment *models.Attachment, img image.Image, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := utils.EncodeThumbnail(&buf, utils.Thumbnail(img, size), attachment.ContentType); err != nil {
+		return nil, err
+	}
+
+	contentType := utils.ThumbnailContentType(attachment.ContentType)
+	if err := blobs.Put(ctx, thumbnailKey(attachment, size), bytes.NewReader(buf.Bytes()), int64(buf.Len()), contentType); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// thumbnailKey is where the thumbnail of the given size of an image
+// attachment is stored
+func thumbnailKey(a *models.Attachment, size int) string {
+	return a.StorageKey + ".thumb-" + strconv.Itoa(size)
+}
+
+// hasThumbnails reports whether thumbnails can be made of an attachment
+func hasThumbnails(a *models.Attachment) bool {
+	return utils.IsAllowedImageType(a.ContentType)
+}
+
+// storeFile streams size bytes of body to the blob store and returns the
+// attachment describing them; the caller saves the attachment. Files whose
+// type is not in allowed are refused.
+func storeFile(ctx context.Context, blobs utils.IBlobStore, allowed []string, taskId uuid.UUID, userId string, filename string, body io.Reader, size int64) (*models.Attachment, error) {
+	// Sniff the content type rather than trusting the client's
+	head := make([]byte, 512)
+	n, err := io.ReadFull(body, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, constants.ErrOpenFileContext
+	}
+	head = head[:n]
+	contentType := http.DetectContentType(head)
+	if !isAllowedFileType(contentType, allowed) {
+		return nil, constants.ErrUnsupportedMediaType
+	}
+
+	attachment := newAttachment(taskId, userId, filename, contentType, size)
+	if err := blobs.Put(ctx, attachment.StorageKey, io.MultiReader(bytes.NewReader(head), body), attachment.Size, attachment.ContentType); err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+// storageQuota is how many bytes of uploads each user may keep
+func storageQuota(config *config.Config) int64 {
+	if config != nil && config.Storage.UserQuota > 0 {
+		return config.Storage.UserQuota
+	}
+	return defaultStorageQuota
+}
+
+// allowedFileTypes are the media types attachments may have
+func allowedFileTypes(config *config.Config) []string {
+	if config != nil && len(config.Storage.AllowedTypes) > 0 {
+		return config.Storage.AllowedTypes
+	}
+	return defaultAllowedFileTypes
+}
+
+// isAllowedFileType reports whether a detected content type, whose
+// parameters such as charset do not count, is one of allowed
+func isAllowedFileType(contentType string, allowed []string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	for _, t := range allowed {
+		if strings.EqualFold(strings.TrimSpace(t), mediaType) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxImageSize is the largest task image, in bytes, that is accepted
+func maxImageSize(config *config.Config) int64 {
+	if config != nil && config.Storage.MaxImageSize > 0 {
+		return config.Storage.MaxImageSize
+	}
+	return defaultMaxImageSize
+}
+
+// checkStorageQuota fails if size more bytes would take the user's uploads
+// over the quota. It only spares uploading a file that cannot be kept:
+// saving the attachment checks the quota again.
+func checkStorageQuota(ctx context.Context, repo repositories.IAttachmentRepository, userId string, size int64, quota int64) error {
+	used, err := repo.GetUserStorageUsed(ctx, userId)
+	if err != nil {
+		return err
+	}
+	if used+size > quota {
+		return constants.ErrStorageQuotaExceeded
+	}
+	return nil
+}
+
+// attachmentETag is the strong entity tag of an attachment's file, which
+// never changes once uploaded
+func attachmentETag(a *models.Attachment) string {
+	return `"` + a.ID.String() + `"`
+}
+
+func toAttachmentResponse(ctx context.Context, signer utils.IURLSigner, userId string, a *models.Attachment) *entities.AttachmentResponse {
+	return &entities.AttachmentResponse{
+		ID:          a.ID.String(),
+		TaskID:      a.TaskID.String(),
+		UserID:      a.UserID,
+		Filename:    a.Filename,
+		ContentType: a.ContentType,
+		Size:        a.Size,
+		URL:         signer.Sign(signedContentPath(a.TaskID.String(), a.ID.String()), userId, constants.TaskPermissionViewer),
+		Thumbnails:  thumbnailURLs(signer, userId, a),
+		CreatedAt:   utils.FormatRFC3339(ctx, a.CreatedAt),
+	}
+}
+
+// thumbnailURLs maps each thumbnail size of an image attachment to a signed
+// link to it
+func thumbnailURLs(signer utils.IURLSigner, userId string, a *models.Attachment) map[string]string {
+	if !hasThumbnails(a) {
+		return nil
+	}
+	urls := make(map[string]string, len(constants.ThumbnailSizes))
+	for _, size := range constants.ThumbnailSizes {
+		path := signedThumbnailPath(a.TaskID.String(), a.ID.String(), size)
+		urls[strconv.Itoa(size)] = signer.Sign(path, userId, constants.TaskPermissionViewer)
+	}
+	return urls
+}
+
+// signedContentPath is where an attachment's file is downloaded with a
+// signed link
+func signedContentPath(taskId string, id string) string {
+	return "/api/v1/files/tasks/" + taskId + "/attachments/" + id + "/content"
+}
+
+// signedThumbnailPath is where a thumbnail of an image attachment is
+// downloaded with a signed link
+func signedThumbnailPath(taskId string, id string, size int) string {
+	return "/api/v1/files/tasks/" + taskId + "/attachments/" + id + "/thumbnails/" + strconv.Itoa(size)
+}
+
+// deleteBlobs removes the contents of deleted attachments. Failures are only
+// logged: the rows are already gone, so a leftover blob is unreachable.
+func deleteBlobs(ctx context.Context, blobs utils.IBlobStore, log *log.Logger, attachments []models.Attachment) {
+	for _, attachment := range attachments {
+		keys := []string{attachment.StorageKey}
+		if hasThumbnails(&attachment) {
+			for _, size := range constants.ThumbnailSizes {
+				keys = append(keys, thumbnailKey(&attachment, size))
+			}
+		}
+		for _, key := range keys {
+			if err := blobs.Delete(ctx, key); err != nil {
+				log.ErrorWithID(ctx, "[Service: deleteBlobs] Failed to delete blob", key, err)
+			}
+		}
+	}
+}
+
+// fileDigest returns the SHA-256 of an uploaded file, hex encoded
+func fileDigest(file *multipart.FileHeader) (string, error) {
+	f, err := file.Open()
+	if err != nil {
+		return "", constants.ErrOpenFileContext
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", constants.ErrOpenFileContext
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
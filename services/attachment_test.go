@@ -0,0 +1,689 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+// pngHeader is enough of a PNG file for content sniffing
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+// testSigner signs the download links in responses
+var testSigner = utils.NewURLSigner(&config.Config{TokenConfig: config.TokenConfig{TokenSymmetricKey: "12345678901234567890123456789012"}})
+
+// testPNG is a decodable PNG image, wider than the largest thumbnail
+var testPNG = func() []byte {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 600, 300))); err != nil {
+		panic(err)
+	}
+	return buf.Bytes()
+}()
+
+// newFileHeader returns an uploaded form file holding content
+func newFileHeader(t *testing.T, filename string, content []byte) *multipart.FileHeader {
+	body := new(bytes.Buffer)
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("image", filename)
+	assert.NoError(t, err)
+	_, err = part.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, w.Close())
+
+	req := httptest.NewRequest("POST", "/", body)
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	assert.NoError(t, req.ParseMultipartForm(1<<20))
+	return req.MultipartForm.File["image"][0]
+}
+
+func TestAttachmentService_GetAttachmentContent(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	attachmentId := "550e8400-e29b-41d4-a716-446655440001"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+	okTask := &models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}
+	okAttachment := &models.Attachment{
+		ID:          uuid.MustParse(attachmentId),
+		TaskID:      okTask.ID,
+		StorageKey:  "tasks/" + taskId + "/" + attachmentId,
+		Filename:    "cat.png",
+		ContentType: "image/png",
+		Size:        int64(len(pngHeader)),
+	}
+
+	testCases := []struct {
+		name   string
+		req    entities.GetAttachmentContentRequest
+		setup  func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore)
+		verify func(t *testing.T, got *entities.AttachmentContent, body []byte, gotErr error)
+	}{
+		{
+			name: "GetAttachmentContent_OK",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+				blobs.EXPECT().Get(ctx, okAttachment.StorageKey).Return(io.NopCloser(bytes.NewReader(pngHeader)), nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, body []byte, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, pngHeader, body)
+				assert.Equal(t, int64(len(pngHeader)), got.Length)
+				assert.Empty(t, got.ContentRange)
+				assert.Equal(t, `"`+attachmentId+`"`, got.ETag)
+			},
+		},
+		{
+			name: "GetAttachmentContent_Range",
+			req:  entities.GetAttachmentContentRequest{Range: "bytes=1-3"},
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+				blobs.EXPECT().GetRange(ctx, okAttachment.StorageKey, int64(1), int64(3)).Return(io.NopCloser(bytes.NewReader(pngHeader[1:4])), nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, body []byte, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, []byte("PNG"), body)
+				assert.Equal(t, int64(3), got.Length)
+				assert.Equal(t, "bytes 1-3/16", got.ContentRange)
+			},
+		},
+		{
+			name: "GetAttachmentContent_RangeNotSatisfiable",
+			req:  entities.GetAttachmentContentRequest{Range: "bytes=100-"},
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, body []byte, gotErr error) {
+				assert.Equal(t, constants.ErrRangeNotSatisfiable, gotErr)
+				assert.Equal(t, "bytes */16", got.ContentRange)
+				assert.Nil(t, got.Body)
+			},
+		},
+		{
+			name: "GetAttachmentContent_NotFound",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, body []byte, gotErr error) {
+				assert.Equal(t, constants.ErrAttachmentNotFound, gotErr)
+			},
+		},
+		{
+			name: "GetAttachmentContent_BlobMissing",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+				blobs.EXPECT().Get(ctx, okAttachment.StorageKey).Return(nil, utils.ErrBlobNotFound)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, body []byte, gotErr error) {
+				assert.Equal(t, constants.ErrAttachmentNotFound, gotErr)
+			},
+		},
+		{
+			name: "GetAttachmentContent_NoAccess",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: okTask.ID, UserID: "2"}, nil)
+				taskRepo.EXPECT().GetSharePermission(ctx, taskId, okPayload.UserId).Return("", nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, body []byte, gotErr error) {
+				assert.Error(t, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIAttachmentRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockBlobs := mocks.NewMockIBlobStore(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tC.setup(mockRepo, mockTaskRepo, mockBlobs)
+
+			svc := NewAttachmentService(mockRepo, mockTaskRepo, mockBlobs, testSigner, lgr, mockPayload, nil)
+			got, gotErr := svc.GetAttachmentContent(ctx, taskId, attachmentId, &tC.req)
+
+			var body []byte
+			if got != nil && got.Body != nil {
+				assert.Equal(t, "cat.png", got.Filename)
+				assert.Equal(t, "image/png", got.ContentType)
+				body, _ = io.ReadAll(got.Body)
+				got.Body.Close()
+			}
+			tC.verify(t, got, body, gotErr)
+		})
+	}
+}
+
+func TestAttachmentService_GetAttachmentThumbnail(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	attachmentId := "550e8400-e29b-41d4-a716-446655440001"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+	okTask := &models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}
+	okAttachment := &models.Attachment{
+		ID:          uuid.MustParse(attachmentId),
+		TaskID:      okTask.ID,
+		StorageKey:  "tasks/" + taskId + "/" + attachmentId,
+		Filename:    "cat.png",
+		ContentType: "image/png",
+		Size:        int64(len(testPNG)),
+	}
+	thumbKey := okAttachment.StorageKey + ".thumb-128"
+
+	testCases := []struct {
+		name   string
+		size   int
+		setup  func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore)
+		verify func(t *testing.T, got *entities.AttachmentContent, gotErr error)
+	}{
+		{
+			name: "stored thumbnail",
+			size: 128,
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+				blobs.EXPECT().Get(ctx, thumbKey).Return(io.NopCloser(strings.NewReader("thumb")), nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, gotErr error) {
+				assert.NoError(t, gotErr)
+				body, _ := io.ReadAll(got.Body)
+				assert.Equal(t, "thumb", string(body))
+				assert.Equal(t, "image/png", got.ContentType)
+				assert.Equal(t, int64(-1), got.Length)
+				assert.Equal(t, `"`+attachmentId+`-128"`, got.ETag)
+			},
+		},
+		{
+			name: "made from the original and stored",
+			size: 128,
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+				blobs.EXPECT().Get(ctx, thumbKey).Return(nil, utils.ErrBlobNotFound)
+				blobs.EXPECT().Get(ctx, okAttachment.StorageKey).Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+				blobs.EXPECT().Put(ctx, thumbKey, mock.Anything, mock.Anything, "image/png").Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, gotErr error) {
+				assert.NoError(t, gotErr)
+				body, _ := io.ReadAll(got.Body)
+				assert.Equal(t, int64(len(body)), got.Length)
+				config, err := png.DecodeConfig(bytes.NewReader(body))
+				assert.NoError(t, err)
+				assert.Equal(t, 128, config.Width)
+				assert.Equal(t, 64, config.Height)
+			},
+		},
+		{
+			name: "not an image",
+			size: 128,
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(&models.Attachment{
+					ID: okAttachment.ID, TaskID: okTask.ID, ContentType: "application/pdf",
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, gotErr error) {
+				assert.Equal(t, constants.ErrThumbnailNotAvailable, gotErr)
+			},
+		},
+		{
+			name: "original cannot be decoded",
+			size: 512,
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+				blobs.EXPECT().Get(ctx, okAttachment.StorageKey+".thumb-512").Return(nil, utils.ErrBlobNotFound)
+				blobs.EXPECT().Get(ctx, okAttachment.StorageKey).Return(io.NopCloser(bytes.NewReader(pngHeader)), nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, gotErr error) {
+				assert.Equal(t, constants.ErrThumbnailNotAvailable, gotErr)
+			},
+		},
+		{
+			name: "unsupported size",
+			size: 64,
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentContent, gotErr error) {
+				assert.Equal(t, constants.ErrInvalidRequestParam, gotErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIAttachmentRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockBlobs := mocks.NewMockIBlobStore(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil).Maybe()
+			tc.setup(mockRepo, mockTaskRepo, mockBlobs)
+
+			svc := NewAttachmentService(mockRepo, mockTaskRepo, mockBlobs, testSigner, lgr, mockPayload, nil)
+			got, err := svc.GetAttachmentThumbnail(ctx, taskId, attachmentId, tc.size)
+
+			tc.verify(t, got, err)
+		})
+	}
+}
+
+// signedGrant reads the grant out of a signed link
+func signedGrant(t *testing.T, link string) *entities.SignedURLRequest {
+	u, err := url.Parse(link)
+	assert.NoError(t, err)
+	expires, err := strconv.ParseInt(u.Query().Get("exp"), 10, 64)
+	assert.NoError(t, err)
+
+	return &entities.SignedURLRequest{
+		UserID:     u.Query().Get("uid"),
+		Permission: u.Query().Get("perm"),
+		Expires:    expires,
+		Signature:  u.Query().Get("sig"),
+	}
+}
+
+func TestAttachmentService_GetSignedAttachment(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	attachmentId := "550e8400-e29b-41d4-a716-446655440001"
+
+	okAttachment := &models.Attachment{
+		ID:          uuid.MustParse(attachmentId),
+		TaskID:      uuid.MustParse(taskId),
+		StorageKey:  "tasks/" + taskId + "/" + attachmentId,
+		Filename:    "cat.png",
+		ContentType: "image/png",
+		Size:        int64(len(testPNG)),
+	}
+	links := toAttachmentResponse(ctx, testSigner, "1", okAttachment)
+
+	t.Run("content", func(t *testing.T) {
+		mockRepo := mocks.NewMockIAttachmentRepository(t)
+		mockBlobs := mocks.NewMockIBlobStore(t)
+		mockRepo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+		mockBlobs.EXPECT().Get(ctx, okAttachment.StorageKey).Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+
+		// No auth payload or task lookup: the link is the permission
+		svc := NewAttachmentService(mockRepo, nil, mockBlobs, testSigner, lgr, nil, nil)
+		got, err := svc.GetSignedAttachmentContent(ctx, taskId, attachmentId, signedGrant(t, links.URL), &entities.GetAttachmentContentRequest{})
+
+		assert.NoError(t, err)
+		body, _ := io.ReadAll(got.Body)
+		assert.Equal(t, testPNG, body)
+	})
+
+	t.Run("thumbnail", func(t *testing.T) {
+		mockRepo := mocks.NewMockIAttachmentRepository(t)
+		mockBlobs := mocks.NewMockIBlobStore(t)
+		mockRepo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(okAttachment, nil)
+		mockBlobs.EXPECT().Get(ctx, okAttachment.StorageKey+".thumb-512").Return(io.NopCloser(strings.NewReader("thumb")), nil)
+
+		svc := NewAttachmentService(mockRepo, nil, mockBlobs, testSigner, lgr, nil, nil)
+		got, err := svc.GetSignedAttachmentThumbnail(ctx, taskId, attachmentId, 512, signedGrant(t, links.Thumbnails["512"]))
+
+		assert.NoError(t, err)
+		assert.Equal(t, "image/png", got.ContentType)
+	})
+
+	t.Run("rejected links", func(t *testing.T) {
+		svc := NewAttachmentService(nil, nil, nil, testSigner, lgr, nil, nil)
+
+		// Signed for another attachment
+		_, err := svc.GetSignedAttachmentContent(ctx, taskId, "550e8400-e29b-41d4-a716-446655440002", signedGrant(t, links.URL), &entities.GetAttachmentContentRequest{})
+		assert.Equal(t, constants.ErrInvalidSignedURL, err)
+
+		// Signed for another thumbnail size
+		_, err = svc.GetSignedAttachmentThumbnail(ctx, taskId, attachmentId, 128, signedGrant(t, links.Thumbnails["512"]))
+		assert.Equal(t, constants.ErrInvalidSignedURL, err)
+
+		// A content link does not open a thumbnail
+		_, err = svc.GetSignedAttachmentThumbnail(ctx, taskId, attachmentId, 512, signedGrant(t, links.URL))
+		assert.Equal(t, constants.ErrInvalidSignedURL, err)
+
+		// A link whose grant was edited
+		grant := signedGrant(t, links.URL)
+		grant.UserID = "2"
+		_, err = svc.GetSignedAttachmentContent(ctx, taskId, attachmentId, grant, &entities.GetAttachmentContentRequest{})
+		assert.Equal(t, constants.ErrInvalidSignedURL, err)
+	})
+}
+
+func TestAttachmentService_MoveLegacyImages(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	imageTask := uuid.New()
+	emptyTask := uuid.New()
+
+	mockRepo := mocks.NewMockIAttachmentRepository(t)
+	mockBlobs := mocks.NewMockIBlobStore(t)
+
+	// One batch with an image too broken to sanitize, kept as it is, and an
+	// empty one, then nothing left
+	mockRepo.EXPECT().GetLegacyImages(ctx, legacyImageBatchSize).Return(&[]repositories.LegacyTaskImage{
+		{ID: imageTask, UserID: "1", Image: base64.StdEncoding.EncodeToString(pngHeader)},
+		{ID: emptyTask, UserID: "1", Image: ""},
+	}, nil).Once()
+	mockRepo.EXPECT().GetLegacyImages(ctx, legacyImageBatchSize).Return(&[]repositories.LegacyTaskImage{}, nil).Once()
+
+	var stored []byte
+	mockBlobs.EXPECT().
+		Put(ctx, mock.MatchedBy(func(key string) bool {
+			return strings.HasPrefix(key, "tasks/"+imageTask.String()+"/")
+		}), mock.Anything, int64(len(pngHeader)), "image/png").
+		RunAndReturn(func(_ context.Context, _ string, body io.Reader, _ int64, _ string) error {
+			stored, _ = io.ReadAll(body)
+			return nil
+		})
+	mockRepo.EXPECT().
+		MoveLegacyImage(ctx, imageTask, mock.MatchedBy(func(a *models.Attachment) bool {
+			return a.TaskID == imageTask && a.UserID == "1" && a.ContentType == "image/png" && a.Size == int64(len(pngHeader))
+		})).
+		Return(nil)
+	mockRepo.EXPECT().MoveLegacyImage(ctx, emptyTask, (*models.Attachment)(nil)).Return(nil)
+
+	svc := NewAttachmentService(mockRepo, nil, mockBlobs, testSigner, lgr, nil, nil)
+	moved, err := svc.MoveLegacyImages(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, moved)
+	assert.Equal(t, pngHeader, stored)
+}
+
+func TestAttachmentService_MoveLegacyImages_RemovesBlobOnFailure(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	errMockError := errors.New("mock error")
+	taskId := uuid.New()
+
+	mockRepo := mocks.NewMockIAttachmentRepository(t)
+	mockBlobs := mocks.NewMockIBlobStore(t)
+
+	var key string
+	mockRepo.EXPECT().GetLegacyImages(ctx, legacyImageBatchSize).Return(&[]repositories.LegacyTaskImage{
+		{ID: taskId, UserID: "1", Image: base64.StdEncoding.EncodeToString(pngHeader)},
+	}, nil)
+	mockBlobs.EXPECT().Put(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, k string, _ io.Reader, _ int64, _ string) error {
+			key = k
+			return nil
+		})
+	mockRepo.EXPECT().MoveLegacyImage(ctx, taskId, mock.Anything).Return(errMockError)
+	// The blob goes, along with any thumbnails of it
+	mockBlobs.EXPECT().Delete(ctx, mock.MatchedBy(func(k string) bool { return strings.HasPrefix(k, key) })).Return(nil).Times(3)
+
+	svc := NewAttachmentService(mockRepo, nil, mockBlobs, testSigner, lgr, nil, nil)
+	moved, err := svc.MoveLegacyImages(ctx)
+
+	assert.Equal(t, errMockError, err)
+	assert.Equal(t, 0, moved)
+}
+
+func TestAttachmentService_UploadAttachment(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	errMockError := errors.New("mock error")
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	content := []byte("%PDF-1.7 quarterly report")
+	cfg := &config.Config{Storage: config.Storage{UserQuota: 1024}}
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+	okTask := &models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}
+	newRequest := func() *entities.UploadAttachmentRequest {
+		return &entities.UploadAttachmentRequest{Filename: `C:\reports\q3.pdf`, Body: bytes.NewReader(content), Size: int64(len(content))}
+	}
+
+	testCases := []struct {
+		name   string
+		setup  func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore)
+		verify func(t *testing.T, got *entities.AttachmentResponse, gotErr error)
+	}{
+		{
+			name: "UploadAttachment_OK",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(1024-len(content)), nil)
+				blobs.EXPECT().
+					Put(ctx, mock.Anything, mock.Anything, int64(len(content)), "application/pdf").
+					RunAndReturn(func(_ context.Context, _ string, body io.Reader, _ int64, _ string) error {
+						got, _ := io.ReadAll(body)
+						assert.Equal(t, content, got)
+						return nil
+					})
+				repo.EXPECT().
+					CreateAttachment(ctx, mock.MatchedBy(func(a *models.Attachment) bool {
+						return a.Filename == "q3.pdf" && a.UserID == okPayload.UserId && a.Size == int64(len(content))
+					}), int64(1024)).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "q3.pdf", got.Filename)
+				assert.Equal(t, "application/pdf", got.ContentType)
+				assert.True(t, strings.HasPrefix(got.URL, "/api/v1/files/tasks/"+taskId+"/attachments/"+got.ID+"/content?"))
+			},
+		},
+		{
+			name: "UploadAttachment_QuotaExceeded",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(1024-len(content)+1), nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrStorageQuotaExceeded, gotErr)
+			},
+		},
+		{
+			// Another upload of the user finished after the first check
+			name: "UploadAttachment_QuotaExceededOnSave",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(0), nil)
+				var key string
+				blobs.EXPECT().Put(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, k string, _ io.Reader, _ int64, _ string) error {
+						key = k
+						return nil
+					})
+				repo.EXPECT().CreateAttachment(ctx, mock.Anything, int64(1024)).Return(constants.ErrStorageQuotaExceeded)
+				blobs.EXPECT().Delete(ctx, mock.MatchedBy(func(k string) bool { return k == key })).Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrStorageQuotaExceeded, gotErr)
+			},
+		},
+		{
+			name: "UploadAttachment_CreateErrorRemovesBlob",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(okTask, nil)
+				repo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(0), nil)
+				var key string
+				blobs.EXPECT().Put(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+					RunAndReturn(func(_ context.Context, k string, _ io.Reader, _ int64, _ string) error {
+						key = k
+						return nil
+					})
+				repo.EXPECT().CreateAttachment(ctx, mock.Anything, int64(1024)).Return(errMockError)
+				blobs.EXPECT().Delete(ctx, mock.MatchedBy(func(k string) bool { return k == key })).Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.AttachmentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, errMockError, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIAttachmentRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockBlobs := mocks.NewMockIBlobStore(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tC.setup(mockRepo, mockTaskRepo, mockBlobs)
+
+			svc := NewAttachmentService(mockRepo, mockTaskRepo, mockBlobs, testSigner, lgr, mockPayload, cfg)
+			got, gotErr := svc.UploadAttachment(ctx, taskId, newRequest())
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestStoreFile_AllowedTypes(t *testing.T) {
+	ctx := context.Background()
+	taskId := uuid.MustParse("550e8400-e29b-41d4-a716-446655440000")
+
+	testCases := []struct {
+		name    string
+		content []byte
+		allowed []string
+		want    error
+	}{
+		{name: "PDF", content: []byte("%PDF-1.7 report"), allowed: defaultAllowedFileTypes},
+		{name: "TextWithCharset", content: []byte("meeting notes"), allowed: defaultAllowedFileTypes},
+		{name: "HTML", content: []byte("<html><script>alert(1)</script></html>"), allowed: defaultAllowedFileTypes, want: constants.ErrUnsupportedMediaType},
+		{name: "UnknownBinary", content: []byte{0x00, 0x01, 0x02, 0xff}, allowed: defaultAllowedFileTypes, want: constants.ErrUnsupportedMediaType},
+		{name: "NotInConfiguredList", content: []byte("%PDF-1.7 report"), allowed: []string{"image/png"}, want: constants.ErrUnsupportedMediaType},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			// A refused file is never uploaded
+			mockBlobs := mocks.NewMockIBlobStore(t)
+			if tC.want == nil {
+				mockBlobs.EXPECT().Put(ctx, mock.Anything, mock.Anything, int64(len(tC.content)), mock.Anything).Return(nil)
+			}
+
+			_, err := storeFile(ctx, mockBlobs, tC.allowed, taskId, "1", "file", bytes.NewReader(tC.content), int64(len(tC.content)))
+
+			assert.Equal(t, tC.want, err)
+		})
+	}
+}
+
+func TestAttachmentService_DeleteAttachment(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	attachmentId := "550e8400-e29b-41d4-a716-446655440001"
+	storageKey := "tasks/" + taskId + "/" + attachmentId
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+	attachmentBy := func(userId string) *models.Attachment {
+		return &models.Attachment{ID: uuid.MustParse(attachmentId), TaskID: uuid.MustParse(taskId), UserID: userId, StorageKey: storageKey}
+	}
+
+	testCases := []struct {
+		name   string
+		setup  func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore)
+		verify func(t *testing.T, gotErr error)
+	}{
+		{
+			name: "DeleteAttachment_ByUploader",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: "2"}, nil)
+				taskRepo.EXPECT().GetSharePermission(ctx, taskId, okPayload.UserId).Return(string(constants.TaskPermissionEditor), nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(attachmentBy(okPayload.UserId), nil)
+				repo.EXPECT().DeleteAttachment(ctx, attachmentId).Return(nil)
+				blobs.EXPECT().Delete(ctx, storageKey).Return(nil)
+			},
+			verify: func(t *testing.T, gotErr error) {
+				assert.NoError(t, gotErr)
+			},
+		},
+		{
+			name: "DeleteAttachment_NotUploaderOrOwner",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: "2"}, nil)
+				taskRepo.EXPECT().GetSharePermission(ctx, taskId, okPayload.UserId).Return(string(constants.TaskPermissionEditor), nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(attachmentBy("3"), nil)
+			},
+			verify: func(t *testing.T, gotErr error) {
+				assert.Equal(t, constants.ErrNotAttachmentUploader, gotErr)
+			},
+		},
+		{
+			name: "DeleteAttachment_TaskImageIsRemovedFromTask",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				imageId := attachmentId
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId, ImageID: &imageId, Version: 2}, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(attachmentBy("3"), nil)
+				taskRepo.EXPECT().
+					UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+						return task.ImageID == nil
+					}), mock.MatchedBy(func(history *models.TaskHistory) bool {
+						return len(history.Changes) == 1 && history.Changes[0].Field == "image" && history.Changes[0].After == nil
+					})).
+					Return(nil)
+				repo.EXPECT().DeleteAttachment(ctx, attachmentId).Return(nil)
+				blobs.EXPECT().Delete(ctx, storageKey).Return(nil)
+			},
+			verify: func(t *testing.T, gotErr error) {
+				assert.NoError(t, gotErr)
+			},
+		},
+		{
+			name: "DeleteAttachment_NotFound",
+			setup: func(repo *mocks.MockIAttachmentRepository, taskRepo *mocks.MockITaskRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}, nil)
+				repo.EXPECT().GetAttachment(ctx, taskId, attachmentId).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, gotErr error) {
+				assert.Equal(t, constants.ErrAttachmentNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIAttachmentRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockBlobs := mocks.NewMockIBlobStore(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tC.setup(mockRepo, mockTaskRepo, mockBlobs)
+
+			svc := NewAttachmentService(mockRepo, mockTaskRepo, mockBlobs, testSigner, lgr, mockPayload, nil)
+			tC.verify(t, svc.DeleteAttachment(ctx, taskId, attachmentId))
+		})
+	}
+}
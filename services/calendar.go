@@ -0,0 +1,251 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+// defaultCalendarRefreshInterval applies when the config does not set one
+const defaultCalendarRefreshInterval = 15 * time.Minute
+
+// calendarFeedName is the name calendar clients show for a feed
+const calendarFeedName = "Tasks"
+
+type ICalendarService interface {
+	CreateCalendarFeed(ctx context.Context) (*entities.CalendarFeedResponse, error)
+	GetCalendarFeed(ctx context.Context) (*entities.CalendarFeedResponse, error)
+	DeleteCalendarFeed(ctx context.Context) error
+	GetCalendarFeedContent(ctx context.Context, token string, req *entities.GetCalendarFeedContentRequest) (*entities.CalendarFeedContent, error)
+}
+
+type CalendarService struct {
+	repo     repositories.ICalendarFeedRepository
+	taskRepo repositories.ITaskRepository
+	userRepo repositories.IUserRepository
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+	config   *config.Config
+}
+
+func NewCalendarService(
+	repo repositories.ICalendarFeedRepository,
+	taskRepo repositories.ITaskRepository,
+	userRepo repositories.IUserRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+	config *config.Config,
+) ICalendarService {
+	return &CalendarService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+		log:      log,
+		payload:  payload,
+		config:   config,
+	}
+}
+
+// CreateCalendarFeed generates the secret token of the caller's feed and
+// returns its URL. A feed that already exists gets a new token, and its old
+// URL stops working.
+func (s *CalendarService) CreateCalendarFeed(ctx context.Context) (*entities.CalendarFeedResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: CreateCalendarFeed] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateCalendarFeed] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	token, tokenHash, err := utils.NewCalendarFeedToken()
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateCalendarFeed] Failed to generate token", err)
+		return nil, err
+	}
+
+	feed := &models.CalendarFeed{
+		UserID:    authPayload.UserId,
+		TokenHash: tokenHash,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.SaveCalendarFeed(ctx, feed); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateCalendarFeed] Failed to save calendar feed", err)
+		return nil, err
+	}
+
+	resp := toCalendarFeedResponse(ctx, feed)
+	resp.URL = calendarFeedPath(token)
+
+	s.log.DebugWithID(ctx, "[Service: CreateCalendarFeed] Calendar feed created successfully")
+	return resp, nil
+}
+
+func (s *CalendarService) GetCalendarFeed(ctx context.Context) (*entities.CalendarFeedResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetCalendarFeed] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetCalendarFeed] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	feed, err := s.repo.GetCalendarFeed(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetCalendarFeed] Failed to get calendar feed", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrCalendarFeedNotFound
+		}
+		return nil, err
+	}
+
+	resp := toCalendarFeedResponse(ctx, feed)
+	s.log.DebugWithID(ctx, "[Service: GetCalendarFeed] Calendar feed retrieved successfully", resp)
+	return resp, nil
+}
+
+// DeleteCalendarFeed turns the caller's feed off; its URL stops working
+func (s *CalendarService) DeleteCalendarFeed(ctx context.Context) error {
+	s.log.DebugWithID(ctx, "[Service: DeleteCalendarFeed] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteCalendarFeed] Failed to get auth payload", err)
+		return err
+	}
+
+	deleted, err := s.repo.DeleteCalendarFeed(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteCalendarFeed] Failed to delete calendar feed", err)
+		return err
+	}
+	if deleted == 0 {
+		s.log.ErrorWithID(ctx, "[Service: DeleteCalendarFeed] Calendar feed not found", constants.ErrCalendarFeedNotFound)
+		return constants.ErrCalendarFeedNotFound
+	}
+
+	s.log.DebugWithID(ctx, "[Service: DeleteCalendarFeed] Calendar feed deleted successfully")
+	return nil
+}
+
+// GetCalendarFeedContent renders the calendar of the feed the token belongs
+// to: the personal tasks of its user that are not archived, by date. The
+// token stands in for an access token, as calendar clients cannot send one.
+func (s *CalendarService) GetCalendarFeedContent(ctx context.Context, token string, req *entities.GetCalendarFeedContentRequest) (*entities.CalendarFeedContent, error) {
+	s.log.DebugWithID(ctx, "[Service: GetCalendarFeedContent] Called")
+
+	feed, err := s.repo.GetCalendarFeedByToken(ctx, utils.HashCalendarFeedToken(token))
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetCalendarFeedContent] Failed to get calendar feed", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrCalendarFeedNotFound
+		}
+		return nil, err
+	}
+	userId := feed.UserID
+
+	component := req.Type
+	if component == "" {
+		component = constants.CalendarComponentEvent
+	}
+
+	// Calendar clients send no time zone, so timed tasks are shown in the
+	// zone the user picked
+	user, err := s.userRepo.GetUserByID(ctx, userId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetCalendarFeedContent] Failed to get user", err)
+		return nil, err
+	}
+	loc := userLocation(user)
+	ctx = utils.WithLocation(ctx, loc)
+
+	listing := &entities.GetAllTasksRequest{
+		SortBy: "date",
+		Order:  "asc",
+		Limit:  constants.ExportBatchSize,
+	}
+	filter, err := prepareTaskListing(listing, userId)
+	if err != nil {
+		return nil, err
+	}
+
+	refresh := s.refreshInterval()
+	var body bytes.Buffer
+	writer, err := utils.NewICalTaskWriter(&body, utils.ICalTaskOptions{
+		Component:       component,
+		Name:            calendarFeedName,
+		Location:        loc,
+		RefreshInterval: refresh,
+		Stamp:           time.Now(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are stamped with the time the feed is generated, so the ETag
+	// is taken from what it shows instead: how it is laid out and which
+	// version of each task is in it. Clients can then poll with
+	// If-None-Match.
+	etag := sha256.New()
+	fmt.Fprintf(etag, "%s %s %s\n", component, loc, refresh)
+	err = eachTaskBatch(ctx, s.taskRepo, listing, filter, userId, func(tasks []models.Task) error {
+		for i := range tasks {
+			fmt.Fprintf(etag, "%s %d %d\n", tasks[i].ID, tasks[i].Version, tasks[i].UpdatedAt.UnixNano())
+			if err := writer.WriteTask(toExportTask(ctx, &tasks[i])); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetCalendarFeedContent] Failed to render tasks", err)
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	content := &entities.CalendarFeedContent{
+		Body:   body.Bytes(),
+		ETag:   `"` + hex.EncodeToString(etag.Sum(nil)[:16]) + `"`,
+		MaxAge: refresh,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetCalendarFeedContent] Calendar feed rendered", content.ETag)
+	return content, nil
+}
+
+// refreshInterval is how often calendar clients are asked to poll a feed
+func (s *CalendarService) refreshInterval() time.Duration {
+	if s.config != nil && s.config.Calendar.RefreshInterval > 0 {
+		return s.config.Calendar.RefreshInterval
+	}
+	return defaultCalendarRefreshInterval
+}
+
+// calendarFeedPath is where calendar clients subscribe to a feed
+func calendarFeedPath(token string) string {
+	return "/api/v1/calendar/feeds/" + token + ".ics"
+}
+
+func toCalendarFeedResponse(ctx context.Context, feed *models.CalendarFeed) *entities.CalendarFeedResponse {
+	return &entities.CalendarFeedResponse{
+		CreatedAt: utils.FormatRFC3339(ctx, feed.CreatedAt),
+	}
+}
@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCalendarService_CreateCalendarFeed(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+
+	mockRepo := mocks.NewMockICalendarFeedRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+	var saved *models.CalendarFeed
+	mockRepo.EXPECT().SaveCalendarFeed(ctx, mock.Anything).RunAndReturn(func(_ context.Context, feed *models.CalendarFeed) error {
+		saved = feed
+		return nil
+	}).Twice()
+
+	svc := NewCalendarService(mockRepo, mocks.NewMockITaskRepository(t), mocks.NewMockIUserRepository(t), lgr, mockPayload, nil)
+	got, err := svc.CreateCalendarFeed(ctx)
+	require.NoError(t, err)
+
+	// Only the hash of the token in the URL is stored
+	require.NotNil(t, saved)
+	assert.Equal(t, okPayload.UserId, saved.UserID)
+	require.True(t, strings.HasPrefix(got.URL, "/api/v1/calendar/feeds/"))
+	require.True(t, strings.HasSuffix(got.URL, ".ics"))
+	token := strings.TrimSuffix(strings.TrimPrefix(got.URL, "/api/v1/calendar/feeds/"), ".ics")
+	assert.Equal(t, utils.HashCalendarFeedToken(token), saved.TokenHash)
+
+	// Regenerating gives a new secret
+	firstHash := saved.TokenHash
+	again, err := svc.CreateCalendarFeed(ctx)
+	require.NoError(t, err)
+	assert.NotEqual(t, got.URL, again.URL)
+	assert.NotEqual(t, firstHash, saved.TokenHash)
+}
+
+func TestCalendarService_GetAndDeleteCalendarFeed(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+
+	t.Run("GetCalendarFeed_NotFound", func(t *testing.T) {
+		mockRepo := mocks.NewMockICalendarFeedRepository(t)
+		mockPayload := mocks.NewMockIPayloadConstruct(t)
+		mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+		mockRepo.EXPECT().GetCalendarFeed(ctx, okPayload.UserId).Return(nil, gorm.ErrRecordNotFound)
+
+		svc := NewCalendarService(mockRepo, mocks.NewMockITaskRepository(t), mocks.NewMockIUserRepository(t), lgr, mockPayload, nil)
+		_, err := svc.GetCalendarFeed(ctx)
+		assert.ErrorIs(t, err, constants.ErrCalendarFeedNotFound)
+	})
+
+	t.Run("GetCalendarFeed_WithoutURL", func(t *testing.T) {
+		mockRepo := mocks.NewMockICalendarFeedRepository(t)
+		mockPayload := mocks.NewMockIPayloadConstruct(t)
+		mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+		mockRepo.EXPECT().GetCalendarFeed(ctx, okPayload.UserId).Return(&models.CalendarFeed{UserID: okPayload.UserId, TokenHash: "hash", CreatedAt: time.Now()}, nil)
+
+		svc := NewCalendarService(mockRepo, mocks.NewMockITaskRepository(t), mocks.NewMockIUserRepository(t), lgr, mockPayload, nil)
+		got, err := svc.GetCalendarFeed(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, got.URL)
+		assert.NotEmpty(t, got.CreatedAt)
+	})
+
+	t.Run("DeleteCalendarFeed_NotFound", func(t *testing.T) {
+		mockRepo := mocks.NewMockICalendarFeedRepository(t)
+		mockPayload := mocks.NewMockIPayloadConstruct(t)
+		mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+		mockRepo.EXPECT().DeleteCalendarFeed(ctx, okPayload.UserId).Return(0, nil)
+
+		svc := NewCalendarService(mockRepo, mocks.NewMockITaskRepository(t), mocks.NewMockIUserRepository(t), lgr, mockPayload, nil)
+		assert.ErrorIs(t, svc.DeleteCalendarFeed(ctx), constants.ErrCalendarFeedNotFound)
+	})
+}
+
+func TestCalendarService_GetCalendarFeedContent(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	token := "q4Jm3X1cVQ2h0k8P9yT7wB5nR6sLdZfA1eGuHiOjKlM"
+	feed := &models.CalendarFeed{UserID: "1", TokenHash: utils.HashCalendarFeedToken(token), CreatedAt: time.Now()}
+	cfg := &config.Config{Calendar: config.Calendar{RefreshInterval: time.Hour}}
+	berlin := "Europe/Berlin"
+	user := &models.User{ID: uuid.New(), Timezone: &berlin}
+
+	tasks := []models.Task{
+		{
+			ID:        uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+			UserID:    "1",
+			Title:     "Release",
+			Status:    string(constants.TaskStatusPending),
+			Date:      time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			AllDay:    true,
+			Version:   1,
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			ID:        uuid.MustParse("550e8400-e29b-41d4-a716-446655440001"),
+			UserID:    "1",
+			Title:     "Review",
+			Status:    string(constants.TaskStatusPending),
+			Date:      time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC),
+			Version:   2,
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			UpdatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC),
+		},
+	}
+
+	testCases := []struct {
+		name   string
+		token  string
+		req    entities.GetCalendarFeedContentRequest
+		setup  func(repo *mocks.MockICalendarFeedRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository)
+		verify func(t *testing.T, got *entities.CalendarFeedContent, err error)
+	}{
+		{
+			name:  "GetCalendarFeedContent_Events",
+			token: token,
+			setup: func(repo *mocks.MockICalendarFeedRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetCalendarFeedByToken(ctx, feed.TokenHash).Return(feed, nil)
+				userRepo.EXPECT().GetUserByID(ctx, feed.UserID).Return(user, nil)
+				isListing := mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+					return req.SortBy == "date" && req.Order == "asc" && !req.Archived
+				})
+				taskRepo.EXPECT().GetAllTasks(mock.Anything, isListing, mock.Anything, (*utils.Cursor)(nil), feed.UserID).Return(&tasks, nil)
+			},
+			verify: func(t *testing.T, got *entities.CalendarFeedContent, err error) {
+				require.NoError(t, err)
+				body := string(got.Body)
+				assert.Contains(t, body, "BEGIN:VEVENT\r\nUID:550e8400-e29b-41d4-a716-446655440000\r\n")
+				assert.Contains(t, body, "DTSTART;VALUE=DATE:20240105\r\n")
+				assert.Contains(t, body, "DTSTART:20240105T093000Z\r\n")
+				assert.Contains(t, body, "SEQUENCE:2\r\nLAST-MODIFIED:20240103T000000Z\r\n")
+				// Calendar clients send no zone, so the feed names the user's
+				assert.Contains(t, body, "X-WR-TIMEZONE:Europe/Berlin\r\n")
+				assert.Contains(t, body, "REFRESH-INTERVAL;VALUE=DURATION:PT1H\r\n")
+				assert.Equal(t, time.Hour, got.MaxAge)
+				assert.NotEmpty(t, got.ETag)
+			},
+		},
+		{
+			name:  "GetCalendarFeedContent_Todos",
+			token: token,
+			req:   entities.GetCalendarFeedContentRequest{Type: constants.CalendarComponentTodo},
+			setup: func(repo *mocks.MockICalendarFeedRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetCalendarFeedByToken(ctx, feed.TokenHash).Return(feed, nil)
+				userRepo.EXPECT().GetUserByID(ctx, feed.UserID).Return(&models.User{ID: uuid.New()}, nil)
+				taskRepo.EXPECT().GetAllTasks(mock.Anything, mock.Anything, mock.Anything, (*utils.Cursor)(nil), feed.UserID).Return(&tasks, nil)
+			},
+			verify: func(t *testing.T, got *entities.CalendarFeedContent, err error) {
+				require.NoError(t, err)
+				assert.Contains(t, string(got.Body), "BEGIN:VTODO\r\n")
+				assert.Contains(t, string(got.Body), "DUE;VALUE=DATE:20240105\r\nSTATUS:NEEDS-ACTION\r\n")
+			},
+		},
+		{
+			name:  "GetCalendarFeedContent_UnknownToken",
+			token: "unknown",
+			setup: func(repo *mocks.MockICalendarFeedRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetCalendarFeedByToken(ctx, utils.HashCalendarFeedToken("unknown")).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.CalendarFeedContent, err error) {
+				assert.ErrorIs(t, err, constants.ErrCalendarFeedNotFound)
+				assert.Nil(t, got)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockICalendarFeedRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockUserRepo := mocks.NewMockIUserRepository(t)
+			tC.setup(mockRepo, mockTaskRepo, mockUserRepo)
+
+			svc := NewCalendarService(mockRepo, mockTaskRepo, mockUserRepo, lgr, mocks.NewMockIPayloadConstruct(t), cfg)
+			got, err := svc.GetCalendarFeedContent(ctx, tC.token, &tC.req)
+			tC.verify(t, got, err)
+		})
+	}
+
+	t.Run("GetCalendarFeedContent_StableETag", func(t *testing.T) {
+		mockRepo := mocks.NewMockICalendarFeedRepository(t)
+		mockTaskRepo := mocks.NewMockITaskRepository(t)
+		mockUserRepo := mocks.NewMockIUserRepository(t)
+		mockRepo.EXPECT().GetCalendarFeedByToken(ctx, feed.TokenHash).Return(feed, nil)
+		mockUserRepo.EXPECT().GetUserByID(ctx, feed.UserID).Return(user, nil)
+		edited := append([]models.Task(nil), tasks...)
+		edited[1].Version = 3
+		edited[1].UpdatedAt = time.Date(2024, 1, 4, 0, 0, 0, 0, time.UTC)
+		mockTaskRepo.EXPECT().GetAllTasks(mock.Anything, mock.Anything, mock.Anything, (*utils.Cursor)(nil), feed.UserID).Return(&tasks, nil).Times(2)
+		mockTaskRepo.EXPECT().GetAllTasks(mock.Anything, mock.Anything, mock.Anything, (*utils.Cursor)(nil), feed.UserID).Return(&edited, nil).Once()
+
+		svc := NewCalendarService(mockRepo, mockTaskRepo, mockUserRepo, lgr, mocks.NewMockIPayloadConstruct(t), cfg)
+		first, err := svc.GetCalendarFeedContent(ctx, token, &entities.GetCalendarFeedContentRequest{})
+		require.NoError(t, err)
+		second, err := svc.GetCalendarFeedContent(ctx, token, &entities.GetCalendarFeedContentRequest{})
+		require.NoError(t, err)
+		assert.Equal(t, first.ETag, second.ETag)
+
+		// An edited task is a new version of the feed
+
+		third, err := svc.GetCalendarFeedContent(ctx, token, &entities.GetCalendarFeedContentRequest{})
+		require.NoError(t, err)
+		assert.NotEqual(t, first.ETag, third.ETag)
+	})
+}
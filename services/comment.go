@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+type ICommentService interface {
+	CreateComment(ctx context.Context, taskId string, req *entities.CreateCommentRequest) (*entities.CommentResponse, error)
+	UpdateComment(ctx context.Context, taskId string, id string, req *entities.UpdateCommentRequest) (*entities.CommentResponse, error)
+	DeleteComment(ctx context.Context, taskId string, id string) error
+	GetComments(ctx context.Context, taskId string, req *entities.GetCommentsRequest) (*entities.GetCommentsResponse, error)
+}
+
+type CommentService struct {
+	repo     repositories.ICommentRepository
+	taskRepo repositories.ITaskRepository
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+}
+
+func NewCommentService(
+	repo repositories.ICommentRepository,
+	taskRepo repositories.ITaskRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) ICommentService {
+	return &CommentService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		log:      log,
+		payload:  payload,
+	}
+}
+
+func (s *CommentService) CreateComment(ctx context.Context, taskId string, req *entities.CreateCommentRequest) (*entities.CommentResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: CreateComment] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateComment] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the task
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateComment] Failed to get task", err)
+		return nil, err
+	}
+
+	// Create comment
+	now := time.Now()
+	arg := &models.Comment{
+		ID:        uuid.New(),
+		TaskID:    task.ID,
+		UserID:    authPayload.UserId,
+		Body:      req.Body,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if err := s.repo.CreateComment(ctx, arg); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateComment] Failed to create comment", err)
+		return nil, err
+	}
+
+	resp := toCommentResponse(ctx, arg)
+	s.log.DebugWithID(ctx, "[Service: CreateComment] Comment created successfully", resp)
+	return resp, nil
+}
+
+func (s *CommentService) UpdateComment(ctx context.Context, taskId string, id string, req *entities.UpdateCommentRequest) (*entities.CommentResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: UpdateComment] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateComment] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the task
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateComment] Failed to get task", err)
+		return nil, err
+	}
+
+	// Get existing comment
+	existingComment, err := s.getComment(ctx, taskId, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateComment] Failed to get comment", err)
+		return nil, err
+	}
+
+	// Only the author may edit
+	if existingComment.UserID != authPayload.UserId {
+		s.log.ErrorWithID(ctx, "[Service: UpdateComment] User is not the comment author", constants.ErrNotCommentAuthor)
+		return nil, constants.ErrNotCommentAuthor
+	}
+
+	// Update comment
+	if existingComment.Body != req.Body {
+		existingComment.Body = req.Body
+		existingComment.Edited = true
+		existingComment.UpdatedAt = time.Now()
+
+		if err := s.repo.UpdateComment(ctx, existingComment); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: UpdateComment] Failed to update comment", err)
+			return nil, err
+		}
+	}
+
+	resp := toCommentResponse(ctx, existingComment)
+	s.log.DebugWithID(ctx, "[Service: UpdateComment] Comment updated successfully", resp)
+	return resp, nil
+}
+
+func (s *CommentService) DeleteComment(ctx context.Context, taskId string, id string) error {
+	s.log.DebugWithID(ctx, "[Service: DeleteComment] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteComment] Failed to get auth payload", err)
+		return err
+	}
+
+	// Check access to the task
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteComment] Failed to get task", err)
+		return err
+	}
+
+	// Get existing comment
+	existingComment, err := s.getComment(ctx, taskId, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteComment] Failed to get comment", err)
+		return err
+	}
+
+	// The author or the task owner may delete
+	if existingComment.UserID != authPayload.UserId && task.UserID != authPayload.UserId {
+		s.log.ErrorWithID(ctx, "[Service: DeleteComment] User may not delete this comment", constants.ErrNotCommentAuthor)
+		return constants.ErrNotCommentAuthor
+	}
+
+	if err := s.repo.DeleteComment(ctx, id); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteComment] Failed to delete comment", err)
+		return err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: DeleteComment] Comment deleted successfully")
+	return nil
+}
+
+func (s *CommentService) GetComments(ctx context.Context, taskId string, req *entities.GetCommentsRequest) (*entities.GetCommentsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetComments] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetComments] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the task
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetComments] Failed to get task", err)
+		return nil, err
+	}
+
+	// Fetch comments from repository
+	offset := (req.Offset - 1) * req.Limit
+	repoComments, total, err := s.repo.GetComments(ctx, taskId, req.Limit, offset)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetComments] Failed to get comments", err)
+		return nil, err
+	}
+
+	comments := []entities.CommentResponse{}
+	for i := range *repoComments {
+		comments = append(comments, *toCommentResponse(ctx, &(*repoComments)[i]))
+	}
+
+	response := &entities.GetCommentsResponse{
+		Total:    total,
+		Comments: comments,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetComments] Comments retrieved successfully", response)
+	return response, nil
+}
+
+func (s *CommentService) getComment(ctx context.Context, taskId string, id string) (*models.Comment, error) {
+	comment, err := s.repo.GetComment(ctx, taskId, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrCommentNotFound
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+func toCommentResponse(ctx context.Context, c *models.Comment) *entities.CommentResponse {
+	return &entities.CommentResponse{
+		ID:        c.ID.String(),
+		TaskID:    c.TaskID.String(),
+		UserID:    c.UserID,
+		Body:      c.Body,
+		Edited:    c.Edited,
+		CreatedAt: utils.FormatRFC3339(ctx, c.CreatedAt),
+		UpdatedAt: utils.FormatRFC3339(ctx, c.UpdatedAt),
+	}
+}
@@ -0,0 +1,338 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCommentService_CreateComment(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	errMockError := errors.New("mock error")
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{
+		ID:        uuid.New(),
+		UserId:    "1",
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour * 24),
+	}
+
+	okRequest := &entities.CreateCommentRequest{Body: "First comment"}
+
+	testCases := []struct {
+		name   string
+		setup  func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct)
+		verify func(t *testing.T, got *entities.CommentResponse, gotErr error)
+	}{
+		{
+			name: "CreateComment_OK",
+			setup: func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockCommentRepo := new(mocks.MockICommentRepository)
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, taskId).
+					Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}, nil)
+
+				mockCommentRepo.EXPECT().
+					CreateComment(ctx, mock.MatchedBy(func(c *models.Comment) bool {
+						return c.Body == okRequest.Body && c.UserID == okPayload.UserId && !c.Edited
+					})).
+					Return(nil)
+
+				return mockCommentRepo, mockTaskRepo, mockPayload
+			},
+			verify: func(t *testing.T, got *entities.CommentResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, taskId, got.TaskID)
+				assert.Equal(t, okRequest.Body, got.Body)
+				assert.False(t, got.Edited)
+			},
+		},
+		{
+			name: "CreateComment_TaskNotFound",
+			setup: func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockCommentRepo := new(mocks.MockICommentRepository)
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, taskId).
+					Return(nil, gorm.ErrRecordNotFound)
+
+				return mockCommentRepo, mockTaskRepo, mockPayload
+			},
+			verify: func(t *testing.T, got *entities.CommentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrTaskNotFound, gotErr)
+			},
+		},
+		{
+			name: "CreateComment_NotMatchUserID",
+			setup: func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockCommentRepo := new(mocks.MockICommentRepository)
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, taskId).
+					Return(&models.Task{ID: uuid.MustParse(taskId), UserID: "2"}, nil)
+
+				mockTaskRepo.EXPECT().
+					GetSharePermission(ctx, taskId, okPayload.UserId).
+					Return("", nil)
+
+				return mockCommentRepo, mockTaskRepo, mockPayload
+			},
+			verify: func(t *testing.T, got *entities.CommentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrUserIdDoesNotMatchWithYourAccount, gotErr)
+			},
+		},
+		{
+			name: "CreateComment_ReturnError",
+			setup: func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockCommentRepo := new(mocks.MockICommentRepository)
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, taskId).
+					Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}, nil)
+
+				mockCommentRepo.EXPECT().
+					CreateComment(ctx, mock.Anything).
+					Return(errMockError)
+
+				return mockCommentRepo, mockTaskRepo, mockPayload
+			},
+			verify: func(t *testing.T, got *entities.CommentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Error(t, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockCommentRepo, mockTaskRepo, mockPayload := tC.setup()
+			defer mockCommentRepo.AssertExpectations(t)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			svc := NewCommentService(mockCommentRepo, mockTaskRepo, lgr, mockPayload)
+
+			got, gotErr := svc.CreateComment(ctx, taskId, okRequest)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestCommentService_UpdateComment(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	commentId := "660e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{
+		ID:        uuid.New(),
+		UserId:    "1",
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour * 24),
+	}
+
+	okRequest := &entities.UpdateCommentRequest{Body: "Edited comment"}
+
+	testCases := []struct {
+		name   string
+		setup  func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct)
+		verify func(t *testing.T, got *entities.CommentResponse, gotErr error)
+	}{
+		{
+			name: "UpdateComment_OK",
+			setup: func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockCommentRepo := new(mocks.MockICommentRepository)
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, taskId).
+					Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}, nil)
+
+				mockCommentRepo.EXPECT().
+					GetComment(ctx, taskId, commentId).
+					Return(&models.Comment{
+						ID:     uuid.MustParse(commentId),
+						TaskID: uuid.MustParse(taskId),
+						UserID: okPayload.UserId,
+						Body:   "Original comment",
+					}, nil)
+
+				mockCommentRepo.EXPECT().
+					UpdateComment(ctx, mock.MatchedBy(func(c *models.Comment) bool {
+						return c.Body == okRequest.Body && c.Edited
+					})).
+					Return(nil)
+
+				return mockCommentRepo, mockTaskRepo, mockPayload
+			},
+			verify: func(t *testing.T, got *entities.CommentResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, okRequest.Body, got.Body)
+				assert.True(t, got.Edited)
+			},
+		},
+		{
+			name: "UpdateComment_NotAuthor",
+			setup: func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockCommentRepo := new(mocks.MockICommentRepository)
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, taskId).
+					Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}, nil)
+
+				mockCommentRepo.EXPECT().
+					GetComment(ctx, taskId, commentId).
+					Return(&models.Comment{
+						ID:     uuid.MustParse(commentId),
+						TaskID: uuid.MustParse(taskId),
+						UserID: "2",
+						Body:   "Original comment",
+					}, nil)
+
+				return mockCommentRepo, mockTaskRepo, mockPayload
+			},
+			verify: func(t *testing.T, got *entities.CommentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrNotCommentAuthor, gotErr)
+			},
+		},
+		{
+			name: "UpdateComment_CommentNotFound",
+			setup: func() (*mocks.MockICommentRepository, *mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockCommentRepo := new(mocks.MockICommentRepository)
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, taskId).
+					Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}, nil)
+
+				mockCommentRepo.EXPECT().
+					GetComment(ctx, taskId, commentId).
+					Return(nil, gorm.ErrRecordNotFound)
+
+				return mockCommentRepo, mockTaskRepo, mockPayload
+			},
+			verify: func(t *testing.T, got *entities.CommentResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrCommentNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockCommentRepo, mockTaskRepo, mockPayload := tC.setup()
+			defer mockCommentRepo.AssertExpectations(t)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			svc := NewCommentService(mockCommentRepo, mockTaskRepo, lgr, mockPayload)
+
+			got, gotErr := svc.UpdateComment(ctx, taskId, commentId, okRequest)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestCommentService_GetComments(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{
+		ID:        uuid.New(),
+		UserId:    "1",
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour * 24),
+	}
+
+	mockCommentRepo := new(mocks.MockICommentRepository)
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockCommentRepo.AssertExpectations(t)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().
+		GetAuthPayload(ctx, mock.Anything).
+		Return(okPayload, nil)
+
+	mockTaskRepo.EXPECT().
+		GetTask(ctx, taskId).
+		Return(&models.Task{ID: uuid.MustParse(taskId), UserID: okPayload.UserId}, nil)
+
+	// Page 3 of 10 maps to offset 20
+	mockCommentRepo.EXPECT().
+		GetComments(ctx, taskId, 10, 20).
+		Return(&[]models.Comment{
+			{ID: uuid.New(), TaskID: uuid.MustParse(taskId), UserID: "1", Body: "Comment 21"},
+		}, int64(21), nil)
+
+	svc := NewCommentService(mockCommentRepo, mockTaskRepo, lgr, mockPayload)
+
+	got, gotErr := svc.GetComments(ctx, taskId, &entities.GetCommentsRequest{Limit: 10, Offset: 3})
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, int64(21), got.Total)
+	assert.Len(t, got.Comments, 1)
+	assert.Equal(t, "Comment 21", got.Comments[0].Body)
+}
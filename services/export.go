@@ -0,0 +1,275 @@
+package services
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"io"
+	"path"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type IExportService interface {
+	ExportTasks(ctx context.Context, req *entities.ExportTasksRequest) (*entities.TaskExport, error)
+}
+
+type ExportService struct {
+	taskRepo       repositories.ITaskRepository
+	attachmentRepo repositories.IAttachmentRepository
+	blobs          utils.IBlobStore
+	signer         utils.IURLSigner
+	log            *log.Logger
+	payload        utils.IPayloadConstruct
+}
+
+func NewExportService(
+	taskRepo repositories.ITaskRepository,
+	attachmentRepo repositories.IAttachmentRepository,
+	blobs utils.IBlobStore,
+	signer utils.IURLSigner,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) IExportService {
+	return &ExportService{
+		taskRepo:       taskRepo,
+		attachmentRepo: attachmentRepo,
+		blobs:          blobs,
+		signer:         signer,
+		log:            log,
+		payload:        payload,
+	}
+}
+
+// exportImage is an image to copy into a ZIP bundle once the task document
+// is written; zip entries cannot be interleaved
+type exportImage struct {
+	storageKey string
+	path       string
+}
+
+// ExportTasks prepares an export of the tasks the listing request matches.
+// Nothing is read until the export is written, and then only a batch of
+// tasks at a time. With embedded images the export is a ZIP bundle of the
+// task document and the images it refers to.
+func (s *ExportService) ExportTasks(ctx context.Context, req *entities.ExportTasksRequest) (*entities.TaskExport, error) {
+	s.log.DebugWithID(ctx, "[Service: ExportTasks] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ExportTasks] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Apply default values and parse the filter
+	listing := &entities.GetAllTasksRequest{
+		Search:   req.Search,
+		Filter:   req.Filter,
+		SortBy:   req.SortBy,
+		Order:    req.Order,
+		Assignee: req.Assignee,
+		Archived: req.Archived,
+		Limit:    constants.ExportBatchSize,
+	}
+	filter, err := prepareTaskListing(listing, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ExportTasks] Invalid filter", err)
+		return nil, constants.ErrInvalidQueryRequestParam
+	}
+	// An export is read in full, batch after batch, so a search keeps to
+	// creation order rather than paging through ties in relevance
+	if listing.SortBy == "relevance" {
+		listing.SortBy = "created_at"
+	}
+
+	images := req.Images
+	if images == "" {
+		images = constants.ExportImagesNone
+	}
+
+	filename := "tasks-" + time.Now().Format("2006-01-02")
+	export := &entities.TaskExport{
+		Filename:    filename + "." + string(req.Format),
+		ContentType: utils.ExportContentType(req.Format),
+		Write: func(w io.Writer) error {
+			return s.writeExport(ctx, w, authPayload.UserId, listing, filter, req.Format, images)
+		},
+	}
+	if images == constants.ExportImagesEmbed {
+		export.Filename, export.ContentType = filename+".zip", "application/zip"
+	}
+
+	s.log.DebugWithID(ctx, "[Service: ExportTasks] Export prepared", export.Filename)
+	return export, nil
+}
+
+func (s *ExportService) writeExport(ctx context.Context, w io.Writer, userId string, listing *entities.GetAllTasksRequest, filter utils.FilterNode, format constants.ExportFormat, images constants.ExportImages) error {
+	var bundle *zip.Writer
+	if images == constants.ExportImagesEmbed {
+		bundle = zip.NewWriter(w)
+		document, err := bundle.Create("tasks." + string(format))
+		if err != nil {
+			return err
+		}
+		w = document
+	}
+
+	writer, err := utils.NewTaskExportWriter(format, w, images != constants.ExportImagesNone)
+	if err != nil {
+		return err
+	}
+
+	var embedded []exportImage
+	err = eachTaskBatch(ctx, s.taskRepo, listing, filter, userId, func(tasks []models.Task) error {
+		var attachments map[string]models.Attachment
+		if images == constants.ExportImagesEmbed {
+			found, err := s.imageAttachments(ctx, tasks)
+			if err != nil {
+				return err
+			}
+			attachments = found
+		}
+
+		for i := range tasks {
+			task := toExportTask(ctx, &tasks[i])
+			switch images {
+			case constants.ExportImagesLink:
+				if url := taskImageURL(s.signer, userId, &tasks[i]); url != nil {
+					task.Image = *url
+				}
+			case constants.ExportImagesEmbed:
+				if tasks[i].ImageID != nil {
+					if a, ok := attachments[*tasks[i].ImageID]; ok {
+						task.Image = path.Join("images", task.ID, path.Base(a.Filename))
+						embedded = append(embedded, exportImage{storageKey: a.StorageKey, path: task.Image})
+					}
+				}
+			}
+			if err := writer.WriteTask(task); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: writeExport] Failed to write tasks", err)
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+
+	if bundle == nil {
+		return nil
+	}
+	for _, image := range embedded {
+		if err := s.copyImage(ctx, bundle, image); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: writeExport] Failed to embed image", image.path, err)
+			return err
+		}
+	}
+	return bundle.Close()
+}
+
+// eachTaskBatch walks the listing a batch at a time, continuing each query
+// after the last task of the one before, as a cursor would
+func eachTaskBatch(ctx context.Context, taskRepo repositories.ITaskRepository, listing *entities.GetAllTasksRequest, filter utils.FilterNode, userId string, fn func(tasks []models.Task) error) error {
+	var cursor *utils.Cursor
+	for {
+		tasks, err := taskRepo.GetAllTasks(ctx, listing, filter, cursor, userId)
+		if err != nil {
+			return err
+		}
+		if len(*tasks) == 0 {
+			return nil
+		}
+		if err := fn(*tasks); err != nil {
+			return err
+		}
+		if len(*tasks) < listing.Limit {
+			return nil
+		}
+
+		last := &(*tasks)[len(*tasks)-1]
+		cursor = &utils.Cursor{Values: repositories.TaskCursorValues(listing.SortBy, last)}
+	}
+}
+
+// imageAttachments returns the image attachments of the tasks by id
+func (s *ExportService) imageAttachments(ctx context.Context, tasks []models.Task) (map[string]models.Attachment, error) {
+	var taskIds []string
+	for _, t := range tasks {
+		if t.ImageID != nil {
+			taskIds = append(taskIds, t.ID.String())
+		}
+	}
+
+	attachments, err := s.attachmentRepo.GetTaskAttachments(ctx, taskIds)
+	if err != nil {
+		return nil, err
+	}
+
+	byId := map[string]models.Attachment{}
+	for _, a := range *attachments {
+		byId[a.ID.String()] = a
+	}
+	return byId, nil
+}
+
+// copyImage streams an image from the blob store into the bundle; an image
+// missing from the store is left out
+func (s *ExportService) copyImage(ctx context.Context, bundle *zip.Writer, image exportImage) error {
+	body, err := s.blobs.Get(ctx, image.storageKey)
+	if errors.Is(err, utils.ErrBlobNotFound) {
+		s.log.ErrorWithID(ctx, "[Service: copyImage] Image missing from the blob store", image.storageKey)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+
+	// Images are already compressed
+	entry, err := bundle.CreateHeader(&zip.FileHeader{Name: image.path, Method: zip.Store, Modified: time.Now()})
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(entry, body)
+	return err
+}
+
+// toExportTask converts a task for an export, with its times in the
+// caller's time zone; an all-day date keeps its day
+func toExportTask(ctx context.Context, t *models.Task) *entities.ExportTask {
+	loc := utils.LocationFromContext(ctx)
+	date := t.Date.In(loc)
+	if t.AllDay {
+		date = t.Date.UTC()
+	}
+
+	tags := []string(t.Tags)
+	if tags == nil {
+		tags = []string{}
+	}
+	return &entities.ExportTask{
+		ID:          t.ID.String(),
+		Title:       t.Title,
+		Description: t.Description,
+		Status:      t.Status,
+		Date:        date,
+		AllDay:      t.AllDay,
+		Tags:        tags,
+		AssigneeID:  t.AssigneeID,
+		Archived:    t.ArchivedAt != nil,
+		CreatedAt:   t.CreatedAt.In(loc),
+		Version:     t.Version,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
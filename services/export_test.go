@@ -0,0 +1,225 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportService_ExportTasks(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+
+	imageId := "550e8400-e29b-41d4-a716-446655440001"
+	withImage := models.Task{
+		ID:        uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+		UserID:    okPayload.UserId,
+		Title:     "With image",
+		Status:    string(constants.TaskStatusPending),
+		Date:      time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+		ImageID:   &imageId,
+		Tags:      models.TaskTags{"work"},
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	}
+	image := models.Attachment{
+		ID:          uuid.MustParse(imageId),
+		TaskID:      withImage.ID,
+		StorageKey:  "tasks/" + withImage.ID.String() + "/" + imageId,
+		Filename:    "cat.png",
+		ContentType: "image/png",
+		Size:        int64(len(testPNG)),
+	}
+
+	// A first batch that is full makes the export read another
+	fullBatch := make([]models.Task, constants.ExportBatchSize)
+	for i := range fullBatch {
+		fullBatch[i] = models.Task{
+			ID:        uuid.New(),
+			UserID:    okPayload.UserId,
+			Title:     "Task",
+			Status:    string(constants.TaskStatusPending),
+			Date:      time.Now(),
+			CreatedAt: time.Now().Add(-time.Duration(i) * time.Minute),
+		}
+	}
+
+	// More than a batch of tasks, all created at the same time and so
+	// ordered by id alone, newest first
+	tiedBatch := make([]models.Task, constants.ExportBatchSize+20)
+	for i := range tiedBatch {
+		tiedBatch[i] = models.Task{
+			ID:        uuid.New(),
+			UserID:    okPayload.UserId,
+			Title:     "Release",
+			Status:    string(constants.TaskStatusPending),
+			Date:      time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		}
+	}
+	sort.Slice(tiedBatch, func(i, j int) bool { return tiedBatch[i].ID.String() > tiedBatch[j].ID.String() })
+
+	testCases := []struct {
+		name   string
+		req    entities.ExportTasksRequest
+		setup  func(taskRepo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore)
+		verify func(t *testing.T, got *entities.TaskExport, body []byte)
+	}{
+		{
+			name: "ExportTasks_CSVInBatches",
+			req:  entities.ExportTasksRequest{Format: constants.ExportFormatCSV, Images: constants.ExportImagesLink, Filter: "status:IN_PROGRESS"},
+			setup: func(taskRepo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				isListing := mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+					return req.Filter == "status:IN_PROGRESS" && req.SortBy == "created_at" && req.Order == "desc" && req.Limit == constants.ExportBatchSize
+				})
+				taskRepo.EXPECT().GetAllTasks(ctx, isListing, mock.Anything, (*utils.Cursor)(nil), okPayload.UserId).Return(&fullBatch, nil).Once()
+				last := fullBatch[len(fullBatch)-1]
+				afterLast := mock.MatchedBy(func(c *utils.Cursor) bool {
+					return c != nil && c.Values[1] == last.ID.String()
+				})
+				taskRepo.EXPECT().GetAllTasks(ctx, isListing, mock.Anything, afterLast, okPayload.UserId).Return(&[]models.Task{withImage}, nil).Once()
+			},
+			verify: func(t *testing.T, got *entities.TaskExport, body []byte) {
+				assert.Equal(t, "text/csv; charset=utf-8", got.ContentType)
+				assert.True(t, strings.HasSuffix(got.Filename, ".csv"))
+
+				lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+				assert.Len(t, lines, constants.ExportBatchSize+2)
+				assert.True(t, strings.HasSuffix(lines[0], ",image"))
+				assert.Contains(t, lines[len(lines)-1], "/api/v1/files/tasks/"+withImage.ID.String()+"/attachments/"+imageId+"/content?")
+			},
+		},
+		{
+			// A search pages in creation order, so batches that tie on
+			// creation time still continue after each other and end
+			name: "ExportTasks_SearchInBatches",
+			req:  entities.ExportTasksRequest{Format: constants.ExportFormatCSV, Search: "release"},
+			setup: func(taskRepo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				isListing := mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+					return req.Search == "release" && req.SortBy == "created_at" && req.Order == "desc"
+				})
+				calls := 0
+				taskRepo.EXPECT().GetAllTasks(ctx, isListing, mock.Anything, mock.Anything, okPayload.UserId).RunAndReturn(func(_ context.Context, req *entities.GetAllTasksRequest, _ utils.FilterNode, cursor *utils.Cursor, _ string) (*[]models.Task, error) {
+					calls++
+					require.LessOrEqual(t, calls, 3, "the export does not stop")
+					page := tasksAfter(t, tiedBatch, cursor)
+					if len(page) > req.Limit {
+						page = page[:req.Limit]
+					}
+					return &page, nil
+				})
+			},
+			verify: func(t *testing.T, got *entities.TaskExport, body []byte) {
+				lines := strings.Split(strings.TrimSuffix(string(body), "\n"), "\n")
+				require.Len(t, lines, len(tiedBatch)+1)
+				seen := map[string]bool{}
+				for _, line := range lines[1:] {
+					id := strings.SplitN(line, ",", 2)[0]
+					assert.False(t, seen[id], "task %s is exported twice", id)
+					seen[id] = true
+				}
+			},
+		},
+		{
+			name: "ExportTasks_EmbeddedImages",
+			req:  entities.ExportTasksRequest{Format: constants.ExportFormatJSON, Images: constants.ExportImagesEmbed},
+			setup: func(taskRepo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetAllTasks(ctx, mock.Anything, mock.Anything, (*utils.Cursor)(nil), okPayload.UserId).Return(&[]models.Task{withImage}, nil).Once()
+				attachmentRepo.EXPECT().GetTaskAttachments(ctx, []string{withImage.ID.String()}).Return(&[]models.Attachment{image}, nil)
+				blobs.EXPECT().Get(ctx, image.StorageKey).Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+			},
+			verify: func(t *testing.T, got *entities.TaskExport, body []byte) {
+				assert.Equal(t, "application/zip", got.ContentType)
+				assert.True(t, strings.HasSuffix(got.Filename, ".zip"))
+
+				bundle, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+				require.NoError(t, err)
+				require.Len(t, bundle.File, 2)
+				assert.Equal(t, "tasks.json", bundle.File[0].Name)
+
+				f, err := bundle.File[0].Open()
+				require.NoError(t, err)
+				var document struct {
+					Tasks []entities.ExportTask `json:"tasks"`
+				}
+				require.NoError(t, json.NewDecoder(f).Decode(&document))
+				require.Len(t, document.Tasks, 1)
+				imagePath := "images/" + withImage.ID.String() + "/cat.png"
+				assert.Equal(t, imagePath, document.Tasks[0].Image)
+
+				assert.Equal(t, imagePath, bundle.File[1].Name)
+				f, err = bundle.File[1].Open()
+				require.NoError(t, err)
+				data, err := io.ReadAll(f)
+				require.NoError(t, err)
+				assert.Equal(t, testPNG, data)
+			},
+		},
+		{
+			name: "ExportTasks_ImagesExcluded",
+			req:  entities.ExportTasksRequest{Format: constants.ExportFormatMarkdown},
+			setup: func(taskRepo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				taskRepo.EXPECT().GetAllTasks(ctx, mock.Anything, mock.Anything, (*utils.Cursor)(nil), okPayload.UserId).Return(&[]models.Task{withImage}, nil).Once()
+			},
+			verify: func(t *testing.T, got *entities.TaskExport, body []byte) {
+				assert.Equal(t, "text/markdown; charset=utf-8", got.ContentType)
+				assert.Contains(t, string(body), "## With image\n")
+				assert.NotContains(t, string(body), "Image")
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+			mockBlobs := mocks.NewMockIBlobStore(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tC.setup(mockTaskRepo, mockAttachmentRepo, mockBlobs)
+
+			svc := NewExportService(mockTaskRepo, mockAttachmentRepo, mockBlobs, testSigner, lgr, mockPayload)
+			got, err := svc.ExportTasks(ctx, &tC.req)
+			require.NoError(t, err)
+
+			var body bytes.Buffer
+			require.NoError(t, got.Write(&body))
+
+			tC.verify(t, got, body.Bytes())
+		})
+	}
+}
+
+// tasksAfter returns the tasks, listed newest first, that a created_at
+// listing continues with after the cursor
+func tasksAfter(t *testing.T, tasks []models.Task, cursor *utils.Cursor) []models.Task {
+	if cursor == nil {
+		return append([]models.Task(nil), tasks...)
+	}
+	require.Len(t, cursor.Values, 2)
+	for i := range tasks {
+		if tasks[i].ID.String() == cursor.Values[1] {
+			return append([]models.Task(nil), tasks[i+1:]...)
+		}
+	}
+	t.Fatalf("cursor %v is not a listed task", cursor.Values)
+	return nil
+}
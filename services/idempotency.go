@@ -0,0 +1,111 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+)
+
+// defaultIdempotencyKeyTTL applies when the config does not set one
+const defaultIdempotencyKeyTTL = 24 * time.Hour
+
+// defaultIdempotencyLockTimeout applies when the config does not set one
+const defaultIdempotencyLockTimeout = time.Minute
+
+// runIdempotent runs a request at most once per idempotency key of the user.
+// A retry with the same key and fingerprint gets the response stored by the
+// first run, passed through replay when set to refresh what goes stale in
+// it, and a key reused for a different request is rejected. A failed run
+// releases its key so that a retry runs again, and a run that has not
+// completed within lockTimeout, such as one whose process died, gives it up
+// to a retry. Without a key, run is simply called.
+func runIdempotent[T any](
+	ctx context.Context,
+	repo repositories.IIdempotencyRepository,
+	log *log.Logger,
+	userId string,
+	key string,
+	fingerprint string,
+	ttl time.Duration,
+	lockTimeout time.Duration,
+	run func() (*T, error),
+	replay func(resp *T) error,
+) (*T, error) {
+	if key == "" {
+		return run()
+	}
+
+	// Claim the key, or find the request that already holds it
+	now := time.Now()
+	lockedUntil := now.Add(lockTimeout)
+	existing, err := repo.ClaimKey(ctx, &models.IdempotencyKey{
+		UserID:      userId,
+		Key:         key,
+		Fingerprint: fingerprint,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+		LockedUntil: &lockedUntil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if existing.Fingerprint != fingerprint {
+			return nil, constants.ErrIdempotencyKeyReused
+		}
+		if existing.Response == nil {
+			return nil, constants.ErrIdempotencyKeyInProgress
+		}
+
+		var resp T
+		if err := json.Unmarshal(existing.Response, &resp); err != nil {
+			return nil, err
+		}
+		if replay != nil {
+			if err := replay(&resp); err != nil {
+				return nil, err
+			}
+		}
+		log.DebugWithID(ctx, "[Service: runIdempotent] Replaying stored response", key)
+		return &resp, nil
+	}
+
+	resp, err := run()
+	if err != nil {
+		if releaseErr := repo.ReleaseKey(ctx, userId, key); releaseErr != nil {
+			log.ErrorWithID(ctx, "[Service: runIdempotent] Failed to release key", releaseErr)
+		}
+		return nil, err
+	}
+
+	// The request has taken effect, so failing to store its response must not
+	// fail it; the key then stays in progress and retries are refused until
+	// its lock times out
+	body, err := json.Marshal(resp)
+	if err == nil {
+		err = repo.SaveResponse(ctx, userId, key, body)
+	}
+	if err != nil {
+		log.ErrorWithID(ctx, "[Service: runIdempotent] Failed to save response", err)
+	}
+
+	return resp, nil
+}
+
+// requestFingerprint digests everything that defines a request, so that a
+// retry can be told apart from another request sent with the same key
+func requestFingerprint(v interface{}) (string, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:]), nil
+}
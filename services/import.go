@@ -0,0 +1,350 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+// defaultMaxImportFileSize applies when the config does not set one
+const defaultMaxImportFileSize int64 = 10 << 20
+
+// defaultMaxImportRows applies when the config does not set one
+const defaultMaxImportRows = 10000
+
+// Reasons recorded on a failed import job
+const (
+	importFailedReason      = "tasks could not be saved; the rows counted as imported were saved"
+	importInterruptedReason = "the server stopped before the import finished; the rows counted as imported were saved"
+)
+
+type IImportService interface {
+	ImportTasks(ctx context.Context, req *entities.ImportTasksRequest) (*entities.ImportJobResponse, error)
+	GetImportJob(ctx context.Context, id string) (*entities.ImportJobResponse, error)
+	FailInterruptedImports(ctx context.Context) (int64, error)
+}
+
+type ImportService struct {
+	repo     repositories.IImportJobRepository
+	taskRepo repositories.ITaskRepository
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+	config   *config.Config
+	// goFunc runs an import in the background
+	goFunc func(func())
+}
+
+func NewImportService(
+	repo repositories.IImportJobRepository,
+	taskRepo repositories.ITaskRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+	config *config.Config,
+) IImportService {
+	return &ImportService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		log:      log,
+		payload:  payload,
+		config:   config,
+		goFunc:   func(f func()) { go f() },
+	}
+}
+
+// ImportTasks reads and validates the tasks of an import file. Rows that fail
+// validation are reported and skipped. A dry run stops at the report; else
+// the valid rows are imported by a job in the background.
+func (s *ImportService) ImportTasks(ctx context.Context, req *entities.ImportTasksRequest) (*entities.ImportJobResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: ImportTasks] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ImportTasks] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Parse column mapping
+	var mapping map[string]string
+	if req.Mapping != "" {
+		if err := json.Unmarshal([]byte(req.Mapping), &mapping); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: ImportTasks] Invalid column mapping", err)
+			return nil, constants.ErrInvalidImportMapping
+		}
+	}
+
+	// Read file
+	data, err := readImportFile(req.File, maxImportFileSize(s.config))
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ImportTasks] Failed to read import file", err)
+		return nil, err
+	}
+
+	rows, err := utils.ParseImport(req.Format, bytes.NewReader(data), mapping, utils.LocationFromContext(ctx))
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ImportTasks] Failed to parse import file", err)
+		return nil, err
+	}
+	if len(rows) > maxImportRows(s.config) {
+		s.log.ErrorWithID(ctx, "[Service: ImportTasks] Too many rows", len(rows))
+		return nil, constants.ErrImportTooLarge
+	}
+
+	// Validate rows
+	tasks, rowErrors := validateImportRows(rows)
+
+	job := &models.ImportJob{
+		ID:        uuid.New(),
+		UserID:    authPayload.UserId,
+		Format:    string(req.Format),
+		Status:    string(constants.ImportJobStatusPending),
+		Total:     len(rows),
+		Failed:    len(rowErrors),
+		Errors:    rowErrors,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	if req.DryRun {
+		job.Status = string(constants.ImportJobStatusCompleted)
+		resp := toImportJobResponse(ctx, job)
+		resp.ID, resp.CreatedAt, resp.DryRun = "", "", true
+
+		s.log.DebugWithID(ctx, "[Service: ImportTasks] Dry run completed", resp.Total, resp.Failed)
+		return resp, nil
+	}
+
+	// Create job
+	if err := s.repo.CreateImportJob(ctx, job); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ImportTasks] Failed to create import job", err)
+		return nil, err
+	}
+
+	// The job outlives the request, but keeps its values such as the workspace
+	resp := toImportJobResponse(ctx, job)
+	jobCtx := context.WithoutCancel(ctx)
+	s.goFunc(func() { s.runImport(jobCtx, job, tasks) })
+
+	s.log.DebugWithID(ctx, "[Service: ImportTasks] Import job started", resp.ID)
+	return resp, nil
+}
+
+func (s *ImportService) GetImportJob(ctx context.Context, id string) (*entities.ImportJobResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetImportJob] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetImportJob] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	if _, err := uuid.Parse(id); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetImportJob] Invalid job id", err)
+		return nil, constants.ErrImportJobNotFound
+	}
+
+	job, err := s.repo.GetImportJob(ctx, authPayload.UserId, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetImportJob] Failed to get import job", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrImportJobNotFound
+		}
+		return nil, err
+	}
+
+	resp := toImportJobResponse(ctx, job)
+	s.log.DebugWithID(ctx, "[Service: GetImportJob] Import job retrieved successfully", resp.Status)
+	return resp, nil
+}
+
+// FailInterruptedImports marks the jobs a previous run of the server left
+// unfinished as failed; the rows they had yet to import are lost with it
+func (s *ImportService) FailInterruptedImports(ctx context.Context) (int64, error) {
+	s.log.DebugWithID(ctx, "[Service: FailInterruptedImports] Called")
+
+	failed, err := s.repo.FailInterruptedImportJobs(ctx, importInterruptedReason)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: FailInterruptedImports] Failed to fail import jobs", err)
+		return 0, err
+	}
+
+	return failed, nil
+}
+
+// runImport creates the tasks of a job in batches, saving its progress after
+// each. A batch that cannot be saved fails the job; the batches before it
+// stay imported.
+func (s *ImportService) runImport(ctx context.Context, job *models.ImportJob, tasks []entities.CreateTaskRequest) {
+	s.log.DebugWithID(ctx, "[Service: runImport] Called", job.ID)
+
+	defer func() {
+		if r := recover(); r != nil {
+			s.log.ErrorWithID(ctx, "[Service: runImport] Import panicked", job.ID, r)
+			s.finishImport(ctx, job, constants.ImportJobStatusFailed)
+		}
+	}()
+
+	job.Status = string(constants.ImportJobStatusRunning)
+	if err := s.repo.UpdateImportJob(ctx, job); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: runImport] Failed to update import job", err)
+	}
+
+	for start := 0; start < len(tasks); start += constants.ImportBatchSize {
+		batch := tasks[start:min(start+constants.ImportBatchSize, len(tasks))]
+
+		arg, histories := newImportedTasks(job.UserID, batch)
+		if err := s.taskRepo.CreateTasks(ctx, arg, histories); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: runImport] Failed to create tasks", err)
+			s.finishImport(ctx, job, constants.ImportJobStatusFailed)
+			return
+		}
+
+		job.Imported += len(arg)
+		if err := s.repo.UpdateImportJob(ctx, job); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: runImport] Failed to update import job", err)
+		}
+	}
+
+	s.finishImport(ctx, job, constants.ImportJobStatusCompleted)
+	s.log.DebugWithID(ctx, "[Service: runImport] Import completed", job.ID, job.Imported)
+}
+
+func (s *ImportService) finishImport(ctx context.Context, job *models.ImportJob, status constants.ImportJobStatus) {
+	now := time.Now()
+	job.Status = string(status)
+	job.FinishedAt = &now
+	if status == constants.ImportJobStatusFailed {
+		reason := importFailedReason
+		job.Error = &reason
+	}
+
+	if err := s.repo.UpdateImportJob(ctx, job); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: finishImport] Failed to update import job", err)
+	}
+}
+
+// validateImportRows checks every row the way a created task is checked and
+// splits the rows into the tasks to import and the errors of the rest
+func validateImportRows(rows []utils.ImportRow) ([]entities.CreateTaskRequest, models.ImportRowErrors) {
+	tasks := []entities.CreateTaskRequest{}
+	rowErrors := models.ImportRowErrors{}
+	for _, row := range rows {
+		detail := utils.ValidateCreateTaskInput(row.Task)
+		if detail == nil {
+			tasks = append(tasks, row.Task)
+			continue
+		}
+
+		rowError := models.ImportRowError{Row: row.Row}
+		for _, e := range detail.([]utils.FieldError) {
+			rowError.Errors = append(rowError.Errors, e)
+		}
+		rowErrors = append(rowErrors, rowError)
+	}
+	return tasks, rowErrors
+}
+
+// newImportedTasks builds imported tasks, with their creation in the history
+// as for a task created on its own
+func newImportedTasks(userId string, reqs []entities.CreateTaskRequest) ([]models.Task, []models.TaskHistory) {
+	now := time.Now()
+	tasks := make([]models.Task, 0, len(reqs))
+	histories := make([]models.TaskHistory, 0, len(reqs))
+	for _, req := range reqs {
+		task := models.Task{
+			ID:          uuid.New(),
+			UserID:      userId,
+			Title:       req.Title,
+			Status:      string(req.Status),
+			Date:        req.Date,
+			AllDay:      req.AllDay,
+			Description: req.Description,
+			Tags:        utils.NormalizeTags(req.Tags),
+			Version:     1,
+			CreatedAt:   now,
+		}
+		history := newTaskHistory(&task, userId, constants.TaskHistoryActionCreate, utils.DiffTaskSnapshots(nil, task.Snapshot()))
+		history.Version = 1
+
+		tasks = append(tasks, task)
+		histories = append(histories, *history)
+	}
+	return tasks, histories
+}
+
+// readImportFile reads an uploaded import file, refusing one over maxSize
+func readImportFile(file *multipart.FileHeader, maxSize int64) ([]byte, error) {
+	if file.Size > maxSize {
+		return nil, constants.ErrImportTooLarge
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		return nil, constants.ErrOpenFileContext
+	}
+	defer f.Close()
+
+	data, err := io.ReadAll(io.LimitReader(f, maxSize+1))
+	if err != nil {
+		return nil, constants.ErrOpenFileContext
+	}
+	if int64(len(data)) > maxSize {
+		return nil, constants.ErrImportTooLarge
+	}
+
+	return data, nil
+}
+
+// maxImportFileSize is the largest import file, in bytes, that is accepted
+func maxImportFileSize(config *config.Config) int64 {
+	if config != nil && config.Import.MaxFileSize > 0 {
+		return config.Import.MaxFileSize
+	}
+	return defaultMaxImportFileSize
+}
+
+// maxImportRows is the most rows an import file may have
+func maxImportRows(config *config.Config) int {
+	if config != nil && config.Import.MaxRows > 0 {
+		return config.Import.MaxRows
+	}
+	return defaultMaxImportRows
+}
+
+func toImportJobResponse(ctx context.Context, job *models.ImportJob) *entities.ImportJobResponse {
+	resp := &entities.ImportJobResponse{
+		ID:        job.ID.String(),
+		Format:    job.Format,
+		Status:    job.Status,
+		Total:     job.Total,
+		Valid:     job.Total - job.Failed,
+		Imported:  job.Imported,
+		Failed:    job.Failed,
+		Errors:    []entities.ImportRowError{},
+		Error:     job.Error,
+		CreatedAt: utils.FormatRFC3339(ctx, job.CreatedAt),
+	}
+	for _, e := range job.Errors {
+		resp.Errors = append(resp.Errors, entities.ImportRowError{Row: e.Row, Errors: e.Errors})
+	}
+	if job.FinishedAt != nil {
+		finishedAt := utils.FormatRFC3339(ctx, *job.FinishedAt)
+		resp.FinishedAt = &finishedAt
+	}
+	return resp
+}
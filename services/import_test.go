@@ -0,0 +1,246 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestImportService_ImportTasks(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+
+	csvFile := "title,status,date,tags,description\n" +
+		"Write report,IN_PROGRESS,2024-01-05,work,Quarterly numbers\n" +
+		",DONE,2024-01-05,,\n" +
+		"Ship,completed,2024-01-06,,\n"
+
+	// lastJob keeps the state of the job as last saved
+	var lastJob models.ImportJob
+	saveJob := func(_ context.Context, job *models.ImportJob) error {
+		lastJob = *job
+		return nil
+	}
+
+	testCases := []struct {
+		name   string
+		req    entities.ImportTasksRequest
+		config *config.Config
+		setup  func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository)
+		verify func(t *testing.T, got *entities.ImportJobResponse, gotErr error)
+	}{
+		{
+			name: "ImportTasks_DryRun",
+			req:  entities.ImportTasksRequest{Format: constants.ImportFormatCSV, DryRun: true},
+			setup: func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository) {
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.True(t, got.DryRun)
+				assert.Empty(t, got.ID)
+				assert.Equal(t, 3, got.Total)
+				assert.Equal(t, 2, got.Valid)
+				assert.Equal(t, 0, got.Imported)
+				assert.Equal(t, 1, got.Failed)
+				assert.Equal(t, 3, got.Errors[0].Row)
+				assert.Equal(t, []map[string]string{
+					{"field": "title", "message": "Title is required"},
+					{"field": "status", "message": "Status must be IN_PROGRESS or COMPLETED"},
+				}, got.Errors[0].Errors)
+			},
+		},
+		{
+			name: "ImportTasks_OK",
+			req:  entities.ImportTasksRequest{Format: constants.ImportFormatCSV},
+			setup: func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository) {
+				repo.EXPECT().CreateImportJob(ctx, mock.Anything).RunAndReturn(saveJob)
+				repo.EXPECT().UpdateImportJob(mock.Anything, mock.Anything).RunAndReturn(saveJob)
+				taskRepo.EXPECT().CreateTasks(mock.Anything, mock.Anything, mock.Anything).RunAndReturn(func(_ context.Context, tasks []models.Task, histories []models.TaskHistory) error {
+					assert.Len(t, tasks, 2)
+					assert.Len(t, histories, 2)
+					assert.Equal(t, "Write report", tasks[0].Title)
+					assert.Equal(t, models.TaskTags{"work"}, tasks[0].Tags)
+					// The creation carries the description, which becomes its revision 1
+					description := "Quarterly numbers"
+					assert.Contains(t, histories[0].Changes, models.FieldChange{Field: "description", After: &description})
+					assert.Equal(t, "Quarterly numbers", *histories[0].Snapshot.Description)
+					assert.Equal(t, string(constants.TaskStatusCompleted), tasks[1].Status)
+					assert.Equal(t, tasks[1].ID, histories[1].TaskID)
+					assert.Equal(t, 1, histories[1].Version)
+					return nil
+				})
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, string(constants.ImportJobStatusPending), got.Status)
+				assert.NotEmpty(t, got.ID)
+
+				assert.Equal(t, string(constants.ImportJobStatusCompleted), lastJob.Status)
+				assert.Equal(t, 2, lastJob.Imported)
+				assert.Equal(t, 1, lastJob.Failed)
+				assert.NotNil(t, lastJob.FinishedAt)
+				assert.Nil(t, lastJob.Error)
+			},
+		},
+		{
+			name: "ImportTasks_SaveFailed",
+			req:  entities.ImportTasksRequest{Format: constants.ImportFormatCSV},
+			setup: func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository) {
+				repo.EXPECT().CreateImportJob(ctx, mock.Anything).RunAndReturn(saveJob)
+				repo.EXPECT().UpdateImportJob(mock.Anything, mock.Anything).RunAndReturn(saveJob)
+				taskRepo.EXPECT().CreateTasks(mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db down"))
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, string(constants.ImportJobStatusFailed), lastJob.Status)
+				assert.Equal(t, 0, lastJob.Imported)
+				assert.NotNil(t, lastJob.Error)
+			},
+		},
+		{
+			name: "ImportTasks_InvalidMapping",
+			req:  entities.ImportTasksRequest{Format: constants.ImportFormatCSV, Mapping: `["title"]`},
+			setup: func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository) {
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.Equal(t, constants.ErrInvalidImportMapping, gotErr)
+			},
+		},
+		{
+			name: "ImportTasks_InvalidFile",
+			req:  entities.ImportTasksRequest{Format: constants.ImportFormatTrello},
+			setup: func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository) {
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.Equal(t, constants.ErrInvalidImportFile, gotErr)
+			},
+		},
+		{
+			name:   "ImportTasks_TooManyRows",
+			req:    entities.ImportTasksRequest{Format: constants.ImportFormatCSV},
+			config: &config.Config{Import: config.Import{MaxRows: 2}},
+			setup: func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository) {
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.Equal(t, constants.ErrImportTooLarge, gotErr)
+			},
+		},
+		{
+			name:   "ImportTasks_FileTooLarge",
+			req:    entities.ImportTasksRequest{Format: constants.ImportFormatCSV},
+			config: &config.Config{Import: config.Import{MaxFileSize: 10}},
+			setup: func(repo *mocks.MockIImportJobRepository, taskRepo *mocks.MockITaskRepository) {
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.Equal(t, constants.ErrImportTooLarge, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			lastJob = models.ImportJob{}
+			mockRepo := mocks.NewMockIImportJobRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tC.setup(mockRepo, mockTaskRepo)
+
+			svc := NewImportService(mockRepo, mockTaskRepo, lgr, mockPayload, tC.config).(*ImportService)
+			// Run the import before returning, for its outcome to be checked
+			svc.goFunc = func(f func()) { f() }
+
+			req := tC.req
+			req.File = newFileHeader(t, "tasks.csv", []byte(csvFile))
+			got, gotErr := svc.ImportTasks(ctx, &req)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestImportService_GetImportJob(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1", IssuedAt: time.Now(), ExpiredAt: time.Now().Add(time.Hour)}
+	jobId := "550e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name   string
+		id     string
+		setup  func(repo *mocks.MockIImportJobRepository)
+		verify func(t *testing.T, got *entities.ImportJobResponse, gotErr error)
+	}{
+		{
+			name: "GetImportJob_OK",
+			id:   jobId,
+			setup: func(repo *mocks.MockIImportJobRepository) {
+				repo.EXPECT().GetImportJob(ctx, okPayload.UserId, jobId).Return(&models.ImportJob{
+					ID:       uuid.MustParse(jobId),
+					Format:   string(constants.ImportFormatJSON),
+					Status:   string(constants.ImportJobStatusRunning),
+					Total:    300,
+					Imported: 100,
+					Failed:   1,
+					Errors:   models.ImportRowErrors{{Row: 7, Errors: []map[string]string{{"field": "date", "message": "Date is required and must be RFC3339 format"}}}},
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, jobId, got.ID)
+				assert.Equal(t, 299, got.Valid)
+				assert.Equal(t, 100, got.Imported)
+				assert.Equal(t, 7, got.Errors[0].Row)
+				assert.Nil(t, got.FinishedAt)
+			},
+		},
+		{
+			name: "GetImportJob_NotFound",
+			id:   jobId,
+			setup: func(repo *mocks.MockIImportJobRepository) {
+				repo.EXPECT().GetImportJob(ctx, okPayload.UserId, jobId).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.Equal(t, constants.ErrImportJobNotFound, gotErr)
+			},
+		},
+		{
+			name:  "GetImportJob_InvalidID",
+			id:    "not-a-uuid",
+			setup: func(repo *mocks.MockIImportJobRepository) {},
+			verify: func(t *testing.T, got *entities.ImportJobResponse, gotErr error) {
+				assert.Equal(t, constants.ErrImportJobNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIImportJobRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tC.setup(mockRepo)
+
+			svc := NewImportService(mockRepo, mocks.NewMockITaskRepository(t), lgr, mockPayload, nil)
+			got, gotErr := svc.GetImportJob(ctx, tC.id)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
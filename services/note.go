@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+type INoteService interface {
+	CreateNote(ctx context.Context, req *entities.CreateNoteRequest) (*entities.NoteResponse, error)
+	GetNote(ctx context.Context, id string, req *entities.GetNoteRequest) (*entities.NoteResponse, error)
+	UpdateNote(ctx context.Context, id string, req *entities.UpdateNoteRequest) (*entities.NoteResponse, error)
+	DeleteNote(ctx context.Context, id string) error
+	GetNotes(ctx context.Context, req *entities.GetNotesRequest) (*entities.GetNotesResponse, error)
+}
+
+type NoteService struct {
+	repo     repositories.INoteRepository
+	taskRepo repositories.ITaskRepository
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+}
+
+func NewNoteService(
+	repo repositories.INoteRepository,
+	taskRepo repositories.ITaskRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) INoteService {
+	return &NoteService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		log:      log,
+		payload:  payload,
+	}
+}
+
+func (s *NoteService) CreateNote(ctx context.Context, req *entities.CreateNoteRequest) (*entities.NoteResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: CreateNote] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateNote] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	arg := &models.Note{
+		ID:        uuid.New(),
+		UserID:    authPayload.UserId,
+		Title:     req.Title,
+		Body:      req.Body,
+		Pinned:    req.Pinned,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	// Attach to a task the caller can see
+	if req.TaskID != nil && *req.TaskID != "" {
+		if arg.TaskID, err = s.linkedTaskID(ctx, authPayload.UserId, *req.TaskID); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: CreateNote] Failed to get task", err)
+			return nil, err
+		}
+	}
+
+	// Create note with its [[links]] and first revision
+	if err := s.repo.CreateNote(ctx, arg, utils.ExtractNoteLinks(arg.Body), newNoteRevision(arg, authPayload.UserId)); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateNote] Failed to create note", err)
+		return nil, err
+	}
+
+	resp := toNoteResponse(ctx, arg)
+	s.log.DebugWithID(ctx, "[Service: CreateNote] Note created successfully", resp)
+	return resp, nil
+}
+
+func (s *NoteService) GetNote(ctx context.Context, id string, req *entities.GetNoteRequest) (*entities.NoteResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetNote] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetNote] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get note from repository
+	note, err := s.getNote(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetNote] Failed to get note", err)
+		return nil, err
+	}
+
+	// Get notes linking here
+	backlinks, err := s.repo.GetBacklinks(ctx, note, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetNote] Failed to get backlinks", err)
+		return nil, err
+	}
+
+	resp := toNoteResponse(ctx, note)
+	resp.Backlinks = []entities.NoteSummary{}
+	for _, b := range *backlinks {
+		resp.Backlinks = append(resp.Backlinks, entities.NoteSummary{ID: b.ID.String(), Title: b.Title})
+	}
+
+	// Render Markdown on request
+	if req.Format == "html" {
+		html, err := utils.RenderMarkdown(note.Body)
+		if err != nil {
+			s.log.ErrorWithID(ctx, "[Service: GetNote] Failed to render markdown", err)
+			return nil, err
+		}
+		resp.BodyHTML = &html
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetNote] Note retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *NoteService) UpdateNote(ctx context.Context, id string, req *entities.UpdateNoteRequest) (*entities.NoteResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: UpdateNote] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateNote] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get existing note
+	existingNote, err := s.getNote(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateNote] Failed to get note", err)
+		return nil, err
+	}
+
+	// Update fields if present
+	beforeTitle, beforeBody := existingNote.Title, existingNote.Body
+	if req.Title != "" {
+		existingNote.Title = req.Title
+	}
+	if req.Body != nil {
+		existingNote.Body = *req.Body
+	}
+	if req.Pinned != nil {
+		existingNote.Pinned = *req.Pinned
+	}
+	if req.TaskID != nil {
+		if *req.TaskID == "" {
+			existingNote.TaskID = nil
+		} else if existingNote.TaskID, err = s.linkedTaskID(ctx, authPayload.UserId, *req.TaskID); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: UpdateNote] Failed to get task", err)
+			return nil, err
+		}
+	}
+	existingNote.UpdatedAt = time.Now()
+
+	// Record a revision only when the text changed, not for pinning or attaching
+	var revision *models.Revision
+	if existingNote.Title != beforeTitle || existingNote.Body != beforeBody {
+		revision = newNoteRevision(existingNote, authPayload.UserId)
+	}
+
+	// Save update, recomputing the [[links]]
+	if err := s.repo.UpdateNote(ctx, existingNote, utils.ExtractNoteLinks(existingNote.Body), revision); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateNote] Failed to update note", err)
+		return nil, err
+	}
+
+	resp := toNoteResponse(ctx, existingNote)
+	s.log.DebugWithID(ctx, "[Service: UpdateNote] Note updated successfully", resp)
+	return resp, nil
+}
+
+func (s *NoteService) DeleteNote(ctx context.Context, id string) error {
+	s.log.DebugWithID(ctx, "[Service: DeleteNote] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteNote] Failed to get auth payload", err)
+		return err
+	}
+
+	// Get existing note
+	existingNote, err := s.getNote(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteNote] Failed to get note", err)
+		return err
+	}
+
+	// Any member can edit a workspace note, only its author or an admin can delete it
+	if _, role, ok := utils.WorkspaceFromContext(ctx); ok &&
+		existingNote.UserID != authPayload.UserId && !role.Allows(constants.WorkspaceRoleAdmin) {
+		s.log.ErrorWithID(ctx, "[Service: DeleteNote] User is not the note author", constants.ErrNotNoteAuthor)
+		return constants.ErrNotNoteAuthor
+	}
+
+	deleted, err := s.repo.DeleteNote(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteNote] Failed to delete note", err)
+		return err
+	}
+	if deleted == 0 {
+		s.log.ErrorWithID(ctx, "[Service: DeleteNote] Note not found", constants.ErrNoteNotFound)
+		return constants.ErrNoteNotFound
+	}
+
+	s.log.DebugWithID(ctx, "[Service: DeleteNote] Note deleted successfully")
+	return nil
+}
+
+func (s *NoteService) GetNotes(ctx context.Context, req *entities.GetNotesRequest) (*entities.GetNotesResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetNotes] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetNotes] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Set Offset
+	req.Offset = (req.Offset - 1) * req.Limit
+
+	// Fetch notes from repository
+	repoNotes, total, err := s.repo.GetNotes(ctx, req, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetNotes] Failed to get notes", err)
+		return nil, err
+	}
+
+	notes := []entities.NoteResponse{}
+	for i := range *repoNotes {
+		notes = append(notes, *toNoteResponse(ctx, &(*repoNotes)[i]))
+	}
+
+	resp := &entities.GetNotesResponse{
+		Total: total,
+		Notes: notes,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetNotes] Notes retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *NoteService) getNote(ctx context.Context, id string, userId string) (*models.Note, error) {
+	note, err := s.repo.GetNote(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrNoteNotFound
+		}
+		return nil, err
+	}
+	return note, nil
+}
+
+// linkedTaskID checks the user can see the task a note is attached to
+func (s *NoteService) linkedTaskID(ctx context.Context, userId string, taskId string) (*string, error) {
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, userId, taskId, constants.TaskPermissionViewer)
+	if err != nil {
+		return nil, err
+	}
+	id := task.ID.String()
+	return &id, nil
+}
+
+// newNoteRevision builds the revision written alongside a note save
+func newNoteRevision(note *models.Note, actorId string) *models.Revision {
+	title := note.Title
+	return &models.Revision{
+		ID:           uuid.New(),
+		ResourceType: string(constants.RevisionResourceNote),
+		ResourceID:   note.ID,
+		Title:        &title,
+		ActorID:      actorId,
+		Content:      note.Body,
+		CreatedAt:    note.UpdatedAt,
+	}
+}
+
+func toNoteResponse(ctx context.Context, n *models.Note) *entities.NoteResponse {
+	return &entities.NoteResponse{
+		ID:          n.ID.String(),
+		UserID:      n.UserID,
+		WorkspaceID: n.WorkspaceID,
+		TaskID:      n.TaskID,
+		Title:       n.Title,
+		Body:        n.Body,
+		Pinned:      n.Pinned,
+		Links:       utils.ExtractNoteLinks(n.Body),
+		CreatedAt:   utils.FormatRFC3339(ctx, n.CreatedAt),
+		UpdatedAt:   utils.FormatRFC3339(ctx, n.UpdatedAt),
+	}
+}
@@ -0,0 +1,225 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestNoteService_CreateNote(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name   string
+		req    *entities.CreateNoteRequest
+		setup  func(*mocks.MockINoteRepository, *mocks.MockITaskRepository)
+		verify func(t *testing.T, got *entities.NoteResponse, gotErr error)
+	}{
+		{
+			name: "CreateNote_OK",
+			req:  &entities.CreateNoteRequest{Title: "Weekly sync", Body: "See [[Roadmap]] and [[ roadmap ]] and [[Q3|plan]]"},
+			setup: func(repo *mocks.MockINoteRepository, taskRepo *mocks.MockITaskRepository) {
+				repo.EXPECT().
+					CreateNote(ctx, mock.MatchedBy(func(note *models.Note) bool {
+						return note.UserID == "1" && note.TaskID == nil
+					}), []string{"roadmap", "q3"}, mock.MatchedBy(func(revision *models.Revision) bool {
+						return revision.ResourceType == string(constants.RevisionResourceNote) && revision.ActorID == "1" &&
+							revision.Content == "See [[Roadmap]] and [[ roadmap ]] and [[Q3|plan]]"
+					})).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.NoteResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "Weekly sync", got.Title)
+				assert.Equal(t, []string{"roadmap", "q3"}, got.Links)
+			},
+		},
+		{
+			name: "CreateNote_AttachedToTask",
+			req:  &entities.CreateNoteRequest{Title: "Notes", TaskID: ptr(taskId)},
+			setup: func(repo *mocks.MockINoteRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: "1"}, nil)
+				repo.EXPECT().
+					CreateNote(ctx, mock.MatchedBy(func(note *models.Note) bool {
+						return note.TaskID != nil && *note.TaskID == taskId
+					}), []string{}, mock.Anything).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.NoteResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, taskId, *got.TaskID)
+			},
+		},
+		{
+			name: "CreateNote_TaskNotFound",
+			req:  &entities.CreateNoteRequest{Title: "Notes", TaskID: ptr(taskId)},
+			setup: func(repo *mocks.MockINoteRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.NoteResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrTaskNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockINoteRepository)
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockRepo.AssertExpectations(t)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			tC.setup(mockRepo, mockTaskRepo)
+
+			svc := NewNoteService(mockRepo, mockTaskRepo, lgr, mockPayload)
+
+			got, gotErr := svc.CreateNote(ctx, tC.req)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestNoteService_GetNote(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	noteId := "550e8400-e29b-41d4-a716-446655440000"
+	note := &models.Note{ID: uuid.MustParse(noteId), UserID: "1", Title: "Roadmap", Body: "# Q3\n\n<script>x</script>"}
+
+	testCases := []struct {
+		name   string
+		req    *entities.GetNoteRequest
+		setup  func(*mocks.MockINoteRepository)
+		verify func(t *testing.T, got *entities.NoteResponse, gotErr error)
+	}{
+		{
+			name: "GetNote_WithBacklinks",
+			req:  &entities.GetNoteRequest{},
+			setup: func(repo *mocks.MockINoteRepository) {
+				repo.EXPECT().GetNote(ctx, noteId, "1").Return(note, nil)
+				repo.EXPECT().GetBacklinks(ctx, note, "1").Return(&[]models.Note{{ID: uuid.New(), Title: "Weekly sync"}}, nil)
+			},
+			verify: func(t *testing.T, got *entities.NoteResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Nil(t, got.BodyHTML)
+				assert.Len(t, got.Backlinks, 1)
+				assert.Equal(t, "Weekly sync", got.Backlinks[0].Title)
+			},
+		},
+		{
+			name: "GetNote_RenderHTML",
+			req:  &entities.GetNoteRequest{Format: "html"},
+			setup: func(repo *mocks.MockINoteRepository) {
+				repo.EXPECT().GetNote(ctx, noteId, "1").Return(note, nil)
+				repo.EXPECT().GetBacklinks(ctx, note, "1").Return(&[]models.Note{}, nil)
+			},
+			verify: func(t *testing.T, got *entities.NoteResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Contains(t, *got.BodyHTML, "<h1")
+				assert.NotContains(t, *got.BodyHTML, "<script")
+			},
+		},
+		{
+			name: "GetNote_NotFound",
+			req:  &entities.GetNoteRequest{},
+			setup: func(repo *mocks.MockINoteRepository) {
+				repo.EXPECT().GetNote(ctx, noteId, "1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.NoteResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrNoteNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockINoteRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			tC.setup(mockRepo)
+
+			svc := NewNoteService(mockRepo, nil, lgr, mockPayload)
+
+			got, gotErr := svc.GetNote(ctx, noteId, tC.req)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestNoteService_DeleteNote(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	noteId := "550e8400-e29b-41d4-a716-446655440000"
+	workspaceId := "660e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name   string
+		ctx    context.Context
+		author string
+		setup  func(context.Context, *mocks.MockINoteRepository)
+		expect error
+	}{
+		{
+			name:   "DeleteNote_Personal",
+			ctx:    context.Background(),
+			author: "1",
+			setup: func(ctx context.Context, repo *mocks.MockINoteRepository) {
+				repo.EXPECT().DeleteNote(ctx, noteId, "1").Return(1, nil)
+			},
+		},
+		{
+			name:   "DeleteNote_WorkspaceMemberNotAuthor",
+			ctx:    utils.WithWorkspace(context.Background(), workspaceId, constants.WorkspaceRoleMember),
+			author: "2",
+			setup:  func(ctx context.Context, repo *mocks.MockINoteRepository) {},
+			expect: constants.ErrNotNoteAuthor,
+		},
+		{
+			name:   "DeleteNote_WorkspaceAdmin",
+			ctx:    utils.WithWorkspace(context.Background(), workspaceId, constants.WorkspaceRoleAdmin),
+			author: "2",
+			setup: func(ctx context.Context, repo *mocks.MockINoteRepository) {
+				repo.EXPECT().DeleteNote(ctx, noteId, "1").Return(1, nil)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockINoteRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(tC.ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			mockRepo.EXPECT().GetNote(tC.ctx, noteId, "1").Return(&models.Note{ID: uuid.MustParse(noteId), UserID: tC.author}, nil)
+			tC.setup(tC.ctx, mockRepo)
+
+			svc := NewNoteService(mockRepo, nil, lgr, mockPayload)
+
+			gotErr := svc.DeleteNote(tC.ctx, noteId)
+
+			assert.Equal(t, tC.expect, gotErr)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"context"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type INotificationService interface {
+	GetNotifications(ctx context.Context, req *entities.GetNotificationsRequest) (*entities.GetNotificationsResponse, error)
+	MarkRead(ctx context.Context, id string) error
+	MarkAllRead(ctx context.Context) (*entities.MarkAllNotificationsReadResponse, error)
+}
+
+type NotificationService struct {
+	repo    repositories.INotificationRepository
+	log     *log.Logger
+	payload utils.IPayloadConstruct
+}
+
+func NewNotificationService(
+	repo repositories.INotificationRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) INotificationService {
+	return &NotificationService{
+		repo:    repo,
+		log:     log,
+		payload: payload,
+	}
+}
+
+func (s *NotificationService) GetNotifications(ctx context.Context, req *entities.GetNotificationsRequest) (*entities.GetNotificationsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetNotifications] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetNotifications] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	offset := (req.Offset - 1) * req.Limit
+	repoNotifications, total, err := s.repo.GetNotifications(ctx, authPayload.UserId, req.UnreadOnly, req.Limit, offset)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetNotifications] Failed to get notifications", err)
+		return nil, err
+	}
+
+	notifications := []entities.NotificationResponse{}
+	for i := range *repoNotifications {
+		notifications = append(notifications, *toNotificationResponse(ctx, &(*repoNotifications)[i]))
+	}
+
+	resp := &entities.GetNotificationsResponse{
+		Total:         total,
+		Notifications: notifications,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetNotifications] Notifications retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *NotificationService) MarkRead(ctx context.Context, id string) error {
+	s.log.DebugWithID(ctx, "[Service: MarkRead] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MarkRead] Failed to get auth payload", err)
+		return err
+	}
+
+	updated, err := s.repo.MarkRead(ctx, authPayload.UserId, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MarkRead] Failed to mark notification read", err)
+		return err
+	}
+
+	// Someone else's notification looks exactly like a missing one
+	if updated == 0 {
+		s.log.ErrorWithID(ctx, "[Service: MarkRead] Notification not found", constants.ErrNotificationNotFound)
+		return constants.ErrNotificationNotFound
+	}
+
+	s.log.DebugWithID(ctx, "[Service: MarkRead] Notification marked read successfully")
+	return nil
+}
+
+func (s *NotificationService) MarkAllRead(ctx context.Context) (*entities.MarkAllNotificationsReadResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: MarkAllRead] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MarkAllRead] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	updated, err := s.repo.MarkAllRead(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MarkAllRead] Failed to mark notifications read", err)
+		return nil, err
+	}
+
+	resp := &entities.MarkAllNotificationsReadResponse{Updated: updated}
+	s.log.DebugWithID(ctx, "[Service: MarkAllRead] Notifications marked read successfully", resp)
+	return resp, nil
+}
+
+func toNotificationResponse(ctx context.Context, n *models.Notification) *entities.NotificationResponse {
+	var readAt *string
+	if n.ReadAt != nil {
+		formatted := utils.FormatRFC3339(ctx, *n.ReadAt)
+		readAt = &formatted
+	}
+
+	return &entities.NotificationResponse{
+		ID:        n.ID.String(),
+		Type:      n.Type,
+		TaskID:    n.TaskID.String(),
+		ActorID:   n.ActorID,
+		Message:   n.Message,
+		ReadAt:    readAt,
+		CreatedAt: utils.FormatRFC3339(ctx, n.CreatedAt),
+	}
+}
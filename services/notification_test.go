@@ -0,0 +1,71 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestNotificationService_GetNotifications(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockRepo := new(mocks.MockINotificationRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockRepo.EXPECT().
+		GetNotifications(ctx, "1", true, 10, 10).
+		Return(&[]models.Notification{{ID: uuid.New(), UserID: "1", Type: "TASK_ASSIGNED"}}, int64(11), nil)
+
+	svc := NewNotificationService(mockRepo, lgr, mockPayload)
+
+	got, gotErr := svc.GetNotifications(ctx, &entities.GetNotificationsRequest{UnreadOnly: true, Limit: 10, Offset: 2})
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, int64(11), got.Total)
+	assert.Len(t, got.Notifications, 1)
+	assert.Nil(t, got.Notifications[0].ReadAt)
+}
+
+func TestNotificationService_MarkRead(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	notificationId := "550e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name    string
+		updated int64
+		expect  error
+	}{
+		{name: "MarkRead_OK", updated: 1},
+		{name: "MarkRead_NotFound", updated: 0, expect: constants.ErrNotificationNotFound},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockINotificationRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			mockRepo.EXPECT().MarkRead(ctx, "1", notificationId).Return(tC.updated, nil)
+
+			svc := NewNotificationService(mockRepo, lgr, mockPayload)
+
+			assert.Equal(t, tC.expect, svc.MarkRead(ctx, notificationId))
+		})
+	}
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+// getTaskWithPermission loads a task and verifies the user holds at least the
+// required permission on it. Every task-scoped resource goes through here so
+// they all share the same ownership, sharing and workspace rules. The resolved permission
+// is stored on task.Permission.
+func getTaskWithPermission(
+	ctx context.Context,
+	repo repositories.ITaskRepository,
+	log *log.Logger,
+	userId string,
+	taskId string,
+	required constants.TaskPermission,
+) (*models.Task, error) {
+	task, err := repo.GetTask(ctx, taskId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			log.ErrorWithID(ctx, "[Service: getTaskWithPermission] Task not found: ", err)
+			return nil, constants.ErrTaskNotFound
+		}
+
+		log.ErrorWithID(ctx, "[Service: getTaskWithPermission] Failed to get task", err)
+		return nil, err
+	}
+
+	permission := constants.TaskPermissionOwner
+	if task.WorkspaceID != nil {
+		// The repository only returns workspace tasks to members of that
+		// workspace, so the role from the request decides the permission
+		_, role, _ := utils.WorkspaceFromContext(ctx)
+		if userId != task.UserID && !role.Allows(constants.WorkspaceRoleAdmin) {
+			permission = constants.TaskPermissionEditor
+		}
+	} else if userId != task.UserID {
+		shared, err := repo.GetSharePermission(ctx, taskId, userId)
+		if err != nil {
+			log.ErrorWithID(ctx, "[Service: getTaskWithPermission] Failed to get share permission", err)
+			return nil, err
+		}
+
+		// Not shared at all: behave exactly like a foreign task
+		if shared == "" {
+			log.ErrorWithID(ctx, "[Service: getTaskWithPermission] Failed to verify task id", constants.ErrUserIdDoesNotMatchWithYourAccount)
+			return nil, constants.ErrUserIdDoesNotMatchWithYourAccount
+		}
+		permission = constants.TaskPermission(shared)
+	}
+
+	if !permission.Allows(required) {
+		log.ErrorWithID(ctx, "[Service: getTaskWithPermission] Permission too low: ", permission, " < ", required)
+		return nil, constants.ErrInsufficientPermission
+	}
+
+	task.Permission = string(permission)
+	return task, nil
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+type IRevisionService interface {
+	GetRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string) (*entities.GetRevisionsResponse, error)
+	DiffRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, req *entities.DiffRevisionsRequest) (*entities.DiffRevisionsResponse, error)
+	RestoreRevision(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, version int) (*entities.GetRevisionsResponse, error)
+}
+
+// RevisionService serves the revisions of note bodies and task descriptions.
+// Access follows the resource: anyone who can read a note or task can read its
+// revisions, and anyone who can edit it can restore one.
+type RevisionService struct {
+	repo     repositories.IRevisionRepository
+	noteRepo repositories.INoteRepository
+	taskRepo repositories.ITaskRepository
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+}
+
+func NewRevisionService(
+	repo repositories.IRevisionRepository,
+	noteRepo repositories.INoteRepository,
+	taskRepo repositories.ITaskRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) IRevisionService {
+	return &RevisionService{
+		repo:     repo,
+		noteRepo: noteRepo,
+		taskRepo: taskRepo,
+		log:      log,
+		payload:  payload,
+	}
+}
+
+func (s *RevisionService) GetRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string) (*entities.GetRevisionsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetRevisions] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetRevisions] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the resource
+	if err := s.checkAccess(ctx, resourceType, resourceId, authPayload.UserId, constants.TaskPermissionViewer); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetRevisions] Failed to get resource", err)
+		return nil, err
+	}
+
+	resp, err := s.getRevisions(ctx, resourceType, resourceId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetRevisions] Failed to get revisions", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetRevisions] Revisions retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *RevisionService) DiffRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, req *entities.DiffRevisionsRequest) (*entities.DiffRevisionsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: DiffRevisions] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DiffRevisions] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the resource
+	if err := s.checkAccess(ctx, resourceType, resourceId, authPayload.UserId, constants.TaskPermissionViewer); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DiffRevisions] Failed to get resource", err)
+		return nil, err
+	}
+
+	// Get both revisions
+	from, err := s.getRevision(ctx, resourceType, resourceId, req.From)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DiffRevisions] Failed to get from revision", err)
+		return nil, err
+	}
+	to, err := s.getRevision(ctx, resourceType, resourceId, req.To)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DiffRevisions] Failed to get to revision", err)
+		return nil, err
+	}
+
+	resp := &entities.DiffRevisionsResponse{
+		From: from.Version,
+		To:   to.Version,
+		Diff: utils.UnifiedDiff(fmt.Sprintf("v%d", from.Version), fmt.Sprintf("v%d", to.Version), from.Content, to.Content),
+	}
+
+	s.log.DebugWithID(ctx, "[Service: DiffRevisions] Revisions diffed successfully")
+	return resp, nil
+}
+
+func (s *RevisionService) RestoreRevision(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, version int) (*entities.GetRevisionsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: RestoreRevision] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RestoreRevision] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Check access to the resource before revealing whether the version exists
+	if err := s.checkAccess(ctx, resourceType, resourceId, authPayload.UserId, constants.TaskPermissionEditor); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RestoreRevision] Failed to get resource", err)
+		return nil, err
+	}
+
+	// Get the target revision
+	target, err := s.getRevision(ctx, resourceType, resourceId, version)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RestoreRevision] Failed to get revision", err)
+		return nil, err
+	}
+
+	// Save the restored text as a new revision
+	switch resourceType {
+	case constants.RevisionResourceNote:
+		err = s.restoreNote(ctx, resourceId, authPayload.UserId, target)
+	case constants.RevisionResourceTask:
+		err = s.restoreTaskDescription(ctx, resourceId, authPayload.UserId, target)
+	}
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RestoreRevision] Failed to restore revision", err)
+		return nil, err
+	}
+
+	resp, err := s.getRevisions(ctx, resourceType, resourceId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RestoreRevision] Failed to get revisions", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: RestoreRevision] Revision restored successfully", resp)
+	return resp, nil
+}
+
+// checkAccess verifies the user holds at least the required permission on the
+// resource. Personal notes are only visible to their author and every member
+// may edit a workspace note, so for notes visibility is enough.
+func (s *RevisionService) checkAccess(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, userId string, required constants.TaskPermission) error {
+	switch resourceType {
+	case constants.RevisionResourceNote:
+		if _, err := s.noteRepo.GetNote(ctx, resourceId, userId); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return constants.ErrNoteNotFound
+			}
+			return err
+		}
+		return nil
+	default:
+		_, err := getTaskWithPermission(ctx, s.taskRepo, s.log, userId, resourceId, required)
+		return err
+	}
+}
+
+// restoreNote puts a revision's title and body back; unchanged text is
+// still recorded, so the restore shows up as the newest revision
+func (s *RevisionService) restoreNote(ctx context.Context, noteId string, userId string, target *models.Revision) error {
+	note, err := s.noteRepo.GetNote(ctx, noteId, userId)
+	if err != nil {
+		return err
+	}
+
+	if target.Title != nil {
+		note.Title = *target.Title
+	}
+	note.Body = target.Content
+	note.UpdatedAt = time.Now()
+
+	return s.noteRepo.UpdateNote(ctx, note, utils.ExtractNoteLinks(note.Body), newNoteRevision(note, userId))
+}
+
+// restoreTaskDescription puts a revision's text back as the task description
+// through the task history, which records the new description revision. An
+// empty revision clears the description.
+func (s *RevisionService) restoreTaskDescription(ctx context.Context, taskId string, userId string, target *models.Revision) error {
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, userId, taskId, constants.TaskPermissionEditor)
+	if err != nil {
+		return err
+	}
+
+	before := task.Snapshot()
+	task.Description = nil
+	if target.Content != "" {
+		content := target.Content
+		task.Description = &content
+	}
+
+	changes := utils.DiffTaskSnapshots(before, task.Snapshot())
+	if len(changes) == 0 {
+		return nil
+	}
+
+	return s.taskRepo.UpdateTask(ctx, task, newTaskHistory(task, userId, constants.TaskHistoryActionRevert, changes))
+}
+
+func (s *RevisionService) getRevision(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string, version int) (*models.Revision, error) {
+	revision, err := s.repo.GetRevision(ctx, resourceType, resourceId, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return revision, nil
+}
+
+func (s *RevisionService) getRevisions(ctx context.Context, resourceType constants.RevisionResourceType, resourceId string) (*entities.GetRevisionsResponse, error) {
+	repoRevisions, err := s.repo.GetRevisions(ctx, resourceType, resourceId)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := []entities.RevisionResponse{}
+	for _, r := range *repoRevisions {
+		revisions = append(revisions, entities.RevisionResponse{
+			Version:     r.Version,
+			Title:       r.Title,
+			ActorID:     r.ActorID,
+			ContentHash: r.ContentHash,
+			Size:        r.Size,
+			CreatedAt:   utils.FormatRFC3339(ctx, r.CreatedAt),
+		})
+	}
+
+	return &entities.GetRevisionsResponse{
+		ResourceID: resourceId,
+		Total:      len(revisions),
+		Revisions:  revisions,
+	}, nil
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestRevisionService_GetRevisions(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	noteId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	testCases := []struct {
+		name   string
+		setup  func(*mocks.MockIRevisionRepository, *mocks.MockINoteRepository)
+		verify func(t *testing.T, got *entities.GetRevisionsResponse, gotErr error)
+	}{
+		{
+			name: "GetRevisions_OK",
+			setup: func(repo *mocks.MockIRevisionRepository, noteRepo *mocks.MockINoteRepository) {
+				noteRepo.EXPECT().GetNote(ctx, noteId, "1").Return(&models.Note{ID: uuid.MustParse(noteId), UserID: "1"}, nil)
+				repo.EXPECT().GetRevisions(ctx, constants.RevisionResourceNote, noteId).Return(&[]models.Revision{
+					{Version: 2, ActorID: "1", ContentHash: "b", Size: 12, CreatedAt: time.Now()},
+					{Version: 1, ActorID: "1", ContentHash: "a", Size: 5, CreatedAt: time.Now()},
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.GetRevisionsResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, 2, got.Total)
+				assert.Equal(t, 2, got.Revisions[0].Version)
+				assert.Equal(t, 12, got.Revisions[0].Size)
+			},
+		},
+		{
+			name: "GetRevisions_NoteNotFound",
+			setup: func(repo *mocks.MockIRevisionRepository, noteRepo *mocks.MockINoteRepository) {
+				noteRepo.EXPECT().GetNote(ctx, noteId, "1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.GetRevisionsResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrNoteNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIRevisionRepository(t)
+			mockNoteRepo := mocks.NewMockINoteRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tc.setup(mockRepo, mockNoteRepo)
+
+			service := NewRevisionService(mockRepo, mockNoteRepo, nil, lgr, mockPayload)
+			got, err := service.GetRevisions(ctx, constants.RevisionResourceNote, noteId)
+			tc.verify(t, got, err)
+		})
+	}
+}
+
+func TestRevisionService_DiffRevisions(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+	ownedTask := &models.Task{ID: uuid.MustParse(taskId), UserID: "1"}
+
+	testCases := []struct {
+		name   string
+		req    *entities.DiffRevisionsRequest
+		setup  func(*mocks.MockIRevisionRepository, *mocks.MockITaskRepository)
+		verify func(t *testing.T, got *entities.DiffRevisionsResponse, gotErr error)
+	}{
+		{
+			name: "DiffRevisions_OK",
+			req:  &entities.DiffRevisionsRequest{From: 1, To: 2},
+			setup: func(repo *mocks.MockIRevisionRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				repo.EXPECT().GetRevision(ctx, constants.RevisionResourceTask, taskId, 1).Return(&models.Revision{Version: 1, Content: "buy milk\n"}, nil)
+				repo.EXPECT().GetRevision(ctx, constants.RevisionResourceTask, taskId, 2).Return(&models.Revision{Version: 2, Content: "buy oat milk\n"}, nil)
+			},
+			verify: func(t *testing.T, got *entities.DiffRevisionsResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.True(t, strings.HasPrefix(got.Diff, "--- v1\n+++ v2\n"))
+				assert.Contains(t, got.Diff, "-buy milk\n+buy oat milk\n")
+			},
+		},
+		{
+			name: "DiffRevisions_RevisionNotFound",
+			req:  &entities.DiffRevisionsRequest{From: 1, To: 9},
+			setup: func(repo *mocks.MockIRevisionRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				repo.EXPECT().GetRevision(ctx, constants.RevisionResourceTask, taskId, 1).Return(&models.Revision{Version: 1}, nil)
+				repo.EXPECT().GetRevision(ctx, constants.RevisionResourceTask, taskId, 9).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.DiffRevisionsResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrRevisionNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIRevisionRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tc.setup(mockRepo, mockTaskRepo)
+
+			service := NewRevisionService(mockRepo, nil, mockTaskRepo, lgr, mockPayload)
+			got, err := service.DiffRevisions(ctx, constants.RevisionResourceTask, taskId, tc.req)
+			tc.verify(t, got, err)
+		})
+	}
+}
+
+func TestRevisionService_RestoreRevision(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	testCases := []struct {
+		name   string
+		setup  func(*mocks.MockIRevisionRepository, *mocks.MockITaskRepository)
+		verify func(t *testing.T, got *entities.GetRevisionsResponse, gotErr error)
+	}{
+		{
+			name: "RestoreRevision_TaskDescription",
+			setup: func(repo *mocks.MockIRevisionRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: "1", Description: ptr("current")}, nil).Times(2)
+				repo.EXPECT().GetRevision(ctx, constants.RevisionResourceTask, taskId, 1).Return(&models.Revision{Version: 1, Content: "original"}, nil)
+				taskRepo.EXPECT().
+					UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+						return task.Description != nil && *task.Description == "original"
+					}), mock.MatchedBy(func(history *models.TaskHistory) bool {
+						return history.Action == string(constants.TaskHistoryActionRevert) &&
+							len(history.Changes) == 1 && history.Changes[0].Field == "description"
+					})).
+					Return(nil)
+				repo.EXPECT().GetRevisions(ctx, constants.RevisionResourceTask, taskId).Return(&[]models.Revision{
+					{Version: 3, ActorID: "1"}, {Version: 2, ActorID: "1"}, {Version: 1, ActorID: "1"},
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.GetRevisionsResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, 3, got.Revisions[0].Version)
+			},
+		},
+		{
+			name: "RestoreRevision_SharedViewer",
+			setup: func(repo *mocks.MockIRevisionRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: "2"}, nil)
+				taskRepo.EXPECT().GetSharePermission(ctx, taskId, "1").Return(string(constants.TaskPermissionViewer), nil)
+			},
+			verify: func(t *testing.T, got *entities.GetRevisionsResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInsufficientPermission, gotErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIRevisionRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tc.setup(mockRepo, mockTaskRepo)
+
+			service := NewRevisionService(mockRepo, nil, mockTaskRepo, lgr, mockPayload)
+			got, err := service.RestoreRevision(ctx, constants.RevisionResourceTask, taskId, 1)
+			tc.verify(t, got, err)
+		})
+	}
+}
@@ -3,9 +3,13 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
 	constants "github.com/guncv/tech-exam-software-engineering/constant"
 	"github.com/guncv/tech-exam-software-engineering/entities"
 	"github.com/guncv/tech-exam-software-engineering/infras/log"
@@ -21,25 +25,55 @@ type ITaskService interface {
 	CreateTask(ctx context.Context, req *entities.CreateTaskRequest) (*entities.CreateTaskResponse, error)
 	GetTask(ctx context.Context, req string) (*entities.GetTaskResponse, error)
 	UpdateTask(ctx context.Context, id string, req *entities.UpdateTaskRequest) (*entities.UpdateTaskResponse, error)
+	PatchTask(ctx context.Context, id string, req *entities.PatchTaskRequest) (*entities.UpdateTaskResponse, error)
 	DeleteTask(ctx context.Context, id string) error
 	GetAllTasks(ctx context.Context, req *entities.GetAllTasksRequest) (*entities.GetAllTasksResponse, error)
+	GetTaskHistory(ctx context.Context, id string) (*entities.GetTaskHistoryResponse, error)
+	RevertTask(ctx context.Context, id string, version int, req *entities.RevertTaskRequest) (*entities.UpdateTaskResponse, error)
+	MoveTask(ctx context.Context, id string, req *entities.MoveTaskRequest) (*entities.GetTaskResponse, error)
+	GetTaskBoard(ctx context.Context) (*entities.GetTaskBoardResponse, error)
+	BulkTasks(ctx context.Context, req *entities.BulkTaskRequest) (*entities.BulkTaskResponse, error)
 }
 
 type TaskService struct {
-	repo    repositories.ITaskRepository
-	log     *log.Logger
-	payload utils.IPayloadConstruct
+	repo             repositories.ITaskRepository
+	workspaceRepo    repositories.IWorkspaceRepository
+	notificationRepo repositories.INotificationRepository
+	idempotencyRepo  repositories.IIdempotencyRepository
+	attachmentRepo   repositories.IAttachmentRepository
+	blobs            utils.IBlobStore
+	signer           utils.IURLSigner
+	log              *log.Logger
+	payload          utils.IPayloadConstruct
+	cursors          utils.ICursorCodec
+	config           *config.Config
 }
 
 func NewTaskService(
 	repo repositories.ITaskRepository,
+	workspaceRepo repositories.IWorkspaceRepository,
+	notificationRepo repositories.INotificationRepository,
+	idempotencyRepo repositories.IIdempotencyRepository,
+	attachmentRepo repositories.IAttachmentRepository,
+	blobs utils.IBlobStore,
+	signer utils.IURLSigner,
 	log *log.Logger,
 	payload utils.IPayloadConstruct,
+	cursors utils.ICursorCodec,
+	config *config.Config,
 ) ITaskService {
 	return &TaskService{
-		repo:    repo,
-		log:     log,
-		payload: payload,
+		repo:             repo,
+		workspaceRepo:    workspaceRepo,
+		notificationRepo: notificationRepo,
+		idempotencyRepo:  idempotencyRepo,
+		attachmentRepo:   attachmentRepo,
+		blobs:            blobs,
+		signer:           signer,
+		log:              log,
+		payload:          payload,
+		cursors:          cursors,
+		config:           config,
 	}
 }
 
@@ -65,32 +99,157 @@ func (s *TaskService) CreateTask(ctx context.Context, req *entities.CreateTaskRe
 	}
 	s.log.DebugWithID(ctx, "[Service: CreateTask] Auth payload: ", authPayload)
 
-	// Encode image to base64
-	base64Image := ""
-	if req.Image != nil {
-		var err error
-		base64Image, err = utils.ConvertFileHeaderToBase64(req.Image)
+	// Create task, once per idempotency key
+	var fingerprint string
+	if req.IdempotencyKey != "" {
+		imageDigest := ""
+		if req.Image != nil {
+			if imageDigest, err = fileDigest(req.Image); err != nil {
+				s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to read image", err)
+				return nil, err
+			}
+		}
+		workspaceId, _, _ := utils.WorkspaceFromContext(ctx)
+		fingerprint, err = requestFingerprint(createTaskFingerprint{
+			WorkspaceID: workspaceId,
+			Title:       req.Title,
+			Description: req.Description,
+			Status:      req.Status,
+			Date:        req.Date,
+			AllDay:      req.AllDay,
+			ImageDigest: imageDigest,
+			ImageID:     req.ImageID,
+			AssigneeID:  req.AssigneeID,
+			Tags:        req.Tags,
+		})
 		if err != nil {
-			s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to encode image to base64", err)
+			s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to fingerprint request", err)
 			return nil, err
 		}
 	}
+	resp, err := runIdempotent(ctx, s.idempotencyRepo, s.log, authPayload.UserId, req.IdempotencyKey, fingerprint, s.idempotencyKeyTTL(), s.idempotencyLockTimeout(), func() (*entities.CreateTaskResponse, error) {
+		return s.createTask(ctx, authPayload.UserId, req)
+	}, func(resp *entities.CreateTaskResponse) error {
+		return s.resignReplayedImage(ctx, authPayload.UserId, resp)
+	})
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to create task", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: CreateTask] Task created successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskService) idempotencyKeyTTL() time.Duration {
+	if s.config != nil && s.config.Idempotency.KeyTTL > 0 {
+		return s.config.Idempotency.KeyTTL
+	}
+	return defaultIdempotencyKeyTTL
+}
+
+func (s *TaskService) idempotencyLockTimeout() time.Duration {
+	if s.config != nil && s.config.Idempotency.LockTimeout > 0 {
+		return s.config.Idempotency.LockTimeout
+	}
+	return defaultIdempotencyLockTimeout
+}
 
+// resignReplayedImage signs the image link of a replayed CreateTask response
+// again, as the stored one expires. The link is to the task's current
+// image; a task deleted since has none.
+func (s *TaskService) resignReplayedImage(ctx context.Context, userId string, resp *entities.CreateTaskResponse) error {
+	if resp.Image == nil {
+		return nil
+	}
+	task, err := s.repo.GetTask(ctx, resp.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		resp.Image = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Image = taskImageURL(s.signer, userId, task)
+	return nil
+}
+
+// createTaskFingerprint is what makes two CreateTask requests the same
+type createTaskFingerprint struct {
+	WorkspaceID string
+	Title       string
+	Description *string
+	Status      constants.TaskStatus
+	Date        time.Time
+	AllDay      bool
+	ImageDigest string
+	ImageID     *string
+	AssigneeID  *string
+	Tags        []string
+}
+
+// createTask stores a new task for the user and notifies its assignee
+func (s *TaskService) createTask(ctx context.Context, userId string, req *entities.CreateTaskRequest) (*entities.CreateTaskResponse, error) {
 	// Create task
 	arg := &models.Task{
 		ID:          uuid.New(),
-		UserID:      authPayload.UserId,
+		UserID:      userId,
 		Title:       req.Title,
 		Status:      string(req.Status),
-		Image:       &base64Image,
-		Date:        req.Date,
 		Description: req.Description,
+		Tags:        utils.NormalizeTags(req.Tags),
+		Version:     1,
 		CreatedAt:   time.Now(),
 	}
+	setTaskDate(ctx, arg, &req.Date, &req.AllDay)
+	if workspaceId, _, ok := utils.WorkspaceFromContext(ctx); ok {
+		arg.WorkspaceID = &workspaceId
+	}
 	s.log.DebugWithID(ctx, "[Service: CreateTask] Task: ", arg)
 
+	// Verify the assignee can access the task
+	if req.AssigneeID != nil && *req.AssigneeID != "" {
+		if err := s.verifyAssignee(ctx, arg, *req.AssigneeID); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to verify assignee", err)
+			return nil, err
+		}
+		arg.AssigneeID = req.AssigneeID
+	}
+
+	// Store image
+	var image *models.Attachment
+	if req.Image != nil {
+		img, err := readImage(req.Image, maxImageSize(s.config))
+		if err != nil {
+			s.log.ErrorWithID(ctx, "[Service: CreateTask] Invalid image", err)
+			return nil, err
+		}
+		if err := checkStorageQuota(ctx, s.attachmentRepo, userId, int64(len(img.Data)), storageQuota(s.config)); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: CreateTask] Storage quota check failed", err)
+			return nil, err
+		}
+		if image, err = storeImage(ctx, s.blobs, s.log, arg.ID, userId, req.Image.Filename, img); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to store image", err)
+			return nil, err
+		}
+		imageId := image.ID.String()
+		arg.ImageID = &imageId
+	} else if req.ImageID != nil && *req.ImageID != "" {
+		var err error
+		if image, err = s.copyUserImage(ctx, userId, arg.ID, *req.ImageID); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to copy image", err)
+			return nil, err
+		}
+		imageId := image.ID.String()
+		arg.ImageID = &imageId
+	}
+
 	// Create task in repository
-	if err := s.repo.CreateTask(ctx, arg); err != nil {
+	history := newTaskHistory(arg, userId, constants.TaskHistoryActionCreate, utils.DiffTaskSnapshots(nil, arg.Snapshot()))
+	if err := s.repo.CreateTask(ctx, arg, image, storageQuota(s.config), history); err != nil {
+		if image != nil {
+			deleteBlobs(ctx, s.blobs, s.log, []models.Attachment{*image})
+		}
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code.Name() {
 			case "unique_violation":
@@ -101,6 +260,7 @@ func (s *TaskService) CreateTask(ctx context.Context, req *entities.CreateTaskRe
 		s.log.ErrorWithID(ctx, "[Service: CreateTask] Failed to create task", err)
 		return nil, err
 	}
+	s.notifyAssignment(ctx, arg, userId, nil)
 
 	// Convert to response
 	resp := &entities.CreateTaskResponse{
@@ -108,12 +268,15 @@ func (s *TaskService) CreateTask(ctx context.Context, req *entities.CreateTaskRe
 		UserID:      arg.UserID,
 		Title:       arg.Title,
 		Status:      arg.Status,
-		Date:        arg.Date,
-		Image:       arg.Image,
+		Date:        utils.FormatTaskDate(ctx, arg.Date, arg.AllDay),
+		AllDay:      arg.AllDay,
+		Image:       taskImageURL(s.signer, userId, arg),
 		Description: arg.Description,
+		AssigneeID:  arg.AssigneeID,
+		Tags:        tagList(arg.Tags),
+		Version:     arg.Version,
 	}
 
-	s.log.DebugWithID(ctx, "[Service: CreateTask] Task created successfully", resp)
 	return resp, nil
 }
 
@@ -129,34 +292,14 @@ func (s *TaskService) GetTask(ctx context.Context, req string) (*entities.GetTas
 	s.log.DebugWithID(ctx, "[Service: GetTask] Auth payload: ", authPayload)
 
 	// Get task from repository
-	repoResponse, err := s.repo.GetTask(ctx, req)
+	repoResponse, err := getTaskWithPermission(ctx, s.repo, s.log, authPayload.UserId, req, constants.TaskPermissionViewer)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.log.ErrorWithID(ctx, "[Service: GetTask] Task not found: ", err)
-			return nil, constants.ErrTaskNotFound
-		}
-
 		s.log.ErrorWithID(ctx, "[Service: GetTask] Failed to get task", err)
 		return nil, err
 	}
 
-	// Verify payload user id with your account id
-	if authPayload.UserId != repoResponse.UserID {
-		s.log.ErrorWithID(ctx, "[Service: GetTask] Failed to verify task id", constants.ErrUserIdDoesNotMatchWithYourAccount)
-		return nil, constants.ErrUserIdDoesNotMatchWithYourAccount
-	}
-
 	// Convert to response
-	resp := &entities.GetTaskResponse{
-		ID:          repoResponse.ID.String(),
-		UserID:      repoResponse.UserID,
-		Title:       repoResponse.Title,
-		Status:      repoResponse.Status,
-		Image:       repoResponse.Image,
-		Date:        utils.FormatBangkokRFC3339(repoResponse.Date),
-		Description: repoResponse.Description,
-		CreatedAt:   utils.FormatBangkokRFC3339(repoResponse.CreatedAt),
-	}
+	resp := toGetTaskResponse(ctx, s.signer, authPayload.UserId, repoResponse)
 
 	s.log.DebugWithID(ctx, "[Service: GetTask] Task retrieved successfully", resp)
 	return resp, nil
@@ -172,25 +315,21 @@ func (s *TaskService) UpdateTask(ctx context.Context, id string, req *entities.U
 		return nil, err
 	}
 
-	// Get existing task
-	existingTask, err := s.repo.GetTask(ctx, id)
+	// Get existing task and check permission
+	existingTask, err := getTaskWithPermission(ctx, s.repo, s.log, authPayload.UserId, id, constants.TaskPermissionEditor)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.log.ErrorWithID(ctx, "[Service: UpdateTask] Task not found: ", err)
-			return nil, constants.ErrTaskNotFound
-		}
-
 		s.log.ErrorWithID(ctx, "[Service: UpdateTask] Failed to get task", err)
 		return nil, err
 	}
 
-	// Check ownership
-	if authPayload.UserId != existingTask.UserID {
-		s.log.ErrorWithID(ctx, "[Service: UpdateTask] User ID does not match task owner", constants.ErrUserIdDoesNotMatchWithYourAccount)
-		return nil, constants.ErrUserIdDoesNotMatchWithYourAccount
+	// Reject the update if the client's copy is out of date
+	if !utils.IfMatch(req.IfMatch, utils.VersionETag(existingTask.Version)) {
+		s.log.ErrorWithID(ctx, "[Service: UpdateTask] Task version does not match", req.IfMatch)
+		return nil, constants.ErrTaskVersionMismatch
 	}
 
 	// Update fields if present
+	before := existingTask.Snapshot()
 	if req.Title != "" {
 		existingTask.Title = req.Title
 	}
@@ -200,40 +339,237 @@ func (s *TaskService) UpdateTask(ctx context.Context, id string, req *entities.U
 	if req.Status != "" {
 		existingTask.Status = string(req.Status)
 	}
+	var date *time.Time
 	if !req.Date.IsZero() {
-		existingTask.Date = req.Date
+		date = &req.Date
 	}
-	if req.Image != nil {
-		base64Image, err := utils.ConvertFileHeaderToBase64(req.Image)
+	setTaskDate(ctx, existingTask, date, req.AllDay)
+	if req.Tags != nil {
+		existingTask.Tags = utils.NormalizeTags(req.Tags)
+	}
+	previousAssignee := existingTask.AssigneeID
+	if req.AssigneeID != nil {
+		if *req.AssigneeID == "" {
+			existingTask.AssigneeID = nil
+		} else {
+			if err := s.verifyAssignee(ctx, existingTask, *req.AssigneeID); err != nil {
+				s.log.ErrorWithID(ctx, "[Service: UpdateTask] Failed to verify assignee", err)
+				return nil, err
+			}
+			existingTask.AssigneeID = req.AssigneeID
+		}
+	}
+
+	// Store the new image; the old one is removed once the update is saved.
+	// An image picked from the task's attachments leaves the old one an
+	// attachment, unless the image is just removed.
+	previousImage := existingTask.ImageID
+	var image *models.Attachment
+	if req.ImageID != nil {
+		existingTask.ImageID = nil
+		if *req.ImageID != "" {
+			selected, err := s.taskImageAttachment(ctx, existingTask.ID.String(), *req.ImageID)
+			if err != nil {
+				s.log.ErrorWithID(ctx, "[Service: UpdateTask] Failed to get image", err)
+				return nil, err
+			}
+			imageId := selected.ID.String()
+			existingTask.ImageID = &imageId
+		}
+	} else if req.Image != nil {
+		img, err := readImage(req.Image, maxImageSize(s.config))
 		if err != nil {
-			s.log.ErrorWithID(ctx, "[Service: UpdateTask] Failed to convert image to base64", err)
+			s.log.ErrorWithID(ctx, "[Service: UpdateTask] Invalid image", err)
 			return nil, err
 		}
-		existingTask.Image = &base64Image
+		if err := checkStorageQuota(ctx, s.attachmentRepo, authPayload.UserId, int64(len(img.Data)), storageQuota(s.config)); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: UpdateTask] Storage quota check failed", err)
+			return nil, err
+		}
+		if image, err = storeImage(ctx, s.blobs, s.log, existingTask.ID, authPayload.UserId, req.Image.Filename, img); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: UpdateTask] Failed to store image", err)
+			return nil, err
+		}
+		if err := s.attachmentRepo.CreateAttachment(ctx, image, storageQuota(s.config)); err != nil {
+			deleteBlobs(ctx, s.blobs, s.log, []models.Attachment{*image})
+			s.log.ErrorWithID(ctx, "[Service: UpdateTask] Failed to save image", err)
+			return nil, err
+		}
+		imageId := image.ID.String()
+		existingTask.ImageID = &imageId
 	}
 
-	// Save update
-	if err := s.repo.UpdateTask(ctx, existingTask); err != nil {
+	resp, err := s.saveTaskUpdate(ctx, authPayload.UserId, existingTask, before, previousAssignee)
+	if err != nil {
+		if image != nil {
+			s.removeAttachment(ctx, existingTask.ID.String(), image.ID.String())
+		}
 		s.log.ErrorWithID(ctx, "[Service: UpdateTask] Failed to update task", err)
 		return nil, err
 	}
-
-	// Response
-	resp := &entities.UpdateTaskResponse{
-		ID:          existingTask.ID.String(),
-		UserID:      existingTask.UserID,
-		Title:       existingTask.Title,
-		Status:      existingTask.Status,
-		Date:        utils.FormatBangkokRFC3339(existingTask.Date),
-		Image:       existingTask.Image,
-		Description: existingTask.Description,
-		CreatedAt:   utils.FormatBangkokRFC3339(existingTask.CreatedAt),
+	if previousImage != nil && (image != nil || existingTask.ImageID == nil) {
+		s.removeAttachment(ctx, existingTask.ID.String(), *previousImage)
 	}
 
 	s.log.DebugWithID(ctx, "[Service: UpdateTask] Task updated successfully", resp)
 	return resp, nil
 }
 
+func (s *TaskService) PatchTask(ctx context.Context, id string, req *entities.PatchTaskRequest) (*entities.UpdateTaskResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: PatchTask] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: PatchTask] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get existing task and check permission
+	existingTask, err := getTaskWithPermission(ctx, s.repo, s.log, authPayload.UserId, id, constants.TaskPermissionEditor)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: PatchTask] Failed to get task", err)
+		return nil, err
+	}
+
+	// Reject the update if the client's copy is out of date
+	if !utils.IfMatch(req.IfMatch, utils.VersionETag(existingTask.Version)) {
+		s.log.ErrorWithID(ctx, "[Service: PatchTask] Task version does not match", req.IfMatch)
+		return nil, constants.ErrTaskVersionMismatch
+	}
+
+	// Merge the patch: members sent as null clear the field
+	before := existingTask.Snapshot()
+	if req.Title.Set {
+		existingTask.Title = req.Title.Value
+	}
+	if req.Description.Set {
+		existingTask.Description = nil
+		if !req.Description.Null {
+			existingTask.Description = &req.Description.Value
+		}
+	}
+	if req.Status.Set {
+		existingTask.Status = string(req.Status.Value)
+	}
+	var date *time.Time
+	var allDay *bool
+	if req.Date.Set {
+		date = &req.Date.Value
+	}
+	if req.AllDay.Set {
+		allDay = &req.AllDay.Value
+	}
+	setTaskDate(ctx, existingTask, date, allDay)
+	previousImage := existingTask.ImageID
+	if req.Image.Set && req.Image.Null {
+		existingTask.ImageID = nil
+	}
+	if req.ImageID.Set {
+		existingTask.ImageID = nil
+		if !req.ImageID.Null {
+			selected, err := s.taskImageAttachment(ctx, existingTask.ID.String(), req.ImageID.Value)
+			if err != nil {
+				s.log.ErrorWithID(ctx, "[Service: PatchTask] Failed to get image", err)
+				return nil, err
+			}
+			imageId := selected.ID.String()
+			existingTask.ImageID = &imageId
+		}
+	}
+	if req.Tags.Set {
+		existingTask.Tags = utils.NormalizeTags(req.Tags.Value)
+	}
+	previousAssignee := existingTask.AssigneeID
+	if req.AssigneeID.Set {
+		if req.AssigneeID.Null {
+			existingTask.AssigneeID = nil
+		} else {
+			if err := s.verifyAssignee(ctx, existingTask, req.AssigneeID.Value); err != nil {
+				s.log.ErrorWithID(ctx, "[Service: PatchTask] Failed to verify assignee", err)
+				return nil, err
+			}
+			existingTask.AssigneeID = &req.AssigneeID.Value
+		}
+	}
+
+	resp, err := s.saveTaskUpdate(ctx, authPayload.UserId, existingTask, before, previousAssignee)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: PatchTask] Failed to update task", err)
+		return nil, err
+	}
+	if previousImage != nil && existingTask.ImageID == nil {
+		s.removeAttachment(ctx, existingTask.ID.String(), *previousImage)
+	}
+
+	s.log.DebugWithID(ctx, "[Service: PatchTask] Task patched successfully", resp)
+	return resp, nil
+}
+
+// taskImageAttachment returns the task's attachment with the given id, if it
+// is an image that can be the task's image
+func (s *TaskService) taskImageAttachment(ctx context.Context, taskId string, id string) (*models.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetAttachment(ctx, taskId, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !utils.IsAllowedImageType(attachment.ContentType) {
+		return nil, constants.ErrUnsupportedImageType
+	}
+	return attachment, nil
+}
+
+// copyUserImage stores a copy of an image the user uploaded to another task
+// as the image of task taskId; the caller saves the attachment
+func (s *TaskService) copyUserImage(ctx context.Context, userId string, taskId uuid.UUID, id string) (*models.Attachment, error) {
+	source, err := s.attachmentRepo.GetUserAttachment(ctx, userId, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, constants.ErrAttachmentNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !utils.IsAllowedImageType(source.ContentType) {
+		return nil, constants.ErrUnsupportedImageType
+	}
+
+	img, err := readStoredImage(ctx, s.blobs, source, maxImageSize(s.config))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkStorageQuota(ctx, s.attachmentRepo, userId, int64(len(img.Data)), storageQuota(s.config)); err != nil {
+		return nil, err
+	}
+	return storeImage(ctx, s.blobs, s.log, taskId, userId, source.Filename, img)
+}
+
+// saveTaskUpdate checks and saves an edited task, recording what changed
+// since before and notifying a change of assignee
+func (s *TaskService) saveTaskUpdate(ctx context.Context, actorId string, task *models.Task, before *models.TaskSnapshot, previousAssignee *string) (*entities.UpdateTaskResponse, error) {
+	// A task cannot be completed while any of its blockers is still open
+	if err := checkCompletable(before, task); err != nil {
+		return nil, err
+	}
+	resetRankOnStatusChange(before, task)
+
+	// Record history only when something actually changed
+	var history *models.TaskHistory
+	if changes := utils.DiffTaskSnapshots(before, task.Snapshot()); len(changes) > 0 {
+		history = newTaskHistory(task, actorId, constants.TaskHistoryActionUpdate, changes)
+	}
+
+	// Save update
+	if err := s.repo.UpdateTask(ctx, task, history); err != nil {
+		return nil, err
+	}
+	s.notifyAssignment(ctx, task, actorId, previousAssignee)
+
+	return toUpdateTaskResponse(ctx, s.signer, actorId, task), nil
+}
+
 func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 	s.log.DebugWithID(ctx, "[Service: DeleteTask] Called")
 
@@ -244,29 +580,27 @@ func (s *TaskService) DeleteTask(ctx context.Context, id string) error {
 		return err
 	}
 
-	// Get existing task
-	existingTask, err := s.repo.GetTask(ctx, id)
+	// Get existing task and check permission
+	existingTask, err := getTaskWithPermission(ctx, s.repo, s.log, authPayload.UserId, id, constants.TaskPermissionOwner)
 	if err != nil {
-		if errors.Is(err, gorm.ErrRecordNotFound) {
-			s.log.ErrorWithID(ctx, "[Service: DeleteTask] Task not found: ", err)
-			return constants.ErrTaskNotFound
-		}
-
 		s.log.ErrorWithID(ctx, "[Service: DeleteTask] Failed to get task", err)
 		return err
 	}
 
-	// Check ownership
-	if authPayload.UserId != existingTask.UserID {
-		s.log.ErrorWithID(ctx, "[Service: DeleteTask] User ID does not match task owner", constants.ErrUserIdDoesNotMatchWithYourAccount)
-		return constants.ErrUserIdDoesNotMatchWithYourAccount
+	// Collect its attachments, whose rows go with the task
+	attachments, err := s.attachmentRepo.GetTaskAttachments(ctx, []string{id})
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteTask] Failed to get attachments", err)
+		return err
 	}
 
 	// Delete task
-	if err := s.repo.DeleteTask(ctx, id); err != nil {
+	history := newTaskHistory(existingTask, authPayload.UserId, constants.TaskHistoryActionDelete, utils.DiffTaskSnapshots(existingTask.Snapshot(), nil))
+	if err := s.repo.DeleteTask(ctx, id, history); err != nil {
 		s.log.ErrorWithID(ctx, "[Service: DeleteTask] Failed to delete task", err)
 		return err
 	}
+	deleteBlobs(ctx, s.blobs, s.log, *attachments)
 
 	s.log.DebugWithID(ctx, "[Service: DeleteTask] Task deleted successfully")
 	return nil
@@ -283,44 +617,837 @@ func (s *TaskService) GetAllTasks(ctx context.Context, req *entities.GetAllTasks
 	}
 	s.log.DebugWithID(ctx, "[Service: GetAllTasks] Auth payload: ", authPayload)
 
-	// Apply default values
-	if req.Order == "" {
-		req.Order = "desc"
+	// Apply default values and parse the filter
+	filter, err := prepareTaskListing(req, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetAllTasks] Invalid filter", err)
+		return nil, constants.ErrInvalidQueryRequestParam
 	}
-	if req.SortBy == "" {
-		req.SortBy = "created_at"
+
+	// A cursor only continues the listing it was made for
+	query := utils.QueryFingerprint(req.Search, req.Filter, req.Assignee, req.SortBy, req.Order, strconv.FormatBool(req.Archived))
+	var cursor *utils.Cursor
+	if req.Cursor != "" {
+		cursor, err = s.cursors.Decode(req.Cursor)
+		if err != nil || cursor.Query != query {
+			s.log.ErrorWithID(ctx, "[Service: GetAllTasks] Invalid cursor", err)
+			return nil, constants.ErrInvalidCursor
+		}
 	}
 
-	// Set Offset
-	req.Offset = (req.Offset - 1) * req.Limit
+	// Fetch one task past the page to learn whether another page follows
+	if req.Offset == 0 {
+		req.Offset = 1
+	}
+	pageReq := *req
+	pageReq.Limit = req.Limit + 1
+	pageReq.Offset = (req.Offset - 1) * req.Limit
 
 	// Fetch tasks from repository
-	repoTasks, err := s.repo.GetAllTasks(ctx, req, authPayload.UserId)
+	repoTasks, err := s.repo.GetAllTasks(ctx, &pageReq, filter, cursor, authPayload.UserId)
 	if err != nil {
 		s.log.ErrorWithID(ctx, "[Service: GetAllTasks] Failed to get all tasks", err)
 		return nil, err
 	}
 
+	page := *repoTasks
+	more := len(page) > req.Limit
+	if more {
+		page = page[:req.Limit]
+	}
+
+	// A backward page arrives nearest the cursor first
+	backward := cursor != nil && cursor.Before
+	if backward {
+		for i, j := 0, len(page)-1; i < j; i, j = i+1, j-1 {
+			page[i], page[j] = page[j], page[i]
+		}
+	}
+
 	// Convert []models.Task → []entities.Task
-	var tasks []entities.GetTaskResponse
-	for _, t := range *repoTasks {
-		tasks = append(tasks, entities.GetTaskResponse{
-			ID:          t.ID.String(),
-			UserID:      t.UserID,
-			Title:       t.Title,
-			Description: t.Description,
-			Image:       t.Image,
-			Date:        utils.FormatBangkokRFC3339(t.Date),
-			Status:      t.Status,
-			CreatedAt:   utils.FormatBangkokRFC3339(t.CreatedAt),
-		})
+	tasks := []entities.GetTaskResponse{}
+	for i := range page {
+		task := toGetTaskResponse(ctx, s.signer, authPayload.UserId, &page[i])
+		if req.Search != "" {
+			setSearchResult(task, &page[i])
+		}
+		tasks = append(tasks, *task)
 	}
 
 	response := &entities.GetAllTasksResponse{
-		Total: len(tasks),
+		Count: len(tasks),
 		Tasks: tasks,
 	}
 
+	// Link the neighbouring pages through the first and last tasks
+	hasNext, hasPrev := more, cursor != nil || pageReq.Offset > 0
+	if backward {
+		hasNext, hasPrev = true, more
+	}
+	if len(page) > 0 {
+		if hasNext {
+			last := &utils.Cursor{Query: query, Values: repositories.TaskCursorValues(req.SortBy, &page[len(page)-1])}
+			if response.NextCursor, err = s.cursors.Encode(last); err != nil {
+				s.log.ErrorWithID(ctx, "[Service: GetAllTasks] Failed to encode cursor", err)
+				return nil, err
+			}
+		}
+		if hasPrev {
+			first := &utils.Cursor{Query: query, Values: repositories.TaskCursorValues(req.SortBy, &page[0]), Before: true}
+			if response.PrevCursor, err = s.cursors.Encode(first); err != nil {
+				s.log.ErrorWithID(ctx, "[Service: GetAllTasks] Failed to encode cursor", err)
+				return nil, err
+			}
+		}
+	}
+
+	// Counting runs a second query, so only when asked
+	if req.IncludeTotal {
+		total, err := s.repo.CountTasks(ctx, req, filter, authPayload.UserId)
+		if err != nil {
+			s.log.ErrorWithID(ctx, "[Service: GetAllTasks] Failed to count tasks", err)
+			return nil, err
+		}
+		response.Total = &total
+	}
+
 	s.log.DebugWithID(ctx, "[Service: GetAllTasks] Tasks retrieved successfully", response)
 	return response, nil
 }
+
+func (s *TaskService) GetTaskHistory(ctx context.Context, id string) (*entities.GetTaskHistoryResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetTaskHistory] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskHistory] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get existing task and check permission
+	existingTask, err := getTaskWithPermission(ctx, s.repo, s.log, authPayload.UserId, id, constants.TaskPermissionViewer)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskHistory] Failed to get task", err)
+		return nil, err
+	}
+
+	// Get history from repository
+	repoHistory, err := s.repo.GetTaskHistory(ctx, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskHistory] Failed to get task history", err)
+		return nil, err
+	}
+
+	// Convert []models.TaskHistory → []entities.TaskHistoryEntry
+	history := []entities.TaskHistoryEntry{}
+	for _, h := range *repoHistory {
+		changes := []entities.TaskFieldChange{}
+		for _, c := range h.Changes {
+			changes = append(changes, entities.TaskFieldChange{
+				Field:  c.Field,
+				Before: c.Before,
+				After:  c.After,
+			})
+		}
+
+		history = append(history, entities.TaskHistoryEntry{
+			Version:   h.Version,
+			Action:    h.Action,
+			ActorID:   h.ActorID,
+			Changes:   changes,
+			CreatedAt: utils.FormatRFC3339(ctx, h.CreatedAt),
+		})
+	}
+
+	resp := &entities.GetTaskHistoryResponse{
+		TaskID:  existingTask.ID.String(),
+		Total:   len(history),
+		History: history,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetTaskHistory] Task history retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskService) RevertTask(ctx context.Context, id string, version int, req *entities.RevertTaskRequest) (*entities.UpdateTaskResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: RevertTask] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevertTask] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get existing task and check permission
+	existingTask, err := getTaskWithPermission(ctx, s.repo, s.log, authPayload.UserId, id, constants.TaskPermissionEditor)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevertTask] Failed to get task", err)
+		return nil, err
+	}
+
+	// Reject the revert if the client's copy is out of date
+	if !utils.IfMatch(req.IfMatch, utils.VersionETag(existingTask.Version)) {
+		s.log.ErrorWithID(ctx, "[Service: RevertTask] Task version does not match", req.IfMatch)
+		return nil, constants.ErrTaskVersionMismatch
+	}
+
+	// Get the target version
+	target, err := s.repo.GetTaskHistoryVersion(ctx, id, version)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.ErrorWithID(ctx, "[Service: RevertTask] Task version not found: ", err)
+			return nil, constants.ErrTaskVersionNotFound
+		}
+
+		s.log.ErrorWithID(ctx, "[Service: RevertTask] Failed to get task version", err)
+		return nil, err
+	}
+	if target.Snapshot == nil || target.Action == string(constants.TaskHistoryActionDelete) {
+		s.log.ErrorWithID(ctx, "[Service: RevertTask] Task version has no restorable snapshot", constants.ErrTaskVersionNotFound)
+		return nil, constants.ErrTaskVersionNotFound
+	}
+
+	// Restore snapshot
+	before := existingTask.Snapshot()
+	existingTask.Restore(target.Snapshot)
+
+	// An image replaced or removed since that version is gone for good, so
+	// the current one stays
+	if existingTask.ImageID != nil {
+		if _, err := s.attachmentRepo.GetAttachment(ctx, id, *existingTask.ImageID); err != nil {
+			if !errors.Is(err, gorm.ErrRecordNotFound) {
+				s.log.ErrorWithID(ctx, "[Service: RevertTask] Failed to get image", err)
+				return nil, err
+			}
+			existingTask.ImageID = before.ImageID
+		}
+	}
+
+	if err := checkCompletable(before, existingTask); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevertTask] Task is blocked", err)
+		return nil, err
+	}
+	resetRankOnStatusChange(before, existingTask)
+
+	var history *models.TaskHistory
+	if changes := utils.DiffTaskSnapshots(before, existingTask.Snapshot()); len(changes) > 0 {
+		history = newTaskHistory(existingTask, authPayload.UserId, constants.TaskHistoryActionRevert, changes)
+	}
+
+	// Save update
+	if err := s.repo.UpdateTask(ctx, existingTask, history); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevertTask] Failed to revert task", err)
+		return nil, err
+	}
+
+	// Response
+	resp := toUpdateTaskResponse(ctx, s.signer, authPayload.UserId, existingTask)
+
+	s.log.DebugWithID(ctx, "[Service: RevertTask] Task reverted successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskService) MoveTask(ctx context.Context, id string, req *entities.MoveTaskRequest) (*entities.GetTaskResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: MoveTask] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MoveTask] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get existing task and check permission
+	existingTask, err := getTaskWithPermission(ctx, s.repo, s.log, authPayload.UserId, id, constants.TaskPermissionEditor)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MoveTask] Failed to get task", err)
+		return nil, err
+	}
+
+	// Moving to another column changes the status
+	before := existingTask.Snapshot()
+	if req.Status != "" {
+		existingTask.Status = string(req.Status)
+	}
+	if err := checkCompletable(before, existingTask); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MoveTask] Task is blocked", err)
+		return nil, err
+	}
+
+	// Get the target column, without the task being moved. The column is
+	// the task owner's board, which may not be the caller's for shared tasks.
+	boardTasks, err := s.repo.GetBoardTasks(ctx, existingTask.UserID)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MoveTask] Failed to get board tasks", err)
+		return nil, err
+	}
+	var column []models.Task
+	for _, t := range *boardTasks {
+		if t.Status == existingTask.Status && t.ID != existingTask.ID {
+			column = append(column, t)
+		}
+	}
+
+	// Find where the task goes; without an anchor it goes to the end
+	position := len(column)
+	if anchor := req.BeforeID + req.AfterID; anchor != "" {
+		position = -1
+		for i, t := range column {
+			if t.ID.String() == anchor {
+				position = i
+				break
+			}
+		}
+		if position < 0 {
+			s.log.ErrorWithID(ctx, "[Service: MoveTask] Anchor task is not in the column", constants.ErrInvalidMoveTarget)
+			return nil, constants.ErrInvalidMoveTarget
+		}
+		if req.AfterID != "" {
+			position++
+		}
+	}
+
+	// Take the rank between the new neighbours, or rebalance the whole column
+	// when there is no room left between them
+	ranks, rank := rankForPosition(column, position)
+	existingTask.Rank = &rank
+
+	var history *models.TaskHistory
+	if changes := utils.DiffTaskSnapshots(before, existingTask.Snapshot()); len(changes) > 0 {
+		history = newTaskHistory(existingTask, authPayload.UserId, constants.TaskHistoryActionUpdate, changes)
+	}
+
+	// Save move
+	if err := s.repo.MoveTask(ctx, existingTask, ranks, history); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: MoveTask] Failed to move task", err)
+		return nil, err
+	}
+
+	resp := toGetTaskResponse(ctx, s.signer, authPayload.UserId, existingTask)
+
+	s.log.DebugWithID(ctx, "[Service: MoveTask] Task moved successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskService) GetTaskBoard(ctx context.Context) (*entities.GetTaskBoardResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetTaskBoard] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskBoard] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Fetch tasks from repository
+	repoTasks, err := s.repo.GetBoardTasks(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskBoard] Failed to get board tasks", err)
+		return nil, err
+	}
+
+	// Group by status, keeping the repository's rank order within a column
+	columns := []entities.TaskBoardColumn{
+		{Status: string(constants.TaskStatusPending), Tasks: []entities.GetTaskResponse{}},
+		{Status: string(constants.TaskStatusCompleted), Tasks: []entities.GetTaskResponse{}},
+	}
+	for i := range *repoTasks {
+		task := &(*repoTasks)[i]
+		for c := range columns {
+			if columns[c].Status == task.Status {
+				columns[c].Tasks = append(columns[c].Tasks, *toGetTaskResponse(ctx, s.signer, authPayload.UserId, task))
+				columns[c].Total++
+			}
+		}
+	}
+
+	resp := &entities.GetTaskBoardResponse{Columns: columns}
+
+	s.log.DebugWithID(ctx, "[Service: GetTaskBoard] Task board retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskService) BulkTasks(ctx context.Context, req *entities.BulkTaskRequest) (*entities.BulkTaskResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: BulkTasks] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: BulkTasks] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Each action needs its own argument
+	if (req.Action == constants.TaskBulkActionSetStatus && req.Status == "") ||
+		((req.Action == constants.TaskBulkActionAddTag || req.Action == constants.TaskBulkActionRemoveTag) && strings.TrimSpace(req.Tag) == "") {
+		s.log.ErrorWithID(ctx, "[Service: BulkTasks] Missing action argument", constants.ErrMissingRequiredFields)
+		return nil, constants.ErrMissingRequiredFields
+	}
+
+	ids, err := s.bulkTaskIDs(ctx, req, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: BulkTasks] Failed to resolve tasks", err)
+		return nil, err
+	}
+
+	// Check and prepare every task; a task that fails is reported and skipped
+	required := constants.TaskPermissionEditor
+	if req.Action == constants.TaskBulkActionDelete {
+		required = constants.TaskPermissionOwner
+	}
+
+	resp := &entities.BulkTaskResponse{
+		Action:  string(req.Action),
+		Total:   len(ids),
+		Results: []entities.BulkTaskResult{},
+	}
+	var changes []repositories.TaskChange
+	for _, id := range ids {
+		result := entities.BulkTaskResult{ID: id}
+
+		change, err := s.bulkTaskChange(ctx, req, authPayload.UserId, id, required)
+		switch {
+		case err != nil:
+			if _, known := constants.ErrorMapWithCode[err]; !known {
+				s.log.ErrorWithID(ctx, "[Service: BulkTasks] Failed to prepare task", err)
+				return nil, err
+			}
+			body := utils.NewErrorBody(err)
+			result.Result, result.Error = constants.TaskBulkResultFailed, &body
+			resp.Failed++
+		case change == nil:
+			result.Result = constants.TaskBulkResultUnchanged
+			resp.Succeeded++
+		case change.Delete:
+			result.Result = constants.TaskBulkResultDeleted
+			changes = append(changes, *change)
+			resp.Succeeded++
+		default:
+			result.Result = constants.TaskBulkResultUpdated
+			changes = append(changes, *change)
+			resp.Succeeded++
+		}
+
+		resp.Results = append(resp.Results, result)
+	}
+
+	// Collect the attachments of deleted tasks, whose rows go with them
+	var deleted []string
+	for _, change := range changes {
+		if change.Delete {
+			deleted = append(deleted, change.Task.ID.String())
+		}
+	}
+	attachments := &[]models.Attachment{}
+	if len(deleted) > 0 {
+		if attachments, err = s.attachmentRepo.GetTaskAttachments(ctx, deleted); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: BulkTasks] Failed to get attachments", err)
+			return nil, err
+		}
+	}
+
+	// Apply all changes together
+	if len(changes) > 0 {
+		if err := s.repo.ApplyTaskChanges(ctx, changes); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: BulkTasks] Failed to apply changes", err)
+			return nil, err
+		}
+	}
+	deleteBlobs(ctx, s.blobs, s.log, *attachments)
+
+	s.log.DebugWithID(ctx, "[Service: BulkTasks] Bulk operation completed", resp)
+	return resp, nil
+}
+
+// bulkTaskIDs returns the ids a bulk operation targets: the listed ids once
+// each, or the tasks matching its filter
+func (s *TaskService) bulkTaskIDs(ctx context.Context, req *entities.BulkTaskRequest, userId string) ([]string, error) {
+	if len(req.IDs) > 0 {
+		ids := []string{}
+		seen := map[string]bool{}
+		for _, id := range req.IDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+		return ids, nil
+	}
+
+	filter, err := utils.ParseTaskFilter(req.Filter)
+	if err != nil {
+		return nil, constants.ErrInvalidRequestBody
+	}
+
+	// Unarchiving looks among the archived tasks
+	listReq := &entities.GetAllTasksRequest{
+		Filter:   req.Filter,
+		SortBy:   "created_at",
+		Order:    "asc",
+		Limit:    constants.MaxBulkTasks + 1,
+		Archived: req.Action == constants.TaskBulkActionUnarchive,
+	}
+	tasks, err := s.repo.GetAllTasks(ctx, listReq, filter, nil, userId)
+	if err != nil {
+		return nil, err
+	}
+	if len(*tasks) > constants.MaxBulkTasks {
+		return nil, constants.ErrTooManyBulkTasks
+	}
+
+	ids := make([]string, len(*tasks))
+	for i, t := range *tasks {
+		ids[i] = t.ID.String()
+	}
+	return ids, nil
+}
+
+// bulkTaskChange checks the caller's permission on one task and applies the
+// action to it, returning nil when the task is already in the wanted state
+func (s *TaskService) bulkTaskChange(ctx context.Context, req *entities.BulkTaskRequest, userId string, id string, required constants.TaskPermission) (*repositories.TaskChange, error) {
+	task, err := getTaskWithPermission(ctx, s.repo, s.log, userId, id, required)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Action == constants.TaskBulkActionDelete {
+		history := newTaskHistory(task, userId, constants.TaskHistoryActionDelete, utils.DiffTaskSnapshots(task.Snapshot(), nil))
+		return &repositories.TaskChange{Task: task, Delete: true, History: history}, nil
+	}
+
+	before := task.Snapshot()
+	switch req.Action {
+	case constants.TaskBulkActionSetStatus:
+		task.Status = string(req.Status)
+	case constants.TaskBulkActionAddTag:
+		task.Tags = utils.NormalizeTags(append(append([]string{}, task.Tags...), req.Tag))
+		if len(task.Tags) > utils.MaxTaskTags {
+			return nil, constants.ErrTooManyTaskTags
+		}
+	case constants.TaskBulkActionRemoveTag:
+		tag, _ := utils.NormalizeTag(req.Tag)
+		tags := models.TaskTags{}
+		for _, t := range task.Tags {
+			if t != tag {
+				tags = append(tags, t)
+			}
+		}
+		task.Tags = tags
+	case constants.TaskBulkActionArchive:
+		if task.ArchivedAt == nil {
+			now := time.Now()
+			task.ArchivedAt = &now
+		}
+	case constants.TaskBulkActionUnarchive:
+		task.ArchivedAt = nil
+	}
+
+	if err := checkCompletable(before, task); err != nil {
+		return nil, err
+	}
+	resetRankOnStatusChange(before, task)
+
+	changes := utils.DiffTaskSnapshots(before, task.Snapshot())
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	history := newTaskHistory(task, userId, constants.TaskHistoryActionUpdate, changes)
+	return &repositories.TaskChange{Task: task, History: history}, nil
+}
+
+// rankForPosition returns the rank for a task inserted at position in column.
+// When the neighbours leave no room, or the column has tasks that were never
+// ranked, every task in the column gets a fresh evenly spaced rank: those are
+// returned by task id, alongside the inserted task's own rank.
+func rankForPosition(column []models.Task, position int) (map[string]string, string) {
+	rebalance := false
+	for _, t := range column {
+		if t.Rank == nil {
+			rebalance = true
+			break
+		}
+	}
+
+	if !rebalance {
+		var prev, next string
+		if position > 0 {
+			prev = *column[position-1].Rank
+		}
+		if position < len(column) {
+			next = *column[position].Rank
+		}
+		if rank, ok := utils.RankBetween(prev, next); ok {
+			return nil, rank
+		}
+	}
+
+	sequence := utils.RankSequence(len(column) + 1)
+	ranks := make(map[string]string, len(column))
+	for i, t := range column {
+		r := sequence[i]
+		if i >= position {
+			r = sequence[i+1]
+		}
+		if t.Rank == nil || *t.Rank != r {
+			ranks[t.ID.String()] = r
+		}
+	}
+
+	return ranks, sequence[position]
+}
+
+// resetRankOnStatusChange drops the rank of a task that changed column, so it
+// joins the end of the new column rather than a random spot in it
+func resetRankOnStatusChange(before *models.TaskSnapshot, task *models.Task) {
+	if before.Status != task.Status {
+		task.Rank = nil
+	}
+}
+
+// toUpdateTaskResponse converts an updated task into its API representation
+func toUpdateTaskResponse(ctx context.Context, signer utils.IURLSigner, userId string, t *models.Task) *entities.UpdateTaskResponse {
+	return &entities.UpdateTaskResponse{
+		ID:          t.ID.String(),
+		UserID:      t.UserID,
+		Title:       t.Title,
+		Status:      t.Status,
+		Date:        utils.FormatTaskDate(ctx, t.Date, t.AllDay),
+		AllDay:      t.AllDay,
+		Image:       taskImageURL(signer, userId, t),
+		Description: t.Description,
+		AssigneeID:  t.AssigneeID,
+		Tags:        tagList(t.Tags),
+		Version:     t.Version,
+		CreatedAt:   utils.FormatRFC3339(ctx, t.CreatedAt),
+	}
+}
+
+// toGetTaskResponse converts a task model into its API representation
+func toGetTaskResponse(ctx context.Context, signer utils.IURLSigner, userId string, t *models.Task) *entities.GetTaskResponse {
+	return &entities.GetTaskResponse{
+		ID:           t.ID.String(),
+		UserID:       t.UserID,
+		WorkspaceID:  t.WorkspaceID,
+		Title:        t.Title,
+		Status:       t.Status,
+		Image:        taskImageURL(signer, userId, t),
+		Date:         utils.FormatTaskDate(ctx, t.Date, t.AllDay),
+		AllDay:       t.AllDay,
+		Description:  t.Description,
+		AssigneeID:   t.AssigneeID,
+		CommentCount: t.CommentCount,
+		Blocked:      t.Blocked,
+		Rank:         t.Rank,
+		Tags:         tagList(t.Tags),
+		Permission:   t.Permission,
+		ArchivedAt:   formatOptionalTime(ctx, t.ArchivedAt),
+		Version:      t.Version,
+		CreatedAt:    utils.FormatRFC3339(ctx, t.CreatedAt),
+	}
+}
+
+// prepareTaskListing fills in the defaults of a task listing and parses its
+// filter expression; binding has already rejected malformed ones
+func prepareTaskListing(req *entities.GetAllTasksRequest, userId string) (utils.FilterNode, error) {
+	if req.Order == "" {
+		req.Order = "desc"
+	}
+	// Searches rank by relevance unless asked otherwise; without a search
+	// term there is nothing to rank by
+	req.Search = strings.TrimSpace(req.Search)
+	if req.SortBy == "" && req.Search != "" {
+		req.SortBy = "relevance"
+	}
+	if req.SortBy == "" || (req.SortBy == "relevance" && req.Search == "") {
+		req.SortBy = "created_at"
+	}
+
+	if req.Assignee == constants.AssigneeFilterMe {
+		req.Assignee = userId
+	}
+
+	return utils.ParseTaskFilter(req.Filter)
+}
+
+// setTaskDate applies a new date and all-day flag, either of which may be
+// nil to leave it as is. An all-day date keeps the day it was written on. A
+// task made all-day or timed without a new date keeps its day in the
+// caller's time zone.
+func setTaskDate(ctx context.Context, t *models.Task, date *time.Time, allDay *bool) {
+	if allDay != nil && *allDay != t.AllDay && date == nil {
+		loc := utils.LocationFromContext(ctx)
+		if *allDay {
+			t.Date = utils.AllDayDate(t.Date.In(loc))
+		} else {
+			t.Date = utils.LocalAllDay(t.Date, loc)
+		}
+	}
+	if allDay != nil {
+		t.AllDay = *allDay
+	}
+	if date != nil {
+		t.Date = *date
+		if t.AllDay {
+			t.Date = utils.AllDayDate(*date)
+		}
+	}
+}
+
+// taskImageURL is a signed link to the task's image, if it has one, that
+// the user can open without an access token
+func taskImageURL(signer utils.IURLSigner, userId string, t *models.Task) *string {
+	if t.ImageID == nil {
+		return nil
+	}
+	url := signer.Sign(signedContentPath(t.ID.String(), *t.ImageID), userId, constants.TaskPermissionViewer)
+	return &url
+}
+
+// removeAttachment deletes an attachment of the task and its contents. It is
+// used to clean up after the task stopped referring to it, so failures are
+// only logged.
+func (s *TaskService) removeAttachment(ctx context.Context, taskId string, id string) {
+	attachment, err := s.attachmentRepo.GetAttachment(ctx, taskId, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: removeAttachment] Failed to get attachment", err)
+		return
+	}
+	if err := s.attachmentRepo.DeleteAttachment(ctx, id); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: removeAttachment] Failed to delete attachment", err)
+		return
+	}
+	deleteBlobs(ctx, s.blobs, s.log, []models.Attachment{*attachment})
+}
+
+// formatOptionalTime formats a nullable timestamp for a response
+func formatOptionalTime(ctx context.Context, t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	s := utils.FormatRFC3339(ctx, *t)
+	return &s
+}
+
+// tagList returns the tags for a response, as an empty list rather than null
+func tagList(tags models.TaskTags) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return tags
+}
+
+// setSearchResult adds the relevance and highlighted snippets of a searched task
+func setSearchResult(resp *entities.GetTaskResponse, t *models.Task) {
+	rank := t.SearchRank
+	resp.SearchRank = &rank
+
+	highlight := &entities.TaskHighlight{Title: t.Title}
+	if t.TitleHighlight != nil {
+		highlight.Title = utils.RenderHighlight(*t.TitleHighlight)
+	}
+	if t.Description != nil && t.DescriptionHighlight != nil {
+		description := utils.RenderHighlight(*t.DescriptionHighlight)
+		highlight.Description = &description
+	}
+	resp.Highlight = highlight
+}
+
+// checkCompletable rejects moving a task to COMPLETED while it is blocked
+func checkCompletable(before *models.TaskSnapshot, task *models.Task) error {
+	if task.Blocked &&
+		task.Status == string(constants.TaskStatusCompleted) &&
+		before.Status != string(constants.TaskStatusCompleted) {
+		return constants.ErrInvalidStatusTransition
+	}
+	return nil
+}
+
+// verifyAssignee checks the user can see the task: its owner, a member of its
+// workspace, or someone it is shared with
+func (s *TaskService) verifyAssignee(ctx context.Context, task *models.Task, assigneeId string) error {
+	if assigneeId == task.UserID {
+		return nil
+	}
+
+	if task.WorkspaceID != nil {
+		if _, err := s.workspaceRepo.GetMember(ctx, *task.WorkspaceID, assigneeId); err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return constants.ErrAssigneeNoAccess
+			}
+			return err
+		}
+		return nil
+	}
+
+	permission, err := s.repo.GetSharePermission(ctx, task.ID.String(), assigneeId)
+	if err != nil {
+		return err
+	}
+	if permission == "" {
+		return constants.ErrAssigneeNoAccess
+	}
+	return nil
+}
+
+// notifyAssignment tells the new and previous assignee about an assignment
+// change. Nobody is notified about their own action. Delivery is best effort:
+// the task write has already succeeded, so a failure here is only logged.
+func (s *TaskService) notifyAssignment(ctx context.Context, task *models.Task, actorId string, previous *string) {
+	if equalAssignee(previous, task.AssigneeID) {
+		return
+	}
+
+	now := time.Now()
+	var notifications []models.Notification
+	add := func(userId string, notificationType constants.NotificationType, message string) {
+		if userId == actorId {
+			return
+		}
+		notifications = append(notifications, models.Notification{
+			ID:        uuid.New(),
+			UserID:    userId,
+			Type:      string(notificationType),
+			TaskID:    task.ID,
+			ActorID:   actorId,
+			Message:   message,
+			CreatedAt: now,
+		})
+	}
+
+	if task.AssigneeID != nil {
+		add(*task.AssigneeID, constants.NotificationTypeTaskAssigned, fmt.Sprintf("You were assigned to %q", task.Title))
+	}
+	if previous != nil {
+		add(*previous, constants.NotificationTypeTaskUnassigned, fmt.Sprintf("You were unassigned from %q", task.Title))
+	}
+	if len(notifications) == 0 {
+		return
+	}
+
+	if err := s.notificationRepo.CreateNotifications(ctx, notifications); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: notifyAssignment] Failed to create notifications", err)
+	}
+}
+
+func equalAssignee(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// newTaskHistory builds the history entry written alongside a task mutation
+func newTaskHistory(task *models.Task, actorId string, action constants.TaskHistoryAction, changes models.FieldChanges) *models.TaskHistory {
+	return &models.TaskHistory{
+		ID:        uuid.New(),
+		TaskID:    task.ID,
+		ActorID:   actorId,
+		Action:    string(action),
+		Changes:   changes,
+		Snapshot:  task.Snapshot(),
+		CreatedAt: time.Now(),
+	}
+}
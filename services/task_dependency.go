@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+)
+
+type ITaskDependencyService interface {
+	AddDependency(ctx context.Context, taskId string, req *entities.AddTaskDependencyRequest) (*entities.GetTaskDependenciesResponse, error)
+	RemoveDependency(ctx context.Context, taskId string, blockedById string) error
+	GetDependencies(ctx context.Context, taskId string) (*entities.GetTaskDependenciesResponse, error)
+}
+
+type TaskDependencyService struct {
+	repo     repositories.ITaskDependencyRepository
+	taskRepo repositories.ITaskRepository
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+}
+
+func NewTaskDependencyService(
+	repo repositories.ITaskDependencyRepository,
+	taskRepo repositories.ITaskRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) ITaskDependencyService {
+	return &TaskDependencyService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		log:      log,
+		payload:  payload,
+	}
+}
+
+func (s *TaskDependencyService) AddDependency(ctx context.Context, taskId string, req *entities.AddTaskDependencyRequest) (*entities.GetTaskDependenciesResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: AddDependency] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: AddDependency] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	if taskId == req.BlockedByID {
+		s.log.ErrorWithID(ctx, "[Service: AddDependency] Task cannot depend on itself", constants.ErrCannotDependOnSelf)
+		return nil, constants.ErrCannotDependOnSelf
+	}
+
+	// Editing the blocked task, only reading the blocker
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionEditor)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: AddDependency] Failed to get task", err)
+		return nil, err
+	}
+
+	blocker, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, req.BlockedByID, constants.TaskPermissionViewer)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: AddDependency] Failed to get blocker", err)
+		return nil, err
+	}
+
+	// Linking across owners would expose one user's task to another's collaborators
+	if !sameDependencyScope(task, blocker) {
+		s.log.ErrorWithID(ctx, "[Service: AddDependency] Tasks belong to different owners", constants.ErrDependencyScopeMismatch)
+		return nil, constants.ErrDependencyScopeMismatch
+	}
+
+	arg := &models.TaskDependency{
+		TaskID:      task.ID,
+		BlockedByID: blocker.ID,
+		CreatedBy:   authPayload.UserId,
+		CreatedAt:   time.Now(),
+	}
+
+	if err := s.repo.CreateDependency(ctx, arg); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: AddDependency] Failed to create dependency", err)
+		return nil, err
+	}
+
+	resp, err := s.getDependencies(ctx, task)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: AddDependency] Failed to get dependencies", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: AddDependency] Dependency added successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskDependencyService) RemoveDependency(ctx context.Context, taskId string, blockedById string) error {
+	s.log.DebugWithID(ctx, "[Service: RemoveDependency] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RemoveDependency] Failed to get auth payload", err)
+		return err
+	}
+
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionEditor); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RemoveDependency] Failed to get task", err)
+		return err
+	}
+
+	deleted, err := s.repo.DeleteDependency(ctx, taskId, blockedById)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RemoveDependency] Failed to delete dependency", err)
+		return err
+	}
+
+	if deleted == 0 {
+		s.log.ErrorWithID(ctx, "[Service: RemoveDependency] Dependency not found", constants.ErrTaskDependencyNotFound)
+		return constants.ErrTaskDependencyNotFound
+	}
+
+	s.log.DebugWithID(ctx, "[Service: RemoveDependency] Dependency removed successfully")
+	return nil
+}
+
+func (s *TaskDependencyService) GetDependencies(ctx context.Context, taskId string) (*entities.GetTaskDependenciesResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetDependencies] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetDependencies] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionViewer)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetDependencies] Failed to get task", err)
+		return nil, err
+	}
+
+	resp, err := s.getDependencies(ctx, task)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetDependencies] Failed to get dependencies", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetDependencies] Dependencies retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskDependencyService) getDependencies(ctx context.Context, task *models.Task) (*entities.GetTaskDependenciesResponse, error) {
+	taskId := task.ID.String()
+
+	blockers, err := s.repo.GetBlockers(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	blocking, err := s.repo.GetBlocking(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &entities.GetTaskDependenciesResponse{
+		TaskID:    taskId,
+		BlockedBy: toDependencyTaskResponses(blockers),
+		Blocking:  toDependencyTaskResponses(blocking),
+	}
+
+	// Derived from the fresh blocker list rather than the task loaded earlier
+	for _, b := range resp.BlockedBy {
+		if b.Status != string(constants.TaskStatusCompleted) {
+			resp.Blocked = true
+			break
+		}
+	}
+
+	return resp, nil
+}
+
+// sameDependencyScope reports whether two tasks may be linked: both in the
+// same workspace, or both personal tasks of the same owner
+func sameDependencyScope(a, b *models.Task) bool {
+	if a.WorkspaceID != nil || b.WorkspaceID != nil {
+		return a.WorkspaceID != nil && b.WorkspaceID != nil && *a.WorkspaceID == *b.WorkspaceID
+	}
+	return a.UserID == b.UserID
+}
+
+func toDependencyTaskResponses(tasks *[]models.Task) []entities.DependencyTaskResponse {
+	resp := []entities.DependencyTaskResponse{}
+	for _, t := range *tasks {
+		resp = append(resp, entities.DependencyTaskResponse{
+			ID:      t.ID.String(),
+			Title:   t.Title,
+			Status:  t.Status,
+			Blocked: t.Blocked,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestTaskDependencyService_AddDependency(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	blockerId := "660e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+	ownedTask := &models.Task{ID: uuid.MustParse(taskId), UserID: "1", Status: "IN_PROGRESS"}
+	ownedBlocker := &models.Task{ID: uuid.MustParse(blockerId), UserID: "1", Title: "Blocker", Status: "IN_PROGRESS"}
+
+	testCases := []struct {
+		name   string
+		req    *entities.AddTaskDependencyRequest
+		setup  func(*mocks.MockITaskDependencyRepository, *mocks.MockITaskRepository)
+		verify func(t *testing.T, got *entities.GetTaskDependenciesResponse, gotErr error)
+	}{
+		{
+			name: "AddDependency_OK",
+			req:  &entities.AddTaskDependencyRequest{BlockedByID: blockerId},
+			setup: func(repo *mocks.MockITaskDependencyRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				taskRepo.EXPECT().GetTask(ctx, blockerId).Return(ownedBlocker, nil)
+				repo.EXPECT().
+					CreateDependency(ctx, mock.MatchedBy(func(dependency *models.TaskDependency) bool {
+						return dependency.TaskID == ownedTask.ID && dependency.BlockedByID == ownedBlocker.ID
+					})).
+					Return(nil)
+				repo.EXPECT().GetBlockers(ctx, taskId).Return(&[]models.Task{*ownedBlocker}, nil)
+				repo.EXPECT().GetBlocking(ctx, taskId).Return(&[]models.Task{}, nil)
+			},
+			verify: func(t *testing.T, got *entities.GetTaskDependenciesResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.True(t, got.Blocked)
+				assert.Len(t, got.BlockedBy, 1)
+				assert.Equal(t, blockerId, got.BlockedBy[0].ID)
+			},
+		},
+		{
+			name: "AddDependency_Self",
+			req:  &entities.AddTaskDependencyRequest{BlockedByID: taskId},
+			setup: func(repo *mocks.MockITaskDependencyRepository, taskRepo *mocks.MockITaskRepository) {
+			},
+			verify: func(t *testing.T, got *entities.GetTaskDependenciesResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrCannotDependOnSelf, gotErr)
+			},
+		},
+		{
+			name: "AddDependency_Cycle",
+			req:  &entities.AddTaskDependencyRequest{BlockedByID: blockerId},
+			setup: func(repo *mocks.MockITaskDependencyRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				taskRepo.EXPECT().GetTask(ctx, blockerId).Return(ownedBlocker, nil)
+				repo.EXPECT().CreateDependency(ctx, mock.Anything).Return(constants.ErrDependencyCycle)
+			},
+			verify: func(t *testing.T, got *entities.GetTaskDependenciesResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrDependencyCycle, gotErr)
+			},
+		},
+		{
+			name: "AddDependency_BlockerOfAnotherOwner",
+			req:  &entities.AddTaskDependencyRequest{BlockedByID: blockerId},
+			setup: func(repo *mocks.MockITaskDependencyRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				taskRepo.EXPECT().GetTask(ctx, blockerId).
+					Return(&models.Task{ID: uuid.MustParse(blockerId), UserID: "2"}, nil)
+				taskRepo.EXPECT().GetSharePermission(ctx, blockerId, "1").Return("viewer", nil)
+			},
+			verify: func(t *testing.T, got *entities.GetTaskDependenciesResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrDependencyScopeMismatch, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockITaskDependencyRepository)
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockRepo.AssertExpectations(t)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+			tC.setup(mockRepo, mockTaskRepo)
+
+			svc := NewTaskDependencyService(mockRepo, mockTaskRepo, lgr, mockPayload)
+
+			got, gotErr := svc.AddDependency(ctx, taskId, tC.req)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
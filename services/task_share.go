@@ -0,0 +1,222 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+type ITaskShareService interface {
+	ShareTask(ctx context.Context, taskId string, req *entities.ShareTaskRequest) (*entities.TaskShareResponse, error)
+	GetTaskShares(ctx context.Context, taskId string) (*entities.GetTaskSharesResponse, error)
+	RevokeShare(ctx context.Context, taskId string, userId string) error
+	GetSharedTasks(ctx context.Context, req *entities.GetSharedTasksRequest) (*entities.GetAllTasksResponse, error)
+}
+
+type TaskShareService struct {
+	repo     repositories.ITaskShareRepository
+	taskRepo repositories.ITaskRepository
+	userRepo repositories.IUserRepository
+	signer   utils.IURLSigner
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+}
+
+func NewTaskShareService(
+	repo repositories.ITaskShareRepository,
+	taskRepo repositories.ITaskRepository,
+	userRepo repositories.IUserRepository,
+	signer utils.IURLSigner,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) ITaskShareService {
+	return &TaskShareService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		userRepo: userRepo,
+		signer:   signer,
+		log:      log,
+		payload:  payload,
+	}
+}
+
+func (s *TaskShareService) ShareTask(ctx context.Context, taskId string, req *entities.ShareTaskRequest) (*entities.TaskShareResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: ShareTask] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ShareTask] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Only the owner may share
+	task, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionOwner)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ShareTask] Failed to get task", err)
+		return nil, err
+	}
+
+	if task.WorkspaceID != nil {
+		s.log.ErrorWithID(ctx, "[Service: ShareTask] Workspace task cannot be shared", constants.ErrWorkspaceTaskNotShareable)
+		return nil, constants.ErrWorkspaceTaskNotShareable
+	}
+
+	// Resolve the grantee by email
+	user, err := s.userRepo.GetUser(ctx, req.Email)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.ErrorWithID(ctx, "[Service: ShareTask] User not found: ", err)
+			return nil, constants.ErrUserNotFound
+		}
+		s.log.ErrorWithID(ctx, "[Service: ShareTask] Failed to get user", err)
+		return nil, err
+	}
+
+	if user.ID.String() == authPayload.UserId {
+		s.log.ErrorWithID(ctx, "[Service: ShareTask] Cannot share with yourself", constants.ErrCannotShareWithSelf)
+		return nil, constants.ErrCannotShareWithSelf
+	}
+
+	arg := &models.TaskShare{
+		ID:         uuid.New(),
+		TaskID:     task.ID,
+		UserID:     user.ID.String(),
+		Permission: req.Permission,
+		SharedBy:   authPayload.UserId,
+		CreatedAt:  time.Now(),
+		Email:      user.Email,
+	}
+
+	if err := s.repo.UpsertShare(ctx, arg); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: ShareTask] Failed to share task", err)
+		return nil, err
+	}
+
+	resp := toTaskShareResponse(ctx, arg)
+	s.log.DebugWithID(ctx, "[Service: ShareTask] Task shared successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskShareService) GetTaskShares(ctx context.Context, taskId string) (*entities.GetTaskSharesResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetTaskShares] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskShares] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Only the owner may see who the task is shared with
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, constants.TaskPermissionOwner); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskShares] Failed to get task", err)
+		return nil, err
+	}
+
+	repoShares, err := s.repo.GetShares(ctx, taskId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetTaskShares] Failed to get shares", err)
+		return nil, err
+	}
+
+	shares := []entities.TaskShareResponse{}
+	for i := range *repoShares {
+		shares = append(shares, *toTaskShareResponse(ctx, &(*repoShares)[i]))
+	}
+
+	resp := &entities.GetTaskSharesResponse{
+		Total:  len(shares),
+		Shares: shares,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetTaskShares] Shares retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *TaskShareService) RevokeShare(ctx context.Context, taskId string, userId string) error {
+	s.log.DebugWithID(ctx, "[Service: RevokeShare] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevokeShare] Failed to get auth payload", err)
+		return err
+	}
+
+	// The owner may revoke anyone; a grantee may only remove themselves
+	required := constants.TaskPermissionOwner
+	if userId == authPayload.UserId {
+		required = constants.TaskPermissionViewer
+	}
+	if _, err := getTaskWithPermission(ctx, s.taskRepo, s.log, authPayload.UserId, taskId, required); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevokeShare] Failed to get task", err)
+		return err
+	}
+
+	deleted, err := s.repo.DeleteShare(ctx, taskId, userId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevokeShare] Failed to revoke share", err)
+		return err
+	}
+
+	if deleted == 0 {
+		s.log.ErrorWithID(ctx, "[Service: RevokeShare] Share not found", constants.ErrTaskShareNotFound)
+		return constants.ErrTaskShareNotFound
+	}
+
+	s.log.DebugWithID(ctx, "[Service: RevokeShare] Share revoked successfully")
+	return nil
+}
+
+func (s *TaskShareService) GetSharedTasks(ctx context.Context, req *entities.GetSharedTasksRequest) (*entities.GetAllTasksResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetSharedTasks] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetSharedTasks] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	offset := (req.Offset - 1) * req.Limit
+	repoTasks, total, err := s.repo.GetSharedTasks(ctx, authPayload.UserId, req.Limit, offset)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetSharedTasks] Failed to get shared tasks", err)
+		return nil, err
+	}
+
+	tasks := []entities.GetTaskResponse{}
+	for i := range *repoTasks {
+		tasks = append(tasks, *toGetTaskResponse(ctx, s.signer, authPayload.UserId, &(*repoTasks)[i]))
+	}
+
+	response := &entities.GetAllTasksResponse{
+		Count: len(tasks),
+		Total: &total,
+		Tasks: tasks,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetSharedTasks] Shared tasks retrieved successfully", response)
+	return response, nil
+}
+
+func toTaskShareResponse(ctx context.Context, share *models.TaskShare) *entities.TaskShareResponse {
+	return &entities.TaskShareResponse{
+		TaskID:     share.TaskID.String(),
+		UserID:     share.UserID,
+		Email:      share.Email,
+		Permission: share.Permission,
+		SharedBy:   share.SharedBy,
+		CreatedAt:  utils.FormatRFC3339(ctx, share.CreatedAt),
+	}
+}
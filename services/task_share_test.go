@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestTaskShareService_ShareTask(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	ownerId := "11111111-1111-1111-1111-111111111111"
+	granteeId := "22222222-2222-2222-2222-222222222222"
+
+	okPayload := &utils.Payload{
+		ID:        uuid.New(),
+		UserId:    ownerId,
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour * 24),
+	}
+
+	okRequest := &entities.ShareTaskRequest{Email: "user2@example.com", Permission: "editor"}
+	ownedTask := &models.Task{ID: uuid.MustParse(taskId), UserID: ownerId}
+
+	testCases := []struct {
+		name   string
+		setup  func(*mocks.MockITaskShareRepository, *mocks.MockITaskRepository, *mocks.MockIUserRepository)
+		verify func(t *testing.T, got *entities.TaskShareResponse, gotErr error)
+	}{
+		{
+			name: "ShareTask_OK",
+			setup: func(shareRepo *mocks.MockITaskShareRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				userRepo.EXPECT().GetUser(ctx, okRequest.Email).
+					Return(&models.User{ID: uuid.MustParse(granteeId), Email: okRequest.Email}, nil)
+				shareRepo.EXPECT().
+					UpsertShare(ctx, mock.MatchedBy(func(share *models.TaskShare) bool {
+						return share.UserID == granteeId && share.Permission == "editor" && share.SharedBy == ownerId
+					})).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.TaskShareResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, granteeId, got.UserID)
+				assert.Equal(t, okRequest.Email, got.Email)
+				assert.Equal(t, "editor", got.Permission)
+			},
+		},
+		{
+			name: "ShareTask_UserNotFound",
+			setup: func(shareRepo *mocks.MockITaskShareRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				userRepo.EXPECT().GetUser(ctx, okRequest.Email).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.TaskShareResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrUserNotFound, gotErr)
+			},
+		},
+		{
+			name: "ShareTask_WithSelf",
+			setup: func(shareRepo *mocks.MockITaskShareRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(ownedTask, nil)
+				userRepo.EXPECT().GetUser(ctx, okRequest.Email).
+					Return(&models.User{ID: uuid.MustParse(ownerId), Email: okRequest.Email}, nil)
+			},
+			verify: func(t *testing.T, got *entities.TaskShareResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrCannotShareWithSelf, gotErr)
+			},
+		},
+		{
+			name: "ShareTask_EditorCannotReshare",
+			setup: func(shareRepo *mocks.MockITaskShareRepository, taskRepo *mocks.MockITaskRepository, userRepo *mocks.MockIUserRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).
+					Return(&models.Task{ID: uuid.MustParse(taskId), UserID: granteeId}, nil)
+				taskRepo.EXPECT().GetSharePermission(ctx, taskId, ownerId).Return("editor", nil)
+			},
+			verify: func(t *testing.T, got *entities.TaskShareResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInsufficientPermission, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockShareRepo := new(mocks.MockITaskShareRepository)
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockUserRepo := new(mocks.MockIUserRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockShareRepo.AssertExpectations(t)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockUserRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().
+				GetAuthPayload(ctx, mock.Anything).
+				Return(okPayload, nil)
+			tC.setup(mockShareRepo, mockTaskRepo, mockUserRepo)
+
+			svc := NewTaskShareService(mockShareRepo, mockTaskRepo, mockUserRepo, testSigner, lgr, mockPayload)
+
+			got, gotErr := svc.ShareTask(ctx, taskId, okRequest)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestTaskShareService_RevokeShare(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	ownerId := "11111111-1111-1111-1111-111111111111"
+	granteeId := "22222222-2222-2222-2222-222222222222"
+
+	testCases := []struct {
+		name   string
+		caller string
+		setup  func(*mocks.MockITaskShareRepository, *mocks.MockITaskRepository)
+		verify func(t *testing.T, gotErr error)
+	}{
+		{
+			name:   "RevokeShare_Owner_OK",
+			caller: ownerId,
+			setup: func(shareRepo *mocks.MockITaskShareRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: ownerId}, nil)
+				shareRepo.EXPECT().DeleteShare(ctx, taskId, granteeId).Return(int64(1), nil)
+			},
+			verify: func(t *testing.T, gotErr error) {
+				assert.NoError(t, gotErr)
+			},
+		},
+		{
+			name:   "RevokeShare_GranteeLeaves_OK",
+			caller: granteeId,
+			setup: func(shareRepo *mocks.MockITaskShareRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: ownerId}, nil)
+				taskRepo.EXPECT().GetSharePermission(ctx, taskId, granteeId).Return("viewer", nil)
+				shareRepo.EXPECT().DeleteShare(ctx, taskId, granteeId).Return(int64(1), nil)
+			},
+			verify: func(t *testing.T, gotErr error) {
+				assert.NoError(t, gotErr)
+			},
+		},
+		{
+			name:   "RevokeShare_NotFound",
+			caller: ownerId,
+			setup: func(shareRepo *mocks.MockITaskShareRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: ownerId}, nil)
+				shareRepo.EXPECT().DeleteShare(ctx, taskId, granteeId).Return(int64(0), nil)
+			},
+			verify: func(t *testing.T, gotErr error) {
+				assert.Equal(t, constants.ErrTaskShareNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockShareRepo := new(mocks.MockITaskShareRepository)
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockShareRepo.AssertExpectations(t)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().
+				GetAuthPayload(ctx, mock.Anything).
+				Return(&utils.Payload{ID: uuid.New(), UserId: tC.caller}, nil)
+			tC.setup(mockShareRepo, mockTaskRepo)
+
+			svc := NewTaskShareService(mockShareRepo, mockTaskRepo, nil, testSigner, lgr, mockPayload)
+
+			tC.verify(t, svc.RevokeShare(ctx, taskId, granteeId))
+		})
+	}
+}
@@ -1,17 +1,25 @@
 package services
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
 	constants "github.com/guncv/tech-exam-software-engineering/constant"
 	"github.com/guncv/tech-exam-software-engineering/entities"
 	"github.com/guncv/tech-exam-software-engineering/infras/log"
 	"github.com/guncv/tech-exam-software-engineering/mocks"
 	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
 	"github.com/guncv/tech-exam-software-engineering/utils"
 	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
@@ -77,7 +85,7 @@ func TestTaskService_HealthCheck(t *testing.T) {
 			mockTaskRepo := tC.setup()
 			defer mockTaskRepo.AssertExpectations(t)
 
-			svc := NewTaskService(mockTaskRepo, lgr, nil)
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, nil, nil, nil)
 
 			got, gotErr := svc.HealthCheck(tC.input())
 
@@ -90,14 +98,12 @@ func TestTaskService_CreateTask(t *testing.T) {
 	lgr := log.Initialize(constants.TestAppEnv)
 	ctx := context.Background()
 	errMockError := errors.New("mock error")
-	empty := ""
 	okResponse := &entities.CreateTaskResponse{
 		ID:          "1",
 		UserID:      "1",
 		Title:       "Test Task",
 		Status:      "IN_PROGRESS",
 		Description: nil,
-		Image:       &empty,
 	}
 
 	okRequest := &entities.CreateTaskRequest{
@@ -135,6 +141,9 @@ func TestTaskService_CreateTask(t *testing.T) {
 						return task.Title == okRequest.Title &&
 							task.Status == string(okRequest.Status) &&
 							task.Description == okRequest.Description
+					}), (*models.Attachment)(nil), mock.Anything, mock.MatchedBy(func(history *models.TaskHistory) bool {
+						return history.Action == string(constants.TaskHistoryActionCreate) &&
+							history.ActorID == okPayload.UserId
 					})).
 					Return(nil)
 
@@ -182,7 +191,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 					Return(okPayload, nil)
 
 				mockTaskRepo.EXPECT().
-					CreateTask(ctx, mock.Anything).
+					CreateTask(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(errMockError)
 
 				return mockTaskRepo, mockPayload
@@ -206,7 +215,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 					Return(okPayload, nil)
 
 				mockTaskRepo.EXPECT().
-					CreateTask(ctx, mock.Anything).
+					CreateTask(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(&pq.Error{Code: "23505", Message: "duplicate key value violates unique constraint"})
 
 				return mockTaskRepo, mockPayload
@@ -228,7 +237,7 @@ func TestTaskService_CreateTask(t *testing.T) {
 			defer mockTaskRepo.AssertExpectations(t)
 			defer mockPayload.AssertExpectations(t)
 
-			svc := NewTaskService(mockTaskRepo, lgr, mockPayload)
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
 
 			got, gotErr := svc.CreateTask(tC.input())
 
@@ -237,12 +246,168 @@ func TestTaskService_CreateTask(t *testing.T) {
 	}
 }
 
+func TestTaskService_CreateTask_Idempotency(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	errMockError := errors.New("mock error")
+	key := "retry-1"
+
+	newRequest := func() *entities.CreateTaskRequest {
+		return &entities.CreateTaskRequest{Title: "Test Task", Status: "IN_PROGRESS", IdempotencyKey: key}
+	}
+	fingerprint, err := requestFingerprint(createTaskFingerprint{Title: "Test Task", Status: "IN_PROGRESS"})
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		name   string
+		setup  func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository)
+		verify func(t *testing.T, got *entities.CreateTaskResponse, gotErr error)
+	}{
+		{
+			name: "NewKey_CreatesAndStoresResponse",
+			setup: func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository) {
+				idempotencyRepo.EXPECT().
+					ClaimKey(ctx, mock.MatchedBy(func(k *models.IdempotencyKey) bool {
+						return k.UserID == "1" && k.Key == key && k.Fingerprint == fingerprint &&
+							k.ExpiresAt.Sub(k.CreatedAt) == defaultIdempotencyKeyTTL &&
+							k.LockedUntil.Sub(k.CreatedAt) == defaultIdempotencyLockTimeout
+					})).
+					Return(nil, nil)
+				taskRepo.EXPECT().CreateTask(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				idempotencyRepo.EXPECT().
+					SaveResponse(ctx, "1", key, mock.MatchedBy(func(body []byte) bool {
+						return strings.Contains(string(body), `"title":"Test Task"`)
+					})).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.CreateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "Test Task", got.Title)
+			},
+		},
+		{
+			name: "Retry_ReplaysStoredResponse",
+			setup: func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository) {
+				idempotencyRepo.EXPECT().ClaimKey(ctx, mock.Anything).Return(&models.IdempotencyKey{
+					UserID:      "1",
+					Key:         key,
+					Fingerprint: fingerprint,
+					Response:    []byte(`{"id":"550e8400-e29b-41d4-a716-446655440000","title":"Test Task","version":1}`),
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.CreateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "550e8400-e29b-41d4-a716-446655440000", got.ID)
+				assert.Equal(t, 1, got.Version)
+			},
+		},
+		{
+			// The stored image link has expired, so the replay signs a new one
+			name: "Retry_ResignsImage",
+			setup: func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository) {
+				idempotencyRepo.EXPECT().ClaimKey(ctx, mock.Anything).Return(&models.IdempotencyKey{
+					UserID:      "1",
+					Key:         key,
+					Fingerprint: fingerprint,
+					Response:    []byte(`{"id":"550e8400-e29b-41d4-a716-446655440000","title":"Test Task","image":"/expired","version":1}`),
+				}, nil)
+				imageId := "550e8400-e29b-41d4-a716-446655440001"
+				taskRepo.EXPECT().GetTask(ctx, "550e8400-e29b-41d4-a716-446655440000").Return(&models.Task{
+					ID:      uuid.MustParse("550e8400-e29b-41d4-a716-446655440000"),
+					UserID:  "1",
+					ImageID: &imageId,
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.CreateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				if assert.NotNil(t, got.Image) {
+					assert.True(t, strings.HasPrefix(*got.Image, "/api/v1/files/tasks/550e8400-e29b-41d4-a716-446655440000/attachments/550e8400-e29b-41d4-a716-446655440001/content?"))
+				}
+			},
+		},
+		{
+			name: "Retry_ImageOfDeletedTask",
+			setup: func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository) {
+				idempotencyRepo.EXPECT().ClaimKey(ctx, mock.Anything).Return(&models.IdempotencyKey{
+					UserID:      "1",
+					Key:         key,
+					Fingerprint: fingerprint,
+					Response:    []byte(`{"id":"550e8400-e29b-41d4-a716-446655440000","title":"Test Task","image":"/expired","version":1}`),
+				}, nil)
+				taskRepo.EXPECT().GetTask(ctx, "550e8400-e29b-41d4-a716-446655440000").Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.CreateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Nil(t, got.Image)
+			},
+		},
+		{
+			name: "DifferentRequest_Rejected",
+			setup: func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository) {
+				idempotencyRepo.EXPECT().ClaimKey(ctx, mock.Anything).Return(&models.IdempotencyKey{
+					UserID:      "1",
+					Key:         key,
+					Fingerprint: "other",
+					Response:    []byte(`{}`),
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.CreateTaskResponse, gotErr error) {
+				assert.ErrorIs(t, gotErr, constants.ErrIdempotencyKeyReused)
+				assert.Nil(t, got)
+			},
+		},
+		{
+			name: "FirstRequestRunning_Conflict",
+			setup: func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository) {
+				idempotencyRepo.EXPECT().ClaimKey(ctx, mock.Anything).Return(&models.IdempotencyKey{
+					UserID:      "1",
+					Key:         key,
+					Fingerprint: fingerprint,
+				}, nil)
+			},
+			verify: func(t *testing.T, got *entities.CreateTaskResponse, gotErr error) {
+				assert.ErrorIs(t, gotErr, constants.ErrIdempotencyKeyInProgress)
+				assert.Nil(t, got)
+			},
+		},
+		{
+			name: "CreateFails_ReleasesKey",
+			setup: func(taskRepo *mocks.MockITaskRepository, idempotencyRepo *mocks.MockIIdempotencyRepository) {
+				idempotencyRepo.EXPECT().ClaimKey(ctx, mock.Anything).Return(nil, nil)
+				taskRepo.EXPECT().CreateTask(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errMockError)
+				idempotencyRepo.EXPECT().ReleaseKey(ctx, "1", key).Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.CreateTaskResponse, gotErr error) {
+				assert.ErrorIs(t, gotErr, errMockError)
+				assert.Nil(t, got)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockIdempotencyRepo := new(mocks.MockIIdempotencyRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockIdempotencyRepo.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			tc.setup(mockTaskRepo, mockIdempotencyRepo)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, mockIdempotencyRepo, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+			got, gotErr := svc.CreateTask(ctx, newRequest())
+
+			tc.verify(t, got, gotErr)
+		})
+	}
+}
+
 func TestTaskService_GetTask(t *testing.T) {
 	lgr := log.Initialize(constants.TestAppEnv)
 	ctx := context.Background()
 	errMockError := errors.New("mock error")
 
-	empty := ""
 	requestId := "550e8400-e29b-41d4-a716-446655440000"
 
 	okResponse := &entities.GetTaskResponse{
@@ -251,7 +416,6 @@ func TestTaskService_GetTask(t *testing.T) {
 		Title:       "Test Task",
 		Status:      "IN_PROGRESS",
 		Description: nil,
-		Image:       &empty,
 		CreatedAt:   "",
 	}
 
@@ -280,7 +444,6 @@ func TestTaskService_GetTask(t *testing.T) {
 					Title:       "Test Task",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -394,7 +557,6 @@ func TestTaskService_GetTask(t *testing.T) {
 					Title:       "Test Task",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -408,6 +570,10 @@ func TestTaskService_GetTask(t *testing.T) {
 					})).
 					Return(getTaskResponse, nil)
 
+				mockTaskRepo.EXPECT().
+					GetSharePermission(ctx, requestId, okPayload.UserId).
+					Return("", nil)
+
 				return mockTaskRepo, mockPayload
 			},
 			input: func() (context.Context, string) {
@@ -426,7 +592,7 @@ func TestTaskService_GetTask(t *testing.T) {
 			defer mockTaskRepo.AssertExpectations(t)
 			defer mockPayload.AssertExpectations(t)
 
-			svc := NewTaskService(mockTaskRepo, lgr, mockPayload)
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
 
 			got, gotErr := svc.GetTask(tC.input())
 
@@ -440,7 +606,6 @@ func TestTaskService_UpdateTask(t *testing.T) {
 	ctx := context.Background()
 	errMockError := errors.New("mock error")
 
-	empty := ""
 	requestId := "550e8400-e29b-41d4-a716-446655440000"
 	description := "Test Description"
 	okResponse := &entities.UpdateTaskResponse{
@@ -449,7 +614,6 @@ func TestTaskService_UpdateTask(t *testing.T) {
 		Title:       "Test Tasks",
 		Status:      "IN_PROGRESS",
 		Description: &description,
-		Image:       &empty,
 		CreatedAt:   "",
 	}
 
@@ -485,7 +649,6 @@ func TestTaskService_UpdateTask(t *testing.T) {
 					Title:       "Test Tasks",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -500,7 +663,7 @@ func TestTaskService_UpdateTask(t *testing.T) {
 					Return(taskResponse, nil)
 
 				mockTaskRepo.EXPECT().
-					UpdateTask(ctx, mock.Anything).
+					UpdateTask(ctx, mock.Anything, mock.Anything).
 					Return(nil)
 
 				return mockTaskRepo, mockPayload
@@ -603,7 +766,6 @@ func TestTaskService_UpdateTask(t *testing.T) {
 					Title:       "Test Task",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -617,6 +779,10 @@ func TestTaskService_UpdateTask(t *testing.T) {
 					})).
 					Return(getTaskResponse, nil)
 
+				mockTaskRepo.EXPECT().
+					GetSharePermission(ctx, requestId, okPayload.UserId).
+					Return("", nil)
+
 				return mockTaskRepo, mockPayload
 			},
 			input: func() (context.Context, string, *entities.UpdateTaskRequest) {
@@ -639,7 +805,6 @@ func TestTaskService_UpdateTask(t *testing.T) {
 					Title:       "Test Tasks",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -654,7 +819,7 @@ func TestTaskService_UpdateTask(t *testing.T) {
 					Return(taskResponse, nil)
 
 				mockTaskRepo.EXPECT().
-					UpdateTask(ctx, mock.Anything).
+					UpdateTask(ctx, mock.Anything, mock.Anything).
 					Return(errMockError)
 
 				return mockTaskRepo, mockPayload
@@ -675,7 +840,7 @@ func TestTaskService_UpdateTask(t *testing.T) {
 			defer mockTaskRepo.AssertExpectations(t)
 			defer mockPayload.AssertExpectations(t)
 
-			svc := NewTaskService(mockTaskRepo, lgr, mockPayload)
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
 
 			got, gotErr := svc.UpdateTask(tC.input())
 
@@ -688,7 +853,6 @@ func TestTaskService_DeleteTask(t *testing.T) {
 	lgr := log.Initialize(constants.TestAppEnv)
 	ctx := context.Background()
 	errMockError := errors.New("mock error")
-	empty := ""
 	requestId := "550e8400-e29b-41d4-a716-446655440000"
 
 	okPayload := &utils.Payload{
@@ -716,7 +880,6 @@ func TestTaskService_DeleteTask(t *testing.T) {
 					Title:       "Test Tasks",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -731,7 +894,7 @@ func TestTaskService_DeleteTask(t *testing.T) {
 					Return(taskResponse, nil)
 
 				mockTaskRepo.EXPECT().
-					DeleteTask(ctx, mock.Anything).
+					DeleteTask(ctx, mock.Anything, mock.Anything).
 					Return(nil)
 
 				return mockTaskRepo, mockPayload
@@ -825,7 +988,6 @@ func TestTaskService_DeleteTask(t *testing.T) {
 					Title:       "Test Tasks",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -839,6 +1001,10 @@ func TestTaskService_DeleteTask(t *testing.T) {
 					})).
 					Return(taskResponse, nil)
 
+				mockTaskRepo.EXPECT().
+					GetSharePermission(ctx, requestId, okPayload.UserId).
+					Return("", nil)
+
 				return mockTaskRepo, mockPayload
 			},
 			input: func() (context.Context, string) {
@@ -860,7 +1026,6 @@ func TestTaskService_DeleteTask(t *testing.T) {
 					Title:       "Test Tasks",
 					Status:      "IN_PROGRESS",
 					Description: nil,
-					Image:       &empty,
 					CreatedAt:   time.Now(),
 				}
 
@@ -875,7 +1040,7 @@ func TestTaskService_DeleteTask(t *testing.T) {
 					Return(taskResponse, nil)
 
 				mockTaskRepo.EXPECT().
-					DeleteTask(ctx, mock.Anything).
+					DeleteTask(ctx, mock.Anything, mock.Anything).
 					Return(errMockError)
 
 				return mockTaskRepo, mockPayload
@@ -895,7 +1060,10 @@ func TestTaskService_DeleteTask(t *testing.T) {
 			defer mockTaskRepo.AssertExpectations(t)
 			defer mockPayload.AssertExpectations(t)
 
-			svc := NewTaskService(mockTaskRepo, lgr, mockPayload)
+			mockAttachmentRepo := new(mocks.MockIAttachmentRepository)
+			mockAttachmentRepo.EXPECT().GetTaskAttachments(ctx, []string{requestId}).Return(&[]models.Attachment{}, nil).Maybe()
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, nil, testSigner, lgr, mockPayload, nil, nil)
 
 			gotErr := svc.DeleteTask(tC.input())
 
@@ -938,25 +1106,27 @@ func TestTaskService_GetAllTasks(t *testing.T) {
 						UserID:      "1",
 						Title:       "Task 1",
 						Description: ptr("Description 1"),
-						Image:       ptr("image1.png"),
+						ImageID:     ptr("550e8400-e29b-41d4-a716-446655440010"),
 						Status:      "pending",
 						CreatedAt:   time.Now(),
 					},
 				}
 
 				mockTaskRepo.EXPECT().
-					GetAllTasks(ctx, mock.Anything, mock.Anything).
+					GetAllTasks(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(mockTasks, nil)
 
 				return mockTaskRepo, mockPayload
 			},
 			input: func() (context.Context, *entities.GetAllTasksRequest) {
-				return ctx, &entities.GetAllTasksRequest{}
+				return ctx, &entities.GetAllTasksRequest{Limit: 10, Offset: 1}
 			},
 			verify: func(t *testing.T, got *entities.GetAllTasksResponse, gotErr error) {
 				assert.NoError(t, gotErr)
 				assert.NotNil(t, got)
-				assert.Equal(t, 1, got.Total)
+				assert.Equal(t, 1, got.Count)
+				assert.Nil(t, got.Total)
+				assert.Empty(t, got.NextCursor)
 				assert.Equal(t, "Task 1", got.Tasks[0].Title)
 			},
 		},
@@ -991,7 +1161,7 @@ func TestTaskService_GetAllTasks(t *testing.T) {
 					Return(okPayload, nil)
 
 				mockTaskRepo.EXPECT().
-					GetAllTasks(ctx, mock.Anything, mock.Anything).
+					GetAllTasks(ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 					Return(nil, errMockError)
 
 				return mockTaskRepo, mockPayload
@@ -1012,7 +1182,7 @@ func TestTaskService_GetAllTasks(t *testing.T) {
 			defer mockTaskRepo.AssertExpectations(t)
 			defer mockPayload.AssertExpectations(t)
 
-			svc := NewTaskService(mockTaskRepo, lgr, mockPayload)
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
 
 			got, gotErr := svc.GetAllTasks(tC.input())
 
@@ -1021,7 +1191,1750 @@ func TestTaskService_GetAllTasks(t *testing.T) {
 	}
 }
 
-// Helper function to convert string to *string
-func ptr(s string) *string {
-	return &s
+func TestTaskService_GetTaskHistory(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	errMockError := errors.New("mock error")
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+	completed := "COMPLETED"
+	inProgress := "IN_PROGRESS"
+
+	okPayload := &utils.Payload{
+		ID:        uuid.New(),
+		UserId:    "1",
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour * 24),
+	}
+
+	taskResponse := &models.Task{
+		ID:        uuid.MustParse(requestId),
+		UserID:    okPayload.UserId,
+		Title:     "Test Task",
+		Status:    "COMPLETED",
+		CreatedAt: time.Now(),
+	}
+
+	testCases := []struct {
+		name   string
+		setup  func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct)
+		input  func() (context.Context, string)
+		verify func(t *testing.T, got *entities.GetTaskHistoryResponse, gotErr error)
+	}{
+		{
+			name: "GetTaskHistory_OK",
+			setup: func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, requestId).
+					Return(taskResponse, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTaskHistory(ctx, requestId).
+					Return(&[]models.TaskHistory{
+						{
+							TaskID:  taskResponse.ID,
+							ActorID: okPayload.UserId,
+							Action:  string(constants.TaskHistoryActionUpdate),
+							Version: 2,
+							Changes: models.FieldChanges{
+								{Field: "status", Before: &inProgress, After: &completed},
+							},
+						},
+						{
+							TaskID:  taskResponse.ID,
+							ActorID: okPayload.UserId,
+							Action:  string(constants.TaskHistoryActionCreate),
+							Version: 1,
+						},
+					}, nil)
+
+				return mockTaskRepo, mockPayload
+			},
+			input: func() (context.Context, string) {
+				return ctx, requestId
+			},
+			verify: func(t *testing.T, got *entities.GetTaskHistoryResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, requestId, got.TaskID)
+				assert.Equal(t, 2, got.Total)
+				assert.Equal(t, 2, got.History[0].Version)
+				assert.Equal(t, "status", got.History[0].Changes[0].Field)
+				assert.Equal(t, &completed, got.History[0].Changes[0].After)
+				assert.Empty(t, got.History[1].Changes)
+			},
+		},
+		{
+			name: "GetTaskHistory_NotMatchUserID",
+			setup: func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				otherTask := *taskResponse
+				otherTask.UserID = "2"
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, requestId).
+					Return(&otherTask, nil)
+
+				mockTaskRepo.EXPECT().
+					GetSharePermission(ctx, requestId, okPayload.UserId).
+					Return("", nil)
+
+				return mockTaskRepo, mockPayload
+			},
+			input: func() (context.Context, string) {
+				return ctx, requestId
+			},
+			verify: func(t *testing.T, got *entities.GetTaskHistoryResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrUserIdDoesNotMatchWithYourAccount, gotErr)
+			},
+		},
+		{
+			name: "GetTaskHistory_GetTaskHistoryError",
+			setup: func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, requestId).
+					Return(taskResponse, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTaskHistory(ctx, requestId).
+					Return(nil, errMockError)
+
+				return mockTaskRepo, mockPayload
+			},
+			input: func() (context.Context, string) {
+				return ctx, requestId
+			},
+			verify: func(t *testing.T, got *entities.GetTaskHistoryResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Error(t, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo, mockPayload := tC.setup()
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			got, gotErr := svc.GetTaskHistory(tC.input())
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestTaskService_RevertTask(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{
+		ID:        uuid.New(),
+		UserId:    "1",
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour * 24),
+	}
+
+	newTaskResponse := func() *models.Task {
+		return &models.Task{
+			ID:        uuid.MustParse(requestId),
+			UserID:    okPayload.UserId,
+			Title:     "Renamed Task",
+			Status:    "COMPLETED",
+			Tags:      models.TaskTags{"later"},
+			Version:   3,
+			CreatedAt: time.Now(),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		setup  func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct)
+		input  func() (context.Context, string, int, *entities.RevertTaskRequest)
+		verify func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error)
+	}{
+		{
+			name: "RevertTask_OK",
+			setup: func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, requestId).
+					Return(newTaskResponse(), nil)
+
+				mockTaskRepo.EXPECT().
+					GetTaskHistoryVersion(ctx, requestId, 1).
+					Return(&models.TaskHistory{
+						Action:  string(constants.TaskHistoryActionCreate),
+						Version: 1,
+						Snapshot: &models.TaskSnapshot{
+							Title:  "Test Task",
+							Status: "IN_PROGRESS",
+							Tags:   []string{"work"},
+						},
+					}, nil)
+
+				mockTaskRepo.EXPECT().
+					UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+						return task.Title == "Test Task" && task.Status == "IN_PROGRESS"
+					}), mock.MatchedBy(func(history *models.TaskHistory) bool {
+						return history.Action == string(constants.TaskHistoryActionRevert) &&
+							len(history.Changes) == 3
+					})).
+					Return(nil)
+
+				return mockTaskRepo, mockPayload
+			},
+			input: func() (context.Context, string, int, *entities.RevertTaskRequest) {
+				return ctx, requestId, 1, &entities.RevertTaskRequest{IfMatch: `"3"`}
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "Test Task", got.Title)
+				assert.Equal(t, "IN_PROGRESS", got.Status)
+				assert.Equal(t, []string{"work"}, got.Tags)
+			},
+		},
+		{
+			name: "RevertTask_VersionNotFound",
+			setup: func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, requestId).
+					Return(newTaskResponse(), nil)
+
+				mockTaskRepo.EXPECT().
+					GetTaskHistoryVersion(ctx, requestId, 9).
+					Return(nil, gorm.ErrRecordNotFound)
+
+				return mockTaskRepo, mockPayload
+			},
+			input: func() (context.Context, string, int, *entities.RevertTaskRequest) {
+				return ctx, requestId, 9, &entities.RevertTaskRequest{}
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrTaskVersionNotFound, gotErr)
+			},
+		},
+		{
+			name: "RevertTask_DeleteVersion",
+			setup: func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, requestId).
+					Return(newTaskResponse(), nil)
+
+				mockTaskRepo.EXPECT().
+					GetTaskHistoryVersion(ctx, requestId, 3).
+					Return(&models.TaskHistory{
+						Action:   string(constants.TaskHistoryActionDelete),
+						Version:  3,
+						Snapshot: &models.TaskSnapshot{Title: "Test Task"},
+					}, nil)
+
+				return mockTaskRepo, mockPayload
+			},
+			input: func() (context.Context, string, int, *entities.RevertTaskRequest) {
+				return ctx, requestId, 3, &entities.RevertTaskRequest{}
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrTaskVersionNotFound, gotErr)
+			},
+		},
+		{
+			name: "RevertTask_VersionMismatch",
+			setup: func() (*mocks.MockITaskRepository, *mocks.MockIPayloadConstruct) {
+				mockTaskRepo := new(mocks.MockITaskRepository)
+				mockPayload := new(mocks.MockIPayloadConstruct)
+
+				mockPayload.EXPECT().
+					GetAuthPayload(ctx, mock.Anything).
+					Return(okPayload, nil)
+
+				mockTaskRepo.EXPECT().
+					GetTask(ctx, requestId).
+					Return(newTaskResponse(), nil)
+
+				return mockTaskRepo, mockPayload
+			},
+			input: func() (context.Context, string, int, *entities.RevertTaskRequest) {
+				return ctx, requestId, 1, &entities.RevertTaskRequest{IfMatch: `"2"`}
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrTaskVersionMismatch, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo, mockPayload := tC.setup()
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			got, gotErr := svc.RevertTask(tC.input())
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestTaskService_SharedPermissions(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{
+		ID:        uuid.New(),
+		UserId:    "1",
+		IssuedAt:  time.Now(),
+		ExpiredAt: time.Now().Add(time.Hour * 24),
+	}
+
+	newSharedTask := func() *models.Task {
+		return &models.Task{
+			ID:        uuid.MustParse(requestId),
+			UserID:    "2",
+			Title:     "Shared Task",
+			Status:    "IN_PROGRESS",
+			CreatedAt: time.Now(),
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		permission string
+		run        func(svc ITaskService) (interface{}, error)
+		setup      func(mockTaskRepo *mocks.MockITaskRepository)
+		verify     func(t *testing.T, got interface{}, gotErr error)
+	}{
+		{
+			name:       "GetTask_Viewer_OK",
+			permission: "viewer",
+			run: func(svc ITaskService) (interface{}, error) {
+				return svc.GetTask(ctx, requestId)
+			},
+			verify: func(t *testing.T, got interface{}, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "viewer", got.(*entities.GetTaskResponse).Permission)
+			},
+		},
+		{
+			name:       "UpdateTask_Viewer_Forbidden",
+			permission: "viewer",
+			run: func(svc ITaskService) (interface{}, error) {
+				return svc.UpdateTask(ctx, requestId, &entities.UpdateTaskRequest{Title: "New Title"})
+			},
+			verify: func(t *testing.T, got interface{}, gotErr error) {
+				assert.Equal(t, constants.ErrInsufficientPermission, gotErr)
+			},
+		},
+		{
+			name:       "UpdateTask_Editor_OK",
+			permission: "editor",
+			run: func(svc ITaskService) (interface{}, error) {
+				return svc.UpdateTask(ctx, requestId, &entities.UpdateTaskRequest{Title: "New Title"})
+			},
+			setup: func(mockTaskRepo *mocks.MockITaskRepository) {
+				mockTaskRepo.EXPECT().
+					UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+						return task.Title == "New Title" && task.UserID == "2"
+					}), mock.MatchedBy(func(history *models.TaskHistory) bool {
+						return history.ActorID == okPayload.UserId
+					})).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got interface{}, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "New Title", got.(*entities.UpdateTaskResponse).Title)
+			},
+		},
+		{
+			name:       "DeleteTask_Editor_Forbidden",
+			permission: "editor",
+			run: func(svc ITaskService) (interface{}, error) {
+				return nil, svc.DeleteTask(ctx, requestId)
+			},
+			verify: func(t *testing.T, got interface{}, gotErr error) {
+				assert.Equal(t, constants.ErrInsufficientPermission, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().
+				GetAuthPayload(ctx, mock.Anything).
+				Return(okPayload, nil)
+
+			mockTaskRepo.EXPECT().
+				GetTask(ctx, requestId).
+				Return(newSharedTask(), nil)
+
+			mockTaskRepo.EXPECT().
+				GetSharePermission(ctx, requestId, okPayload.UserId).
+				Return(tC.permission, nil)
+
+			if tC.setup != nil {
+				tC.setup(mockTaskRepo)
+			}
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			got, gotErr := tC.run(svc)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestTaskService_GetTask_Workspace(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+	workspaceId := "660e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name       string
+		role       constants.WorkspaceRole
+		permission string
+	}{
+		{name: "Member_IsEditor", role: constants.WorkspaceRoleMember, permission: "editor"},
+		{name: "Admin_IsOwner", role: constants.WorkspaceRoleAdmin, permission: "owner"},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			ctx := utils.WithWorkspace(context.Background(), workspaceId, tC.role)
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().
+				GetAuthPayload(ctx, mock.Anything).
+				Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+
+			// Workspace tasks never consult per-task shares
+			mockTaskRepo.EXPECT().
+				GetTask(ctx, requestId).
+				Return(&models.Task{ID: uuid.MustParse(requestId), UserID: "2", WorkspaceID: &workspaceId}, nil)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			got, gotErr := svc.GetTask(ctx, requestId)
+
+			assert.NoError(t, gotErr)
+			assert.Equal(t, tC.permission, got.Permission)
+			assert.Equal(t, &workspaceId, got.WorkspaceID)
+		})
+	}
+}
+
+func TestTaskService_Assignment(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+	assigneeId := "22222222-2222-2222-2222-222222222222"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	newOwnedTask := func(assignee *string) *models.Task {
+		return &models.Task{
+			ID:         uuid.MustParse(requestId),
+			UserID:     "1",
+			Title:      "Task 1",
+			Status:     "IN_PROGRESS",
+			AssigneeID: assignee,
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		task   *models.Task
+		req    *entities.UpdateTaskRequest
+		setup  func(*mocks.MockITaskRepository, *mocks.MockINotificationRepository)
+		verify func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error)
+	}{
+		{
+			name: "Assign_SharedUser_Notifies",
+			task: newOwnedTask(nil),
+			req:  &entities.UpdateTaskRequest{AssigneeID: &assigneeId},
+			setup: func(taskRepo *mocks.MockITaskRepository, notificationRepo *mocks.MockINotificationRepository) {
+				taskRepo.EXPECT().GetSharePermission(ctx, requestId, assigneeId).Return("viewer", nil)
+				taskRepo.EXPECT().
+					UpdateTask(ctx, mock.Anything, mock.MatchedBy(func(history *models.TaskHistory) bool {
+						return len(history.Changes) == 1 && history.Changes[0].Field == "assignee_id"
+					})).
+					Return(nil)
+				notificationRepo.EXPECT().
+					CreateNotifications(ctx, mock.MatchedBy(func(notifications []models.Notification) bool {
+						return len(notifications) == 1 &&
+							notifications[0].UserID == assigneeId &&
+							notifications[0].Type == string(constants.NotificationTypeTaskAssigned)
+					})).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, &assigneeId, got.AssigneeID)
+			},
+		},
+		{
+			name: "Assign_UserWithoutAccess",
+			task: newOwnedTask(nil),
+			req:  &entities.UpdateTaskRequest{AssigneeID: &assigneeId},
+			setup: func(taskRepo *mocks.MockITaskRepository, notificationRepo *mocks.MockINotificationRepository) {
+				taskRepo.EXPECT().GetSharePermission(ctx, requestId, assigneeId).Return("", nil)
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrAssigneeNoAccess, gotErr)
+			},
+		},
+		{
+			name: "Unassign_NotifiesPrevious",
+			task: newOwnedTask(&assigneeId),
+			req:  &entities.UpdateTaskRequest{AssigneeID: ptr("")},
+			setup: func(taskRepo *mocks.MockITaskRepository, notificationRepo *mocks.MockINotificationRepository) {
+				taskRepo.EXPECT().UpdateTask(ctx, mock.Anything, mock.Anything).Return(nil)
+				notificationRepo.EXPECT().
+					CreateNotifications(ctx, mock.MatchedBy(func(notifications []models.Notification) bool {
+						return len(notifications) == 1 &&
+							notifications[0].UserID == assigneeId &&
+							notifications[0].Type == string(constants.NotificationTypeTaskUnassigned)
+					})).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Nil(t, got.AssigneeID)
+			},
+		},
+		{
+			name: "AssignSelf_NoNotification",
+			task: newOwnedTask(nil),
+			req:  &entities.UpdateTaskRequest{AssigneeID: ptr("1")},
+			setup: func(taskRepo *mocks.MockITaskRepository, notificationRepo *mocks.MockINotificationRepository) {
+				taskRepo.EXPECT().UpdateTask(ctx, mock.Anything, mock.Anything).Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "1", *got.AssigneeID)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockNotificationRepo := new(mocks.MockINotificationRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockNotificationRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+			mockTaskRepo.EXPECT().GetTask(ctx, requestId).Return(tC.task, nil)
+			tC.setup(mockTaskRepo, mockNotificationRepo)
+
+			svc := NewTaskService(mockTaskRepo, nil, mockNotificationRepo, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			got, gotErr := svc.UpdateTask(ctx, requestId, tC.req)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestTaskService_GetAllTasks_AssigneeMe(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockTaskRepo.EXPECT().
+		GetAllTasks(ctx, mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+			return req.Assignee == "1"
+		}), nil, (*utils.Cursor)(nil), "1").
+		Return(&[]models.Task{}, nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+	_, gotErr := svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Assignee: "me", Limit: 10, Offset: 1})
+
+	assert.NoError(t, gotErr)
+}
+
+func TestTaskService_GetAllTasks_Search(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockTaskRepo.EXPECT().
+		GetAllTasks(ctx, mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+			return req.Search == "release -draft" && req.SortBy == "relevance" && req.Order == "desc"
+		}), nil, (*utils.Cursor)(nil), "1").
+		Return(&[]models.Task{
+			{
+				ID:                   uuid.New(),
+				UserID:               "1",
+				Title:                "Plan <release>",
+				Description:          ptr("Notes for the release"),
+				SearchRank:           0.6,
+				TitleHighlight:       ptr("Plan <" + utils.HighlightStart + "release" + utils.HighlightStop + ">"),
+				DescriptionHighlight: ptr("Notes for the " + utils.HighlightStart + "release" + utils.HighlightStop),
+			},
+			{
+				ID:                   uuid.New(),
+				UserID:               "1",
+				Title:                "Release",
+				SearchRank:           0.3,
+				TitleHighlight:       ptr(utils.HighlightStart + "Release" + utils.HighlightStop),
+				DescriptionHighlight: ptr(""),
+			},
+		}, nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+	got, gotErr := svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Search: "  release -draft ", Limit: 10, Offset: 1})
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, 0.6, *got.Tasks[0].SearchRank)
+	assert.Equal(t, "Plan &lt;<mark>release</mark>&gt;", got.Tasks[0].Highlight.Title)
+	assert.Equal(t, "Notes for the <mark>release</mark>", *got.Tasks[0].Highlight.Description)
+	assert.Nil(t, got.Tasks[1].Highlight.Description)
+}
+
+func TestTaskService_GetAllTasks_RelevanceWithoutSearch(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockTaskRepo.EXPECT().
+		GetAllTasks(ctx, mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+			return req.SortBy == "created_at"
+		}), nil, (*utils.Cursor)(nil), "1").
+		Return(&[]models.Task{{ID: uuid.New(), UserID: "1", Title: "Task 1"}}, nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+	got, gotErr := svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{SortBy: "relevance", Limit: 10, Offset: 1})
+
+	assert.NoError(t, gotErr)
+	assert.Nil(t, got.Tasks[0].SearchRank)
+	assert.Nil(t, got.Tasks[0].Highlight)
+}
+
+func TestTaskService_GetAllTasks_Filter(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockTaskRepo.EXPECT().
+		GetAllTasks(ctx, mock.Anything, mock.MatchedBy(func(filter utils.FilterNode) bool {
+			and, ok := filter.(*utils.FilterAnd)
+			return ok && and.Left.(*utils.FilterTerm).Value == "IN_PROGRESS" && and.Right.(*utils.FilterNot) != nil
+		}), (*utils.Cursor)(nil), "1").
+		Return(&[]models.Task{}, nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+	_, gotErr := svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Filter: "status:in_progress -tag:later", Limit: 10, Offset: 1})
+
+	assert.NoError(t, gotErr)
+}
+
+func TestTaskService_UpdateTask_Tags(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockTaskRepo.EXPECT().GetTask(ctx, requestId).Return(&models.Task{
+		ID:     uuid.MustParse(requestId),
+		UserID: "1",
+		Title:  "Task 1",
+		Status: "IN_PROGRESS",
+		Tags:   models.TaskTags{"home"},
+	}, nil)
+	mockTaskRepo.EXPECT().
+		UpdateTask(ctx, mock.Anything, mock.MatchedBy(func(history *models.TaskHistory) bool {
+			return len(history.Changes) == 1 &&
+				history.Changes[0].Field == "tags" &&
+				*history.Changes[0].Before == "home" &&
+				*history.Changes[0].After == "work,deep-work"
+		})).
+		Return(nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+	got, gotErr := svc.UpdateTask(ctx, requestId, &entities.UpdateTaskRequest{Tags: []string{"Work", "deep-work", "work"}})
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, []string{"work", "deep-work"}, got.Tags)
+}
+
+func TestTaskService_PatchTask(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+	description := "Keep me"
+	assignee := "2"
+
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	mockNotificationRepo := new(mocks.MockINotificationRepository)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockNotificationRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockTaskRepo.EXPECT().GetTask(ctx, requestId).Return(&models.Task{
+		ID:          uuid.MustParse(requestId),
+		UserID:      "1",
+		Title:       "Task 1",
+		Status:      "IN_PROGRESS",
+		Description: &description,
+		AssigneeID:  &assignee,
+		Tags:        models.TaskTags{"home"},
+	}, nil)
+	mockTaskRepo.EXPECT().
+		UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+			return task.Title == "Task 2" && task.Description == nil && task.AssigneeID == nil
+		}), mock.MatchedBy(func(history *models.TaskHistory) bool {
+			return len(history.Changes) == 3
+		})).
+		Return(nil)
+	mockNotificationRepo.EXPECT().
+		CreateNotifications(ctx, mock.MatchedBy(func(notifications []models.Notification) bool {
+			return len(notifications) == 1 &&
+				notifications[0].UserID == assignee &&
+				notifications[0].Type == string(constants.NotificationTypeTaskUnassigned)
+		})).
+		Return(nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, mockNotificationRepo, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+	// Members left out keep their value, members sent as null are cleared
+	var req entities.PatchTaskRequest
+	err := json.Unmarshal([]byte(`{"title": "Task 2", "description": null, "assignee_id": null}`), &req)
+	assert.NoError(t, err)
+
+	got, gotErr := svc.PatchTask(ctx, requestId, &req)
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, "Task 2", got.Title)
+	assert.Equal(t, "IN_PROGRESS", got.Status)
+	assert.Nil(t, got.Description)
+	assert.Nil(t, got.AssigneeID)
+	assert.Equal(t, []string{"home"}, got.Tags)
+}
+
+func TestTaskService_UpdateTask_IfMatch(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name    string
+		ifMatch string
+		setup   func(mockTaskRepo *mocks.MockITaskRepository)
+		verify  func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error)
+	}{
+		{
+			name:    "CurrentVersion_Updates",
+			ifMatch: `"4"`,
+			setup: func(mockTaskRepo *mocks.MockITaskRepository) {
+				mockTaskRepo.EXPECT().
+					UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+						return task.Version == 4
+					}), mock.Anything).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "Task 2", got.Title)
+			},
+		},
+		{
+			name:    "StaleVersion_PreconditionFailed",
+			ifMatch: `"3"`,
+			setup:   func(mockTaskRepo *mocks.MockITaskRepository) {},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.ErrorIs(t, gotErr, constants.ErrTaskVersionMismatch)
+				assert.Nil(t, got)
+			},
+		},
+		{
+			name:    "ConcurrentUpdate_PreconditionFailed",
+			ifMatch: "",
+			setup: func(mockTaskRepo *mocks.MockITaskRepository) {
+				mockTaskRepo.EXPECT().
+					UpdateTask(ctx, mock.Anything, mock.Anything).
+					Return(constants.ErrTaskVersionMismatch)
+			},
+			verify: func(t *testing.T, got *entities.UpdateTaskResponse, gotErr error) {
+				assert.ErrorIs(t, gotErr, constants.ErrTaskVersionMismatch)
+				assert.Nil(t, got)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			mockTaskRepo.EXPECT().GetTask(ctx, requestId).Return(&models.Task{
+				ID:      uuid.MustParse(requestId),
+				UserID:  "1",
+				Title:   "Task 1",
+				Status:  "IN_PROGRESS",
+				Version: 4,
+			}, nil)
+			tc.setup(mockTaskRepo)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+			got, gotErr := svc.UpdateTask(ctx, requestId, &entities.UpdateTaskRequest{Title: "Task 2", IfMatch: tc.ifMatch})
+
+			tc.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestTaskService_UpdateTask_Blocked(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name   string
+		status string
+		req    *entities.UpdateTaskRequest
+		expect error
+	}{
+		{
+			name:   "Complete_WhileBlocked",
+			status: "IN_PROGRESS",
+			req:    &entities.UpdateTaskRequest{Status: constants.TaskStatusCompleted},
+			expect: constants.ErrInvalidStatusTransition,
+		},
+		{
+			name:   "EditTitle_WhileBlocked",
+			status: "IN_PROGRESS",
+			req:    &entities.UpdateTaskRequest{Title: "New Title"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			mockTaskRepo.EXPECT().
+				GetTask(ctx, requestId).
+				Return(&models.Task{ID: uuid.MustParse(requestId), UserID: "1", Status: tC.status, Blocked: true}, nil)
+			if tC.expect == nil {
+				mockTaskRepo.EXPECT().UpdateTask(ctx, mock.Anything, mock.Anything).Return(nil)
+			}
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			_, gotErr := svc.UpdateTask(ctx, requestId, tC.req)
+
+			assert.Equal(t, tC.expect, gotErr)
+		})
+	}
+}
+
+func TestTaskService_MoveTask(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+	firstId := uuid.MustParse("660e8400-e29b-41d4-a716-446655440000")
+	secondId := uuid.MustParse("770e8400-e29b-41d4-a716-446655440000")
+
+	board := func(firstRank, secondRank *string) *[]models.Task {
+		return &[]models.Task{
+			{ID: firstId, UserID: "1", Status: "IN_PROGRESS", Rank: firstRank},
+			{ID: secondId, UserID: "1", Status: "IN_PROGRESS", Rank: secondRank},
+			{ID: uuid.MustParse(requestId), UserID: "1", Status: "IN_PROGRESS", Rank: ptr("k")},
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		req    *entities.MoveTaskRequest
+		task   *models.Task
+		setup  func(*mocks.MockITaskRepository)
+		verify func(t *testing.T, got *entities.GetTaskResponse, gotErr error)
+	}{
+		{
+			name: "MoveTask_BetweenRankedTasks",
+			req:  &entities.MoveTaskRequest{AfterID: firstId.String()},
+			task: &models.Task{ID: uuid.MustParse(requestId), UserID: "1", Status: "IN_PROGRESS", Rank: ptr("k")},
+			setup: func(repo *mocks.MockITaskRepository) {
+				repo.EXPECT().GetBoardTasks(ctx, "1").Return(board(ptr("A"), ptr("B")), nil)
+				repo.EXPECT().
+					MoveTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+						return *task.Rank > "A" && *task.Rank < "B"
+					}), map[string]string(nil), (*models.TaskHistory)(nil)).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.GetTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "IN_PROGRESS", got.Status)
+			},
+		},
+		{
+			name: "MoveTask_RebalancesUnrankedColumn",
+			req:  &entities.MoveTaskRequest{BeforeID: firstId.String()},
+			task: &models.Task{ID: uuid.MustParse(requestId), UserID: "1", Status: "IN_PROGRESS"},
+			setup: func(repo *mocks.MockITaskRepository) {
+				repo.EXPECT().GetBoardTasks(ctx, "1").Return(board(nil, nil), nil)
+				repo.EXPECT().
+					MoveTask(ctx, mock.Anything, mock.MatchedBy(func(ranks map[string]string) bool {
+						return len(ranks) == 2 && ranks[firstId.String()] < ranks[secondId.String()]
+					}), (*models.TaskHistory)(nil)).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.GetTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.NotNil(t, got.Rank)
+			},
+		},
+		{
+			name: "MoveTask_ToOtherColumnRecordsHistory",
+			req:  &entities.MoveTaskRequest{Status: constants.TaskStatusCompleted},
+			task: &models.Task{ID: uuid.MustParse(requestId), UserID: "1", Status: "IN_PROGRESS"},
+			setup: func(repo *mocks.MockITaskRepository) {
+				repo.EXPECT().GetBoardTasks(ctx, "1").Return(board(ptr("A"), ptr("B")), nil)
+				repo.EXPECT().
+					MoveTask(ctx, mock.Anything, map[string]string(nil), mock.MatchedBy(func(history *models.TaskHistory) bool {
+						return history != nil && history.Changes[0].Field == "status"
+					})).
+					Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.GetTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "COMPLETED", got.Status)
+			},
+		},
+		{
+			name: "MoveTask_AnchorNotInColumn",
+			req:  &entities.MoveTaskRequest{Status: constants.TaskStatusCompleted, AfterID: firstId.String()},
+			task: &models.Task{ID: uuid.MustParse(requestId), UserID: "1", Status: "IN_PROGRESS"},
+			setup: func(repo *mocks.MockITaskRepository) {
+				repo.EXPECT().GetBoardTasks(ctx, "1").Return(board(ptr("A"), ptr("B")), nil)
+			},
+			verify: func(t *testing.T, got *entities.GetTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInvalidMoveTarget, gotErr)
+			},
+		},
+		{
+			name: "MoveTask_BlockedToCompleted",
+			req:  &entities.MoveTaskRequest{Status: constants.TaskStatusCompleted},
+			task: &models.Task{ID: uuid.MustParse(requestId), UserID: "1", Status: "IN_PROGRESS", Blocked: true},
+			setup: func(repo *mocks.MockITaskRepository) {
+			},
+			verify: func(t *testing.T, got *entities.GetTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInvalidStatusTransition, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo := new(mocks.MockITaskRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockTaskRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			mockTaskRepo.EXPECT().GetTask(ctx, requestId).Return(tC.task, nil)
+			tC.setup(mockTaskRepo)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			got, gotErr := svc.MoveTask(ctx, requestId, tC.req)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestTaskService_GetTaskBoard(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockTaskRepo := new(mocks.MockITaskRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockTaskRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockTaskRepo.EXPECT().GetBoardTasks(ctx, "1").Return(&[]models.Task{
+		{ID: uuid.New(), UserID: "1", Title: "Done", Status: "COMPLETED", Rank: ptr("V")},
+		{ID: uuid.New(), UserID: "1", Title: "First", Status: "IN_PROGRESS", Rank: ptr("A")},
+		{ID: uuid.New(), UserID: "1", Title: "Second", Status: "IN_PROGRESS"},
+	}, nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+	got, gotErr := svc.GetTaskBoard(ctx)
+
+	assert.NoError(t, gotErr)
+	assert.Len(t, got.Columns, 2)
+	assert.Equal(t, "IN_PROGRESS", got.Columns[0].Status)
+	assert.Equal(t, 2, got.Columns[0].Total)
+	assert.Equal(t, "First", got.Columns[0].Tasks[0].Title)
+	assert.Equal(t, "Second", got.Columns[0].Tasks[1].Title)
+	assert.Equal(t, 1, got.Columns[1].Total)
+}
+
+// Helper function to convert string to *string
+func ptr(s string) *string {
+	return &s
+}
+
+func TestTaskService_GetAllTasks_Cursor(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	cursors := utils.NewCursorCodec(&config.Config{TokenConfig: config.TokenConfig{TokenSymmetricKey: "12345678901234567890123456789012"}})
+
+	created := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	task := func(n int) models.Task {
+		return models.Task{ID: uuid.New(), UserID: "1", Title: fmt.Sprintf("Task %d", n), CreatedAt: created.Add(-time.Duration(n) * time.Hour)}
+	}
+	t1, t2, t3 := task(1), task(2), task(3)
+
+	newService := func() (*mocks.MockITaskRepository, ITaskService) {
+		mockTaskRepo := mocks.NewMockITaskRepository(t)
+		mockPayload := mocks.NewMockIPayloadConstruct(t)
+		mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+		return mockTaskRepo, NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, cursors, nil)
+	}
+
+	// First page: one task too many means another page follows
+	repo, svc := newService()
+	repo.EXPECT().
+		GetAllTasks(ctx, mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+			return req.Limit == 3 && req.Offset == 0
+		}), nil, (*utils.Cursor)(nil), "1").
+		Return(&[]models.Task{t1, t2, t3}, nil)
+	repo.EXPECT().CountTasks(ctx, mock.Anything, nil, "1").Return(int64(3), nil)
+
+	first, err := svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Limit: 2, IncludeTotal: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, first.Count)
+	assert.Equal(t, int64(3), *first.Total)
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Empty(t, first.PrevCursor)
+
+	// Second page starts after the last task of the first
+	repo, svc = newService()
+	repo.EXPECT().
+		GetAllTasks(ctx, mock.Anything, nil, mock.MatchedBy(func(c *utils.Cursor) bool {
+			return c != nil && !c.Before && c.Values[1] == t2.ID.String()
+		}), "1").
+		Return(&[]models.Task{t3}, nil)
+
+	second, err := svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Limit: 2, Cursor: first.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, "Task 3", second.Tasks[0].Title)
+	assert.Empty(t, second.NextCursor)
+	assert.NotEmpty(t, second.PrevCursor)
+
+	// Going back, the repository returns the nearest task first
+	repo, svc = newService()
+	repo.EXPECT().
+		GetAllTasks(ctx, mock.Anything, nil, mock.MatchedBy(func(c *utils.Cursor) bool {
+			return c != nil && c.Before && c.Values[1] == t3.ID.String()
+		}), "1").
+		Return(&[]models.Task{t2, t1}, nil)
+
+	back, err := svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Limit: 2, Cursor: second.PrevCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Task 1", "Task 2"}, []string{back.Tasks[0].Title, back.Tasks[1].Title})
+	assert.NotEmpty(t, back.NextCursor)
+	assert.Empty(t, back.PrevCursor)
+
+	// A cursor does not carry over to another query
+	_, svc = newService()
+	_, err = svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Filter: "tag:work", Limit: 2, Cursor: first.NextCursor})
+	assert.Equal(t, constants.ErrInvalidCursor, err)
+
+	_, svc = newService()
+	_, err = svc.GetAllTasks(ctx, &entities.GetAllTasksRequest{Limit: 2, Cursor: "forged"})
+	assert.Equal(t, constants.ErrInvalidCursor, err)
+}
+
+func TestTaskService_BulkTasks(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	errMockError := errors.New("mock error")
+
+	ownId := "550e8400-e29b-41d4-a716-446655440001"
+	foreignId := "550e8400-e29b-41d4-a716-446655440002"
+	sharedId := "550e8400-e29b-41d4-a716-446655440003"
+	missingId := "550e8400-e29b-41d4-a716-446655440004"
+
+	ownTask := func() *models.Task {
+		return &models.Task{ID: uuid.MustParse(ownId), UserID: "1", Title: "Own", Status: "IN_PROGRESS", Tags: models.TaskTags{"work"}}
+	}
+
+	testCases := []struct {
+		name   string
+		req    *entities.BulkTaskRequest
+		setup  func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore)
+		verify func(t *testing.T, got *entities.BulkTaskResponse, gotErr error)
+	}{
+		{
+			name: "BulkTasks_SetStatusWithPerItemFailures",
+			req:  &entities.BulkTaskRequest{IDs: []string{ownId, foreignId, missingId, ownId}, Action: constants.TaskBulkActionSetStatus, Status: constants.TaskStatusCompleted},
+			setup: func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				repo.EXPECT().GetTask(ctx, ownId).Return(ownTask(), nil)
+				repo.EXPECT().GetTask(ctx, foreignId).Return(&models.Task{ID: uuid.MustParse(foreignId), UserID: "2"}, nil)
+				repo.EXPECT().GetSharePermission(ctx, foreignId, "1").Return("", nil)
+				repo.EXPECT().GetTask(ctx, missingId).Return(nil, gorm.ErrRecordNotFound)
+				repo.EXPECT().ApplyTaskChanges(ctx, mock.MatchedBy(func(changes []repositories.TaskChange) bool {
+					return len(changes) == 1 && changes[0].Task.Status == "COMPLETED" && !changes[0].Delete &&
+						changes[0].History.Changes[0].Field == "status"
+				})).Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.BulkTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, 3, got.Total)
+				assert.Equal(t, 1, got.Succeeded)
+				assert.Equal(t, 2, got.Failed)
+				assert.Equal(t, constants.TaskBulkResultUpdated, got.Results[0].Result)
+				assert.Equal(t, constants.TaskBulkResultFailed, got.Results[1].Result)
+				assert.Equal(t, int(constants.CodeUserIdDoesNotMatchWithYourAccount), got.Results[1].Error.Code)
+				assert.Equal(t, int(constants.CodeTaskNotFound), got.Results[2].Error.Code)
+			},
+		},
+		{
+			name: "BulkTasks_AddExistingTagIsUnchanged",
+			req:  &entities.BulkTaskRequest{IDs: []string{ownId}, Action: constants.TaskBulkActionAddTag, Tag: "Work"},
+			setup: func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				repo.EXPECT().GetTask(ctx, ownId).Return(ownTask(), nil)
+			},
+			verify: func(t *testing.T, got *entities.BulkTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, constants.TaskBulkResultUnchanged, got.Results[0].Result)
+			},
+		},
+		{
+			name: "BulkTasks_DeleteNeedsOwner",
+			req:  &entities.BulkTaskRequest{IDs: []string{ownId, sharedId}, Action: constants.TaskBulkActionDelete},
+			setup: func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				repo.EXPECT().GetTask(ctx, ownId).Return(ownTask(), nil)
+				repo.EXPECT().GetTask(ctx, sharedId).Return(&models.Task{ID: uuid.MustParse(sharedId), UserID: "2"}, nil)
+				repo.EXPECT().GetSharePermission(ctx, sharedId, "1").Return(string(constants.TaskPermissionEditor), nil)
+				repo.EXPECT().ApplyTaskChanges(ctx, mock.MatchedBy(func(changes []repositories.TaskChange) bool {
+					return len(changes) == 1 && changes[0].Delete && changes[0].History.Action == "DELETE"
+				})).Return(nil)
+				attachmentRepo.EXPECT().GetTaskAttachments(ctx, []string{ownId}).
+					Return(&[]models.Attachment{{StorageKey: "tasks/" + ownId + "/image"}}, nil)
+				blobs.EXPECT().Delete(ctx, "tasks/"+ownId+"/image").Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.BulkTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, constants.TaskBulkResultDeleted, got.Results[0].Result)
+				assert.Equal(t, int(constants.CodeInsufficientPermission), got.Results[1].Error.Code)
+			},
+		},
+		{
+			name: "BulkTasks_ArchiveByFilter",
+			req:  &entities.BulkTaskRequest{Filter: "status:COMPLETED", Action: constants.TaskBulkActionArchive},
+			setup: func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				repo.EXPECT().
+					GetAllTasks(ctx, mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+						return !req.Archived && req.Limit == constants.MaxBulkTasks+1
+					}), mock.Anything, (*utils.Cursor)(nil), "1").
+					Return(&[]models.Task{*ownTask()}, nil)
+				repo.EXPECT().GetTask(ctx, ownId).Return(ownTask(), nil)
+				repo.EXPECT().ApplyTaskChanges(ctx, mock.MatchedBy(func(changes []repositories.TaskChange) bool {
+					return len(changes) == 1 && changes[0].Task.ArchivedAt != nil && changes[0].History.Changes[0].Field == "archived"
+				})).Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.BulkTaskResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, 1, got.Succeeded)
+			},
+		},
+		{
+			name: "BulkTasks_FilterMatchesTooMany",
+			req:  &entities.BulkTaskRequest{Filter: "tag:work", Action: constants.TaskBulkActionArchive},
+			setup: func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				tasks := make([]models.Task, constants.MaxBulkTasks+1)
+				repo.EXPECT().GetAllTasks(ctx, mock.Anything, mock.Anything, (*utils.Cursor)(nil), "1").Return(&tasks, nil)
+			},
+			verify: func(t *testing.T, got *entities.BulkTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrTooManyBulkTasks, gotErr)
+			},
+		},
+		{
+			name: "BulkTasks_MissingStatus",
+			req:  &entities.BulkTaskRequest{IDs: []string{ownId}, Action: constants.TaskBulkActionSetStatus},
+			setup: func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+			},
+			verify: func(t *testing.T, got *entities.BulkTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrMissingRequiredFields, gotErr)
+			},
+		},
+		{
+			name: "BulkTasks_ApplyError",
+			req:  &entities.BulkTaskRequest{IDs: []string{ownId}, Action: constants.TaskBulkActionRemoveTag, Tag: "work"},
+			setup: func(repo *mocks.MockITaskRepository, attachmentRepo *mocks.MockIAttachmentRepository, blobs *mocks.MockIBlobStore) {
+				repo.EXPECT().GetTask(ctx, ownId).Return(ownTask(), nil)
+				repo.EXPECT().ApplyTaskChanges(ctx, mock.Anything).Return(errMockError)
+			},
+			verify: func(t *testing.T, got *entities.BulkTaskResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, errMockError, gotErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+
+			mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+			mockBlobs := mocks.NewMockIBlobStore(t)
+
+			tc.setup(mockTaskRepo, mockAttachmentRepo, mockBlobs)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, mockBlobs, testSigner, lgr, mockPayload, nil, nil)
+			got, err := svc.BulkTasks(ctx, tc.req)
+			tc.verify(t, got, err)
+		})
+	}
+}
+
+func TestTaskService_CreateTask_StoresImage(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	errMockError := errors.New("mock error")
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	for _, fail := range []bool{false, true} {
+		mockTaskRepo := mocks.NewMockITaskRepository(t)
+		mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+		mockBlobs := mocks.NewMockIBlobStore(t)
+		mockPayload := mocks.NewMockIPayloadConstruct(t)
+		mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+		mockAttachmentRepo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(0), nil)
+
+		// The image and its thumbnails are stored under the task before the task is saved
+		var keys []string
+		mockBlobs.EXPECT().
+			Put(ctx, mock.Anything, mock.Anything, mock.Anything, "image/png").
+			RunAndReturn(func(_ context.Context, k string, _ io.Reader, _ int64, _ string) error {
+				keys = append(keys, k)
+				return nil
+			}).
+			Times(3)
+		var saved *models.Task
+		repoErr := error(nil)
+		if fail {
+			repoErr = errMockError
+		}
+		mockTaskRepo.EXPECT().
+			CreateTask(ctx, mock.Anything, mock.MatchedBy(func(a *models.Attachment) bool {
+				return a != nil && a.Filename == "cat.png" && a.StorageKey == keys[0] && a.UserID == okPayload.UserId
+			}), mock.Anything, mock.Anything).
+			RunAndReturn(func(_ context.Context, task *models.Task, _ *models.Attachment, _ int64, _ *models.TaskHistory) error {
+				saved = task
+				return repoErr
+			})
+		if fail {
+			// A task that could not be saved leaves no blob behind
+			mockBlobs.EXPECT().Delete(ctx, mock.MatchedBy(func(k string) bool { return slices.Contains(keys, k) })).Return(nil).Times(3)
+		}
+
+		svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, mockBlobs, testSigner, lgr, mockPayload, nil, nil)
+		got, err := svc.CreateTask(ctx, &entities.CreateTaskRequest{
+			Title:  "Test Task",
+			Status: "IN_PROGRESS",
+			Image:  newFileHeader(t, "cat.png", testPNG),
+		})
+
+		if fail {
+			assert.Equal(t, errMockError, err)
+			continue
+		}
+		assert.NoError(t, err)
+		assert.True(t, strings.HasPrefix(keys[0], "tasks/"+saved.ID.String()+"/"))
+		assert.Equal(t, []string{keys[0] + ".thumb-128", keys[0] + ".thumb-512"}, keys[1:])
+		assert.True(t, strings.HasPrefix(*got.Image, "/api/v1/files/tasks/"+saved.ID.String()+"/attachments/"+*saved.ImageID+"/content?"))
+	}
+}
+
+func TestTaskService_UpdateTask_ReplacesImage(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	oldImageId := "550e8400-e29b-41d4-a716-446655440001"
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	mockTaskRepo := mocks.NewMockITaskRepository(t)
+	mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+	mockBlobs := mocks.NewMockIBlobStore(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+	mockTaskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{
+		ID: uuid.MustParse(taskId), UserID: "1", Title: "Task", Status: "IN_PROGRESS", ImageID: ptr(oldImageId), Version: 1,
+	}, nil)
+	mockAttachmentRepo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(1024), nil)
+	mockBlobs.EXPECT().Put(ctx, mock.Anything, mock.Anything, mock.Anything, "image/png").Return(nil).Times(3)
+	mockAttachmentRepo.EXPECT().CreateAttachment(ctx, mock.Anything, defaultStorageQuota).Return(nil)
+	mockTaskRepo.EXPECT().
+		UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+			return task.ImageID != nil && *task.ImageID != oldImageId
+		}), mock.MatchedBy(func(history *models.TaskHistory) bool {
+			return history.Changes[0].Field == "image" && *history.Changes[0].Before == oldImageId
+		})).
+		Return(nil)
+
+	// The old image is removed once the task no longer refers to it
+	oldKey := "tasks/" + taskId + "/" + oldImageId
+	mockAttachmentRepo.EXPECT().GetAttachment(ctx, taskId, oldImageId).Return(&models.Attachment{StorageKey: oldKey}, nil)
+	mockAttachmentRepo.EXPECT().DeleteAttachment(ctx, oldImageId).Return(nil)
+	mockBlobs.EXPECT().Delete(ctx, oldKey).Return(nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, mockBlobs, testSigner, lgr, mockPayload, nil, nil)
+	got, err := svc.UpdateTask(ctx, taskId, &entities.UpdateTaskRequest{Image: newFileHeader(t, "new.png", testPNG)})
+
+	assert.NoError(t, err)
+	assert.NotContains(t, *got.Image, oldImageId)
+}
+
+func TestTaskService_DeleteTask_RemovesAttachmentBlobs(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	mockTaskRepo := mocks.NewMockITaskRepository(t)
+	mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+	mockBlobs := mocks.NewMockIBlobStore(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+	mockTaskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{ID: uuid.MustParse(taskId), UserID: "1"}, nil)
+	mockAttachmentRepo.EXPECT().GetTaskAttachments(ctx, []string{taskId}).Return(&[]models.Attachment{
+		{StorageKey: "tasks/" + taskId + "/a"},
+		{StorageKey: "tasks/" + taskId + "/b"},
+	}, nil)
+	mockTaskRepo.EXPECT().DeleteTask(ctx, taskId, mock.Anything).Return(nil)
+
+	// A blob that fails to delete does not fail the request
+	mockBlobs.EXPECT().Delete(ctx, "tasks/"+taskId+"/a").Return(errors.New("mock error"))
+	mockBlobs.EXPECT().Delete(ctx, "tasks/"+taskId+"/b").Return(nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, mockBlobs, testSigner, lgr, mockPayload, nil, nil)
+	assert.NoError(t, svc.DeleteTask(ctx, taskId))
+}
+
+func TestTaskService_CreateTask_StorageQuotaExceeded(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+	cfg := &config.Config{Storage: config.Storage{UserQuota: 1024}}
+
+	mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+	// Nothing is uploaded once the quota would be exceeded
+	mockAttachmentRepo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(1024), nil)
+
+	svc := NewTaskService(nil, nil, nil, nil, mockAttachmentRepo, nil, testSigner, lgr, mockPayload, nil, cfg)
+	_, err := svc.CreateTask(ctx, &entities.CreateTaskRequest{
+		Title:  "Test Task",
+		Status: "IN_PROGRESS",
+		Image:  newFileHeader(t, "cat.png", testPNG),
+	})
+
+	assert.Equal(t, constants.ErrStorageQuotaExceeded, err)
+}
+
+func TestTaskService_CreateTask_RejectsInvalidImage(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	testCases := []struct {
+		name    string
+		content []byte
+		maxSize int64
+		want    error
+	}{
+		{name: "not an image", content: []byte("%PDF-1.7\n"), want: constants.ErrUnsupportedImageType},
+		{name: "unsupported image type", content: []byte("BM\x00\x00\x00\x00\x00\x00\x00\x00"), want: constants.ErrUnsupportedImageType},
+		{name: "corrupt image", content: pngHeader, want: constants.ErrInvalidImage},
+		{name: "too large", content: testPNG, maxSize: 16, want: constants.ErrImageTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+			cfg := &config.Config{Storage: config.Storage{MaxImageSize: tc.maxSize}}
+
+			// Nothing is stored for a rejected image
+			svc := NewTaskService(nil, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, cfg)
+			_, err := svc.CreateTask(ctx, &entities.CreateTaskRequest{
+				Title:  "Test Task",
+				Status: "IN_PROGRESS",
+				Image:  newFileHeader(t, "cat.png", tc.content),
+			})
+
+			assert.Equal(t, tc.want, err)
+		})
+	}
+}
+
+func TestTaskService_CreateTask_CopiesImage(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	sourceId := "550e8400-e29b-41d4-a716-446655440001"
+	sourceKey := "tasks/550e8400-e29b-41d4-a716-446655440000/" + sourceId
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	mockTaskRepo := mocks.NewMockITaskRepository(t)
+	mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+	mockBlobs := mocks.NewMockIBlobStore(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+	// The new task gets a copy of an image the user uploaded to another task
+	mockAttachmentRepo.EXPECT().GetUserAttachment(ctx, okPayload.UserId, sourceId).Return(&models.Attachment{
+		ID: uuid.MustParse(sourceId), StorageKey: sourceKey, Filename: "cat.png", ContentType: "image/png", Size: int64(len(testPNG)),
+	}, nil)
+	mockBlobs.EXPECT().Get(ctx, sourceKey).Return(io.NopCloser(bytes.NewReader(testPNG)), nil)
+	mockAttachmentRepo.EXPECT().GetUserStorageUsed(ctx, okPayload.UserId).Return(int64(0), nil)
+	var keys []string
+	mockBlobs.EXPECT().
+		Put(ctx, mock.Anything, mock.Anything, mock.Anything, "image/png").
+		RunAndReturn(func(_ context.Context, k string, _ io.Reader, _ int64, _ string) error {
+			keys = append(keys, k)
+			return nil
+		}).
+		Times(3)
+	var saved *models.Task
+	mockTaskRepo.EXPECT().
+		CreateTask(ctx, mock.Anything, mock.MatchedBy(func(a *models.Attachment) bool {
+			return a != nil && a.ID.String() != sourceId && a.Filename == "cat.png" && a.StorageKey == keys[0]
+		}), mock.Anything, mock.Anything).
+		RunAndReturn(func(_ context.Context, task *models.Task, _ *models.Attachment, _ int64, _ *models.TaskHistory) error {
+			saved = task
+			return nil
+		})
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, mockBlobs, testSigner, lgr, mockPayload, nil, nil)
+	got, err := svc.CreateTask(ctx, &entities.CreateTaskRequest{
+		Title:   "Test Task",
+		Status:  "IN_PROGRESS",
+		ImageID: ptr(sourceId),
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(keys[0], "tasks/"+saved.ID.String()+"/"))
+	assert.True(t, strings.HasPrefix(*got.Image, "/api/v1/files/tasks/"+saved.ID.String()+"/attachments/"+*saved.ImageID+"/content?"))
+}
+
+func TestTaskService_CreateTask_RejectsImageID(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	imageId := "550e8400-e29b-41d4-a716-446655440001"
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	testCases := []struct {
+		name       string
+		attachment *models.Attachment
+		repoErr    error
+		want       error
+	}{
+		{name: "not the user's", repoErr: gorm.ErrRecordNotFound, want: constants.ErrAttachmentNotFound},
+		{name: "not an image", attachment: &models.Attachment{ContentType: "application/pdf", Size: 16}, want: constants.ErrUnsupportedImageType},
+		{name: "too large", attachment: &models.Attachment{ContentType: "image/png", Size: defaultMaxImageSize + 1}, want: constants.ErrImageTooLarge},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+			mockAttachmentRepo.EXPECT().GetUserAttachment(ctx, okPayload.UserId, imageId).Return(tc.attachment, tc.repoErr)
+
+			// Nothing is stored for a rejected image
+			svc := NewTaskService(nil, nil, nil, nil, mockAttachmentRepo, nil, testSigner, lgr, mockPayload, nil, nil)
+			_, err := svc.CreateTask(ctx, &entities.CreateTaskRequest{
+				Title:   "Test Task",
+				Status:  "IN_PROGRESS",
+				ImageID: ptr(imageId),
+			})
+
+			assert.Equal(t, tc.want, err)
+		})
+	}
+}
+
+func TestTaskService_UpdateTask_SelectsImage(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	oldImageId := "550e8400-e29b-41d4-a716-446655440001"
+	imageId := "550e8400-e29b-41d4-a716-446655440002"
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	testCases := []struct {
+		name       string
+		attachment *models.Attachment
+		repoErr    error
+		want       error
+	}{
+		{name: "UpdateTask_TaskImage", attachment: &models.Attachment{ID: uuid.MustParse(imageId), ContentType: "image/png"}},
+		{name: "UpdateTask_NotTaskAttachment", repoErr: gorm.ErrRecordNotFound, want: constants.ErrAttachmentNotFound},
+		{name: "UpdateTask_NotAnImage", attachment: &models.Attachment{ID: uuid.MustParse(imageId), ContentType: "text/plain; charset=utf-8"}, want: constants.ErrUnsupportedImageType},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			mockTaskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{
+				ID: uuid.MustParse(taskId), UserID: "1", Title: "Task", Status: "IN_PROGRESS", ImageID: ptr(oldImageId), Version: 1,
+			}, nil)
+			mockAttachmentRepo.EXPECT().GetAttachment(ctx, taskId, imageId).Return(tc.attachment, tc.repoErr)
+			if tc.want == nil {
+				// The old image stays one of the task's attachments
+				mockTaskRepo.EXPECT().
+					UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+						return task.ImageID != nil && *task.ImageID == imageId
+					}), mock.Anything).
+					Return(nil)
+			}
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, nil, testSigner, lgr, mockPayload, nil, nil)
+			got, err := svc.UpdateTask(ctx, taskId, &entities.UpdateTaskRequest{ImageID: ptr(imageId)})
+
+			assert.Equal(t, tc.want, err)
+			if tc.want == nil {
+				assert.Contains(t, *got.Image, "/attachments/"+imageId+"/content?")
+			}
+		})
+	}
+}
+
+func TestTaskService_PatchTask_ImageID(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	taskId := "550e8400-e29b-41d4-a716-446655440000"
+	oldImageId := "550e8400-e29b-41d4-a716-446655440001"
+	imageId := "550e8400-e29b-41d4-a716-446655440002"
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	mockTaskRepo := mocks.NewMockITaskRepository(t)
+	mockAttachmentRepo := mocks.NewMockIAttachmentRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+	mockTaskRepo.EXPECT().GetTask(ctx, taskId).Return(&models.Task{
+		ID: uuid.MustParse(taskId), UserID: "1", Title: "Task", Status: "IN_PROGRESS", ImageID: ptr(oldImageId), Version: 1,
+	}, nil)
+
+	// Only an image attachment can become the task's image
+	mockAttachmentRepo.EXPECT().GetAttachment(ctx, taskId, imageId).Return(&models.Attachment{ID: uuid.MustParse(imageId), ContentType: "application/pdf"}, nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, mockAttachmentRepo, nil, testSigner, lgr, mockPayload, nil, nil)
+	var req entities.PatchTaskRequest
+	assert.NoError(t, json.Unmarshal([]byte(`{"image_id": "`+imageId+`"}`), &req))
+	_, err := svc.PatchTask(ctx, taskId, &req)
+
+	assert.Equal(t, constants.ErrUnsupportedImageType, err)
+}
+
+func TestTaskService_CreateTask_AllDay(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	newYork, _ := utils.LoadTimezone("America/New_York")
+	ctx := utils.WithLocation(context.Background(), newYork)
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	mockTaskRepo := mocks.NewMockITaskRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+	// The day is kept as written, and stored as midnight UTC of that day
+	mockTaskRepo.EXPECT().
+		CreateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+			return task.AllDay && task.Date.Equal(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+		}), (*models.Attachment)(nil), mock.Anything, mock.Anything).
+		Return(nil)
+
+	svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+	got, err := svc.CreateTask(ctx, &entities.CreateTaskRequest{
+		Title:  "Test Task",
+		Status: "IN_PROGRESS",
+		Date:   time.Date(2024, 1, 5, 0, 0, 0, 0, time.FixedZone("ICT", 7*60*60)),
+		AllDay: true,
+	})
+
+	assert.NoError(t, err)
+	assert.True(t, got.AllDay)
+	assert.Equal(t, "2024-01-05T00:00:00.000-05:00", got.Date)
+}
+
+func TestTaskService_PatchTask_AllDay(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	tokyo, _ := utils.LoadTimezone("Asia/Tokyo")
+	ctx := utils.WithLocation(context.Background(), tokyo)
+	requestId := "550e8400-e29b-41d4-a716-446655440000"
+
+	testCases := []struct {
+		name     string
+		existing models.Task
+		body     string
+		wantDate time.Time
+		wantDay  bool
+	}{
+		{
+			// 20:00 UTC on the 4th is the 5th in Tokyo
+			name:     "PatchTask_ToAllDayKeepsLocalDay",
+			existing: models.Task{Date: time.Date(2024, 1, 4, 20, 0, 0, 0, time.UTC)},
+			body:     `{"all_day": true}`,
+			wantDate: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC),
+			wantDay:  true,
+		},
+		{
+			name:     "PatchTask_ToTimedStartsAtLocalMidnight",
+			existing: models.Task{Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), AllDay: true},
+			body:     `{"all_day": false}`,
+			wantDate: time.Date(2024, 1, 5, 0, 0, 0, 0, tokyo),
+		},
+		{
+			name:     "PatchTask_NewDateOfAllDayTask",
+			existing: models.Task{Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), AllDay: true},
+			body:     `{"date": "2024-02-01T00:00:00+09:00"}`,
+			wantDate: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+			wantDay:  true,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+
+			existing := tC.existing
+			existing.ID, existing.UserID, existing.Title, existing.Status = uuid.MustParse(requestId), "1", "Task 1", "IN_PROGRESS"
+			mockTaskRepo.EXPECT().GetTask(ctx, requestId).Return(&existing, nil)
+			mockTaskRepo.EXPECT().
+				UpdateTask(ctx, mock.MatchedBy(func(task *models.Task) bool {
+					return task.AllDay == tC.wantDay && task.Date.Equal(tC.wantDate)
+				}), mock.Anything).
+				Return(nil)
+
+			svc := NewTaskService(mockTaskRepo, nil, nil, nil, nil, nil, testSigner, lgr, mockPayload, nil, nil)
+
+			var req entities.PatchTaskRequest
+			assert.NoError(t, json.Unmarshal([]byte(tC.body), &req))
+			got, err := svc.PatchTask(ctx, requestId, &req)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tC.wantDay, got.AllDay)
+		})
+	}
 }
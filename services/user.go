@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/guncv/tech-exam-software-engineering/config"
@@ -19,12 +20,16 @@ import (
 type IUserService interface {
 	RegisterUser(ctx context.Context, req *entities.RegisterRequest) (*entities.RegisterResponse, error)
 	LoginUser(ctx context.Context, req *entities.LoginRequest) (*entities.LoginResponse, error)
+	GetPreferences(ctx context.Context) (*entities.UserPreferencesResponse, error)
+	UpdatePreferences(ctx context.Context, req *entities.UpdateUserPreferencesRequest) (*entities.UserPreferencesResponse, error)
+	GetLocation(ctx context.Context) (*time.Location, error)
 }
 
 type UserService struct {
 	repo       repositories.IUserRepository
 	log        *log.Logger
 	tokenMaker utils.IPasetoMaker
+	payload    utils.IPayloadConstruct
 	config     *config.Config
 }
 
@@ -32,12 +37,14 @@ func NewUserService(
 	repo repositories.IUserRepository,
 	log *log.Logger,
 	tokenMaker utils.IPasetoMaker,
+	payload utils.IPayloadConstruct,
 	config *config.Config,
 ) IUserService {
 	return &UserService{
 		repo:       repo,
 		log:        log,
 		tokenMaker: tokenMaker,
+		payload:    payload,
 		config:     config,
 	}
 }
@@ -57,6 +64,7 @@ func (s *UserService) RegisterUser(ctx context.Context, req *entities.RegisterRe
 		Password:  hashedPassword,
 		FirstName: req.FirstName,
 		LastName:  req.LastName,
+		Timezone:  req.Timezone,
 	}
 
 	// Register user
@@ -77,6 +85,7 @@ func (s *UserService) RegisterUser(ctx context.Context, req *entities.RegisterRe
 		LastName:     arg.LastName,
 		Email:        arg.Email,
 		HashPassword: hashedPassword,
+		Timezone:     userLocation(&arg).String(),
 	}
 
 	s.log.DebugWithID(ctx, "[Service: RegisterUser] Successfully registered user: ", resp)
@@ -121,3 +130,108 @@ func (s *UserService) LoginUser(ctx context.Context, req *entities.LoginRequest)
 	s.log.DebugWithID(ctx, "[Service: LoginUser] Successfully logged in user: ", response)
 	return &response, nil
 }
+
+func (s *UserService) GetPreferences(ctx context.Context) (*entities.UserPreferencesResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetPreferences] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetPreferences] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get user
+	user, err := s.repo.GetUserByID(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetPreferences] Failed to get user", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// Response
+	resp := &entities.UserPreferencesResponse{
+		Timezone: userLocation(user).String(),
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetPreferences] Preferences retrieved successfully", resp)
+	return resp, nil
+}
+
+// UpdatePreferences saves the caller's preferences; a null timezone goes back
+// to the default zone
+func (s *UserService) UpdatePreferences(ctx context.Context, req *entities.UpdateUserPreferencesRequest) (*entities.UserPreferencesResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: UpdatePreferences] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdatePreferences] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Validate timezone
+	user := &models.User{Timezone: req.Timezone}
+	if req.Timezone != nil {
+		if _, err := utils.LoadTimezone(*req.Timezone); err != nil {
+			s.log.ErrorWithID(ctx, "[Service: UpdatePreferences] Invalid timezone", err)
+			return nil, err
+		}
+	}
+
+	// Update timezone
+	if err := s.repo.UpdateUserTimezone(ctx, authPayload.UserId, req.Timezone); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdatePreferences] Failed to update timezone", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	// Response
+	resp := &entities.UserPreferencesResponse{
+		Timezone: userLocation(user).String(),
+	}
+
+	s.log.DebugWithID(ctx, "[Service: UpdatePreferences] Preferences updated successfully", resp)
+	return resp, nil
+}
+
+// GetLocation returns the time zone the caller picked, or the default zone
+func (s *UserService) GetLocation(ctx context.Context) (*time.Location, error) {
+	s.log.DebugWithID(ctx, "[Service: GetLocation] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetLocation] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get user
+	user, err := s.repo.GetUserByID(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetLocation] Failed to get user", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return userLocation(user), nil
+}
+
+// userLocation is the time zone of the user, or the default zone when they
+// have not picked one or the stored name no longer loads
+func userLocation(user *models.User) *time.Location {
+	if user.Timezone == nil {
+		return utils.DefaultLocation()
+	}
+	loc, err := utils.LoadTimezone(*user.Timezone)
+	if err != nil {
+		return utils.DefaultLocation()
+	}
+	return loc
+}
@@ -117,7 +117,7 @@ func TestUserService_RegisterUser(t *testing.T) {
 			mockUserRepo := tC.setup()
 			defer mockUserRepo.AssertExpectations(t)
 
-			svc := NewUserService(mockUserRepo, lgr, nil, nil)
+			svc := NewUserService(mockUserRepo, lgr, nil, nil, nil)
 
 			got, gotErr := svc.RegisterUser(tC.input())
 
@@ -282,7 +282,7 @@ func TestUserService_LoginUser(t *testing.T) {
 			defer mockUserRepo.AssertExpectations(t)
 			defer mockTokenMaker.AssertExpectations(t)
 
-			svc := NewUserService(mockUserRepo, lgr, mockTokenMaker, cfg)
+			svc := NewUserService(mockUserRepo, lgr, mockTokenMaker, nil, cfg)
 
 			got, gotErr := svc.LoginUser(tC.input())
 
@@ -290,3 +290,128 @@ func TestUserService_LoginUser(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_Preferences(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+	berlin := "Europe/Berlin"
+	gone := "Mars/Olympus_Mons"
+
+	testCases := []struct {
+		name   string
+		setup  func(repo *mocks.MockIUserRepository)
+		run    func(svc IUserService) (*entities.UserPreferencesResponse, error)
+		verify func(t *testing.T, got *entities.UserPreferencesResponse, gotErr error)
+	}{
+		{
+			name: "GetPreferences_Default",
+			setup: func(repo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetUserByID(ctx, okPayload.UserId).Return(&models.User{}, nil)
+			},
+			run: func(svc IUserService) (*entities.UserPreferencesResponse, error) {
+				return svc.GetPreferences(ctx)
+			},
+			verify: func(t *testing.T, got *entities.UserPreferencesResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, constants.DefaultTimeZone, got.Timezone)
+			},
+		},
+		{
+			// A zone that no longer loads falls back to the default
+			name: "GetPreferences_UnknownStoredZone",
+			setup: func(repo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetUserByID(ctx, okPayload.UserId).Return(&models.User{Timezone: &gone}, nil)
+			},
+			run: func(svc IUserService) (*entities.UserPreferencesResponse, error) {
+				return svc.GetPreferences(ctx)
+			},
+			verify: func(t *testing.T, got *entities.UserPreferencesResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, constants.DefaultTimeZone, got.Timezone)
+			},
+		},
+		{
+			name: "GetPreferences_UserNotFound",
+			setup: func(repo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetUserByID(ctx, okPayload.UserId).Return(nil, gorm.ErrRecordNotFound)
+			},
+			run: func(svc IUserService) (*entities.UserPreferencesResponse, error) {
+				return svc.GetPreferences(ctx)
+			},
+			verify: func(t *testing.T, got *entities.UserPreferencesResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrUserNotFound, gotErr)
+			},
+		},
+		{
+			name: "UpdatePreferences_OK",
+			setup: func(repo *mocks.MockIUserRepository) {
+				repo.EXPECT().UpdateUserTimezone(ctx, okPayload.UserId, &berlin).Return(nil)
+			},
+			run: func(svc IUserService) (*entities.UserPreferencesResponse, error) {
+				return svc.UpdatePreferences(ctx, &entities.UpdateUserPreferencesRequest{Timezone: &berlin})
+			},
+			verify: func(t *testing.T, got *entities.UserPreferencesResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, berlin, got.Timezone)
+			},
+		},
+		{
+			name: "UpdatePreferences_Reset",
+			setup: func(repo *mocks.MockIUserRepository) {
+				repo.EXPECT().UpdateUserTimezone(ctx, okPayload.UserId, (*string)(nil)).Return(nil)
+			},
+			run: func(svc IUserService) (*entities.UserPreferencesResponse, error) {
+				return svc.UpdatePreferences(ctx, &entities.UpdateUserPreferencesRequest{})
+			},
+			verify: func(t *testing.T, got *entities.UserPreferencesResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, constants.DefaultTimeZone, got.Timezone)
+			},
+		},
+		{
+			name:  "UpdatePreferences_InvalidTimezone",
+			setup: func(repo *mocks.MockIUserRepository) {},
+			run: func(svc IUserService) (*entities.UserPreferencesResponse, error) {
+				return svc.UpdatePreferences(ctx, &entities.UpdateUserPreferencesRequest{Timezone: &gone})
+			},
+			verify: func(t *testing.T, got *entities.UserPreferencesResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInvalidTimezone, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockUserRepo := mocks.NewMockIUserRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+			tC.setup(mockUserRepo)
+
+			svc := NewUserService(mockUserRepo, lgr, nil, mockPayload, nil)
+			got, gotErr := tC.run(svc)
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestUserService_GetLocation(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+	tokyo := "Asia/Tokyo"
+
+	mockUserRepo := mocks.NewMockIUserRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+	mockUserRepo.EXPECT().GetUserByID(ctx, okPayload.UserId).Return(&models.User{Timezone: &tokyo}, nil)
+
+	svc := NewUserService(mockUserRepo, lgr, nil, mockPayload, nil)
+	got, err := svc.GetLocation(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tokyo, got.String())
+}
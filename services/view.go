@@ -0,0 +1,385 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+type IViewService interface {
+	CreateView(ctx context.Context, req *entities.CreateViewRequest) (*entities.ViewResponse, error)
+	GetViews(ctx context.Context) (*entities.GetViewsResponse, error)
+	GetView(ctx context.Context, id string) (*entities.ViewResponse, error)
+	UpdateView(ctx context.Context, id string, req *entities.UpdateViewRequest) (*entities.ViewResponse, error)
+	DeleteView(ctx context.Context, id string) error
+	GetViewTasks(ctx context.Context, id string, req *entities.GetViewTasksRequest) (*entities.GetViewTasksResponse, error)
+}
+
+// builtInViews are offered to every user and cannot be changed. Their
+// relative days resolve each time the view runs.
+var builtInViews = []entities.ViewResponse{
+	{ID: "today", Name: "Today", Filter: "status:IN_PROGRESS due:today", SortBy: "date", Order: "asc", GroupBy: string(constants.ViewGroupByNone), BuiltIn: true},
+	{ID: "overdue", Name: "Overdue", Filter: "status:IN_PROGRESS due<today", SortBy: "date", Order: "asc", GroupBy: string(constants.ViewGroupByNone), BuiltIn: true},
+	{ID: "next-7-days", Name: "Next 7 days", Filter: "status:IN_PROGRESS due>=today due<today+7d", SortBy: "date", Order: "asc", GroupBy: string(constants.ViewGroupByDue), BuiltIn: true},
+}
+
+type ViewService struct {
+	repo     repositories.IViewRepository
+	taskRepo repositories.ITaskRepository
+	signer   utils.IURLSigner
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+}
+
+func NewViewService(
+	repo repositories.IViewRepository,
+	taskRepo repositories.ITaskRepository,
+	signer utils.IURLSigner,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+) IViewService {
+	return &ViewService{
+		repo:     repo,
+		taskRepo: taskRepo,
+		signer:   signer,
+		log:      log,
+		payload:  payload,
+	}
+}
+
+func (s *ViewService) CreateView(ctx context.Context, req *entities.CreateViewRequest) (*entities.ViewResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: CreateView] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateView] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Create view, filling in the default sort and grouping
+	now := time.Now()
+	arg := &models.SavedView{
+		ID:        uuid.New(),
+		UserID:    authPayload.UserId,
+		Name:      req.Name,
+		Filter:    req.Filter,
+		SortBy:    "created_at",
+		SortOrder: "desc",
+		GroupBy:   string(constants.ViewGroupByNone),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	if req.SortBy != "" {
+		arg.SortBy = req.SortBy
+	}
+	if req.Order != "" {
+		arg.SortOrder = req.Order
+	}
+	if req.GroupBy != "" {
+		arg.GroupBy = string(req.GroupBy)
+	}
+
+	if err := s.repo.CreateView(ctx, arg); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateView] Failed to create view", err)
+		return nil, err
+	}
+
+	resp := toViewResponse(ctx, arg)
+
+	s.log.DebugWithID(ctx, "[Service: CreateView] View created successfully", resp)
+	return resp, nil
+}
+
+func (s *ViewService) GetViews(ctx context.Context) (*entities.GetViewsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetViews] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetViews] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	repoViews, err := s.repo.GetViews(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetViews] Failed to get views", err)
+		return nil, err
+	}
+
+	// Built-in views first, then the user's own by name
+	views := append([]entities.ViewResponse{}, builtInViews...)
+	for i := range *repoViews {
+		views = append(views, *toViewResponse(ctx, &(*repoViews)[i]))
+	}
+
+	resp := &entities.GetViewsResponse{
+		Total: len(views),
+		Views: views,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetViews] Views retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *ViewService) GetView(ctx context.Context, id string) (*entities.ViewResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetView] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetView] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	resp, err := s.getView(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetView] Failed to get view", err)
+		return nil, err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetView] View retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *ViewService) UpdateView(ctx context.Context, id string, req *entities.UpdateViewRequest) (*entities.ViewResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: UpdateView] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateView] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Get existing view
+	existingView, err := s.getSavedView(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateView] Failed to get view", err)
+		return nil, err
+	}
+
+	// Update fields if present
+	if req.Name != "" {
+		existingView.Name = req.Name
+	}
+	if req.Filter != nil {
+		existingView.Filter = *req.Filter
+	}
+	if req.SortBy != "" {
+		existingView.SortBy = req.SortBy
+	}
+	if req.Order != "" {
+		existingView.SortOrder = req.Order
+	}
+	if req.GroupBy != "" {
+		existingView.GroupBy = string(req.GroupBy)
+	}
+	existingView.UpdatedAt = time.Now()
+
+	if err := s.repo.UpdateView(ctx, existingView); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateView] Failed to update view", err)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrViewNotFound
+		}
+		return nil, err
+	}
+
+	resp := toViewResponse(ctx, existingView)
+
+	s.log.DebugWithID(ctx, "[Service: UpdateView] View updated successfully", resp)
+	return resp, nil
+}
+
+func (s *ViewService) DeleteView(ctx context.Context, id string) error {
+	s.log.DebugWithID(ctx, "[Service: DeleteView] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteView] Failed to get auth payload", err)
+		return err
+	}
+
+	if _, ok := findBuiltInView(id); ok {
+		s.log.ErrorWithID(ctx, "[Service: DeleteView] Built-in view", constants.ErrBuiltInViewReadOnly)
+		return constants.ErrBuiltInViewReadOnly
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteView] Invalid view id", err)
+		return constants.ErrViewNotFound
+	}
+
+	deleted, err := s.repo.DeleteView(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: DeleteView] Failed to delete view", err)
+		return err
+	}
+
+	if deleted == 0 {
+		s.log.ErrorWithID(ctx, "[Service: DeleteView] View not found", constants.ErrViewNotFound)
+		return constants.ErrViewNotFound
+	}
+
+	s.log.DebugWithID(ctx, "[Service: DeleteView] View deleted successfully")
+	return nil
+}
+
+func (s *ViewService) GetViewTasks(ctx context.Context, id string, req *entities.GetViewTasksRequest) (*entities.GetViewTasksResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetViewTasks] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetViewTasks] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	view, err := s.getView(ctx, id, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetViewTasks] Failed to get view", err)
+		return nil, err
+	}
+
+	// Stored filters were valid when saved; parse again to compile them
+	filter, err := utils.ParseTaskFilter(view.Filter)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetViewTasks] Invalid stored filter", err)
+		return nil, constants.ErrInvalidQueryRequestParam
+	}
+
+	// Run the stored query as a task listing in the current tenant
+	listReq := &entities.GetAllTasksRequest{
+		Filter: view.Filter,
+		SortBy: view.SortBy,
+		Order:  view.Order,
+		Limit:  req.Limit,
+		Offset: (req.Offset - 1) * req.Limit,
+	}
+	repoTasks, err := s.taskRepo.GetAllTasks(ctx, listReq, filter, nil, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetViewTasks] Failed to get tasks", err)
+		return nil, err
+	}
+
+	tasks := []entities.GetTaskResponse{}
+	for i := range *repoTasks {
+		tasks = append(tasks, *toGetTaskResponse(ctx, s.signer, authPayload.UserId, &(*repoTasks)[i]))
+	}
+
+	resp := &entities.GetViewTasksResponse{
+		View:  *view,
+		Total: len(tasks),
+	}
+	if constants.ViewGroupBy(view.GroupBy) == constants.ViewGroupByNone {
+		resp.Tasks = tasks
+	} else {
+		resp.Groups = groupViewTasks(constants.ViewGroupBy(view.GroupBy), tasks)
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetViewTasks] View tasks retrieved successfully", resp)
+	return resp, nil
+}
+
+// getView returns a built-in view or one of the user's saved views
+func (s *ViewService) getView(ctx context.Context, id string, userId string) (*entities.ViewResponse, error) {
+	if view, ok := findBuiltInView(id); ok {
+		return &view, nil
+	}
+
+	view, err := s.getSavedView(ctx, id, userId)
+	if err != nil {
+		return nil, err
+	}
+	return toViewResponse(ctx, view), nil
+}
+
+// getSavedView returns one of the user's saved views; built-in views are
+// rejected as read-only
+func (s *ViewService) getSavedView(ctx context.Context, id string, userId string) (*models.SavedView, error) {
+	if _, ok := findBuiltInView(id); ok {
+		return nil, constants.ErrBuiltInViewReadOnly
+	}
+	if _, err := uuid.Parse(id); err != nil {
+		return nil, constants.ErrViewNotFound
+	}
+
+	view, err := s.repo.GetView(ctx, id, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrViewNotFound
+		}
+		return nil, err
+	}
+	return view, nil
+}
+
+func findBuiltInView(id string) (entities.ViewResponse, bool) {
+	for _, v := range builtInViews {
+		if v.ID == id {
+			return v, true
+		}
+	}
+	return entities.ViewResponse{}, false
+}
+
+// groupViewTasks splits the tasks into groups in order of first appearance,
+// keeping the view's sort within each group
+func groupViewTasks(groupBy constants.ViewGroupBy, tasks []entities.GetTaskResponse) []entities.ViewTaskGroup {
+	groups := []entities.ViewTaskGroup{}
+	index := map[string]int{}
+
+	for _, t := range tasks {
+		key := viewGroupKey(groupBy, &t)
+		i, ok := index[key]
+		if !ok {
+			i = len(groups)
+			index[key] = i
+			groups = append(groups, entities.ViewTaskGroup{Key: key, Tasks: []entities.GetTaskResponse{}})
+		}
+		groups[i].Tasks = append(groups[i].Tasks, t)
+		groups[i].Total++
+	}
+
+	return groups
+}
+
+func viewGroupKey(groupBy constants.ViewGroupBy, t *entities.GetTaskResponse) string {
+	switch groupBy {
+	case constants.ViewGroupByStatus:
+		return t.Status
+	case constants.ViewGroupByAssignee:
+		if t.AssigneeID == nil {
+			return constants.AssigneeFilterUnassigned
+		}
+		return *t.AssigneeID
+	case constants.ViewGroupByDue:
+		// The formatted date is already in the caller's time zone
+		if len(t.Date) >= len("2006-01-02") {
+			return t.Date[:len("2006-01-02")]
+		}
+	}
+	return ""
+}
+
+func toViewResponse(ctx context.Context, v *models.SavedView) *entities.ViewResponse {
+	return &entities.ViewResponse{
+		ID:        v.ID.String(),
+		Name:      v.Name,
+		Filter:    v.Filter,
+		SortBy:    v.SortBy,
+		Order:     v.SortOrder,
+		GroupBy:   v.GroupBy,
+		CreatedAt: utils.FormatRFC3339(ctx, v.CreatedAt),
+		UpdatedAt: utils.FormatRFC3339(ctx, v.UpdatedAt),
+	}
+}
@@ -0,0 +1,211 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestViewService_CreateView(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	testCases := []struct {
+		name   string
+		req    *entities.CreateViewRequest
+		setup  func(*mocks.MockIViewRepository)
+		verify func(t *testing.T, got *entities.ViewResponse, gotErr error)
+	}{
+		{
+			name: "CreateView_OKWithDefaults",
+			req:  &entities.CreateViewRequest{Name: "Work", Filter: "tag:work"},
+			setup: func(repo *mocks.MockIViewRepository) {
+				repo.EXPECT().CreateView(ctx, mock.MatchedBy(func(v *models.SavedView) bool {
+					return v.UserID == "1" && v.SortBy == "created_at" && v.SortOrder == "desc" && v.GroupBy == string(constants.ViewGroupByNone)
+				})).Return(nil)
+			},
+			verify: func(t *testing.T, got *entities.ViewResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, "Work", got.Name)
+				assert.Equal(t, "tag:work", got.Filter)
+				assert.False(t, got.BuiltIn)
+			},
+		},
+		{
+			name: "CreateView_AlreadyExists",
+			req:  &entities.CreateViewRequest{Name: "Work", SortBy: "date", Order: "asc", GroupBy: constants.ViewGroupByStatus},
+			setup: func(repo *mocks.MockIViewRepository) {
+				repo.EXPECT().CreateView(ctx, mock.Anything).Return(constants.ErrViewAlreadyExists)
+			},
+			verify: func(t *testing.T, got *entities.ViewResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrViewAlreadyExists, gotErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIViewRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tc.setup(mockRepo)
+
+			service := NewViewService(mockRepo, nil, testSigner, lgr, mockPayload)
+			got, err := service.CreateView(ctx, tc.req)
+			tc.verify(t, got, err)
+		})
+	}
+}
+
+func TestViewService_GetViews(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockIViewRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockRepo.EXPECT().GetViews(ctx, "1").Return(&[]models.SavedView{
+		{ID: uuid.New(), UserID: "1", Name: "Work", Filter: "tag:work", SortBy: "created_at", SortOrder: "desc", GroupBy: "none"},
+	}, nil)
+
+	service := NewViewService(mockRepo, nil, testSigner, lgr, mockPayload)
+	got, err := service.GetViews(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 4, got.Total)
+	assert.Equal(t, "today", got.Views[0].ID)
+	assert.True(t, got.Views[0].BuiltIn)
+	assert.Equal(t, "Work", got.Views[3].Name)
+}
+
+func TestViewService_GetViewTasks(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	viewId := "550e8400-e29b-41d4-a716-446655440000"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+	loc := utils.DefaultLocation()
+
+	testCases := []struct {
+		name   string
+		id     string
+		setup  func(*mocks.MockIViewRepository, *mocks.MockITaskRepository)
+		verify func(t *testing.T, got *entities.GetViewTasksResponse, gotErr error)
+	}{
+		{
+			name: "GetViewTasks_BuiltInGroupedByDue",
+			id:   "next-7-days",
+			setup: func(repo *mocks.MockIViewRepository, taskRepo *mocks.MockITaskRepository) {
+				taskRepo.EXPECT().
+					GetAllTasks(ctx, mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+						return req.SortBy == "date" && req.Order == "asc" && req.Limit == 10 && req.Offset == 0
+					}), mock.Anything, (*utils.Cursor)(nil), "1").
+					Return(&[]models.Task{
+						{ID: uuid.New(), UserID: "1", Title: "A", Status: "IN_PROGRESS", Date: time.Date(2026, 10, 20, 9, 0, 0, 0, loc)},
+						{ID: uuid.New(), UserID: "1", Title: "B", Status: "IN_PROGRESS", Date: time.Date(2026, 10, 20, 23, 30, 0, 0, loc)},
+						{ID: uuid.New(), UserID: "1", Title: "C", Status: "IN_PROGRESS", Date: time.Date(2026, 10, 22, 8, 0, 0, 0, loc)},
+					}, nil)
+			},
+			verify: func(t *testing.T, got *entities.GetViewTasksResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, 3, got.Total)
+				assert.Nil(t, got.Tasks)
+				if assert.Len(t, got.Groups, 2) {
+					assert.Equal(t, "2026-10-20", got.Groups[0].Key)
+					assert.Equal(t, 2, got.Groups[0].Total)
+					assert.Equal(t, "2026-10-22", got.Groups[1].Key)
+				}
+			},
+		},
+		{
+			name: "GetViewTasks_SavedUngrouped",
+			id:   viewId,
+			setup: func(repo *mocks.MockIViewRepository, taskRepo *mocks.MockITaskRepository) {
+				repo.EXPECT().GetView(ctx, viewId, "1").Return(&models.SavedView{
+					ID: uuid.MustParse(viewId), UserID: "1", Name: "Work", Filter: "tag:work", SortBy: "created_at", SortOrder: "desc", GroupBy: "none",
+				}, nil)
+				taskRepo.EXPECT().
+					GetAllTasks(ctx, mock.MatchedBy(func(req *entities.GetAllTasksRequest) bool {
+						return req.Filter == "tag:work" && req.SortBy == "created_at"
+					}), mock.Anything, (*utils.Cursor)(nil), "1").
+					Return(&[]models.Task{{ID: uuid.New(), UserID: "1", Title: "A", Status: "IN_PROGRESS"}}, nil)
+			},
+			verify: func(t *testing.T, got *entities.GetViewTasksResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Len(t, got.Tasks, 1)
+				assert.Nil(t, got.Groups)
+			},
+		},
+		{
+			name:  "GetViewTasks_NotAViewId",
+			id:    "someday",
+			setup: func(repo *mocks.MockIViewRepository, taskRepo *mocks.MockITaskRepository) {},
+			verify: func(t *testing.T, got *entities.GetViewTasksResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrViewNotFound, gotErr)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockRepo := mocks.NewMockIViewRepository(t)
+			mockTaskRepo := mocks.NewMockITaskRepository(t)
+			mockPayload := mocks.NewMockIPayloadConstruct(t)
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+
+			tc.setup(mockRepo, mockTaskRepo)
+
+			service := NewViewService(mockRepo, mockTaskRepo, testSigner, lgr, mockPayload)
+			got, err := service.GetViewTasks(ctx, tc.id, &entities.GetViewTasksRequest{Limit: 10, Offset: 1})
+			tc.verify(t, got, err)
+		})
+	}
+}
+
+func TestViewService_BuiltInViewsAreReadOnly(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+
+	mockRepo := mocks.NewMockIViewRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+
+	service := NewViewService(mockRepo, nil, testSigner, lgr, mockPayload)
+
+	_, err := service.UpdateView(ctx, "today", &entities.UpdateViewRequest{Name: "Mine"})
+	assert.True(t, errors.Is(err, constants.ErrBuiltInViewReadOnly))
+
+	err = service.DeleteView(ctx, "overdue")
+	assert.True(t, errors.Is(err, constants.ErrBuiltInViewReadOnly))
+}
+
+func TestViewService_DeleteView_NotFound(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	viewId := "550e8400-e29b-41d4-a716-446655440000"
+
+	mockRepo := mocks.NewMockIViewRepository(t)
+	mockPayload := mocks.NewMockIPayloadConstruct(t)
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+	mockRepo.EXPECT().DeleteView(ctx, viewId, "1").Return(0, nil)
+
+	service := NewViewService(mockRepo, nil, testSigner, lgr, mockPayload)
+
+	assert.Equal(t, constants.ErrViewNotFound, service.DeleteView(ctx, viewId))
+}
@@ -0,0 +1,570 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/repositories"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"gorm.io/gorm"
+)
+
+// defaultInvitationDuration applies when the config does not set one
+const defaultInvitationDuration = 72 * time.Hour
+
+type IWorkspaceService interface {
+	CreateWorkspace(ctx context.Context, req *entities.CreateWorkspaceRequest) (*entities.WorkspaceResponse, error)
+	GetWorkspaces(ctx context.Context) (*entities.GetWorkspacesResponse, error)
+	GetWorkspace(ctx context.Context, id string) (*entities.WorkspaceResponse, error)
+	GetMembers(ctx context.Context, id string) (*entities.GetWorkspaceMembersResponse, error)
+	UpdateMemberRole(ctx context.Context, id string, userId string, req *entities.UpdateWorkspaceMemberRequest) (*entities.WorkspaceMemberResponse, error)
+	RemoveMember(ctx context.Context, id string, userId string) error
+	CreateInvitation(ctx context.Context, id string, req *entities.CreateInvitationRequest) (*entities.InvitationResponse, error)
+	GetInvitations(ctx context.Context, id string) (*entities.GetInvitationsResponse, error)
+	RevokeInvitation(ctx context.Context, id string, invitationId string) error
+	AcceptInvitation(ctx context.Context, req *entities.AcceptInvitationRequest) (*entities.WorkspaceResponse, error)
+	GetMembership(ctx context.Context, id string) (constants.WorkspaceRole, error)
+}
+
+type WorkspaceService struct {
+	repo     repositories.IWorkspaceRepository
+	userRepo repositories.IUserRepository
+	log      *log.Logger
+	payload  utils.IPayloadConstruct
+	config   *config.Config
+}
+
+func NewWorkspaceService(
+	repo repositories.IWorkspaceRepository,
+	userRepo repositories.IUserRepository,
+	log *log.Logger,
+	payload utils.IPayloadConstruct,
+	config *config.Config,
+) IWorkspaceService {
+	return &WorkspaceService{
+		repo:     repo,
+		userRepo: userRepo,
+		log:      log,
+		payload:  payload,
+		config:   config,
+	}
+}
+
+func (s *WorkspaceService) CreateWorkspace(ctx context.Context, req *entities.CreateWorkspaceRequest) (*entities.WorkspaceResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: CreateWorkspace] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateWorkspace] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	workspace := &models.Workspace{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(req.Name),
+		OwnerID:   authPayload.UserId,
+		CreatedAt: now,
+		Role:      string(constants.WorkspaceRoleOwner),
+	}
+
+	// The creator becomes the owner member
+	owner := &models.WorkspaceMember{
+		WorkspaceID: workspace.ID,
+		UserID:      authPayload.UserId,
+		Role:        string(constants.WorkspaceRoleOwner),
+		CreatedAt:   now,
+	}
+
+	if err := s.repo.CreateWorkspace(ctx, workspace, owner); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateWorkspace] Failed to create workspace", err)
+		return nil, err
+	}
+
+	resp := toWorkspaceResponse(ctx, workspace)
+	s.log.DebugWithID(ctx, "[Service: CreateWorkspace] Workspace created successfully", resp)
+	return resp, nil
+}
+
+func (s *WorkspaceService) GetWorkspaces(ctx context.Context) (*entities.GetWorkspacesResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetWorkspaces] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetWorkspaces] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	repoWorkspaces, err := s.repo.GetWorkspaces(ctx, authPayload.UserId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetWorkspaces] Failed to get workspaces", err)
+		return nil, err
+	}
+
+	workspaces := []entities.WorkspaceResponse{}
+	for i := range *repoWorkspaces {
+		workspaces = append(workspaces, *toWorkspaceResponse(ctx, &(*repoWorkspaces)[i]))
+	}
+
+	resp := &entities.GetWorkspacesResponse{
+		Total:      len(workspaces),
+		Workspaces: workspaces,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetWorkspaces] Workspaces retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *WorkspaceService) GetWorkspace(ctx context.Context, id string) (*entities.WorkspaceResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetWorkspace] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetWorkspace] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	member, err := s.requireRole(ctx, id, authPayload.UserId, constants.WorkspaceRoleMember)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetWorkspace] Failed to verify membership", err)
+		return nil, err
+	}
+
+	workspace, err := s.getWorkspace(ctx, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetWorkspace] Failed to get workspace", err)
+		return nil, err
+	}
+	workspace.Role = member.Role
+
+	resp := toWorkspaceResponse(ctx, workspace)
+	s.log.DebugWithID(ctx, "[Service: GetWorkspace] Workspace retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *WorkspaceService) GetMembers(ctx context.Context, id string) (*entities.GetWorkspaceMembersResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetMembers] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetMembers] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	if _, err := s.requireRole(ctx, id, authPayload.UserId, constants.WorkspaceRoleMember); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetMembers] Failed to verify membership", err)
+		return nil, err
+	}
+
+	repoMembers, err := s.repo.GetMembers(ctx, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetMembers] Failed to get members", err)
+		return nil, err
+	}
+
+	members := []entities.WorkspaceMemberResponse{}
+	for i := range *repoMembers {
+		members = append(members, *toWorkspaceMemberResponse(ctx, &(*repoMembers)[i]))
+	}
+
+	resp := &entities.GetWorkspaceMembersResponse{
+		Total:   len(members),
+		Members: members,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetMembers] Members retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *WorkspaceService) UpdateMemberRole(ctx context.Context, id string, userId string, req *entities.UpdateWorkspaceMemberRequest) (*entities.WorkspaceMemberResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: UpdateMemberRole] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateMemberRole] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	// Admins and the owner manage roles
+	if _, err := s.requireRole(ctx, id, authPayload.UserId, constants.WorkspaceRoleAdmin); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateMemberRole] Failed to verify membership", err)
+		return nil, err
+	}
+
+	target, err := s.getMember(ctx, id, userId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateMemberRole] Failed to get member", err)
+		return nil, err
+	}
+
+	if target.Role == string(constants.WorkspaceRoleOwner) {
+		s.log.ErrorWithID(ctx, "[Service: UpdateMemberRole] Cannot change the owner's role", constants.ErrCannotModifyWorkspaceOwner)
+		return nil, constants.ErrCannotModifyWorkspaceOwner
+	}
+
+	if _, err := s.repo.UpdateMemberRole(ctx, id, userId, req.Role); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: UpdateMemberRole] Failed to update member role", err)
+		return nil, err
+	}
+	target.Role = req.Role
+
+	resp := toWorkspaceMemberResponse(ctx, target)
+	s.log.DebugWithID(ctx, "[Service: UpdateMemberRole] Member role updated successfully", resp)
+	return resp, nil
+}
+
+func (s *WorkspaceService) RemoveMember(ctx context.Context, id string, userId string) error {
+	s.log.DebugWithID(ctx, "[Service: RemoveMember] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RemoveMember] Failed to get auth payload", err)
+		return err
+	}
+
+	// Admins remove anyone; a member may only leave
+	required := constants.WorkspaceRoleAdmin
+	if userId == authPayload.UserId {
+		required = constants.WorkspaceRoleMember
+	}
+	if _, err := s.requireRole(ctx, id, authPayload.UserId, required); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RemoveMember] Failed to verify membership", err)
+		return err
+	}
+
+	target, err := s.getMember(ctx, id, userId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RemoveMember] Failed to get member", err)
+		return err
+	}
+
+	if target.Role == string(constants.WorkspaceRoleOwner) {
+		s.log.ErrorWithID(ctx, "[Service: RemoveMember] Cannot remove the owner", constants.ErrCannotModifyWorkspaceOwner)
+		return constants.ErrCannotModifyWorkspaceOwner
+	}
+
+	if _, err := s.repo.DeleteMember(ctx, id, userId); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RemoveMember] Failed to remove member", err)
+		return err
+	}
+
+	s.log.DebugWithID(ctx, "[Service: RemoveMember] Member removed successfully")
+	return nil
+}
+
+func (s *WorkspaceService) CreateInvitation(ctx context.Context, id string, req *entities.CreateInvitationRequest) (*entities.InvitationResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: CreateInvitation] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateInvitation] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	if _, err := s.requireRole(ctx, id, authPayload.UserId, constants.WorkspaceRoleAdmin); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateInvitation] Failed to verify membership", err)
+		return nil, err
+	}
+
+	// Inviting someone who already belongs to the workspace is a no-op
+	email := strings.ToLower(strings.TrimSpace(req.Email))
+	if user, err := s.userRepo.GetUser(ctx, email); err == nil {
+		if _, err := s.repo.GetMember(ctx, id, user.ID.String()); err == nil {
+			s.log.ErrorWithID(ctx, "[Service: CreateInvitation] User is already a member", constants.ErrAlreadyWorkspaceMember)
+			return nil, constants.ErrAlreadyWorkspaceMember
+		}
+	}
+
+	token, tokenHash, err := utils.NewInvitationToken()
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateInvitation] Failed to generate token", err)
+		return nil, err
+	}
+
+	workspaceId, err := uuid.Parse(id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateInvitation] Invalid workspace id", err)
+		return nil, constants.ErrInvalidRequestParam
+	}
+
+	now := time.Now()
+	invitation := &models.WorkspaceInvitation{
+		ID:          uuid.New(),
+		WorkspaceID: workspaceId,
+		Email:       email,
+		Role:        req.Role,
+		TokenHash:   tokenHash,
+		InvitedBy:   authPayload.UserId,
+		ExpiresAt:   now.Add(s.invitationDuration()),
+		CreatedAt:   now,
+	}
+
+	if err := s.repo.CreateInvitation(ctx, invitation); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: CreateInvitation] Failed to create invitation", err)
+		return nil, err
+	}
+
+	resp := toInvitationResponse(ctx, invitation)
+	resp.Token = token
+
+	s.log.DebugWithID(ctx, "[Service: CreateInvitation] Invitation created successfully", invitation.ID)
+	return resp, nil
+}
+
+func (s *WorkspaceService) GetInvitations(ctx context.Context, id string) (*entities.GetInvitationsResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: GetInvitations] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetInvitations] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	if _, err := s.requireRole(ctx, id, authPayload.UserId, constants.WorkspaceRoleAdmin); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetInvitations] Failed to verify membership", err)
+		return nil, err
+	}
+
+	repoInvitations, err := s.repo.GetInvitations(ctx, id)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetInvitations] Failed to get invitations", err)
+		return nil, err
+	}
+
+	invitations := []entities.InvitationResponse{}
+	for i := range *repoInvitations {
+		invitations = append(invitations, *toInvitationResponse(ctx, &(*repoInvitations)[i]))
+	}
+
+	resp := &entities.GetInvitationsResponse{
+		Total:       len(invitations),
+		Invitations: invitations,
+	}
+
+	s.log.DebugWithID(ctx, "[Service: GetInvitations] Invitations retrieved successfully", resp)
+	return resp, nil
+}
+
+func (s *WorkspaceService) RevokeInvitation(ctx context.Context, id string, invitationId string) error {
+	s.log.DebugWithID(ctx, "[Service: RevokeInvitation] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevokeInvitation] Failed to get auth payload", err)
+		return err
+	}
+
+	if _, err := s.requireRole(ctx, id, authPayload.UserId, constants.WorkspaceRoleAdmin); err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevokeInvitation] Failed to verify membership", err)
+		return err
+	}
+
+	deleted, err := s.repo.DeleteInvitation(ctx, id, invitationId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: RevokeInvitation] Failed to revoke invitation", err)
+		return err
+	}
+
+	if deleted == 0 {
+		s.log.ErrorWithID(ctx, "[Service: RevokeInvitation] Invitation not found", constants.ErrInvitationNotFound)
+		return constants.ErrInvitationNotFound
+	}
+
+	s.log.DebugWithID(ctx, "[Service: RevokeInvitation] Invitation revoked successfully")
+	return nil
+}
+
+func (s *WorkspaceService) AcceptInvitation(ctx context.Context, req *entities.AcceptInvitationRequest) (*entities.WorkspaceResponse, error) {
+	s.log.DebugWithID(ctx, "[Service: AcceptInvitation] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Failed to get auth payload", err)
+		return nil, err
+	}
+
+	invitation, err := s.repo.GetInvitationByTokenHash(ctx, utils.HashInvitationToken(req.Token))
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Invitation not found: ", err)
+			return nil, constants.ErrInvitationNotFound
+		}
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Failed to get invitation", err)
+		return nil, err
+	}
+
+	now := time.Now()
+	if !invitation.Pending(now) {
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Invitation is no longer pending", constants.ErrInvitationExpired)
+		return nil, constants.ErrInvitationExpired
+	}
+
+	// The invitation is bound to the invited email address
+	user, err := s.userRepo.GetUserByID(ctx, authPayload.UserId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] User not found: ", err)
+			return nil, constants.ErrUserNotFound
+		}
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Failed to get user", err)
+		return nil, err
+	}
+
+	if !strings.EqualFold(user.Email, invitation.Email) {
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Invitation email does not match", constants.ErrInvitationEmailMismatch)
+		return nil, constants.ErrInvitationEmailMismatch
+	}
+
+	workspaceId := invitation.WorkspaceID.String()
+	if _, err := s.repo.GetMember(ctx, workspaceId, authPayload.UserId); err == nil {
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] User is already a member", constants.ErrAlreadyWorkspaceMember)
+		return nil, constants.ErrAlreadyWorkspaceMember
+	}
+
+	invitation.AcceptedAt = &now
+	member := &models.WorkspaceMember{
+		WorkspaceID: invitation.WorkspaceID,
+		UserID:      authPayload.UserId,
+		Role:        invitation.Role,
+		CreatedAt:   now,
+	}
+
+	if err := s.repo.AcceptInvitation(ctx, invitation, member); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Invitation was already used: ", err)
+			return nil, constants.ErrInvitationExpired
+		}
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Failed to accept invitation", err)
+		return nil, err
+	}
+
+	workspace, err := s.getWorkspace(ctx, workspaceId)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: AcceptInvitation] Failed to get workspace", err)
+		return nil, err
+	}
+	workspace.Role = member.Role
+
+	resp := toWorkspaceResponse(ctx, workspace)
+	s.log.DebugWithID(ctx, "[Service: AcceptInvitation] Invitation accepted successfully", resp)
+	return resp, nil
+}
+
+// GetMembership returns the caller's role in the workspace. It backs the
+// workspace middleware that scopes task requests to a tenant.
+func (s *WorkspaceService) GetMembership(ctx context.Context, id string) (constants.WorkspaceRole, error) {
+	s.log.DebugWithID(ctx, "[Service: GetMembership] Called")
+
+	// Get auth payload
+	authPayload, err := s.payload.GetAuthPayload(ctx, s.log)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetMembership] Failed to get auth payload", err)
+		return "", err
+	}
+
+	member, err := s.requireRole(ctx, id, authPayload.UserId, constants.WorkspaceRoleMember)
+	if err != nil {
+		s.log.ErrorWithID(ctx, "[Service: GetMembership] Failed to verify membership", err)
+		return "", err
+	}
+
+	return constants.WorkspaceRole(member.Role), nil
+}
+
+// requireRole verifies the user belongs to the workspace with at least the required role
+func (s *WorkspaceService) requireRole(ctx context.Context, workspaceId string, userId string, required constants.WorkspaceRole) (*models.WorkspaceMember, error) {
+	member, err := s.repo.GetMember(ctx, workspaceId, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			s.log.ErrorWithID(ctx, "[Service: requireRole] Not a member: ", err)
+			return nil, constants.ErrNotWorkspaceMember
+		}
+		s.log.ErrorWithID(ctx, "[Service: requireRole] Failed to get member", err)
+		return nil, err
+	}
+
+	if !constants.WorkspaceRole(member.Role).Allows(required) {
+		s.log.ErrorWithID(ctx, "[Service: requireRole] Role too low: ", member.Role, " < ", required)
+		return nil, constants.ErrInsufficientPermission
+	}
+
+	return member, nil
+}
+
+func (s *WorkspaceService) getMember(ctx context.Context, workspaceId string, userId string) (*models.WorkspaceMember, error) {
+	member, err := s.repo.GetMember(ctx, workspaceId, userId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrUserNotFound
+		}
+		return nil, err
+	}
+	return member, nil
+}
+
+func (s *WorkspaceService) getWorkspace(ctx context.Context, id string) (*models.Workspace, error) {
+	workspace, err := s.repo.GetWorkspace(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, constants.ErrWorkspaceNotFound
+		}
+		return nil, err
+	}
+	return workspace, nil
+}
+
+func (s *WorkspaceService) invitationDuration() time.Duration {
+	if s.config != nil && s.config.Workspace.InvitationDuration > 0 {
+		return s.config.Workspace.InvitationDuration
+	}
+	return defaultInvitationDuration
+}
+
+func toWorkspaceResponse(ctx context.Context, workspace *models.Workspace) *entities.WorkspaceResponse {
+	return &entities.WorkspaceResponse{
+		ID:        workspace.ID.String(),
+		Name:      workspace.Name,
+		OwnerID:   workspace.OwnerID,
+		Role:      workspace.Role,
+		CreatedAt: utils.FormatRFC3339(ctx, workspace.CreatedAt),
+	}
+}
+
+func toWorkspaceMemberResponse(ctx context.Context, member *models.WorkspaceMember) *entities.WorkspaceMemberResponse {
+	return &entities.WorkspaceMemberResponse{
+		UserID:    member.UserID,
+		Email:     member.Email,
+		Role:      member.Role,
+		CreatedAt: utils.FormatRFC3339(ctx, member.CreatedAt),
+	}
+}
+
+func toInvitationResponse(ctx context.Context, invitation *models.WorkspaceInvitation) *entities.InvitationResponse {
+	return &entities.InvitationResponse{
+		ID:          invitation.ID.String(),
+		WorkspaceID: invitation.WorkspaceID.String(),
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		InvitedBy:   invitation.InvitedBy,
+		ExpiresAt:   utils.FormatRFC3339(ctx, invitation.ExpiresAt),
+		CreatedAt:   utils.FormatRFC3339(ctx, invitation.CreatedAt),
+	}
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/guncv/tech-exam-software-engineering/infras/log"
+	"github.com/guncv/tech-exam-software-engineering/mocks"
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/guncv/tech-exam-software-engineering/utils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestWorkspaceService_CreateWorkspace(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: "1"}
+
+	mockRepo := new(mocks.MockIWorkspaceRepository)
+	mockPayload := new(mocks.MockIPayloadConstruct)
+	defer mockRepo.AssertExpectations(t)
+	defer mockPayload.AssertExpectations(t)
+
+	mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+	mockRepo.EXPECT().
+		CreateWorkspace(ctx, mock.MatchedBy(func(workspace *models.Workspace) bool {
+			return workspace.Name == "Platform Team" && workspace.OwnerID == "1"
+		}), mock.MatchedBy(func(owner *models.WorkspaceMember) bool {
+			return owner.UserID == "1" && owner.Role == "owner"
+		})).
+		Return(nil)
+
+	svc := NewWorkspaceService(mockRepo, nil, lgr, mockPayload, nil)
+
+	got, gotErr := svc.CreateWorkspace(ctx, &entities.CreateWorkspaceRequest{Name: "  Platform Team "})
+
+	assert.NoError(t, gotErr)
+	assert.Equal(t, "Platform Team", got.Name)
+	assert.Equal(t, "owner", got.Role)
+}
+
+func TestWorkspaceService_AcceptInvitation(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	userId := "11111111-1111-1111-1111-111111111111"
+	workspaceId := uuid.New()
+	token := "invite-token"
+
+	okPayload := &utils.Payload{ID: uuid.New(), UserId: userId}
+	okUser := &models.User{ID: uuid.MustParse(userId), Email: "jane.doe@example.com"}
+
+	newInvitation := func() *models.WorkspaceInvitation {
+		return &models.WorkspaceInvitation{
+			ID:          uuid.New(),
+			WorkspaceID: workspaceId,
+			Email:       "Jane.Doe@example.com",
+			Role:        "member",
+			ExpiresAt:   time.Now().Add(time.Hour),
+		}
+	}
+
+	testCases := []struct {
+		name   string
+		setup  func(*mocks.MockIWorkspaceRepository, *mocks.MockIUserRepository)
+		verify func(t *testing.T, got *entities.WorkspaceResponse, gotErr error)
+	}{
+		{
+			name: "AcceptInvitation_OK",
+			setup: func(repo *mocks.MockIWorkspaceRepository, userRepo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetInvitationByTokenHash(ctx, utils.HashInvitationToken(token)).Return(newInvitation(), nil)
+				userRepo.EXPECT().GetUserByID(ctx, userId).Return(okUser, nil)
+				repo.EXPECT().GetMember(ctx, workspaceId.String(), userId).Return(nil, gorm.ErrRecordNotFound)
+				repo.EXPECT().
+					AcceptInvitation(ctx, mock.MatchedBy(func(invitation *models.WorkspaceInvitation) bool {
+						return invitation.AcceptedAt != nil
+					}), mock.MatchedBy(func(member *models.WorkspaceMember) bool {
+						return member.UserID == userId && member.Role == "member"
+					})).
+					Return(nil)
+				repo.EXPECT().GetWorkspace(ctx, workspaceId.String()).
+					Return(&models.Workspace{ID: workspaceId, Name: "Platform Team"}, nil)
+			},
+			verify: func(t *testing.T, got *entities.WorkspaceResponse, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, workspaceId.String(), got.ID)
+				assert.Equal(t, "member", got.Role)
+			},
+		},
+		{
+			name: "AcceptInvitation_NotFound",
+			setup: func(repo *mocks.MockIWorkspaceRepository, userRepo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetInvitationByTokenHash(ctx, utils.HashInvitationToken(token)).Return(nil, gorm.ErrRecordNotFound)
+			},
+			verify: func(t *testing.T, got *entities.WorkspaceResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInvitationNotFound, gotErr)
+			},
+		},
+		{
+			name: "AcceptInvitation_Expired",
+			setup: func(repo *mocks.MockIWorkspaceRepository, userRepo *mocks.MockIUserRepository) {
+				invitation := newInvitation()
+				invitation.ExpiresAt = time.Now().Add(-time.Minute)
+				repo.EXPECT().GetInvitationByTokenHash(ctx, utils.HashInvitationToken(token)).Return(invitation, nil)
+			},
+			verify: func(t *testing.T, got *entities.WorkspaceResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInvitationExpired, gotErr)
+			},
+		},
+		{
+			name: "AcceptInvitation_EmailMismatch",
+			setup: func(repo *mocks.MockIWorkspaceRepository, userRepo *mocks.MockIUserRepository) {
+				repo.EXPECT().GetInvitationByTokenHash(ctx, utils.HashInvitationToken(token)).Return(newInvitation(), nil)
+				userRepo.EXPECT().GetUserByID(ctx, userId).
+					Return(&models.User{ID: uuid.MustParse(userId), Email: "someone.else@example.com"}, nil)
+			},
+			verify: func(t *testing.T, got *entities.WorkspaceResponse, gotErr error) {
+				assert.Nil(t, got)
+				assert.Equal(t, constants.ErrInvitationEmailMismatch, gotErr)
+			},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockIWorkspaceRepository)
+			mockUserRepo := new(mocks.MockIUserRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockRepo.AssertExpectations(t)
+			defer mockUserRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(okPayload, nil)
+			tC.setup(mockRepo, mockUserRepo)
+
+			svc := NewWorkspaceService(mockRepo, mockUserRepo, lgr, mockPayload, nil)
+
+			got, gotErr := svc.AcceptInvitation(ctx, &entities.AcceptInvitationRequest{Token: token})
+
+			tC.verify(t, got, gotErr)
+		})
+	}
+}
+
+func TestWorkspaceService_ManageMembers(t *testing.T) {
+	lgr := log.Initialize(constants.TestAppEnv)
+	ctx := context.Background()
+	workspaceId := "550e8400-e29b-41d4-a716-446655440000"
+
+	member := func(userId string, role constants.WorkspaceRole) *models.WorkspaceMember {
+		return &models.WorkspaceMember{WorkspaceID: uuid.MustParse(workspaceId), UserID: userId, Role: string(role)}
+	}
+
+	testCases := []struct {
+		name   string
+		run    func(svc IWorkspaceService) error
+		setup  func(repo *mocks.MockIWorkspaceRepository)
+		expect error
+	}{
+		{
+			name: "UpdateMemberRole_Admin_OK",
+			run: func(svc IWorkspaceService) error {
+				_, err := svc.UpdateMemberRole(ctx, workspaceId, "2", &entities.UpdateWorkspaceMemberRequest{Role: "admin"})
+				return err
+			},
+			setup: func(repo *mocks.MockIWorkspaceRepository) {
+				repo.EXPECT().GetMember(ctx, workspaceId, "1").Return(member("1", constants.WorkspaceRoleAdmin), nil)
+				repo.EXPECT().GetMember(ctx, workspaceId, "2").Return(member("2", constants.WorkspaceRoleMember), nil)
+				repo.EXPECT().UpdateMemberRole(ctx, workspaceId, "2", "admin").Return(int64(1), nil)
+			},
+		},
+		{
+			name: "UpdateMemberRole_Owner_Forbidden",
+			run: func(svc IWorkspaceService) error {
+				_, err := svc.UpdateMemberRole(ctx, workspaceId, "2", &entities.UpdateWorkspaceMemberRequest{Role: "member"})
+				return err
+			},
+			setup: func(repo *mocks.MockIWorkspaceRepository) {
+				repo.EXPECT().GetMember(ctx, workspaceId, "1").Return(member("1", constants.WorkspaceRoleAdmin), nil)
+				repo.EXPECT().GetMember(ctx, workspaceId, "2").Return(member("2", constants.WorkspaceRoleOwner), nil)
+			},
+			expect: constants.ErrCannotModifyWorkspaceOwner,
+		},
+		{
+			name: "RemoveMember_MemberRemovesOther_Forbidden",
+			run: func(svc IWorkspaceService) error {
+				return svc.RemoveMember(ctx, workspaceId, "2")
+			},
+			setup: func(repo *mocks.MockIWorkspaceRepository) {
+				repo.EXPECT().GetMember(ctx, workspaceId, "1").Return(member("1", constants.WorkspaceRoleMember), nil)
+			},
+			expect: constants.ErrInsufficientPermission,
+		},
+		{
+			name: "RemoveMember_Leave_OK",
+			run: func(svc IWorkspaceService) error {
+				return svc.RemoveMember(ctx, workspaceId, "1")
+			},
+			setup: func(repo *mocks.MockIWorkspaceRepository) {
+				repo.EXPECT().GetMember(ctx, workspaceId, "1").Return(member("1", constants.WorkspaceRoleMember), nil)
+				repo.EXPECT().DeleteMember(ctx, workspaceId, "1").Return(int64(1), nil)
+			},
+		},
+		{
+			name: "GetMembers_NotMember",
+			run: func(svc IWorkspaceService) error {
+				_, err := svc.GetMembers(ctx, workspaceId)
+				return err
+			},
+			setup: func(repo *mocks.MockIWorkspaceRepository) {
+				repo.EXPECT().GetMember(ctx, workspaceId, "1").Return(nil, gorm.ErrRecordNotFound)
+			},
+			expect: constants.ErrNotWorkspaceMember,
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			mockRepo := new(mocks.MockIWorkspaceRepository)
+			mockPayload := new(mocks.MockIPayloadConstruct)
+			defer mockRepo.AssertExpectations(t)
+			defer mockPayload.AssertExpectations(t)
+
+			mockPayload.EXPECT().GetAuthPayload(ctx, mock.Anything).Return(&utils.Payload{ID: uuid.New(), UserId: "1"}, nil)
+			tC.setup(mockRepo)
+
+			svc := NewWorkspaceService(mockRepo, nil, lgr, mockPayload, nil)
+
+			assert.Equal(t, tC.expect, tC.run(svc))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+package utils
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/guncv/tech-exam-software-engineering/config"
+)
+
+// IBlobStore keeps file contents outside the database, addressed by key
+type IBlobStore interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// GetRange opens length bytes of the blob starting at offset
+	GetRange(ctx context.Context, key string, offset, length int64) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// ErrBlobNotFound is returned by Get for a key that holds no blob
+var ErrBlobNotFound = errors.New("blob not found")
+
+// NewBlobStore returns the blob store selected by the storage config
+func NewBlobStore(config *config.Config) (IBlobStore, error) {
+	storage := config.Storage
+	switch storage.Driver {
+	case "", "local":
+		dir := storage.LocalDir
+		if dir == "" {
+			dir = "./data/blobs"
+		}
+		return NewLocalBlobStore(dir)
+	case "s3":
+		return NewS3BlobStore(storage.S3Endpoint, storage.S3Region, storage.S3Bucket, storage.S3AccessKey, storage.S3SecretKey, storage.S3PathStyle)
+	}
+	return nil, fmt.Errorf("unknown storage driver %q", storage.Driver)
+}
+
+// validBlobKey accepts slash-separated keys that stay inside the store
+func validBlobKey(key string) bool {
+	if key == "" || strings.HasPrefix(key, "/") || path.Clean(key) != key {
+		return false
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "." || part == ".." {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+)
+
+// NewCalendarFeedToken returns a random URL-safe feed token and its hash.
+// Only the hash is stored; the token is shown to the user once, in the URL.
+func NewCalendarFeedToken() (token string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashCalendarFeedToken(token), nil
+}
+
+// HashCalendarFeedToken returns the hex SHA-256 of a feed token
+func HashCalendarFeedToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ICalTaskOptions shapes the calendar a task writer produces
+type ICalTaskOptions struct {
+	// Component is what each task becomes: a to-do due at its date, or an
+	// event on it
+	Component constants.CalendarComponent
+
+	// Name and Location are announced to clients as the calendar's name and
+	// time zone. All-day tasks are entries on their date; every other date
+	// is a UTC date-time.
+	Name     string
+	Location *time.Location
+
+	// RefreshInterval, when set, is how often clients are asked to poll
+	RefreshInterval time.Duration
+
+	// Stamp is the DTSTAMP of every entry, when the calendar was generated.
+	// A zero Stamp uses the current time.
+	Stamp time.Time
+}
+
+// iCalTaskWriter writes a calendar with an entry per task
+type iCalTaskWriter struct {
+	c    *ICalWriter
+	opts ICalTaskOptions
+}
+
+// NewICalTaskWriter starts a calendar of tasks
+func NewICalTaskWriter(w io.Writer, opts ICalTaskOptions) (ITaskExportWriter, error) {
+	if opts.Component == "" {
+		opts.Component = constants.CalendarComponentTodo
+	}
+	if opts.Stamp.IsZero() {
+		opts.Stamp = time.Now()
+	}
+
+	c := NewICalWriter(w)
+	c.Line("BEGIN", "VCALENDAR")
+	c.Line("VERSION", "2.0")
+	c.Line("PRODID", "-//Task-Note//Tasks//EN")
+	c.Line("CALSCALE", "GREGORIAN")
+	if opts.Name != "" {
+		c.Text("X-WR-CALNAME", opts.Name)
+	}
+	if opts.Location != nil {
+		c.Text("X-WR-TIMEZONE", opts.Location.String())
+	}
+	if opts.RefreshInterval > 0 {
+		interval := formatICalDuration(opts.RefreshInterval)
+		c.Line("REFRESH-INTERVAL;VALUE=DURATION", interval)
+		c.Line("X-PUBLISHED-TTL", interval)
+	}
+	if err := c.Err(); err != nil {
+		return nil, err
+	}
+	return &iCalTaskWriter{c: c, opts: opts}, nil
+}
+
+func (e *iCalTaskWriter) WriteTask(task *entities.ExportTask) error {
+	c := e.c
+	event := e.opts.Component == constants.CalendarComponentEvent
+	component := "VTODO"
+	if event {
+		component = "VEVENT"
+	}
+
+	c.Line("BEGIN", component)
+	c.Text("UID", task.ID)
+	c.Line("DTSTAMP", FormatICalTime(e.opts.Stamp))
+	c.Line("CREATED", FormatICalTime(task.CreatedAt))
+	// Clients replace an entry they hold when its sequence goes up
+	c.Line("SEQUENCE", strconv.Itoa(task.Version))
+	if !task.UpdatedAt.IsZero() {
+		c.Line("LAST-MODIFIED", FormatICalTime(task.UpdatedAt))
+	}
+	c.Text("SUMMARY", task.Title)
+	if task.Description != nil && *task.Description != "" {
+		c.Text("DESCRIPTION", *task.Description)
+	}
+
+	// An event on the date, or a to-do due then
+	name, value := "DUE", FormatICalTime(task.Date)
+	if event {
+		name = "DTSTART"
+	}
+	if task.AllDay {
+		name, value = name+";VALUE=DATE", task.Date.UTC().Format("20060102")
+	}
+	c.Line(name, value)
+
+	completed := task.Status == string(constants.TaskStatusCompleted)
+	switch {
+	case event:
+		// Tasks do not make the user busy
+		c.Line("TRANSP", "TRANSPARENT")
+	case completed:
+		c.Line("STATUS", "COMPLETED")
+	default:
+		c.Line("STATUS", "NEEDS-ACTION")
+	}
+	if len(task.Tags) > 0 {
+		categories := make([]string, len(task.Tags))
+		for i, tag := range task.Tags {
+			categories[i] = EscapeICalText(tag)
+		}
+		c.Line("CATEGORIES", strings.Join(categories, ","))
+	}
+	if task.Image != "" {
+		c.Line("ATTACH", task.Image)
+	}
+	c.Line("END", component)
+	return c.Err()
+}
+
+func (e *iCalTaskWriter) Close() error {
+	e.c.Line("END", "VCALENDAR")
+	return e.c.Err()
+}
+
+// formatICalDuration formats d as an iCalendar DURATION, to the second
+func formatICalDuration(d time.Duration) string {
+	seconds := int64(d / time.Second)
+	if seconds%3600 == 0 {
+		return "PT" + strconv.FormatInt(seconds/3600, 10) + "H"
+	}
+	if seconds%60 == 0 {
+		return "PT" + strconv.FormatInt(seconds/60, 10) + "M"
+	}
+	return "PT" + strconv.FormatInt(seconds, 10) + "S"
+}
@@ -0,0 +1,88 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalendarFeedToken(t *testing.T) {
+	token, hash, err := NewCalendarFeedToken()
+	require.NoError(t, err)
+	assert.Len(t, token, 43)
+	assert.Equal(t, HashCalendarFeedToken(token), hash)
+
+	other, _, err := NewCalendarFeedToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}
+
+func TestICalTaskWriter_Events(t *testing.T) {
+	bangkok := time.FixedZone("Asia/Bangkok", 7*60*60)
+	tasks := []entities.ExportTask{
+		{ID: "1", Title: "All day", Status: string(constants.TaskStatusPending), Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), AllDay: true, CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), Version: 1, UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "2", Title: "Timed", Status: string(constants.TaskStatusCompleted), Date: time.Date(2024, 1, 5, 9, 30, 0, 0, bangkok), CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC), Version: 3, UpdatedAt: time.Date(2024, 1, 4, 8, 15, 0, 0, time.UTC)},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewICalTaskWriter(&buf, ICalTaskOptions{
+		Component:       constants.CalendarComponentEvent,
+		Name:            "Tasks",
+		Location:        bangkok,
+		RefreshInterval: 15 * time.Minute,
+		Stamp:           time.Date(2024, 2, 1, 12, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	for i := range tasks {
+		require.NoError(t, w.WriteTask(&tasks[i]))
+	}
+	require.NoError(t, w.Close())
+	got := buf.String()
+
+	assert.Contains(t, got, "X-WR-CALNAME:Tasks\r\nX-WR-TIMEZONE:Asia/Bangkok\r\n")
+	assert.Contains(t, got, "REFRESH-INTERVAL;VALUE=DURATION:PT15M\r\nX-PUBLISHED-TTL:PT15M\r\n")
+	assert.Equal(t, 2, strings.Count(got, "BEGIN:VEVENT"))
+	assert.NotContains(t, got, "VTODO")
+	assert.Contains(t, got, "DTSTART;VALUE=DATE:20240105\r\n")
+	assert.Contains(t, got, "DTSTART:20240105T023000Z\r\n")
+	assert.Contains(t, got, "TRANSP:TRANSPARENT\r\n")
+	// Events have no to-do status
+	assert.NotContains(t, got, "STATUS:")
+
+	// Entries are stamped when the calendar is generated, and carry when and
+	// how often their task changed
+	assert.Equal(t, 2, strings.Count(got, "DTSTAMP:20240201T120000Z\r\n"))
+	assert.Contains(t, got, "UID:2\r\nDTSTAMP:20240201T120000Z\r\nCREATED:20240102T000000Z\r\nSEQUENCE:3\r\nLAST-MODIFIED:20240104T081500Z\r\n")
+	assert.Contains(t, got, "SEQUENCE:1\r\nLAST-MODIFIED:20240101T000000Z\r\n")
+}
+
+func TestICalTaskWriter_Todos(t *testing.T) {
+	bangkok := time.FixedZone("Asia/Bangkok", 7*60*60)
+	tasks := []entities.ExportTask{
+		{ID: "1", Title: "All day", Status: string(constants.TaskStatusPending), Date: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), AllDay: true, Version: 2, UpdatedAt: time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)},
+		// Midnight in the calendar's zone is a time like any other
+		{ID: "2", Title: "Midnight", Status: string(constants.TaskStatusPending), Date: time.Date(2024, 1, 6, 0, 0, 0, 0, bangkok)},
+	}
+
+	var buf bytes.Buffer
+	w, err := NewICalTaskWriter(&buf, ICalTaskOptions{Component: constants.CalendarComponentTodo, Location: bangkok})
+	require.NoError(t, err)
+	for i := range tasks {
+		require.NoError(t, w.WriteTask(&tasks[i]))
+	}
+	require.NoError(t, w.Close())
+
+	assert.Contains(t, buf.String(), "BEGIN:VTODO\r\n")
+	assert.Contains(t, buf.String(), "DUE;VALUE=DATE:20240105\r\nSTATUS:NEEDS-ACTION\r\n")
+	assert.Contains(t, buf.String(), "DUE:20240105T170000Z\r\n")
+	assert.Contains(t, buf.String(), "SEQUENCE:2\r\nLAST-MODIFIED:20240103T000000Z\r\n")
+	// Without a stamp, entries are stamped now rather than at the zero time
+	assert.NotContains(t, buf.String(), "DTSTAMP:0001")
+	assert.NotContains(t, buf.String(), "REFRESH-INTERVAL")
+}
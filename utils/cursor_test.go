@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/guncv/tech-exam-software-engineering/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorCodec(t *testing.T) {
+	codec := NewCursorCodec(&config.Config{TokenConfig: config.TokenConfig{TokenSymmetricKey: "12345678901234567890123456789012"}})
+	cursor := &Cursor{
+		Query:  QueryFingerprint("", "tag:work", "", "created_at", "desc"),
+		Values: []interface{}{"2026-10-19T08:00:00.123456Z", "550e8400-e29b-41d4-a716-446655440000"},
+		Before: true,
+	}
+
+	token, err := codec.Encode(cursor)
+	assert.NoError(t, err)
+
+	got, err := codec.Decode(token)
+	assert.NoError(t, err)
+	assert.Equal(t, cursor, got)
+
+	// Any change to the payload breaks the signature
+	body, sig, _ := strings.Cut(token, ".")
+	_, err = codec.Decode(body[:len(body)-1] + "A." + sig)
+	assert.Error(t, err)
+
+	// A cursor signed with another key is rejected
+	other := NewCursorCodec(&config.Config{TokenConfig: config.TokenConfig{TokenSymmetricKey: "abcdefghijabcdefghijabcdefghijab"}})
+	_, err = other.Decode(token)
+	assert.Error(t, err)
+
+	_, err = codec.Decode("not-a-cursor")
+	assert.Error(t, err)
+}
+
+func TestQueryFingerprint(t *testing.T) {
+	assert.Equal(t, QueryFingerprint("a", "b"), QueryFingerprint("a", "b"))
+	assert.NotEqual(t, QueryFingerprint("ab", ""), QueryFingerprint("a", "b"))
+}
+
+func TestPageLinks(t *testing.T) {
+	u, _ := url.Parse("/api/v1/tasks?limit=10&offset=3&filter=tag%3Awork")
+
+	assert.Equal(t,
+		`</api/v1/tasks?cursor=n1&filter=tag%3Awork&limit=10>; rel="next", </api/v1/tasks?cursor=p1&filter=tag%3Awork&limit=10>; rel="prev"`,
+		PageLinks(u, "n1", "p1"))
+	assert.Equal(t, `</api/v1/tasks?cursor=n1&filter=tag%3Awork&limit=10>; rel="next"`, PageLinks(u, "n1", ""))
+	assert.Empty(t, PageLinks(u, "", ""))
+}
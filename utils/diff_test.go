@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	testCases := []struct {
+		name string
+		a    string
+		b    string
+		want string
+	}{
+		{
+			name: "Identical",
+			a:    "one\ntwo\n",
+			b:    "one\ntwo\n",
+			want: "",
+		},
+		{
+			name: "ChangedLine",
+			a:    "one\ntwo\nthree\n",
+			b:    "one\n2\nthree\n",
+			want: "--- a\n+++ b\n@@ -1,3 +1,3 @@\n one\n-two\n+2\n three\n",
+		},
+		{
+			name: "FromEmpty",
+			a:    "",
+			b:    "new\n",
+			want: "--- a\n+++ b\n@@ -0,0 +1 @@\n+new\n",
+		},
+		{
+			name: "ToEmpty",
+			a:    "old\nlines\n",
+			b:    "",
+			want: "--- a\n+++ b\n@@ -1,2 +0,0 @@\n-old\n-lines\n",
+		},
+		{
+			name: "Appended",
+			a:    "1\n2\n3\n4\n5\n",
+			b:    "1\n2\n3\n4\n5\n6\n",
+			want: "--- a\n+++ b\n@@ -3,3 +3,4 @@\n 3\n 4\n 5\n+6\n",
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			assert.Equal(t, tC.want, UnifiedDiff("a", "b", tC.a, tC.b))
+		})
+	}
+}
+
+func TestUnifiedDiff_SeparateHunks(t *testing.T) {
+	var lines []string
+	for i := 1; i <= 20; i++ {
+		lines = append(lines, fmt.Sprint(i))
+	}
+	a := strings.Join(lines, "\n")
+	lines[1], lines[17] = "two", "eighteen"
+	b := strings.Join(lines, "\n")
+
+	want := "--- a\n+++ b\n" +
+		"@@ -1,5 +1,5 @@\n 1\n-2\n+two\n 3\n 4\n 5\n" +
+		"@@ -15,6 +15,6 @@\n 15\n 16\n 17\n-18\n+eighteen\n 19\n 20\n"
+	assert.Equal(t, want, UnifiedDiff("a", "b", a, b))
+}
+
+func TestUnifiedDiff_MergesCloseChanges(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\nB\n3\n4\n5\n6\n7\nH\n9\n10\n"
+
+	want := "--- a\n+++ b\n@@ -1,10 +1,10 @@\n 1\n-2\n+B\n 3\n 4\n 5\n 6\n 7\n-8\n+H\n 9\n 10\n"
+	assert.Equal(t, want, UnifiedDiff("a", "b", a, b))
+}
+
+func TestUnifiedDiff_TooManyEditsReplacesAll(t *testing.T) {
+	var a, b []string
+	for i := 0; i < maxDiffEdits; i++ {
+		a = append(a, fmt.Sprint("a", i))
+		b = append(b, fmt.Sprint("b", i))
+	}
+
+	got := UnifiedDiff("a", "b", strings.Join(a, "\n"), strings.Join(b, "\n"))
+
+	assert.True(t, strings.HasPrefix(got, fmt.Sprintf("--- a\n+++ b\n@@ -1,%d +1,%d @@\n-a0\n", maxDiffEdits, maxDiffEdits)))
+	assert.Equal(t, 2*maxDiffEdits+3, strings.Count(got, "\n"))
+}
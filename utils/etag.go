@@ -0,0 +1,58 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"strings"
+)
+
+// VersionETag returns the strong entity tag of a resource version
+func VersionETag(version int) string {
+	return `"` + strconv.Itoa(version) + `"`
+}
+
+// ContentETag returns the strong entity tag of a response body, for a
+// representation that changes with more than its resource's version
+func ContentETag(body []byte) string {
+	sum := sha256.Sum256(body)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// IfMatch reports whether an If-Match header lets a write go ahead on the
+// resource with the given tag. Without the header there is no precondition.
+// Weak tags never match, as If-Match compares strongly (RFC 9110).
+func IfMatch(header string, etag string) bool {
+	if strings.TrimSpace(header) == "" {
+		return true
+	}
+	return matchETag(header, etag, false)
+}
+
+// IfNoneMatch reports whether an If-None-Match header already holds the
+// resource's tag, so a read can answer 304 Not Modified
+func IfNoneMatch(header string, etag string) bool {
+	if strings.TrimSpace(header) == "" {
+		return false
+	}
+	return matchETag(header, etag, true)
+}
+
+func matchETag(header string, etag string, weak bool) bool {
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return true
+		}
+		if strings.HasPrefix(tag, "W/") {
+			if !weak {
+				continue
+			}
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag == etag {
+			return true
+		}
+	}
+	return false
+}
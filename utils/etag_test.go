@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionETag(t *testing.T) {
+	assert.Equal(t, `"3"`, VersionETag(3))
+}
+
+func TestContentETag(t *testing.T) {
+	etag := ContentETag([]byte(`{"id":"1"}`))
+	assert.Equal(t, etag, ContentETag([]byte(`{"id":"1"}`)))
+	assert.NotEqual(t, etag, ContentETag([]byte(`{"id":"2"}`)))
+	assert.Len(t, etag, 34)
+}
+
+func TestIfMatch(t *testing.T) {
+	etag := VersionETag(3)
+
+	testCases := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "NoHeader", header: "", want: true},
+		{name: "Same", header: `"3"`, want: true},
+		{name: "Stale", header: `"2"`, want: false},
+		{name: "List", header: `"1", "3"`, want: true},
+		{name: "Any", header: "*", want: true},
+		{name: "WeakNeverMatches", header: `W/"3"`, want: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IfMatch(tc.header, etag))
+		})
+	}
+}
+
+func TestIfNoneMatch(t *testing.T) {
+	etag := VersionETag(3)
+
+	testCases := []struct {
+		name   string
+		header string
+		want   bool
+	}{
+		{name: "NoHeader", header: "", want: false},
+		{name: "Same", header: `"3"`, want: true},
+		{name: "Stale", header: `"2"`, want: false},
+		{name: "Weak", header: `W/"3"`, want: true},
+		{name: "Any", header: "*", want: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, IfNoneMatch(tc.header, etag))
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package utils
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+)
+
+// ITaskExportWriter writes the tasks of an export one at a time, so an
+// export of any size is streamed. Close finishes the document.
+type ITaskExportWriter interface {
+	WriteTask(task *entities.ExportTask) error
+	Close() error
+}
+
+// NewTaskExportWriter starts an export document in the format; withImages
+// adds the image of each task
+func NewTaskExportWriter(format constants.ExportFormat, w io.Writer, withImages bool) (ITaskExportWriter, error) {
+	var writer ITaskExportWriter
+	var err error
+	switch format {
+	case constants.ExportFormatCSV:
+		writer, err = newCSVExportWriter(w, withImages)
+	case constants.ExportFormatJSON:
+		writer, err = newJSONExportWriter(w)
+	case constants.ExportFormatMarkdown:
+		writer, err = newMarkdownExportWriter(w)
+	case constants.ExportFormatICal:
+		writer, err = NewICalTaskWriter(w, ICalTaskOptions{Component: constants.CalendarComponentTodo, Stamp: time.Now()})
+	default:
+		return nil, constants.ErrInvalidQueryRequestParam
+	}
+	if err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+// ExportContentType is the media type of an export document
+func ExportContentType(format constants.ExportFormat) string {
+	switch format {
+	case constants.ExportFormatCSV:
+		return "text/csv; charset=utf-8"
+	case constants.ExportFormatJSON:
+		return "application/json; charset=utf-8"
+	case constants.ExportFormatMarkdown:
+		return "text/markdown; charset=utf-8"
+	case constants.ExportFormatICal:
+		return "text/calendar; charset=utf-8"
+	}
+	return "application/octet-stream"
+}
+
+// csvExportHeader lists the columns of a CSV export; import reads the ones
+// named after task fields back
+var csvExportHeader = []string{"id", "title", "description", "status", "date", "tags", "assignee_id", "archived", "created_at"}
+
+type csvExportWriter struct {
+	w          *csv.Writer
+	withImages bool
+}
+
+func newCSVExportWriter(w io.Writer, withImages bool) (*csvExportWriter, error) {
+	writer := &csvExportWriter{w: csv.NewWriter(w), withImages: withImages}
+	header := csvExportHeader
+	if withImages {
+		header = append(header[:len(header):len(header)], "image")
+	}
+	if err := writer.w.Write(header); err != nil {
+		return nil, err
+	}
+	return writer, nil
+}
+
+func (e *csvExportWriter) WriteTask(task *entities.ExportTask) error {
+	record := []string{
+		task.ID,
+		task.Title,
+		stringOrEmpty(task.Description),
+		task.Status,
+		formatExportDate(task),
+		strings.Join(task.Tags, ","),
+		stringOrEmpty(task.AssigneeID),
+		strconv.FormatBool(task.Archived),
+		task.CreatedAt.Format(time.RFC3339),
+	}
+	if e.withImages {
+		record = append(record, task.Image)
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExportWriter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+// jsonExportWriter writes {"tasks": [...]}, one task per line
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+func newJSONExportWriter(w io.Writer) (*jsonExportWriter, error) {
+	if _, err := io.WriteString(w, "{\"tasks\":["); err != nil {
+		return nil, err
+	}
+	return &jsonExportWriter{w: w}, nil
+}
+
+func (e *jsonExportWriter) WriteTask(task *entities.ExportTask) error {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return err
+	}
+	separator := ",\n"
+	if e.count == 0 {
+		separator = "\n"
+	}
+	e.count++
+	if _, err := io.WriteString(e.w, separator); err != nil {
+		return err
+	}
+	_, err = e.w.Write(data)
+	return err
+}
+
+func (e *jsonExportWriter) Close() error {
+	_, err := io.WriteString(e.w, "\n]}\n")
+	return err
+}
+
+// markdownExportWriter writes a section per task: its title as a heading,
+// its fields as a list and its description as the body
+type markdownExportWriter struct {
+	w io.Writer
+}
+
+func newMarkdownExportWriter(w io.Writer) (*markdownExportWriter, error) {
+	if _, err := io.WriteString(w, "# Tasks\n"); err != nil {
+		return nil, err
+	}
+	return &markdownExportWriter{w: w}, nil
+}
+
+func (e *markdownExportWriter) WriteTask(task *entities.ExportTask) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\n## %s\n\n", escapeMarkdown(task.Title))
+	fmt.Fprintf(&b, "- Status: %s\n", task.Status)
+	fmt.Fprintf(&b, "- Date: %s\n", formatExportDate(task))
+	if len(task.Tags) > 0 {
+		fmt.Fprintf(&b, "- Tags: %s\n", strings.Join(task.Tags, ", "))
+	}
+	if task.AssigneeID != nil {
+		fmt.Fprintf(&b, "- Assignee: %s\n", *task.AssigneeID)
+	}
+	if task.Archived {
+		b.WriteString("- Archived\n")
+	}
+	if task.Image != "" {
+		fmt.Fprintf(&b, "- Image: ![%s](<%s>)\n", escapeMarkdown(task.Title), task.Image)
+	}
+	if task.Description != nil && strings.TrimSpace(*task.Description) != "" {
+		fmt.Fprintf(&b, "\n%s\n", strings.TrimSpace(*task.Description))
+	}
+
+	_, err := io.WriteString(e.w, b.String())
+	return err
+}
+
+func (e *markdownExportWriter) Close() error {
+	return nil
+}
+
+// escapeMarkdown escapes the characters that would format inline text
+func escapeMarkdown(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if strings.ContainsRune("\\`*_[]<>", r) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// formatExportDate writes an all-day date as the day alone, which import
+// reads back as an all-day date
+func formatExportDate(task *entities.ExportTask) string {
+	if task.AllDay {
+		return task.Date.Format(importDayLayout)
+	}
+	return task.Date.Format(time.RFC3339)
+}
+
+func stringOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
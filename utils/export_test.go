@@ -0,0 +1,119 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func exportTasks() []entities.ExportTask {
+	description := "Numbers for Q1, then review"
+	return []entities.ExportTask{
+		{
+			ID:          "550e8400-e29b-41d4-a716-446655440000",
+			Title:       "Write *report*",
+			Description: &description,
+			Status:      string(constants.TaskStatusPending),
+			Date:        time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC),
+			Tags:        []string{"work", "q1"},
+			CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+			Image:       "images/550e8400-e29b-41d4-a716-446655440000/chart.png",
+		},
+		{
+			ID:        "550e8400-e29b-41d4-a716-446655440001",
+			Title:     "Ship",
+			Status:    string(constants.TaskStatusCompleted),
+			Date:      time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC),
+			AllDay:    true,
+			Tags:      []string{},
+			Archived:  true,
+			CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+}
+
+func writeExport(t *testing.T, format constants.ExportFormat, withImages bool) string {
+	var buf bytes.Buffer
+	w, err := NewTaskExportWriter(format, &buf, withImages)
+	require.NoError(t, err)
+	for _, task := range exportTasks() {
+		require.NoError(t, w.WriteTask(&task))
+	}
+	require.NoError(t, w.Close())
+	return buf.String()
+}
+
+func TestTaskExportWriter_CSV(t *testing.T) {
+	got := writeExport(t, constants.ExportFormatCSV, true)
+
+	assert.Equal(t, "id,title,description,status,date,tags,assignee_id,archived,created_at,image\n"+
+		"550e8400-e29b-41d4-a716-446655440000,Write *report*,\"Numbers for Q1, then review\",IN_PROGRESS,2024-01-05T09:00:00Z,\"work,q1\",,false,2024-01-01T00:00:00Z,images/550e8400-e29b-41d4-a716-446655440000/chart.png\n"+
+		"550e8400-e29b-41d4-a716-446655440001,Ship,,COMPLETED,2024-01-06,,,true,2024-01-02T00:00:00Z,\n", got)
+
+	// An export reads back as an import
+	rows, err := ParseImport(constants.ImportFormatCSV, strings.NewReader(got), nil, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, []string{"work", "q1"}, rows[0].Task.Tags)
+	assert.Nil(t, ValidateCreateTaskInput(rows[0].Task))
+	assert.True(t, rows[1].Task.AllDay)
+	assert.True(t, rows[1].Task.Date.Equal(time.Date(2024, 1, 6, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestTaskExportWriter_JSON(t *testing.T) {
+	got := writeExport(t, constants.ExportFormatJSON, false)
+
+	rows, err := ParseImport(constants.ImportFormatJSON, strings.NewReader(got), nil, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+	assert.Equal(t, "Write *report*", rows[0].Task.Title)
+	assert.True(t, rows[0].Task.Date.Equal(time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)))
+	assert.Equal(t, constants.TaskStatusCompleted, rows[1].Task.Status)
+	assert.True(t, rows[1].Task.AllDay)
+	for _, row := range rows {
+		assert.Nil(t, ValidateCreateTaskInput(row.Task))
+	}
+
+	// An empty export is still a document
+	var buf bytes.Buffer
+	w, err := NewTaskExportWriter(constants.ExportFormatJSON, &buf, false)
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.JSONEq(t, `{"tasks": []}`, buf.String())
+}
+
+func TestTaskExportWriter_Markdown(t *testing.T) {
+	got := writeExport(t, constants.ExportFormatMarkdown, true)
+
+	assert.Equal(t, "# Tasks\n"+
+		"\n## Write \\*report\\*\n\n"+
+		"- Status: IN_PROGRESS\n"+
+		"- Date: 2024-01-05T09:00:00Z\n"+
+		"- Tags: work, q1\n"+
+		"- Image: ![Write \\*report\\*](<images/550e8400-e29b-41d4-a716-446655440000/chart.png>)\n"+
+		"\nNumbers for Q1, then review\n"+
+		"\n## Ship\n\n"+
+		"- Status: COMPLETED\n"+
+		"- Date: 2024-01-06\n"+
+		"- Archived\n", got)
+}
+
+func TestTaskExportWriter_ICal(t *testing.T) {
+	got := writeExport(t, constants.ExportFormatICal, true)
+
+	assert.True(t, strings.HasPrefix(got, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n"))
+	assert.True(t, strings.HasSuffix(got, "END:VTODO\r\nEND:VCALENDAR\r\n"))
+	assert.Contains(t, got, "UID:550e8400-e29b-41d4-a716-446655440000\r\n")
+	assert.Contains(t, got, "SUMMARY:Write *report*\r\n")
+	assert.Contains(t, got, "DESCRIPTION:Numbers for Q1\\, then review\r\n")
+	assert.Contains(t, got, "DUE:20240105T090000Z\r\nSTATUS:NEEDS-ACTION\r\nCATEGORIES:work,q1\r\n")
+	assert.Contains(t, got, "DUE;VALUE=DATE:20240106\r\nSTATUS:COMPLETED\r\n")
+	assert.Equal(t, 2, strings.Count(got, "BEGIN:VTODO"))
+	assert.Contains(t, got, "ATTACH:images/550e8400-e29b-41d4-a716-446655440000/chart.png\r\n")
+}
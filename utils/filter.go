@@ -0,0 +1,415 @@
+package utils
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+)
+
+// A task filter is a small query language over task fields, e.g.
+//
+//	status:IN_PROGRESS due<2026-11-01 (tag:work OR tag:home) -tag:later
+//
+// Terms next to each other must all match; OR binds looser than that, and
+// a leading '-' or NOT negates a term or parenthesized group.
+//
+// Fields and operators:
+//
+//	status    : = !=            IN_PROGRESS or COMPLETED
+//	tag       : = !=            a tag
+//	assignee  : = !=            me, unassigned or a user id
+//	title     :                 contains the text, ignoring case
+//	blocked   : =               true or false
+//	due       : = != < <= > >=  a day: 2026-11-01, today, tomorrow, today+7d
+//	created   : = != < <= > >=  as for due
+//
+// Values with spaces are quoted: title:"weekly sync".
+
+// FilterNode is a node of a parsed task filter
+type FilterNode interface {
+	filterNode()
+}
+
+// FilterAnd matches when both sides match
+type FilterAnd struct {
+	Left, Right FilterNode
+}
+
+// FilterOr matches when either side matches
+type FilterOr struct {
+	Left, Right FilterNode
+}
+
+// FilterNot matches when its operand does not
+type FilterNot struct {
+	Operand FilterNode
+}
+
+// FilterTerm compares one field with a value. Op is one of =, !=, <, <=, >
+// and >= (':' is read as '='), and Value is already normalized.
+type FilterTerm struct {
+	Field    string
+	Op       string
+	Value    string
+	Position int
+}
+
+func (*FilterAnd) filterNode()  {}
+func (*FilterOr) filterNode()   {}
+func (*FilterNot) filterNode()  {}
+func (*FilterTerm) filterNode() {}
+
+// FilterError is a syntax or value error in a filter, at a 1-based
+// character position
+type FilterError struct {
+	Position int
+	Message  string
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Message, e.Position)
+}
+
+// Task filter fields
+const (
+	FilterFieldStatus   = "status"
+	FilterFieldTag      = "tag"
+	FilterFieldAssignee = "assignee"
+	FilterFieldTitle    = "title"
+	FilterFieldBlocked  = "blocked"
+	FilterFieldDue      = "due"
+	FilterFieldCreated  = "created"
+)
+
+var filterFieldOps = map[string][]string{
+	FilterFieldStatus:   {"=", "!="},
+	FilterFieldTag:      {"=", "!="},
+	FilterFieldAssignee: {"=", "!="},
+	FilterFieldTitle:    {"="},
+	FilterFieldBlocked:  {"="},
+	FilterFieldDue:      {"=", "!=", "<", "<=", ">", ">="},
+	FilterFieldCreated:  {"=", "!=", "<", "<=", ">", ">="},
+}
+
+var relativeFilterDate = regexp.MustCompile(`^today([+-])(\d{1,3})([dw])$`)
+
+// ParseTaskFilter parses a task filter expression. An empty expression
+// yields a nil node, which matches every task.
+func ParseTaskFilter(s string) (FilterNode, error) {
+	p := &filterParser{src: []rune(s)}
+	p.skipSpace()
+	if p.eof() {
+		return nil, nil
+	}
+
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		if p.peek() == ')' {
+			return nil, p.errorf(p.pos, "unexpected )")
+		}
+		return nil, p.errorf(p.pos, "unexpected %q", string(p.peek()))
+	}
+	return node, nil
+}
+
+// FilterDate resolves a filter day value to the start of that day in loc
+func FilterDate(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	now = now.In(loc)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+
+	switch value {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if m := relativeFilterDate.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[2])
+		if m[3] == "w" {
+			n *= 7
+		}
+		if m[1] == "-" {
+			n = -n
+		}
+		return today.AddDate(0, 0, n), nil
+	}
+
+	day, err := time.ParseInLocation("2006-01-02", value, loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return day, nil
+}
+
+type filterParser struct {
+	src []rune
+	pos int
+}
+
+func (p *filterParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *filterParser) peek() rune {
+	return p.src[p.pos]
+}
+
+func (p *filterParser) skipSpace() {
+	for !p.eof() && unicode.IsSpace(p.peek()) {
+		p.pos++
+	}
+}
+
+func (p *filterParser) errorf(pos int, format string, args ...interface{}) error {
+	return &FilterError{Position: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
+
+// keyword consumes an upper- or lowercase keyword standing on its own
+func (p *filterParser) keyword(k string) bool {
+	end := p.pos + len(k)
+	if end > len(p.src) || !strings.EqualFold(string(p.src[p.pos:end]), k) {
+		return false
+	}
+	if end < len(p.src) && !unicode.IsSpace(p.src[end]) && p.src[end] != '(' {
+		return false
+	}
+	p.pos = end
+	return true
+}
+
+func (p *filterParser) parseOr() (FilterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if !p.keyword("OR") {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterOr{Left: left, Right: right}
+	}
+}
+
+func (p *filterParser) parseAnd() (FilterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		p.skipSpace()
+		if p.eof() || p.peek() == ')' {
+			return left, nil
+		}
+		start := p.pos
+		if p.keyword("OR") {
+			p.pos = start
+			return left, nil
+		}
+		p.keyword("AND")
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &FilterAnd{Left: left, Right: right}
+	}
+}
+
+func (p *filterParser) parseUnary() (FilterNode, error) {
+	p.skipSpace()
+	if !p.eof() && p.peek() == '-' {
+		p.pos++
+	} else if !p.keyword("NOT") {
+		return p.parsePrimary()
+	}
+
+	operand, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return &FilterNot{Operand: operand}, nil
+}
+
+func (p *filterParser) parsePrimary() (FilterNode, error) {
+	p.skipSpace()
+	if p.eof() {
+		return nil, p.errorf(p.pos, "expected a filter term")
+	}
+
+	switch p.peek() {
+	case '(':
+		open := p.pos
+		p.pos++
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpace()
+		if p.eof() || p.peek() != ')' {
+			return nil, p.errorf(open, "unclosed (")
+		}
+		p.pos++
+		return node, nil
+	case ')':
+		return nil, p.errorf(p.pos, "unexpected )")
+	}
+
+	return p.parseTerm()
+}
+
+func (p *filterParser) parseTerm() (FilterNode, error) {
+	// Field name
+	start := p.pos
+	for !p.eof() && (unicode.IsLetter(p.peek()) || p.peek() == '_') {
+		p.pos++
+	}
+	field := strings.ToLower(string(p.src[start:p.pos]))
+	if field == "" {
+		return nil, p.errorf(start, "expected a field name")
+	}
+	ops, ok := filterFieldOps[field]
+	if !ok {
+		return nil, p.errorf(start, "unknown field %q", field)
+	}
+
+	// Operator
+	opPos := p.pos
+	op := p.parseOp()
+	if op == "" {
+		return nil, p.errorf(opPos, "expected an operator after %q", field)
+	}
+	if !containsString(ops, op) {
+		return nil, p.errorf(opPos, "operator %s is not supported for %s", op, field)
+	}
+
+	// Value
+	valuePos := p.pos
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	if value == "" {
+		return nil, p.errorf(valuePos, "expected a value for %s", field)
+	}
+
+	normalized, message := normalizeFilterValue(field, value)
+	if message != "" {
+		return nil, p.errorf(valuePos, "%s", message)
+	}
+
+	return &FilterTerm{Field: field, Op: op, Value: normalized, Position: start + 1}, nil
+}
+
+func (p *filterParser) parseOp() string {
+	for _, op := range []string{"!=", "<=", ">=", ":", "=", "<", ">"} {
+		end := p.pos + len(op)
+		if end <= len(p.src) && string(p.src[p.pos:end]) == op {
+			p.pos = end
+			if op == ":" {
+				return "="
+			}
+			return op
+		}
+	}
+	return ""
+}
+
+func (p *filterParser) parseValue() (string, error) {
+	if p.eof() || p.peek() != '"' {
+		start := p.pos
+		for !p.eof() && !unicode.IsSpace(p.peek()) && p.peek() != '(' && p.peek() != ')' {
+			p.pos++
+		}
+		return string(p.src[start:p.pos]), nil
+	}
+
+	// Quoted value; \" and \\ are escapes
+	open := p.pos
+	p.pos++
+	var b strings.Builder
+	for !p.eof() {
+		r := p.peek()
+		p.pos++
+		switch {
+		case r == '"':
+			return b.String(), nil
+		case r == '\\' && !p.eof():
+			b.WriteRune(p.peek())
+			p.pos++
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", p.errorf(open, "unterminated quoted value")
+}
+
+// normalizeFilterValue checks a value against its field, returning the
+// normalized value or an error message
+func normalizeFilterValue(field, value string) (string, string) {
+	switch field {
+	case FilterFieldStatus:
+		status := constants.TaskStatus(strings.ToUpper(value))
+		if isInvalidStatus(status) {
+			return "", "status must be IN_PROGRESS or COMPLETED"
+		}
+		return string(status), ""
+	case FilterFieldTag:
+		tag, ok := NormalizeTag(value)
+		if !ok {
+			return "", fmt.Sprintf("invalid tag %q", value)
+		}
+		return tag, ""
+	case FilterFieldAssignee:
+		lower := strings.ToLower(value)
+		if lower == constants.AssigneeFilterMe || lower == constants.AssigneeFilterUnassigned {
+			return lower, ""
+		}
+		if _, err := uuid.Parse(value); err != nil {
+			return "", "assignee must be me, unassigned or a user id"
+		}
+		return value, ""
+	case FilterFieldBlocked:
+		blocked, err := strconv.ParseBool(value)
+		if err != nil {
+			return "", "blocked must be true or false"
+		}
+		return strconv.FormatBool(blocked), ""
+	case FilterFieldDue, FilterFieldCreated:
+		lower := strings.ToLower(value)
+		if _, err := FilterDate(lower, time.Now(), time.UTC); err != nil {
+			return "", fmt.Sprintf("%s must be a date such as 2026-11-01, today or today+7d", field)
+		}
+		return lower, ""
+	}
+	return value, ""
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTaskFilter(t *testing.T) {
+	testCases := []struct {
+		name   string
+		in     string
+		verify func(t *testing.T, got FilterNode, gotErr error)
+	}{
+		{
+			name: "Empty",
+			in:   "   ",
+			verify: func(t *testing.T, got FilterNode, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Nil(t, got)
+			},
+		},
+		{
+			name: "ImplicitAnd",
+			in:   "status:in_progress due<2026-11-01 tag:Work -tag:later",
+			verify: func(t *testing.T, got FilterNode, gotErr error) {
+				assert.NoError(t, gotErr)
+				assert.Equal(t, &FilterAnd{
+					Left: &FilterAnd{
+						Left: &FilterAnd{
+							Left:  &FilterTerm{Field: "status", Op: "=", Value: "IN_PROGRESS", Position: 1},
+							Right: &FilterTerm{Field: "due", Op: "<", Value: "2026-11-01", Position: 20},
+						},
+						Right: &FilterTerm{Field: "tag", Op: "=", Value: "work", Position: 35},
+					},
+					Right: &FilterNot{Operand: &FilterTerm{Field: "tag", Op: "=", Value: "later", Position: 45}},
+				}, got)
+			},
+		},
+		{
+			name: "OrBindsLooserThanAnd",
+			in:   "tag:a tag:b OR tag:c",
+			verify: func(t *testing.T, got FilterNode, gotErr error) {
+				assert.NoError(t, gotErr)
+				or, ok := got.(*FilterOr)
+				assert.True(t, ok)
+				assert.IsType(t, &FilterAnd{}, or.Left)
+				assert.Equal(t, "c", or.Right.(*FilterTerm).Value)
+			},
+		},
+		{
+			name: "GroupsAndNot",
+			in:   `NOT (tag:a or title:"weekly \"sync\"")`,
+			verify: func(t *testing.T, got FilterNode, gotErr error) {
+				assert.NoError(t, gotErr)
+				not, ok := got.(*FilterNot)
+				assert.True(t, ok)
+				or := not.Operand.(*FilterOr)
+				assert.Equal(t, `weekly "sync"`, or.Right.(*FilterTerm).Value)
+			},
+		},
+		{
+			name: "ExplicitAndAndComparisons",
+			in:   "created>=today-1w AND blocked:TRUE AND assignee!=unassigned",
+			verify: func(t *testing.T, got FilterNode, gotErr error) {
+				assert.NoError(t, gotErr)
+				and := got.(*FilterAnd)
+				assert.Equal(t, &FilterTerm{Field: "assignee", Op: "!=", Value: "unassigned", Position: 40}, and.Right)
+				assert.Equal(t, &FilterTerm{Field: "blocked", Op: "=", Value: "true", Position: 23}, and.Left.(*FilterAnd).Right)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTaskFilter(tc.in)
+			tc.verify(t, got, err)
+		})
+	}
+}
+
+func TestParseTaskFilter_Errors(t *testing.T) {
+	testCases := []struct {
+		name     string
+		in       string
+		position int
+		message  string
+	}{
+		{name: "UnknownField", in: "status:COMPLETED colour:red", position: 18, message: `unknown field "colour"`},
+		{name: "MissingOperator", in: "tag", position: 4, message: `expected an operator after "tag"`},
+		{name: "UnsupportedOperator", in: "tag<work", position: 4, message: "operator < is not supported for tag"},
+		{name: "MissingValue", in: "due< tag:a", position: 5, message: "expected a value for due"},
+		{name: "InvalidStatus", in: "status:DONE", position: 8, message: "status must be IN_PROGRESS or COMPLETED"},
+		{name: "InvalidDate", in: "due<2026-13-01", position: 5, message: "due must be a date such as 2026-11-01, today or today+7d"},
+		{name: "InvalidAssignee", in: "assignee:bob", position: 10, message: "assignee must be me, unassigned or a user id"},
+		{name: "UnclosedGroup", in: "(tag:a OR tag:b", position: 1, message: "unclosed ("},
+		{name: "UnexpectedClose", in: "tag:a)", position: 6, message: "unexpected )"},
+		{name: "DanglingOr", in: "tag:a OR", position: 9, message: "expected a filter term"},
+		{name: "UnterminatedQuote", in: `title:"weekly`, position: 7, message: "unterminated quoted value"},
+		{name: "NotAField", in: "'; DROP TABLE tasks", position: 1, message: "expected a field name"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseTaskFilter(tc.in)
+			assert.Nil(t, got)
+			filterErr, ok := err.(*FilterError)
+			if assert.True(t, ok) {
+				assert.Equal(t, tc.position, filterErr.Position)
+				assert.Equal(t, tc.message, filterErr.Message)
+			}
+		})
+	}
+}
+
+func TestFilterDate(t *testing.T) {
+	loc := time.FixedZone("ICT", 7*60*60)
+	// 20:00 UTC is already the next day at +07:00
+	now := time.Date(2026, 10, 19, 20, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		in   string
+		want time.Time
+	}{
+		{in: "today", want: time.Date(2026, 10, 20, 0, 0, 0, 0, loc)},
+		{in: "tomorrow", want: time.Date(2026, 10, 21, 0, 0, 0, 0, loc)},
+		{in: "yesterday", want: time.Date(2026, 10, 19, 0, 0, 0, 0, loc)},
+		{in: "today+7d", want: time.Date(2026, 10, 27, 0, 0, 0, 0, loc)},
+		{in: "today-2w", want: time.Date(2026, 10, 6, 0, 0, 0, 0, loc)},
+		{in: "2026-11-01", want: time.Date(2026, 11, 1, 0, 0, 0, 0, loc)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := FilterDate(tc.in, now, loc)
+			assert.NoError(t, err)
+			assert.True(t, tc.want.Equal(got), "got %s", got)
+		})
+	}
+
+	_, err := FilterDate("next week", now, loc)
+	assert.Error(t, err)
+}
+
+func TestNormalizeTags(t *testing.T) {
+	assert.Equal(t, []string{"work", "deep-work", "q3_plan"}, NormalizeTags([]string{" Work ", "deep-work", "", "WORK", "q3_plan", "no spaces"}))
+	assert.True(t, IsValidTagInput(""))
+	assert.False(t, IsValidTagInput("a,b"))
+}
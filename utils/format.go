@@ -1,12 +1,7 @@
 package utils
 
 import (
-	"bytes"
-	"encoding/base64"
-	"io"
-	"mime/multipart"
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
 	constants "github.com/guncv/tech-exam-software-engineering/constant"
@@ -19,6 +14,14 @@ func ErrorResponse(ctx *gin.Context, err error, details ...interface{}) {
 		statusCode = http.StatusInternalServerError
 	}
 
+	ctx.JSON(statusCode, gin.H{
+		"error": NewErrorBody(err, details...),
+	})
+}
+
+// NewErrorBody returns the error as it appears in a response body; errors
+// without a code are reported as internal errors
+func NewErrorBody(err error, details ...interface{}) entities.ErrorResponse {
 	code, ok := constants.ErrorMapWithCode[err]
 	if !ok {
 		code = constants.CodeInternalServerError
@@ -29,13 +32,11 @@ func ErrorResponse(ctx *gin.Context, err error, details ...interface{}) {
 		detail = details[0]
 	}
 
-	ctx.JSON(statusCode, gin.H{
-		"error": entities.ErrorResponse{
-			Code:    int(code),
-			Message: err.Error(),
-			Details: detail,
-		},
-	})
+	return entities.ErrorResponse{
+		Code:    int(code),
+		Message: err.Error(),
+		Details: detail,
+	}
 }
 
 func AbortWithErrorResponse(ctx *gin.Context, err error) {
@@ -46,24 +47,3 @@ func AbortWithErrorResponse(ctx *gin.Context, err error) {
 		},
 	})
 }
-
-func ConvertFileHeaderToBase64(fileHeader *multipart.FileHeader) (string, error) {
-	file, err := fileHeader.Open() // Get multipart.File
-	if err != nil {
-		return "", constants.ErrOpenFileContext
-	}
-	defer file.Close()
-
-	buf := new(bytes.Buffer)
-	if _, err := io.Copy(buf, file); err != nil {
-		return "", constants.ErrConvertFileHeaderToBase64
-	}
-
-	base64Str := base64.StdEncoding.EncodeToString(buf.Bytes())
-	return base64Str, nil
-}
-
-func FormatBangkokRFC3339(t time.Time) string {
-	loc, _ := time.LoadLocation(constants.CurrentTimeLocation)
-	return t.In(loc).Format("2006-01-02T15:04:05.000Z07:00")
-}
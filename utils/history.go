@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"strings"
+	"time"
+
+	"github.com/guncv/tech-exam-software-engineering/models"
+)
+
+// DiffTaskSnapshots returns the field-level changes between two task states.
+// A nil before means the task was created, a nil after means it was deleted.
+func DiffTaskSnapshots(before, after *models.TaskSnapshot) models.FieldChanges {
+	if before == nil {
+		before = &models.TaskSnapshot{}
+	}
+	if after == nil {
+		after = &models.TaskSnapshot{}
+	}
+
+	changes := models.FieldChanges{}
+	appendChange := func(field string, b, a *string) {
+		if equalStringPtr(b, a) {
+			return
+		}
+		changes = append(changes, models.FieldChange{Field: field, Before: b, After: a})
+	}
+
+	appendChange("title", nonEmpty(before.Title), nonEmpty(after.Title))
+	appendChange("description", before.Description, after.Description)
+	appendChange("status", nonEmpty(before.Status), nonEmpty(after.Status))
+	appendChange("date", formatTime(before.Date), formatTime(after.Date))
+	appendChange("all_day", trueOrNil(before.AllDay), trueOrNil(after.AllDay))
+	appendChange("image", before.ImageID, after.ImageID)
+	appendChange("assignee_id", before.AssigneeID, after.AssigneeID)
+	appendChange("tags", joinTags(before.Tags), joinTags(after.Tags))
+	appendChange("archived", trueOrNil(before.Archived), trueOrNil(after.Archived))
+
+	return changes
+}
+
+func joinTags(tags []string) *string {
+	return nonEmpty(strings.Join(tags, ","))
+}
+
+func trueOrNil(b bool) *string {
+	if !b {
+		return nil
+	}
+	return nonEmpty("true")
+}
+
+func equalStringPtr(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+func nonEmpty(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func formatTime(t time.Time) *string {
+	if t.IsZero() {
+		return nil
+	}
+	s := t.UTC().Format(time.RFC3339)
+	return &s
+}
@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/guncv/tech-exam-software-engineering/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffTaskSnapshots(t *testing.T) {
+	date := time.Date(2025, 5, 4, 14, 30, 0, 0, time.UTC)
+	description := "Description"
+	imageId := "550e8400-e29b-41d4-a716-446655440000"
+
+	before := &models.TaskSnapshot{
+		Title:  "Task 1",
+		Status: "IN_PROGRESS",
+		Date:   date,
+	}
+
+	// Create records every non-empty field
+	changes := DiffTaskSnapshots(nil, before)
+	require.Len(t, changes, 3)
+	require.Equal(t, "title", changes[0].Field)
+	require.Nil(t, changes[0].Before)
+	require.Equal(t, "Task 1", *changes[0].After)
+
+	// Update records only the fields that changed
+	after := *before
+	after.Status = "COMPLETED"
+	after.Description = &description
+	after.ImageID = &imageId
+
+	changes = DiffTaskSnapshots(before, &after)
+	require.Len(t, changes, 3)
+	require.Equal(t, "description", changes[0].Field)
+	require.Equal(t, "status", changes[1].Field)
+	require.Equal(t, "IN_PROGRESS", *changes[1].Before)
+	require.Equal(t, "COMPLETED", *changes[1].After)
+	require.Equal(t, "image", changes[2].Field)
+	require.Nil(t, changes[2].Before)
+	require.Equal(t, imageId, *changes[2].After)
+
+	// Identical states produce no changes
+	require.Empty(t, DiffTaskSnapshots(&after, &after))
+
+	// Archiving is recorded as archived: true
+	archived := after
+	archived.Archived = true
+	changes = DiffTaskSnapshots(&after, &archived)
+	require.Len(t, changes, 1)
+	require.Equal(t, "archived", changes[0].Field)
+	require.Nil(t, changes[0].Before)
+	require.Equal(t, "true", *changes[0].After)
+}
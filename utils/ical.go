@@ -0,0 +1,80 @@
+package utils
+
+import (
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// iCalLineLimit is the most octets of a content line before it is folded
+const iCalLineLimit = 75
+
+// ICalWriter writes iCalendar (RFC 5545) content lines, ended with CRLF and
+// folded at 75 octets. The first write error is kept and later writes are
+// skipped; Err returns it.
+type ICalWriter struct {
+	w   io.Writer
+	err error
+}
+
+func NewICalWriter(w io.Writer) *ICalWriter {
+	return &ICalWriter{w: w}
+}
+
+// Line writes a property whose value is already in iCalendar form. name may
+// carry parameters, as in "DTSTART;TZID=Asia/Bangkok".
+func (c *ICalWriter) Line(name string, value string) {
+	if c.err != nil {
+		return
+	}
+	_, c.err = io.WriteString(c.w, foldICalLine(name+":"+value))
+}
+
+// Text writes a property with a text value, escaping it
+func (c *ICalWriter) Text(name string, value string) {
+	c.Line(name, EscapeICalText(value))
+}
+
+func (c *ICalWriter) Err() error {
+	return c.err
+}
+
+// EscapeICalText escapes a TEXT value: backslashes, semicolons, commas and
+// line breaks
+func EscapeICalText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+		"\r", `\n`,
+	).Replace(s)
+}
+
+// FormatICalTime formats t as an iCalendar UTC date-time
+func FormatICalTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
+
+// foldICalLine ends the line with CRLF, breaking it with CRLF and a space
+// wherever it runs past the limit; a character is never split
+func foldICalLine(line string) string {
+	var b strings.Builder
+	limit := iCalLineLimit
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+		b.WriteString(line[:cut])
+		b.WriteString("\r\n ")
+		line = line[cut:]
+		// The space that starts a continuation counts towards its length
+		limit = iCalLineLimit - 1
+	}
+	b.WriteString(line)
+	b.WriteString("\r\n")
+	return b.String()
+}
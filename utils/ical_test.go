@@ -0,0 +1,39 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestICalWriter_Folding(t *testing.T) {
+	var buf bytes.Buffer
+	c := NewICalWriter(&buf)
+	c.Text("SUMMARY", strings.Repeat("a", 70)+"ééé"+strings.Repeat("b", 80))
+	assert.NoError(t, c.Err())
+
+	lines := strings.Split(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n")
+	assert.Len(t, lines, 3)
+	for i, line := range lines {
+		assert.LessOrEqual(t, len(line), 75)
+		if i > 0 {
+			assert.True(t, strings.HasPrefix(line, " "))
+		}
+	}
+
+	// Unfolding gives the line back, without splitting a character
+	unfolded := strings.ReplaceAll(strings.TrimSuffix(buf.String(), "\r\n"), "\r\n ", "")
+	assert.Equal(t, "SUMMARY:"+strings.Repeat("a", 70)+"ééé"+strings.Repeat("b", 80), unfolded)
+}
+
+func TestEscapeICalText(t *testing.T) {
+	assert.Equal(t, `a\\b\; c\, d\ne`, EscapeICalText("a\\b; c, d\r\ne"))
+}
+
+func TestFormatICalTime(t *testing.T) {
+	bangkok := time.FixedZone("ICT", 7*60*60)
+	assert.Equal(t, "20240105T020000Z", FormatICalTime(time.Date(2024, 1, 5, 9, 0, 0, 0, bangkok)))
+}
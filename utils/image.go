@@ -0,0 +1,315 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	xdraw "golang.org/x/image/draw"
+	"golang.org/x/image/webp"
+)
+
+// maxImagePixels guards against images that are small on disk but would
+// take gigabytes of memory to decode
+const maxImagePixels = 50_000_000
+
+// allowedImageTypes are the sniffed content types accepted as task images
+var allowedImageTypes = map[string]bool{
+	"image/png":  true,
+	"image/jpeg": true,
+	"image/gif":  true,
+	"image/webp": true,
+}
+
+// IsAllowedImageType reports whether a content type is accepted as a task image
+func IsAllowedImageType(contentType string) bool {
+	return allowedImageTypes[contentType]
+}
+
+// SanitizedImage is an uploaded image stripped of its metadata
+type SanitizedImage struct {
+	Data        []byte
+	ContentType string
+	// Image is the decoded picture; the first frame of an animation
+	Image image.Image
+}
+
+// SanitizeImage checks that data is a PNG, JPEG, GIF or WebP image and
+// returns it without metadata such as EXIF and GPS tags. PNG, JPEG and GIF
+// images are decoded and encoded again; a JPEG is turned upright first, as
+// its EXIF orientation goes with the rest. WebP cannot be encoded here, so
+// it is decoded to check it and its metadata chunks are cut out.
+func SanitizeImage(data []byte) (*SanitizedImage, error) {
+	contentType := http.DetectContentType(data)
+	if !IsAllowedImageType(contentType) {
+		return nil, constants.ErrUnsupportedImageType
+	}
+	if err := checkImageConfig(contentType, data); err != nil {
+		return nil, err
+	}
+
+	var (
+		img image.Image
+		buf bytes.Buffer
+		err error
+	)
+	switch contentType {
+	case "image/png":
+		if img, err = png.Decode(bytes.NewReader(data)); err != nil {
+			return nil, constants.ErrInvalidImage
+		}
+		err = png.Encode(&buf, img)
+	case "image/jpeg":
+		if img, err = jpeg.Decode(bytes.NewReader(data)); err != nil {
+			return nil, constants.ErrInvalidImage
+		}
+		img = orientImage(img, jpegOrientation(data))
+		err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
+	case "image/gif":
+		g, decodeErr := gif.DecodeAll(bytes.NewReader(data))
+		if decodeErr != nil || len(g.Image) == 0 {
+			return nil, constants.ErrInvalidImage
+		}
+		img = g.Image[0]
+		err = gif.EncodeAll(&buf, g)
+	case "image/webp":
+		if img, err = webp.Decode(bytes.NewReader(data)); err != nil {
+			return nil, constants.ErrInvalidImage
+		}
+		var stripped []byte
+		if stripped, err = stripWebPMetadata(data); err == nil {
+			buf.Write(stripped)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &SanitizedImage{Data: buf.Bytes(), ContentType: contentType, Image: img}, nil
+}
+
+// DecodeImage decodes an image of one of the allowed types
+func DecodeImage(data []byte) (image.Image, error) {
+	contentType := http.DetectContentType(data)
+	if !IsAllowedImageType(contentType) {
+		return nil, constants.ErrUnsupportedImageType
+	}
+	if err := checkImageConfig(contentType, data); err != nil {
+		return nil, err
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, constants.ErrInvalidImage
+	}
+	if contentType == "image/jpeg" {
+		img = orientImage(img, jpegOrientation(data))
+	}
+	return img, nil
+}
+
+// checkImageConfig reads the image header and rejects images too big to decode
+func checkImageConfig(contentType string, data []byte) error {
+	var (
+		config image.Config
+		err    error
+	)
+	r := bytes.NewReader(data)
+	switch contentType {
+	case "image/png":
+		config, err = png.DecodeConfig(r)
+	case "image/jpeg":
+		config, err = jpeg.DecodeConfig(r)
+	case "image/gif":
+		config, err = gif.DecodeConfig(r)
+	case "image/webp":
+		config, err = webp.DecodeConfig(r)
+	}
+	if err != nil || config.Width <= 0 || config.Height <= 0 {
+		return constants.ErrInvalidImage
+	}
+	if int64(config.Width)*int64(config.Height) > maxImagePixels {
+		return constants.ErrImageTooLarge
+	}
+	return nil
+}
+
+// Thumbnail scales img down so neither edge is longer than size pixels,
+// keeping its aspect ratio; smaller images are returned as they are
+func Thumbnail(img image.Image, size int) image.Image {
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w <= size && h <= size {
+		return img
+	}
+
+	tw, th := size, size
+	if w > h {
+		th = max(1, h*size/w)
+	} else {
+		tw = max(1, w*size/h)
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, tw, th))
+	xdraw.CatmullRom.Scale(dst, dst.Bounds(), img, b, xdraw.Src, nil)
+	return dst
+}
+
+// ThumbnailContentType is the type thumbnails of an image are encoded as:
+// JPEG for photos, PNG otherwise to keep transparency
+func ThumbnailContentType(contentType string) string {
+	if contentType == "image/jpeg" {
+		return "image/jpeg"
+	}
+	return "image/png"
+}
+
+// EncodeThumbnail encodes a thumbnail of an image of the given content type
+func EncodeThumbnail(w io.Writer, img image.Image, contentType string) error {
+	if ThumbnailContentType(contentType) == "image/jpeg" {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: 85})
+	}
+	return png.Encode(w, img)
+}
+
+// jpegOrientation reads the EXIF orientation of a JPEG; 1 is upright and
+// is also returned when there is none
+func jpegOrientation(data []byte) int {
+	// Walk the segments before the image data
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			break
+		}
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			break
+		}
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return exifOrientation(segment[6:])
+		}
+		i += 2 + length
+	}
+	return 1
+}
+
+// exifOrientation finds the Orientation tag in the first IFD of EXIF data
+func exifOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	ifd := int(order.Uint32(tiff[4:]))
+	if ifd < 8 || ifd+2 > len(tiff) {
+		return 1
+	}
+	entries := int(order.Uint16(tiff[ifd:]))
+	for k := 0; k < entries; k++ {
+		entry := ifd + 2 + k*12
+		if entry+12 > len(tiff) {
+			break
+		}
+		if order.Uint16(tiff[entry:]) == 0x0112 {
+			if v := int(order.Uint16(tiff[entry+8:])); v >= 1 && v <= 8 {
+				return v
+			}
+			break
+		}
+	}
+	return 1
+}
+
+// orientImage turns an image upright according to its EXIF orientation
+func orientImage(img image.Image, orientation int) image.Image {
+	if orientation <= 1 || orientation > 8 {
+		return img
+	}
+
+	b := img.Bounds()
+	w, h := b.Dx(), b.Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2: // mirrored
+				dx, dy = w-1-x, y
+			case 3: // upside down
+				dx, dy = w-1-x, h-1-y
+			case 4: // upside down, mirrored
+				dx, dy = x, h-1-y
+			case 5: // transposed
+				dx, dy = y, x
+			case 6: // rotated 90° counter-clockwise
+				dx, dy = h-1-y, x
+			case 7: // transversed
+				dx, dy = h-1-y, w-1-x
+			case 8: // rotated 90° clockwise
+				dx, dy = y, w-1-x
+			}
+			dst.Set(dx, dy, img.At(b.Min.X+x, b.Min.Y+y))
+		}
+	}
+	return dst
+}
+
+// stripWebPMetadata cuts the EXIF and XMP chunks out of a WebP file and
+// clears their flags in the extended header
+func stripWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, constants.ErrInvalidImage
+	}
+
+	out := make([]byte, 12, len(data))
+	copy(out, data[:12])
+	for i := 12; i < len(data); {
+		if i+8 > len(data) {
+			return nil, constants.ErrInvalidImage
+		}
+		id := string(data[i : i+4])
+		size := int(binary.LittleEndian.Uint32(data[i+4:]))
+		// Chunks are padded to an even size
+		end := i + 8 + size + size&1
+		if end > len(data) {
+			if i+8+size != len(data) {
+				return nil, constants.ErrInvalidImage
+			}
+			end = len(data)
+		}
+
+		switch id {
+		case "EXIF", "XMP ":
+		case "VP8X":
+			chunk := append([]byte(nil), data[i:end]...)
+			if len(chunk) > 8 {
+				chunk[8] &^= 0x08 | 0x04
+			}
+			out = append(out, chunk...)
+		default:
+			out = append(out, data[i:end]...)
+		}
+		i = end
+	}
+
+	binary.LittleEndian.PutUint32(out[4:], uint32(len(out)-8))
+	return out, nil
+}
@@ -0,0 +1,163 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/binary"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// withExif inserts an EXIF segment holding an orientation and a camera make
+// right after the start of a JPEG
+func withExif(data []byte, orientation uint16) []byte {
+	tiff := []byte("II*\x00\x08\x00\x00\x00")
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	// Orientation, SHORT
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x0112)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 3)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 1)
+	tiff = binary.LittleEndian.AppendUint32(tiff, uint32(orientation))
+	// Make, ASCII stored inline
+	tiff = binary.LittleEndian.AppendUint16(tiff, 0x010F)
+	tiff = binary.LittleEndian.AppendUint16(tiff, 2)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 4)
+	tiff = append(tiff, "Cam\x00"...)
+	tiff = binary.LittleEndian.AppendUint32(tiff, 0)
+
+	segment := append([]byte("Exif\x00\x00"), tiff...)
+	app1 := []byte{0xFF, 0xE1}
+	app1 = binary.BigEndian.AppendUint16(app1, uint16(len(segment)+2))
+	app1 = append(app1, segment...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, app1...)
+	return append(out, data[2:]...)
+}
+
+func TestSanitizeImage(t *testing.T) {
+	// A 40x20 JPEG whose left half is red, stored sideways
+	src := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	for y := 0; y < 20; y++ {
+		for x := 0; x < 40; x++ {
+			c := color.RGBA{B: 255, A: 255}
+			if x < 20 {
+				c = color.RGBA{R: 255, A: 255}
+			}
+			src.Set(x, y, c)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, jpeg.Encode(&buf, src, nil))
+	photo := withExif(buf.Bytes(), 6)
+
+	t.Run("jpeg is turned upright and loses its EXIF", func(t *testing.T) {
+		got, err := SanitizeImage(photo)
+		require.NoError(t, err)
+
+		assert.Equal(t, "image/jpeg", got.ContentType)
+		assert.NotContains(t, string(got.Data), "Exif")
+		assert.NotContains(t, string(got.Data), "Cam")
+		assert.Equal(t, image.Rect(0, 0, 20, 40), got.Image.Bounds())
+
+		// Rotated clockwise, the red half is now on top
+		r, _, b, _ := got.Image.At(10, 5).RGBA()
+		assert.Greater(t, r, b)
+	})
+
+	t.Run("png", func(t *testing.T) {
+		buf.Reset()
+		require.NoError(t, png.Encode(&buf, src))
+		got, err := SanitizeImage(buf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", got.ContentType)
+		assert.Equal(t, src.Bounds(), got.Image.Bounds())
+	})
+
+	t.Run("gif keeps its frames", func(t *testing.T) {
+		palette := color.Palette{color.Black, color.White}
+		frames := &gif.GIF{
+			Image: []*image.Paletted{image.NewPaletted(image.Rect(0, 0, 4, 4), palette), image.NewPaletted(image.Rect(0, 0, 4, 4), palette)},
+			Delay: []int{10, 10},
+		}
+		buf.Reset()
+		require.NoError(t, gif.EncodeAll(&buf, frames))
+
+		got, err := SanitizeImage(buf.Bytes())
+		require.NoError(t, err)
+		assert.Equal(t, "image/gif", got.ContentType)
+		decoded, err := gif.DecodeAll(bytes.NewReader(got.Data))
+		require.NoError(t, err)
+		assert.Len(t, decoded.Image, 2)
+	})
+
+	t.Run("rejected", func(t *testing.T) {
+		_, err := SanitizeImage([]byte("<html><body>hi</body></html>"))
+		assert.Equal(t, constants.ErrUnsupportedImageType, err)
+
+		_, err = SanitizeImage([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"))
+		assert.Equal(t, constants.ErrInvalidImage, err)
+
+		// A header claiming 100000x100000 pixels is refused before decoding
+		ihdr := []byte("IHDR")
+		ihdr = binary.BigEndian.AppendUint32(ihdr, 100000)
+		ihdr = binary.BigEndian.AppendUint32(ihdr, 100000)
+		ihdr = append(ihdr, 8, 2, 0, 0, 0)
+		huge := append([]byte("\x89PNG\r\n\x1a\n\x00\x00\x00\r"), ihdr...)
+		huge = binary.BigEndian.AppendUint32(huge, crc32.ChecksumIEEE(ihdr))
+		_, err = SanitizeImage(huge)
+		assert.Equal(t, constants.ErrImageTooLarge, err)
+	})
+}
+
+func TestStripWebPMetadata(t *testing.T) {
+	chunk := func(id string, data string) []byte {
+		out := append([]byte(id), binary.LittleEndian.AppendUint32(nil, uint32(len(data)))...)
+		out = append(out, data...)
+		if len(data)%2 == 1 {
+			out = append(out, 0)
+		}
+		return out
+	}
+	riff := func(chunks ...[]byte) []byte {
+		body := []byte("WEBP")
+		for _, c := range chunks {
+			body = append(body, c...)
+		}
+		return append(append([]byte("RIFF"), binary.LittleEndian.AppendUint32(nil, uint32(len(body)))...), body...)
+	}
+
+	// VP8X with the EXIF and XMP flags set
+	header := chunk("VP8X", "\x0C\x00\x00\x00\x00\x00\x00\x00\x00\x00")
+	pixels := chunk("VP8L", "pixels")
+	got, err := stripWebPMetadata(riff(header, pixels, chunk("EXIF", "gps"), chunk("XMP ", "<x/>")))
+	require.NoError(t, err)
+
+	assert.Equal(t, riff(chunk("VP8X", "\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00"), pixels), got)
+
+	_, err = stripWebPMetadata([]byte("RIFF\x00\x00\x00\x00WEBPVP8X\xFF"))
+	assert.Equal(t, constants.ErrInvalidImage, err)
+}
+
+func TestThumbnail(t *testing.T) {
+	wide := image.NewRGBA(image.Rect(0, 0, 600, 300))
+	assert.Equal(t, image.Rect(0, 0, 128, 64), Thumbnail(wide, 128).Bounds())
+
+	tall := image.NewRGBA(image.Rect(0, 0, 300, 600))
+	assert.Equal(t, image.Rect(0, 0, 256, 512), Thumbnail(tall, 512).Bounds())
+
+	// Small images are not scaled up
+	small := image.NewRGBA(image.Rect(0, 0, 50, 20))
+	assert.Same(t, small, Thumbnail(small, 128))
+
+	assert.Equal(t, "image/jpeg", ThumbnailContentType("image/jpeg"))
+	assert.Equal(t, "image/png", ThumbnailContentType("image/gif"))
+}
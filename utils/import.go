@@ -0,0 +1,436 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"strings"
+	"time"
+	"unicode"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/guncv/tech-exam-software-engineering/entities"
+)
+
+// ImportFields are the task fields an import file can set; a CSV column
+// mapping maps them to header names
+var ImportFields = []string{"title", "description", "status", "date", "tags"}
+
+// ImportRow is one task read from an import file. Row numbers it the way the
+// user sees the file: by line for CSV and by position in the array for JSON.
+type ImportRow struct {
+	Row  int
+	Task entities.CreateTaskRequest
+}
+
+// importDateLayouts are the local date-times accepted besides RFC 3339;
+// they are read in the importing user's time zone
+var importDateLayouts = []string{
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// importDayLayout is a date without a time, imported as an all-day task
+const importDayLayout = "2006-01-02"
+
+// trelloDoneLists are the list names whose cards are imported as completed
+var trelloDoneLists = map[string]bool{
+	"done":      true,
+	"complete":  true,
+	"completed": true,
+	"finished":  true,
+}
+
+// ParseImport reads the tasks of an import file. mapping only applies to CSV
+// and may be nil. Dates without a zone are read in loc. Rows are returned as
+// read; validating them is up to the caller. The file as a whole failing to
+// parse gives ErrInvalidImportFile.
+func ParseImport(format constants.ImportFormat, r io.Reader, mapping map[string]string, loc *time.Location) ([]ImportRow, error) {
+	switch format {
+	case constants.ImportFormatCSV:
+		return parseCSVImport(r, mapping, loc)
+	case constants.ImportFormatJSON:
+		return parseJSONImport(r, loc)
+	case constants.ImportFormatTodoist:
+		return parseTodoistImport(r, loc)
+	case constants.ImportFormatTrello:
+		return parseTrelloImport(r, loc)
+	}
+	return nil, constants.ErrInvalidImportFile
+}
+
+func parseCSVImport(r io.Reader, mapping map[string]string, loc *time.Location) ([]ImportRow, error) {
+	reader := newImportCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, constants.ErrInvalidImportFile
+	}
+
+	columns, err := csvImportColumns(header, mapping)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []ImportRow{}
+	err = readCSVRecords(reader, func(line int, record []string) {
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		task := entities.CreateTaskRequest{
+			Title:  field("title"),
+			Status: importStatus(field("status")),
+			Tags:   splitImportTags(field("tags")),
+		}
+		if description := field("description"); description != "" {
+			task.Description = &description
+		}
+		setImportDate(&task, field("date"), loc)
+
+		rows = append(rows, ImportRow{Row: line, Task: task})
+	})
+	return rows, err
+}
+
+// csvImportColumns finds the column of each task field. A field the mapping
+// leaves out is read from the column of the same name, if there is one.
+func csvImportColumns(header []string, mapping map[string]string) (map[string]int, error) {
+	index := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(headerName(name))
+		if _, ok := index[name]; !ok {
+			index[name] = i
+		}
+	}
+
+	columns := map[string]int{}
+	for _, field := range ImportFields {
+		if i, ok := index[field]; ok {
+			columns[field] = i
+		}
+	}
+	for field, name := range mapping {
+		if !isImportField(field) {
+			return nil, constants.ErrInvalidImportMapping
+		}
+		i, ok := index[strings.ToLower(strings.TrimSpace(name))]
+		if !ok {
+			return nil, constants.ErrInvalidImportMapping
+		}
+		columns[field] = i
+	}
+	return columns, nil
+}
+
+// importJSONTask is a task in our own JSON format, the one exports are
+// written in; fields other than these are ignored
+type importJSONTask struct {
+	Title       string   `json:"title"`
+	Description *string  `json:"description"`
+	Status      string   `json:"status"`
+	Date        string   `json:"date"`
+	AllDay      bool     `json:"all_day"`
+	Tags        []string `json:"tags"`
+}
+
+// parseJSONImport reads either an array of tasks or an object holding them
+// under "tasks"
+func parseJSONImport(r io.Reader, loc *time.Location) ([]ImportRow, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, constants.ErrInvalidImportFile
+	}
+
+	var tasks []importJSONTask
+	if trimmed := bytes.TrimSpace(data); len(trimmed) > 0 && trimmed[0] == '[' {
+		err = json.Unmarshal(trimmed, &tasks)
+	} else {
+		var wrapper struct {
+			Tasks *[]importJSONTask `json:"tasks"`
+		}
+		if err = json.Unmarshal(trimmed, &wrapper); err == nil && wrapper.Tasks == nil {
+			err = errors.New("no tasks")
+		} else if err == nil {
+			tasks = *wrapper.Tasks
+		}
+	}
+	if err != nil {
+		return nil, constants.ErrInvalidImportFile
+	}
+
+	rows := make([]ImportRow, 0, len(tasks))
+	for i, t := range tasks {
+		task := entities.CreateTaskRequest{
+			Title:       strings.TrimSpace(t.Title),
+			Description: t.Description,
+			Status:      importStatus(t.Status),
+			Tags:        t.Tags,
+		}
+		if setImportDate(&task, t.Date, loc) && t.AllDay {
+			task.Date, task.AllDay = AllDayDate(task.Date), true
+		}
+
+		rows = append(rows, ImportRow{Row: i + 1, Task: task})
+	}
+	return rows, nil
+}
+
+// parseTodoistImport reads the CSV a Todoist project is exported as. Only
+// its tasks are imported; sections, notes and blank lines are skipped. Labels
+// written into the content as @label become tags. Todoist dates are often
+// free text such as "every monday"; a task whose date cannot be read is
+// dated now.
+func parseTodoistImport(r io.Reader, loc *time.Location) ([]ImportRow, error) {
+	reader := newImportCSVReader(r)
+	header, err := reader.Read()
+	if err != nil {
+		return nil, constants.ErrInvalidImportFile
+	}
+
+	columns := map[string]int{}
+	for i, name := range header {
+		columns[strings.ToUpper(headerName(name))] = i
+	}
+	if _, ok := columns["TYPE"]; !ok {
+		return nil, constants.ErrInvalidImportFile
+	}
+	if _, ok := columns["CONTENT"]; !ok {
+		return nil, constants.ErrInvalidImportFile
+	}
+
+	now := time.Now()
+	rows := []ImportRow{}
+	err = readCSVRecords(reader, func(line int, record []string) {
+		field := func(name string) string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+		if !strings.EqualFold(field("TYPE"), "task") {
+			return
+		}
+
+		var words, labels []string
+		for _, word := range strings.Fields(field("CONTENT")) {
+			if len(word) > 1 && word[0] == '@' {
+				labels = append(labels, word[1:])
+				continue
+			}
+			words = append(words, word)
+		}
+
+		task := entities.CreateTaskRequest{
+			Title:  strings.Join(words, " "),
+			Status: constants.TaskStatusPending,
+			Tags:   importLabelTags(labels),
+		}
+		if description := field("DESCRIPTION"); description != "" {
+			task.Description = &description
+		}
+		if !setImportDate(&task, field("DATE"), loc) {
+			task.Date = now
+		}
+
+		rows = append(rows, ImportRow{Row: line, Task: task})
+	})
+	return rows, err
+}
+
+// trelloExport is the part of a Trello board export that is imported
+type trelloExport struct {
+	Cards *[]struct {
+		Name             string  `json:"name"`
+		Desc             string  `json:"desc"`
+		Closed           bool    `json:"closed"`
+		Due              *string `json:"due"`
+		DueComplete      bool    `json:"dueComplete"`
+		IDList           string  `json:"idList"`
+		DateLastActivity string  `json:"dateLastActivity"`
+		Labels           []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	} `json:"cards"`
+	Lists []struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Closed bool   `json:"closed"`
+	} `json:"lists"`
+}
+
+// parseTrelloImport reads the JSON a Trello board is exported as. Archived
+// cards, and cards of archived lists, are skipped. A card is completed when
+// its due date is marked complete or it sits in a list named like "Done". It
+// is dated by its due date, else by its last activity.
+func parseTrelloImport(r io.Reader, loc *time.Location) ([]ImportRow, error) {
+	var export trelloExport
+	if err := json.NewDecoder(r).Decode(&export); err != nil || export.Cards == nil {
+		return nil, constants.ErrInvalidImportFile
+	}
+
+	closedLists := map[string]bool{}
+	doneLists := map[string]bool{}
+	for _, list := range export.Lists {
+		closedLists[list.ID] = list.Closed
+		doneLists[list.ID] = trelloDoneLists[strings.ToLower(strings.TrimSpace(list.Name))]
+	}
+
+	now := time.Now()
+	rows := []ImportRow{}
+	for i, card := range *export.Cards {
+		if card.Closed || closedLists[card.IDList] {
+			continue
+		}
+
+		task := entities.CreateTaskRequest{
+			Title:  strings.TrimSpace(card.Name),
+			Status: constants.TaskStatusPending,
+		}
+		if card.DueComplete || doneLists[card.IDList] {
+			task.Status = constants.TaskStatusCompleted
+		}
+		if description := strings.TrimSpace(card.Desc); description != "" {
+			task.Description = &description
+		}
+
+		dated := card.Due != nil && setImportDate(&task, *card.Due, loc)
+		if !dated && !setImportDate(&task, card.DateLastActivity, loc) {
+			task.Date = now
+		}
+
+		labels := make([]string, 0, len(card.Labels))
+		for _, label := range card.Labels {
+			labels = append(labels, label.Name)
+		}
+		task.Tags = importLabelTags(labels)
+
+		rows = append(rows, ImportRow{Row: i + 1, Task: task})
+	}
+	return rows, nil
+}
+
+// ParseImportDate reads an imported date given in RFC 3339 or as a local
+// date-time in loc. A date without a time is all-day, stored as such. ok is
+// false for an empty or unreadable date.
+func ParseImportDate(s string, loc *time.Location) (t time.Time, allDay bool, ok bool) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, false, false
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, true
+	}
+	for _, layout := range importDateLayouts {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, false, true
+		}
+	}
+	if t, err := time.Parse(importDayLayout, s); err == nil {
+		return t, true, true
+	}
+	return time.Time{}, false, false
+}
+
+// setImportDate dates the task by s, if it can be read
+func setImportDate(task *entities.CreateTaskRequest, s string, loc *time.Location) bool {
+	t, allDay, ok := ParseImportDate(s, loc)
+	if ok {
+		task.Date, task.AllDay = t, allDay
+	}
+	return ok
+}
+
+func newImportCSVReader(r io.Reader) *csv.Reader {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	return reader
+}
+
+// readCSVRecords calls fn with every non-blank record after the header and
+// the line it starts on
+func readCSVRecords(reader *csv.Reader, fn func(line int, record []string)) error {
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return constants.ErrInvalidImportFile
+		}
+		if isBlankRecord(record) {
+			continue
+		}
+		line, _ := reader.FieldPos(0)
+		fn(line, record)
+	}
+}
+
+// headerName trims a CSV header cell, including the byte order mark that
+// spreadsheet apps put before the first one
+func headerName(s string) string {
+	return strings.TrimSpace(strings.TrimPrefix(s, "\ufeff"))
+}
+
+func isBlankRecord(record []string) bool {
+	for _, value := range record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func isImportField(field string) bool {
+	for _, f := range ImportFields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+// importStatus accepts a status in any case and with spaces or hyphens for
+// underscores, as spreadsheets tend to have it
+func importStatus(s string) constants.TaskStatus {
+	s = strings.ToUpper(strings.TrimSpace(s))
+	s = strings.NewReplacer(" ", "_", "-", "_").Replace(s)
+	return constants.TaskStatus(s)
+}
+
+// splitImportTags reads the comma separated tags of a CSV cell
+func splitImportTags(s string) []string {
+	var tags []string
+	for _, tag := range strings.Split(s, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// importLabelTags turns the free-form labels of another app into tags:
+// spaces become '-' and other characters a tag cannot hold are dropped, as
+// are labels left empty or too long
+func importLabelTags(labels []string) []string {
+	var tags []string
+	for _, label := range labels {
+		var b strings.Builder
+		for _, r := range strings.Join(strings.Fields(label), "-") {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '-' || r == '_' {
+				b.WriteRune(r)
+			}
+		}
+		if tag, ok := NormalizeTag(b.String()); ok {
+			tags = append(tags, tag)
+		}
+	}
+	return NormalizeTags(tags)
+}
@@ -0,0 +1,184 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseImport_CSV(t *testing.T) {
+	file := "\ufeffTitle,Status,Date,Tags,Description\n" +
+		"Write report,in progress,2024-01-05,\"work, urgent\",Quarterly\n" +
+		"\n" +
+		"Ship,COMPLETED,2024-01-06T10:00:00Z,,\n" +
+		",DONE,yesterday,,\n"
+
+	rows, err := ParseImport(constants.ImportFormatCSV, strings.NewReader(file), nil, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 2, rows[0].Row)
+	assert.Equal(t, "Write report", rows[0].Task.Title)
+	assert.Equal(t, constants.TaskStatusPending, rows[0].Task.Status)
+	assert.True(t, rows[0].Task.AllDay)
+	assert.True(t, rows[0].Task.Date.Equal(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"work", "urgent"}, rows[0].Task.Tags)
+	assert.Equal(t, "Quarterly", *rows[0].Task.Description)
+
+	assert.Equal(t, 4, rows[1].Row)
+	assert.Nil(t, rows[1].Task.Description)
+	assert.False(t, rows[1].Task.AllDay)
+	assert.True(t, rows[1].Task.Date.Equal(time.Date(2024, 1, 6, 10, 0, 0, 0, time.UTC)))
+
+	// Rows are returned as read, for the caller to validate
+	assert.Equal(t, 5, rows[2].Row)
+	assert.NotNil(t, ValidateCreateTaskInput(rows[2].Task))
+}
+
+func TestParseImport_CSVMapping(t *testing.T) {
+	file := "Name,State,Due\nTask,COMPLETED,2024-01-05\n"
+
+	rows, err := ParseImport(constants.ImportFormatCSV, strings.NewReader(file), map[string]string{
+		"title":  "name",
+		"status": "State",
+		"date":   "DUE",
+	}, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "Task", rows[0].Task.Title)
+	assert.Equal(t, constants.TaskStatusCompleted, rows[0].Task.Status)
+	assert.Nil(t, ValidateCreateTaskInput(rows[0].Task))
+
+	_, err = ParseImport(constants.ImportFormatCSV, strings.NewReader(file), map[string]string{"title": "missing"}, time.UTC)
+	assert.Equal(t, constants.ErrInvalidImportMapping, err)
+
+	_, err = ParseImport(constants.ImportFormatCSV, strings.NewReader(file), map[string]string{"owner": "Name"}, time.UTC)
+	assert.Equal(t, constants.ErrInvalidImportMapping, err)
+}
+
+func TestParseImport_JSON(t *testing.T) {
+	testCases := []struct {
+		name string
+		file string
+		err  error
+	}{
+		{name: "Array", file: `[{"id": "x", "title": "Task", "status": "COMPLETED", "date": "2024-01-05T00:00:00Z", "tags": ["work"]}]`},
+		{name: "Object", file: `{"tasks": [{"title": "Task", "status": "COMPLETED", "date": "2024-01-05T00:00:00Z", "tags": ["work"]}]}`},
+		{name: "NoTasks", file: `{"cards": []}`, err: constants.ErrInvalidImportFile},
+		{name: "Malformed", file: `[{"title": 1}]`, err: constants.ErrInvalidImportFile},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			rows, err := ParseImport(constants.ImportFormatJSON, strings.NewReader(tc.file), nil, time.UTC)
+
+			if tc.err != nil {
+				assert.Equal(t, tc.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, rows, 1)
+			assert.Equal(t, 1, rows[0].Row)
+			assert.Equal(t, "Task", rows[0].Task.Title)
+			assert.Equal(t, []string{"work"}, rows[0].Task.Tags)
+			assert.Nil(t, ValidateCreateTaskInput(rows[0].Task))
+		})
+	}
+}
+
+func TestParseImport_Todoist(t *testing.T) {
+	file := "TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE\n" +
+		"section,Inbox,,,,,,,,\n" +
+		"task,Buy milk @errands @Home,2 litres,4,1,Me,,2024-01-05,en,Asia/Bangkok\n" +
+		",,,,,,,,,\n" +
+		"task,Call mum,,1,1,Me,,every sunday,en,Asia/Bangkok\n"
+
+	rows, err := ParseImport(constants.ImportFormatTodoist, strings.NewReader(file), nil, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, rows, 2)
+
+	assert.Equal(t, 3, rows[0].Row)
+	assert.Equal(t, "Buy milk", rows[0].Task.Title)
+	assert.Equal(t, []string{"errands", "home"}, rows[0].Task.Tags)
+	assert.Equal(t, "2 litres", *rows[0].Task.Description)
+	assert.Nil(t, ValidateCreateTaskInput(rows[0].Task))
+
+	// A recurring date cannot be read, so the task is dated now
+	assert.Equal(t, 5, rows[1].Row)
+	assert.WithinDuration(t, time.Now(), rows[1].Task.Date, time.Minute)
+	assert.Nil(t, ValidateCreateTaskInput(rows[1].Task))
+
+	_, err = ParseImport(constants.ImportFormatTodoist, strings.NewReader("Title,Status\n"), nil, time.UTC)
+	assert.Equal(t, constants.ErrInvalidImportFile, err)
+}
+
+func TestParseImport_Trello(t *testing.T) {
+	file := `{
+		"lists": [
+			{"id": "l1", "name": "To Do"},
+			{"id": "l2", "name": "Done"},
+			{"id": "l3", "name": "Old", "closed": true}
+		],
+		"cards": [
+			{"name": "Plan", "desc": "Next sprint", "idList": "l1", "due": "2024-01-05T09:00:00.000Z", "labels": [{"name": "High Priority!"}]},
+			{"name": "Archived", "idList": "l1", "closed": true},
+			{"name": "Shipped", "idList": "l2", "dateLastActivity": "2024-01-03T00:00:00.000Z"},
+			{"name": "Forgotten", "idList": "l3"},
+			{"name": "Checked", "idList": "l1", "due": "2024-01-04T00:00:00.000Z", "dueComplete": true}
+		]
+	}`
+
+	rows, err := ParseImport(constants.ImportFormatTrello, strings.NewReader(file), nil, time.UTC)
+	require.NoError(t, err)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, 1, rows[0].Row)
+	assert.Equal(t, "Plan", rows[0].Task.Title)
+	assert.Equal(t, constants.TaskStatusPending, rows[0].Task.Status)
+	assert.True(t, rows[0].Task.Date.Equal(time.Date(2024, 1, 5, 9, 0, 0, 0, time.UTC)))
+	assert.Equal(t, []string{"high-priority"}, rows[0].Task.Tags)
+
+	assert.Equal(t, 3, rows[1].Row)
+	assert.Equal(t, constants.TaskStatusCompleted, rows[1].Task.Status)
+	assert.True(t, rows[1].Task.Date.Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)))
+
+	assert.Equal(t, 5, rows[2].Row)
+	assert.Equal(t, constants.TaskStatusCompleted, rows[2].Task.Status)
+
+	for _, row := range rows {
+		assert.Nil(t, ValidateCreateTaskInput(row.Task))
+	}
+
+	_, err = ParseImport(constants.ImportFormatTrello, strings.NewReader(`[]`), nil, time.UTC)
+	assert.Equal(t, constants.ErrInvalidImportFile, err)
+}
+
+func TestParseImportDate(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	testCases := []struct {
+		value  string
+		want   time.Time
+		allDay bool
+		ok     bool
+	}{
+		{value: "2024-01-05T10:00:00+07:00", want: time.Date(2024, 1, 5, 3, 0, 0, 0, time.UTC), ok: true},
+		{value: "2024-01-05 10:30", want: time.Date(2024, 1, 5, 10, 30, 0, 0, berlin), ok: true},
+		// A day alone is all-day, whatever the zone
+		{value: " 2024-01-05 ", want: time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), allDay: true, ok: true},
+		{value: ""},
+		{value: "05/01/2024"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, allDay, ok := ParseImportDate(tc.value, berlin)
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.allDay, allDay)
+			assert.True(t, tc.want.Equal(got))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"bytes"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/extension"
+)
+
+// MaxNoteTitleLength bounds note titles and the [[title]] links pointing at them
+const MaxNoteTitleLength = 200
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.GFM))
+
+	// Markdown may carry raw HTML, so the rendered output is always sanitized
+	markdownPolicy = bluemonday.UGCPolicy()
+
+	// [[Title]] or [[Title|label]]
+	noteLinkPattern = regexp.MustCompile(`\[\[([^\[\]|\n]+)(?:\|[^\[\]\n]*)?\]\]`)
+)
+
+// RenderMarkdown converts a Markdown body to sanitized HTML
+func RenderMarkdown(src string) (string, error) {
+	var buf bytes.Buffer
+	if err := markdown.Convert([]byte(src), &buf); err != nil {
+		return "", err
+	}
+	return markdownPolicy.Sanitize(buf.String()), nil
+}
+
+// ExtractNoteLinks returns the distinct note titles linked from body with
+// [[title]], normalized with NormalizeNoteTitle, in order of appearance
+func ExtractNoteLinks(body string) []string {
+	links := []string{}
+	seen := map[string]bool{}
+	for _, m := range noteLinkPattern.FindAllStringSubmatch(body, -1) {
+		title := NormalizeNoteTitle(m[1])
+		if title == "" || len(title) > MaxNoteTitleLength || seen[title] {
+			continue
+		}
+		seen[title] = true
+		links = append(links, title)
+	}
+	return links
+}
+
+// NormalizeNoteTitle is the form titles are matched in: links resolve
+// regardless of case and surrounding whitespace
+func NormalizeNoteTitle(title string) string {
+	return strings.ToLower(strings.TrimSpace(title))
+}
@@ -0,0 +1,74 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		src      string
+		contains []string
+		excludes []string
+	}{
+		{
+			name:     "Markdown",
+			src:      "# Title\n\n**bold** and a [link](https://example.com)",
+			contains: []string{"<h1", "<strong>bold</strong>", `href="https://example.com"`},
+		},
+		{
+			name:     "TaskList",
+			src:      "- [x] done\n- [ ] todo",
+			contains: []string{"<li>", "done", "todo"},
+		},
+		{
+			name:     "ScriptIsStripped",
+			src:      "hello <script>alert(1)</script>",
+			contains: []string{"hello"},
+			excludes: []string{"<script"},
+		},
+		{
+			name:     "JavascriptLinkIsStripped",
+			src:      "[click](javascript:alert(1))",
+			excludes: []string{"javascript:"},
+		},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			got, err := RenderMarkdown(tC.src)
+
+			require.NoError(t, err)
+			for _, s := range tC.contains {
+				assert.Contains(t, got, s)
+			}
+			for _, s := range tC.excludes {
+				assert.NotContains(t, got, s)
+			}
+		})
+	}
+}
+
+func TestExtractNoteLinks(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+		want []string
+	}{
+		{name: "NoLinks", body: "plain text", want: []string{}},
+		{name: "Single", body: "see [[Meeting Notes]]", want: []string{"meeting notes"}},
+		{name: "Label", body: "see [[Roadmap|the plan]]", want: []string{"roadmap"}},
+		{name: "Duplicates", body: "[[A]] then [[ a ]] and [[B]]", want: []string{"a", "b"}},
+		{name: "Empty", body: "[[ ]] and [[]]", want: []string{}},
+		{name: "Unclosed", body: "[[broken", want: []string{}},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			assert.Equal(t, tC.want, ExtractNoteLinks(tC.body))
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package utils
+
+import (
+	"strconv"
+	"strings"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+)
+
+// ByteRange is a part of a resource of Size bytes, from Start for Length bytes
+type ByteRange struct {
+	Start  int64
+	Length int64
+	Size   int64
+}
+
+// ContentRange formats the range for a Content-Range header
+func (r ByteRange) ContentRange() string {
+	return "bytes " + strconv.FormatInt(r.Start, 10) + "-" + strconv.FormatInt(r.Start+r.Length-1, 10) + "/" + strconv.FormatInt(r.Size, 10)
+}
+
+// ParseRange returns the part of a resource of size bytes that a Range
+// header asks for, or nil for the whole resource. Only a single byte range
+// is served; other ranges, and an If-Range that does not hold the
+// resource's strong tag, get the whole resource (RFC 9110). A range that
+// starts past the end fails with ErrRangeNotSatisfiable.
+func ParseRange(header string, ifRange string, etag string, size int64) (*ByteRange, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || !strings.HasPrefix(header, "bytes=") {
+		return nil, nil
+	}
+	if ifRange = strings.TrimSpace(ifRange); ifRange != "" && ifRange != etag {
+		return nil, nil
+	}
+
+	spec := strings.TrimSpace(strings.TrimPrefix(header, "bytes="))
+	if strings.Contains(spec, ",") {
+		return nil, nil
+	}
+	first, last, ok := strings.Cut(spec, "-")
+	if !ok {
+		return nil, nil
+	}
+	first, last = strings.TrimSpace(first), strings.TrimSpace(last)
+
+	// A suffix range asks for the last bytes
+	if first == "" {
+		n, err := strconv.ParseInt(last, 10, 64)
+		if err != nil || n < 0 {
+			return nil, nil
+		}
+		if n == 0 || size == 0 {
+			return nil, constants.ErrRangeNotSatisfiable
+		}
+		if n > size {
+			n = size
+		}
+		return &ByteRange{Start: size - n, Length: n, Size: size}, nil
+	}
+
+	start, err := strconv.ParseInt(first, 10, 64)
+	if err != nil || start < 0 {
+		return nil, nil
+	}
+	end := size - 1
+	if last != "" {
+		if end, err = strconv.ParseInt(last, 10, 64); err != nil || end < start {
+			return nil, nil
+		}
+		if end > size-1 {
+			end = size - 1
+		}
+	}
+	if start >= size {
+		return nil, constants.ErrRangeNotSatisfiable
+	}
+
+	return &ByteRange{Start: start, Length: end - start + 1, Size: size}, nil
+}
@@ -0,0 +1,43 @@
+package utils
+
+import (
+	"testing"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRange(t *testing.T) {
+	etag := `"abc"`
+	testCases := []struct {
+		name    string
+		header  string
+		ifRange string
+		want    *ByteRange
+		wantErr error
+	}{
+		{name: "NoHeader"},
+		{name: "Bounded", header: "bytes=0-9", want: &ByteRange{Start: 0, Length: 10, Size: 100}},
+		{name: "OpenEnded", header: "bytes=90-", want: &ByteRange{Start: 90, Length: 10, Size: 100}},
+		{name: "EndPastSize", header: "bytes=50-500", want: &ByteRange{Start: 50, Length: 50, Size: 100}},
+		{name: "Suffix", header: "bytes=-5", want: &ByteRange{Start: 95, Length: 5, Size: 100}},
+		{name: "SuffixLongerThanSize", header: "bytes=-500", want: &ByteRange{Start: 0, Length: 100, Size: 100}},
+		{name: "StartPastSize", header: "bytes=100-", wantErr: constants.ErrRangeNotSatisfiable},
+		{name: "EmptySuffix", header: "bytes=-0", wantErr: constants.ErrRangeNotSatisfiable},
+		{name: "MultipleRangesServeWhole", header: "bytes=0-1,5-6"},
+		{name: "OtherUnitServesWhole", header: "items=0-1"},
+		{name: "MalformedServesWhole", header: "bytes=9-1"},
+		{name: "IfRangeMatches", header: "bytes=0-0", ifRange: etag, want: &ByteRange{Start: 0, Length: 1, Size: 100}},
+		{name: "IfRangeChangedServesWhole", header: "bytes=0-0", ifRange: `"old"`},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			got, err := ParseRange(tC.header, tC.ifRange, etag, 100)
+			assert.Equal(t, tC.wantErr, err)
+			assert.Equal(t, tC.want, got)
+		})
+	}
+
+	assert.Equal(t, "bytes 95-99/100", ByteRange{Start: 95, Length: 5, Size: 100}.ContentRange())
+}
@@ -0,0 +1,87 @@
+package utils
+
+import "strings"
+
+// Ranks are base-62 fractions written as strings and compared byte-wise
+// (tasks.rank uses COLLATE "C"), so there is always room for a rank between
+// two others by extending the string. Ranks never end in '0', which keeps
+// every rank distinct from its own prefix.
+const (
+	rankAlphabet  = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+	rankBase      = len(rankAlphabet)
+	MaxRankLength = 32
+)
+
+// RankBetween returns a rank that sorts strictly between prev and next. An
+// empty prev means the start of the column and an empty next its end. ok is
+// false when the bounds are out of order or the result would exceed
+// MaxRankLength, in which case the column needs to be rebalanced.
+func RankBetween(prev, next string) (rank string, ok bool) {
+	if next != "" && prev >= next {
+		return "", false
+	}
+
+	out := make([]byte, 0, MaxRankLength)
+	open := next == "" // no upper bound left from this digit on
+	for i := 0; i < MaxRankLength; i++ {
+		lo := rankDigit(prev, i)
+		hi := rankBase
+		if !open {
+			hi = rankDigit(next, i)
+		}
+		if lo < 0 || hi < 0 {
+			return "", false
+		}
+
+		if mid := (lo + hi) / 2; mid > lo {
+			return string(append(out, rankAlphabet[mid])), true
+		}
+
+		// The bounds differ by at most one here: keep prev's digit and, once
+		// it is below next's, anything after it is below next as well
+		out = append(out, rankAlphabet[lo])
+		if hi > lo {
+			open = true
+		}
+	}
+
+	return "", false
+}
+
+// RankSequence returns n evenly spaced ranks in ascending order, used to
+// (re)assign every rank of a column at once
+func RankSequence(n int) []string {
+	if n <= 0 {
+		return nil
+	}
+
+	// Leave roughly a full digit of room between neighbours
+	width, span := 1, rankBase
+	for span < (n+1)*rankBase {
+		width++
+		span *= rankBase
+	}
+	step := span / (n + 1)
+
+	ranks := make([]string, n)
+	digits := make([]byte, width)
+	for i := range ranks {
+		v := (i + 1) * step
+		for j := width - 1; j >= 0; j-- {
+			digits[j] = rankAlphabet[v%rankBase]
+			v /= rankBase
+		}
+		ranks[i] = strings.TrimRight(string(digits), "0")
+	}
+
+	return ranks
+}
+
+// rankDigit returns the value of the i-th digit of rank, 0 past its end and
+// -1 for a character outside the alphabet
+func rankDigit(rank string, i int) int {
+	if i >= len(rank) {
+		return 0
+	}
+	return strings.IndexByte(rankAlphabet, rank[i])
+}
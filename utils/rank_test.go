@@ -0,0 +1,83 @@
+package utils
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRankBetween(t *testing.T) {
+	testCases := []struct {
+		name string
+		prev string
+		next string
+		ok   bool
+	}{
+		{name: "EmptyColumn", prev: "", next: "", ok: true},
+		{name: "Start", prev: "", next: "V", ok: true},
+		{name: "End", prev: "V", next: "", ok: true},
+		{name: "Middle", prev: "A", next: "z", ok: true},
+		{name: "Adjacent", prev: "A", next: "B", ok: true},
+		{name: "Prefix", prev: "A", next: "A1", ok: true},
+		{name: "TopOfAlphabet", prev: "zzz", next: "", ok: true},
+		{name: "BottomOfAlphabet", prev: "", next: "001", ok: true},
+		{name: "Equal", prev: "A", next: "A", ok: false},
+		{name: "OutOfOrder", prev: "B", next: "A", ok: false},
+		{name: "NoRoom", prev: "", next: "0", ok: false},
+		{name: "InvalidCharacter", prev: "A-", next: "B", ok: false},
+	}
+
+	for _, tC := range testCases {
+		t.Run(tC.name, func(t *testing.T) {
+			got, ok := RankBetween(tC.prev, tC.next)
+
+			assert.Equal(t, tC.ok, ok)
+			if !tC.ok {
+				return
+			}
+			assert.Greater(t, got, tC.prev)
+			if tC.next != "" {
+				assert.Less(t, got, tC.next)
+			}
+			assert.False(t, strings.HasSuffix(got, "0"))
+		})
+	}
+}
+
+func TestRankBetween_RepeatedInsertsRunOutOfRoom(t *testing.T) {
+	// Always inserting right after the same rank halves the gap each time
+	prev, next := "A", "B"
+	for i := 0; ; i++ {
+		rank, ok := RankBetween(prev, next)
+		if !ok {
+			assert.Greater(t, i, 100)
+			return
+		}
+		require.LessOrEqual(t, len(rank), MaxRankLength)
+		require.True(t, prev < rank && rank < next)
+		next = rank
+	}
+}
+
+func TestRankSequence(t *testing.T) {
+	for _, n := range []int{1, 2, 61, 62, 1000} {
+		ranks := RankSequence(n)
+
+		require.Len(t, ranks, n)
+		assert.True(t, sort.StringsAreSorted(ranks))
+		for i, r := range ranks {
+			assert.NotEmpty(t, r)
+			assert.False(t, strings.HasSuffix(r, "0"))
+			if i > 0 {
+				assert.NotEqual(t, ranks[i-1], r)
+				_, ok := RankBetween(ranks[i-1], r)
+				assert.True(t, ok)
+			}
+		}
+	}
+
+	assert.Nil(t, RankSequence(0))
+}
@@ -0,0 +1,29 @@
+package utils
+
+import (
+	"html"
+	"strings"
+)
+
+// Highlight delimiters handed to ts_headline. Private-use code points do not
+// occur in ordinary text, so the snippet can be HTML-escaped first and the
+// delimiters turned into <mark> tags afterwards.
+const (
+	HighlightStart = "\ue000"
+	HighlightStop  = "\ue001"
+)
+
+// TitleHighlightOptions keeps the whole title; DescriptionHighlightOptions
+// cuts the description down to the fragments around the matches
+var (
+	TitleHighlightOptions       = `StartSel="` + HighlightStart + `", StopSel="` + HighlightStop + `", HighlightAll=true`
+	DescriptionHighlightOptions = `StartSel="` + HighlightStart + `", StopSel="` + HighlightStop + `", MaxFragments=2, MaxWords=30, MinWords=10, FragmentDelimiter=" ... "`
+)
+
+var highlightReplacer = strings.NewReplacer(HighlightStart, "<mark>", HighlightStop, "</mark>")
+
+// RenderHighlight escapes a ts_headline snippet for HTML and wraps the matched
+// words in <mark> tags
+func RenderHighlight(s string) string {
+	return highlightReplacer.Replace(html.EscapeString(s))
+}
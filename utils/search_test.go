@@ -0,0 +1,37 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderHighlight(t *testing.T) {
+	testCases := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "NoMatch",
+			in:   "Weekly sync",
+			want: "Weekly sync",
+		},
+		{
+			name: "MarksMatches",
+			in:   "Plan the " + HighlightStart + "release" + HighlightStop + " and " + HighlightStart + "releases" + HighlightStop,
+			want: "Plan the <mark>release</mark> and <mark>releases</mark>",
+		},
+		{
+			name: "EscapesMarkup",
+			in:   "<b>" + HighlightStart + "bold" + HighlightStop + "</b> & more",
+			want: "&lt;b&gt;<mark>bold</mark>&lt;/b&gt; &amp; more",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, RenderHighlight(tc.in))
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/guncv/tech-exam-software-engineering/config"
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseSignedURL splits a signed link into its path and grant
+func parseSignedURL(t *testing.T, link string) (string, *URLGrant) {
+	u, err := url.Parse(link)
+	require.NoError(t, err)
+	query := u.Query()
+	expires, err := strconv.ParseInt(query.Get("exp"), 10, 64)
+	require.NoError(t, err)
+
+	return u.Path, &URLGrant{
+		UserID:     query.Get("uid"),
+		Permission: constants.TaskPermission(query.Get("perm")),
+		Expires:    expires,
+		Signature:  query.Get("sig"),
+	}
+}
+
+func TestURLSigner(t *testing.T) {
+	cfg := &config.Config{TokenConfig: config.TokenConfig{TokenSymmetricKey: "12345678901234567890123456789012"}}
+	signer := NewURLSigner(cfg)
+	path := "/api/v1/files/tasks/1/attachments/2/content"
+
+	link := signer.Sign(path, "7", constants.TaskPermissionViewer)
+	assert.True(t, strings.HasPrefix(link, path+"?"))
+
+	gotPath, grant := parseSignedURL(t, link)
+	assert.Equal(t, path, gotPath)
+	assert.Equal(t, "7", grant.UserID)
+	assert.Equal(t, constants.TaskPermissionViewer, grant.Permission)
+	assert.InDelta(t, time.Now().Add(defaultSignedURLTTL).Unix(), grant.Expires, 61)
+	assert.NoError(t, signer.Verify(path, grant))
+
+	// The same link is handed out within the minute
+	assert.Equal(t, link, signer.Sign(path, "7", constants.TaskPermissionViewer))
+
+	// Another path, user, permission or expiry breaks the signature
+	assert.Equal(t, constants.ErrInvalidSignedURL, signer.Verify("/api/v1/files/tasks/1/attachments/3/content", grant))
+	for _, change := range []func(g URLGrant) URLGrant{
+		func(g URLGrant) URLGrant { g.UserID = "8"; return g },
+		func(g URLGrant) URLGrant { g.Permission = constants.TaskPermissionOwner; return g },
+		func(g URLGrant) URLGrant { g.Expires += 3600; return g },
+		func(g URLGrant) URLGrant { g.Signature = "not base64!"; return g },
+	} {
+		changed := change(*grant)
+		assert.Equal(t, constants.ErrInvalidSignedURL, signer.Verify(path, &changed))
+	}
+
+	// A link signed with another key is rejected
+	other := NewURLSigner(&config.Config{TokenConfig: config.TokenConfig{TokenSymmetricKey: "abcdefghijabcdefghijabcdefghijab"}})
+	assert.Equal(t, constants.ErrInvalidSignedURL, other.Verify(path, grant))
+
+	// An expired link is rejected even though its signature holds
+	expired := &URLSigner{key: signer.(*URLSigner).key, ttl: -time.Minute}
+	_, grant = parseSignedURL(t, expired.Sign(path, "7", constants.TaskPermissionViewer))
+	assert.Equal(t, constants.ErrSignedURLExpired, signer.Verify(path, grant))
+}
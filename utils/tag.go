@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Limits on the tags of a single task
+const (
+	MaxTaskTags  = 20
+	MaxTagLength = 30
+)
+
+// NormalizeTag lowercases and trims a tag. ok is false when the tag is empty,
+// too long, or holds anything other than letters, digits, '-' and '_'.
+func NormalizeTag(s string) (tag string, ok bool) {
+	tag = strings.ToLower(strings.TrimSpace(s))
+	if tag == "" || utf8.RuneCountInString(tag) > MaxTagLength {
+		return "", false
+	}
+	for _, r := range tag {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return "", false
+		}
+	}
+	return tag, true
+}
+
+// IsValidTagInput reports whether a submitted tag is acceptable; an empty
+// value is allowed so that a form can send tags= to clear them
+func IsValidTagInput(s string) bool {
+	if strings.TrimSpace(s) == "" {
+		return true
+	}
+	_, ok := NormalizeTag(s)
+	return ok
+}
+
+// NormalizeTags normalizes the tags and drops empty, invalid and repeated
+// ones, keeping the first occurrence order
+func NormalizeTags(tags []string) []string {
+	normalized := []string{}
+	seen := map[string]bool{}
+	for _, t := range tags {
+		tag, ok := NormalizeTag(t)
+		if !ok || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
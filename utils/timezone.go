@@ -0,0 +1,99 @@
+package utils
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	// Zones are looked up by name for every user, so the binary carries the
+	// time zone database rather than relying on the host's
+	_ "time/tzdata"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+)
+
+// maxTimezoneLength bounds a zone name; the longest IANA names are about 30
+const maxTimezoneLength = 64
+
+var (
+	defaultLocation     *time.Location
+	defaultLocationOnce sync.Once
+)
+
+// LoadTimezone loads an IANA time zone such as "Europe/Berlin" or "UTC".
+// The server's own "Local" zone and the empty name are not accepted.
+func LoadTimezone(name string) (*time.Location, error) {
+	if name == "" || name == "Local" || len(name) > maxTimezoneLength {
+		return nil, constants.ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, constants.ErrInvalidTimezone
+	}
+	return loc, nil
+}
+
+// IsValidTimezone reports whether name is a time zone LoadTimezone accepts
+func IsValidTimezone(name string) bool {
+	_, err := LoadTimezone(name)
+	return err == nil
+}
+
+// DefaultLocation is the time zone of users who have not picked one
+func DefaultLocation() *time.Location {
+	defaultLocationOnce.Do(func() {
+		loc, err := time.LoadLocation(constants.DefaultTimeZone)
+		if err != nil {
+			loc = time.UTC
+		}
+		defaultLocation = loc
+	})
+	return defaultLocation
+}
+
+// WithLocation returns a copy of ctx whose times are shown in loc
+func WithLocation(ctx context.Context, loc *time.Location) context.Context {
+	return context.WithValue(ctx, constants.TimeLocationKey, loc)
+}
+
+// LocationFromContext returns the time zone the request's times are shown
+// and read in, or the default zone when none was set
+func LocationFromContext(ctx context.Context) *time.Location {
+	if loc, ok := ctx.Value(constants.TimeLocationKey).(*time.Location); ok && loc != nil {
+		return loc
+	}
+	return DefaultLocation()
+}
+
+// FormatRFC3339 formats t for a response, in the request's time zone
+func FormatRFC3339(ctx context.Context, t time.Time) string {
+	return formatRFC3339(t.In(LocationFromContext(ctx)))
+}
+
+// FormatTaskDate formats the date of a task for a response. An all-day date
+// is midnight of its day in the request's time zone, so it is the same day
+// wherever it is read.
+func FormatTaskDate(ctx context.Context, date time.Time, allDay bool) string {
+	loc := LocationFromContext(ctx)
+	if allDay {
+		return formatRFC3339(LocalAllDay(date, loc))
+	}
+	return formatRFC3339(date.In(loc))
+}
+
+// AllDayDate is how the date of an all-day task is stored: midnight UTC of
+// the day t falls on where it was written
+func AllDayDate(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+// LocalAllDay returns a stored all-day date as midnight of that day in loc
+func LocalAllDay(date time.Time, loc *time.Location) time.Time {
+	y, m, d := date.UTC().Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, loc)
+}
+
+func formatRFC3339(t time.Time) string {
+	return t.Format("2006-01-02T15:04:05.000Z07:00")
+}
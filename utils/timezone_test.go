@@ -0,0 +1,70 @@
+package utils
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadTimezone(t *testing.T) {
+	testCases := []struct {
+		name    string
+		wantErr bool
+	}{
+		{name: "Europe/Berlin"},
+		{name: "UTC"},
+		{name: "America/Argentina/Buenos_Aires"},
+		{name: "", wantErr: true},
+		{name: "Local", wantErr: true},
+		{name: "Mars/Olympus_Mons", wantErr: true},
+		{name: "../../etc/passwd", wantErr: true},
+		{name: strings.Repeat("a", 65), wantErr: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			loc, err := LoadTimezone(tc.name)
+			if tc.wantErr {
+				assert.Equal(t, constants.ErrInvalidTimezone, err)
+				assert.False(t, IsValidTimezone(tc.name))
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.name, loc.String())
+		})
+	}
+}
+
+func TestLocationFromContext(t *testing.T) {
+	assert.Equal(t, constants.DefaultTimeZone, LocationFromContext(context.Background()).String())
+
+	berlin, err := LoadTimezone("Europe/Berlin")
+	require.NoError(t, err)
+	ctx := WithLocation(context.Background(), berlin)
+	assert.Equal(t, berlin, LocationFromContext(ctx))
+
+	instant := time.Date(2024, 1, 5, 9, 30, 0, 0, time.UTC)
+	assert.Equal(t, "2024-01-05T10:30:00.000+01:00", FormatRFC3339(ctx, instant))
+	assert.Equal(t, "2024-01-05T16:30:00.000+07:00", FormatRFC3339(context.Background(), instant))
+}
+
+func TestFormatTaskDate(t *testing.T) {
+	newYork, err := LoadTimezone("America/New_York")
+	require.NoError(t, err)
+	tokyo, err := LoadTimezone("Asia/Tokyo")
+	require.NoError(t, err)
+
+	// Written as the 5th in Tokyo, an all-day date is the 5th everywhere
+	date := AllDayDate(time.Date(2024, 1, 5, 0, 0, 0, 0, tokyo))
+	assert.Equal(t, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC), date)
+	assert.Equal(t, "2024-01-05T00:00:00.000-05:00", FormatTaskDate(WithLocation(context.Background(), newYork), date, true))
+	assert.Equal(t, "2024-01-05T00:00:00.000+09:00", FormatTaskDate(WithLocation(context.Background(), tokyo), date, true))
+
+	// A timed date is the same instant everywhere
+	assert.Equal(t, "2024-01-04T19:00:00.000-05:00", FormatTaskDate(WithLocation(context.Background(), newYork), date, false))
+}
@@ -1,9 +1,11 @@
 package utils
 
 import (
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	constants "github.com/guncv/tech-exam-software-engineering/constant"
 	"github.com/guncv/tech-exam-software-engineering/entities"
 )
@@ -29,6 +31,14 @@ func ValidateCreateTaskInput(input entities.CreateTaskRequest) interface{} {
 		errs = append(errs, newFieldError("date", "Date is required and must be RFC3339 format"))
 	}
 
+	errs = append(errs, imageIDErrors(input.Image != nil, input.ImageID)...)
+
+	if isInvalidOptionalUUID(input.AssigneeID) {
+		errs = append(errs, newFieldError("assignee_id", "Assignee id must be a valid UUID"))
+	}
+
+	errs = append(errs, tagErrors(input.Tags)...)
+
 	return returnIfErrors(errs)
 }
 
@@ -43,6 +53,64 @@ func ValidateUpdateTaskInput(input entities.UpdateTaskRequest) interface{} {
 		errs = append(errs, newFieldError("status", "Status must be IN_PROGRESS or COMPLETED"))
 	}
 
+	errs = append(errs, imageIDErrors(input.Image != nil, input.ImageID)...)
+
+	if isInvalidOptionalUUID(input.AssigneeID) {
+		errs = append(errs, newFieldError("assignee_id", "Assignee id must be a valid UUID"))
+	}
+
+	errs = append(errs, tagErrors(input.Tags)...)
+
+	return returnIfErrors(errs)
+}
+
+func ValidatePatchTaskInput(input entities.PatchTaskRequest) interface{} {
+	var errs []FieldError
+
+	if input.Title.Null {
+		errs = append(errs, newFieldError("title", "Title cannot be null"))
+	} else if input.Title.Set && isEmpty(input.Title.Value) {
+		errs = append(errs, newFieldError("title", "Title must not be empty"))
+	} else if exceedsMaxLength(input.Title.Value, 100) {
+		errs = append(errs, newFieldError("title", "Title must not exceed 100 characters"))
+	}
+
+	if input.Status.Null {
+		errs = append(errs, newFieldError("status", "Status cannot be null"))
+	} else if input.Status.Set && isInvalidStatus(input.Status.Value) {
+		errs = append(errs, newFieldError("status", "Status must be IN_PROGRESS or COMPLETED"))
+	}
+
+	if input.Date.Null || (input.Date.Set && isZeroTime(input.Date.Value)) {
+		errs = append(errs, newFieldError("date", "Date cannot be null and must be RFC3339 format"))
+	}
+
+	if input.AllDay.Null {
+		errs = append(errs, newFieldError("all_day", "All day cannot be null"))
+	}
+
+	// Images are uploaded with a form; a patch can only remove one, or pick
+	// one of the task's attachments in image_id
+	if input.Image.Set && !input.Image.Null {
+		errs = append(errs, newFieldError("image", "Image can only be set to null; set image_id to an image attachment instead"))
+	}
+
+	if input.ImageID.Set && !input.ImageID.Null {
+		if input.Image.Set {
+			errs = append(errs, newFieldError("image_id", "Image id cannot be sent with image"))
+		} else if _, err := uuid.Parse(input.ImageID.Value); err != nil {
+			errs = append(errs, newFieldError("image_id", "Image id must be a valid UUID"))
+		}
+	}
+
+	if input.AssigneeID.Set && !input.AssigneeID.Null {
+		if _, err := uuid.Parse(input.AssigneeID.Value); err != nil {
+			errs = append(errs, newFieldError("assignee_id", "Assignee id must be a valid UUID"))
+		}
+	}
+
+	errs = append(errs, tagErrors(input.Tags.Value)...)
+
 	return returnIfErrors(errs)
 }
 
@@ -87,6 +155,20 @@ func ValidateRegisterInput(input entities.RegisterRequest) interface{} {
 		errs = append(errs, newFieldError("password", "Password must be at least 8 characters"))
 	}
 
+	if input.Timezone != nil && !IsValidTimezone(*input.Timezone) {
+		errs = append(errs, newFieldError("timezone", "Timezone must be an IANA time zone name"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateUpdateUserPreferencesInput(input entities.UpdateUserPreferencesRequest) interface{} {
+	var errs []FieldError
+
+	if input.Timezone != nil && !IsValidTimezone(*input.Timezone) {
+		errs = append(errs, newFieldError("timezone", "Timezone must be an IANA time zone name"))
+	}
+
 	return returnIfErrors(errs)
 }
 
@@ -101,16 +183,28 @@ func ValidateGetAllTasksInput(input entities.GetAllTasksRequest) interface{} {
 		errs = append(errs, newFieldError("order", "Order must be asc or desc"))
 	}
 
+	if err, ok := filterError(input.Filter); ok {
+		errs = append(errs, err)
+	}
+
 	if isInvalidSortBy(input.SortBy) {
-		errs = append(errs, newFieldError("sort_by", "Sort by must be title, created_at, or status"))
+		errs = append(errs, newFieldError("sort_by", "Sort by must be title, created_at, status, rank, date, or relevance"))
+	}
+
+	if !IsValidAssigneeFilter(input.Assignee) {
+		errs = append(errs, newFieldError("assignee", "Assignee must be me, unassigned, or a user id"))
 	}
 
 	if input.Limit < 1 {
 		errs = append(errs, newFieldError("limit", "Limit must be greater than 0"))
 	}
 
-	if input.Offset < 1 {
-		errs = append(errs, newFieldError("offset", "Offset must be greater than 0"))
+	if input.Offset < 0 {
+		errs = append(errs, newFieldError("offset", "Offset must not be negative"))
+	}
+
+	if exceedsMaxLength(input.Cursor, 1000) {
+		errs = append(errs, newFieldError("cursor", "Cursor must not exceed 1000 characters"))
 	}
 
 	if input.Limit > 100 {
@@ -120,6 +214,452 @@ func ValidateGetAllTasksInput(input entities.GetAllTasksRequest) interface{} {
 	return returnIfErrors(errs)
 }
 
+func ValidateCommentInput(body string) interface{} {
+	var errs []FieldError
+
+	if isEmpty(body) {
+		errs = append(errs, newFieldError("body", "Body is required"))
+	} else if exceedsMaxLength(body, 5000) {
+		errs = append(errs, newFieldError("body", "Body must not exceed 5000 characters"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateGetCommentsInput(input entities.GetCommentsRequest) interface{} {
+	return returnIfErrors(paginationErrors(input.Limit, input.Offset))
+}
+
+func ValidateUploadAttachmentInput(input entities.UploadAttachmentRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.Filename) {
+		errs = append(errs, newFieldError("filename", "Filename is required"))
+	} else if exceedsMaxLength(input.Filename, 255) {
+		errs = append(errs, newFieldError("filename", "Filename must not exceed 255 characters"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateImportTasksInput(input entities.ImportTasksRequest) interface{} {
+	var errs []FieldError
+
+	if input.File == nil {
+		errs = append(errs, newFieldError("file", "File is required"))
+	}
+
+	if isEmpty(string(input.Format)) {
+		errs = append(errs, newFieldError("format", "Format is required"))
+	} else {
+		switch input.Format {
+		case constants.ImportFormatCSV, constants.ImportFormatJSON, constants.ImportFormatTodoist, constants.ImportFormatTrello:
+		default:
+			errs = append(errs, newFieldError("format", "Format must be csv, json, todoist or trello"))
+		}
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateExportTasksInput(input entities.ExportTasksRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(string(input.Format)) {
+		errs = append(errs, newFieldError("format", "Format is required"))
+	} else {
+		switch input.Format {
+		case constants.ExportFormatCSV, constants.ExportFormatJSON, constants.ExportFormatMarkdown, constants.ExportFormatICal:
+		default:
+			errs = append(errs, newFieldError("format", "Format must be csv, json, md or ics"))
+		}
+	}
+
+	switch input.Images {
+	case "", constants.ExportImagesNone, constants.ExportImagesLink, constants.ExportImagesEmbed:
+	default:
+		errs = append(errs, newFieldError("images", "Images must be none, link or embed"))
+	}
+
+	if exceedsMaxLength(input.Search, 100) {
+		errs = append(errs, newFieldError("search", "Search must not exceed 100 characters"))
+	}
+
+	if isInvalidOrder(input.Order) {
+		errs = append(errs, newFieldError("order", "Order must be asc or desc"))
+	}
+
+	if err, ok := filterError(input.Filter); ok {
+		errs = append(errs, err)
+	}
+
+	if isInvalidSortBy(input.SortBy) {
+		errs = append(errs, newFieldError("sort_by", "Sort by must be title, created_at, status, rank, date, or relevance"))
+	}
+
+	if !IsValidAssigneeFilter(input.Assignee) {
+		errs = append(errs, newFieldError("assignee", "Assignee must be me, unassigned, or a user id"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateGetCalendarFeedContentInput(input entities.GetCalendarFeedContentRequest) interface{} {
+	var errs []FieldError
+
+	switch input.Type {
+	case "", constants.CalendarComponentEvent, constants.CalendarComponentTodo:
+	default:
+		errs = append(errs, newFieldError("type", "Type must be event or todo"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateShareTaskInput(input entities.ShareTaskRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.Email) {
+		errs = append(errs, newFieldError("email", "Email is required"))
+	} else if isInvalidEmail(input.Email) {
+		errs = append(errs, newFieldError("email", "Email is invalid"))
+	}
+
+	if isEmpty(input.Permission) {
+		errs = append(errs, newFieldError("permission", "Permission is required"))
+	} else if input.Permission != "viewer" && input.Permission != "editor" {
+		errs = append(errs, newFieldError("permission", "Permission must be viewer or editor"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateGetSharedTasksInput(input entities.GetSharedTasksRequest) interface{} {
+	return returnIfErrors(paginationErrors(input.Limit, input.Offset))
+}
+
+func ValidateAddTaskDependencyInput(input entities.AddTaskDependencyRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.BlockedByID) {
+		errs = append(errs, newFieldError("blocked_by_id", "Blocked by id is required"))
+	} else if isInvalidOptionalUUID(&input.BlockedByID) {
+		errs = append(errs, newFieldError("blocked_by_id", "Blocked by id must be a valid UUID"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateMoveTaskInput(input entities.MoveTaskRequest) interface{} {
+	var errs []FieldError
+
+	if !isEmpty(string(input.Status)) && isInvalidStatus(input.Status) {
+		errs = append(errs, newFieldError("status", "Status must be IN_PROGRESS or COMPLETED"))
+	}
+
+	if isInvalidOptionalUUID(&input.BeforeID) {
+		errs = append(errs, newFieldError("before_id", "Before id must be a valid UUID"))
+	}
+
+	if isInvalidOptionalUUID(&input.AfterID) {
+		errs = append(errs, newFieldError("after_id", "After id must be a valid UUID"))
+	}
+
+	if !isEmpty(input.BeforeID) && !isEmpty(input.AfterID) {
+		errs = append(errs, newFieldError("before_id", "Only one of before_id or after_id can be set"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateBulkTaskInput(input entities.BulkTaskRequest) interface{} {
+	var errs []FieldError
+
+	if len(input.IDs) == 0 && isEmpty(input.Filter) {
+		errs = append(errs, newFieldError("ids", "Either ids or filter is required"))
+	} else if len(input.IDs) > 0 && !isEmpty(input.Filter) {
+		errs = append(errs, newFieldError("ids", "Only one of ids or filter can be set"))
+	}
+
+	if len(input.IDs) > constants.MaxBulkTasks {
+		errs = append(errs, newFieldError("ids", "At most 500 ids can be sent"))
+	}
+
+	for _, id := range input.IDs {
+		if _, err := uuid.Parse(id); err != nil {
+			errs = append(errs, newFieldError("ids", "Ids must be valid UUIDs"))
+			break
+		}
+	}
+
+	if err, ok := filterError(input.Filter); ok {
+		errs = append(errs, err)
+	}
+
+	switch input.Action {
+	case constants.TaskBulkActionSetStatus:
+		if isInvalidStatus(input.Status) {
+			errs = append(errs, newFieldError("status", "Status must be IN_PROGRESS or COMPLETED"))
+		}
+	case constants.TaskBulkActionAddTag, constants.TaskBulkActionRemoveTag:
+		if isEmpty(input.Tag) || !IsValidTagInput(input.Tag) {
+			errs = append(errs, newFieldError("tag", "Tag must be up to 30 letters, digits, - or _"))
+		}
+	case constants.TaskBulkActionArchive, constants.TaskBulkActionUnarchive, constants.TaskBulkActionDelete:
+	default:
+		errs = append(errs, newFieldError("action", "Action must be set_status, add_tag, remove_tag, archive, unarchive or delete"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateCreateNoteInput(input entities.CreateNoteRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.Title) {
+		errs = append(errs, newFieldError("title", "Title is required"))
+	} else if exceedsMaxLength(input.Title, MaxNoteTitleLength) {
+		errs = append(errs, newFieldError("title", "Title must not exceed 200 characters"))
+	}
+
+	if isInvalidOptionalUUID(input.TaskID) {
+		errs = append(errs, newFieldError("task_id", "Task id must be a valid UUID"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateUpdateNoteInput(input entities.UpdateNoteRequest) interface{} {
+	var errs []FieldError
+
+	if !isEmpty(input.Title) && exceedsMaxLength(input.Title, MaxNoteTitleLength) {
+		errs = append(errs, newFieldError("title", "Title must not exceed 200 characters"))
+	}
+
+	if isInvalidOptionalUUID(input.TaskID) {
+		errs = append(errs, newFieldError("task_id", "Task id must be a valid UUID"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateGetNoteInput(input entities.GetNoteRequest) interface{} {
+	var errs []FieldError
+
+	if input.Format != "" && input.Format != "markdown" && input.Format != "html" {
+		errs = append(errs, newFieldError("format", "Format must be markdown or html"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateGetNotesInput(input entities.GetNotesRequest) interface{} {
+	var errs []FieldError
+
+	if exceedsMaxLength(input.Search, 100) {
+		errs = append(errs, newFieldError("search", "Search must not exceed 100 characters"))
+	}
+
+	if isInvalidOptionalUUID(&input.TaskID) {
+		errs = append(errs, newFieldError("task_id", "Task id must be a valid UUID"))
+	}
+
+	return returnIfErrors(append(errs, paginationErrors(input.Limit, input.Offset)...))
+}
+
+func ValidateCreateViewInput(input entities.CreateViewRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.Name) {
+		errs = append(errs, newFieldError("name", "Name is required"))
+	} else if exceedsMaxLength(input.Name, 100) {
+		errs = append(errs, newFieldError("name", "Name must not exceed 100 characters"))
+	}
+
+	return returnIfErrors(append(errs, viewErrors(input.Filter, input.SortBy, input.Order, input.GroupBy)...))
+}
+
+func ValidateUpdateViewInput(input entities.UpdateViewRequest) interface{} {
+	var errs []FieldError
+
+	if !isEmpty(input.Name) && exceedsMaxLength(input.Name, 100) {
+		errs = append(errs, newFieldError("name", "Name must not exceed 100 characters"))
+	}
+
+	filter := ""
+	if input.Filter != nil {
+		filter = *input.Filter
+	}
+
+	return returnIfErrors(append(errs, viewErrors(filter, input.SortBy, input.Order, input.GroupBy)...))
+}
+
+func ValidateGetViewTasksInput(input entities.GetViewTasksRequest) interface{} {
+	return returnIfErrors(paginationErrors(input.Limit, input.Offset))
+}
+
+func ValidateDiffRevisionsInput(input entities.DiffRevisionsRequest) interface{} {
+	var errs []FieldError
+
+	if input.From < 1 {
+		errs = append(errs, newFieldError("from", "From must be a version greater than 0"))
+	}
+
+	if input.To < 1 {
+		errs = append(errs, newFieldError("to", "To must be a version greater than 0"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateGetNotificationsInput(input entities.GetNotificationsRequest) interface{} {
+	return returnIfErrors(paginationErrors(input.Limit, input.Offset))
+}
+
+func ValidateCreateWorkspaceInput(input entities.CreateWorkspaceRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.Name) {
+		errs = append(errs, newFieldError("name", "Name is required"))
+	} else if exceedsMaxLength(input.Name, 100) {
+		errs = append(errs, newFieldError("name", "Name must not exceed 100 characters"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateUpdateWorkspaceMemberInput(input entities.UpdateWorkspaceMemberRequest) interface{} {
+	var errs []FieldError
+
+	if err := workspaceRoleError(input.Role); err != nil {
+		errs = append(errs, err)
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateCreateInvitationInput(input entities.CreateInvitationRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.Email) {
+		errs = append(errs, newFieldError("email", "Email is required"))
+	} else if isInvalidEmail(input.Email) {
+		errs = append(errs, newFieldError("email", "Email is invalid"))
+	}
+
+	if err := workspaceRoleError(input.Role); err != nil {
+		errs = append(errs, err)
+	}
+
+	return returnIfErrors(errs)
+}
+
+func ValidateAcceptInvitationInput(input entities.AcceptInvitationRequest) interface{} {
+	var errs []FieldError
+
+	if isEmpty(input.Token) {
+		errs = append(errs, newFieldError("token", "Token is required"))
+	}
+
+	return returnIfErrors(errs)
+}
+
+func workspaceRoleError(role string) FieldError {
+	if isEmpty(role) {
+		return newFieldError("role", "Role is required")
+	}
+	if role != "admin" && role != "member" {
+		return newFieldError("role", "Role must be admin or member")
+	}
+	return nil
+}
+
+func paginationErrors(limit, offset int) []FieldError {
+	var errs []FieldError
+
+	if limit < 1 {
+		errs = append(errs, newFieldError("limit", "Limit must be greater than 0"))
+	}
+
+	if offset < 1 {
+		errs = append(errs, newFieldError("offset", "Offset must not be negative"))
+	}
+
+	if limit > 100 {
+		errs = append(errs, newFieldError("limit", "Limit must not exceed 100"))
+	}
+
+	return errs
+}
+
+// IsValidAssigneeFilter reports whether s is empty, me, unassigned or a user id
+func IsValidAssigneeFilter(s string) bool {
+	if s == "" || s == constants.AssigneeFilterMe || s == constants.AssigneeFilterUnassigned {
+		return true
+	}
+	_, err := uuid.Parse(s)
+	return err == nil
+}
+
+func viewErrors(filter, sortBy, order string, groupBy constants.ViewGroupBy) []FieldError {
+	var errs []FieldError
+
+	if err, ok := filterError(filter); ok {
+		errs = append(errs, err)
+	}
+
+	if sortBy == "relevance" || isInvalidSortBy(sortBy) {
+		errs = append(errs, newFieldError("sort_by", "Sort by must be title, created_at, status, rank, or date"))
+	}
+
+	if isInvalidOrder(order) {
+		errs = append(errs, newFieldError("order", "Order must be asc or desc"))
+	}
+
+	switch groupBy {
+	case "", constants.ViewGroupByNone, constants.ViewGroupByStatus, constants.ViewGroupByAssignee, constants.ViewGroupByDue:
+	default:
+		errs = append(errs, newFieldError("group_by", "Group by must be none, status, assignee, or due"))
+	}
+
+	return errs
+}
+
+func tagErrors(tags []string) []FieldError {
+	var errs []FieldError
+
+	if len(tags) > MaxTaskTags {
+		errs = append(errs, newFieldError("tags", "A task can have at most 20 tags"))
+	}
+
+	for _, tag := range tags {
+		if !IsValidTagInput(tag) {
+			errs = append(errs, newFieldError("tags", "Tags must be up to 30 letters, digits, - or _"))
+			break
+		}
+	}
+
+	return errs
+}
+
+// filterError reports a malformed filter expression along with the
+// position of the problem
+func filterError(filter string) (FieldError, bool) {
+	if exceedsMaxLength(filter, 500) {
+		return newFieldError("filter", "Filter must not exceed 500 characters"), true
+	}
+
+	_, err := ParseTaskFilter(filter)
+	filterErr, ok := err.(*FilterError)
+	if !ok {
+		return nil, false
+	}
+
+	fieldErr := newFieldError("filter", filterErr.Error())
+	fieldErr["position"] = strconv.Itoa(filterErr.Position)
+	return fieldErr, true
+}
+
 func newFieldError(field, message string) FieldError {
 	return FieldError{"field": field, "message": message}
 }
@@ -166,5 +706,27 @@ func isInvalidSortBy(s string) bool {
 	if s == "" {
 		return false
 	}
-	return s != "title" && s != "created_at" && s != "status"
+	return s != "title" && s != "created_at" && s != "status" && s != "rank" && s != "date" && s != "relevance"
+}
+
+// imageIDErrors checks an image_id sent in place of an image file
+func imageIDErrors(hasImage bool, imageId *string) []FieldError {
+	if imageId == nil {
+		return nil
+	}
+	if hasImage {
+		return []FieldError{newFieldError("image_id", "Image id cannot be sent with an image file")}
+	}
+	if isInvalidOptionalUUID(imageId) {
+		return []FieldError{newFieldError("image_id", "Image id must be a valid UUID")}
+	}
+	return nil
+}
+
+func isInvalidOptionalUUID(s *string) bool {
+	if s == nil || *s == "" {
+		return false
+	}
+	_, err := uuid.Parse(*s)
+	return err != nil
 }
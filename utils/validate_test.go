@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/guncv/tech-exam-software-engineering/entities"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidatePatchTaskInput(t *testing.T) {
+	testCases := []struct {
+		name   string
+		body   string
+		fields []string
+	}{
+		{name: "Empty", body: `{}`},
+		{name: "ClearOptionalFields", body: `{"description": null, "assignee_id": null, "tags": null, "image": null}`},
+		{name: "SetFields", body: `{"title": "Task", "status": "COMPLETED", "date": "2024-01-01T00:00:00Z", "tags": ["work"]}`},
+		{name: "NullRequiredFields", body: `{"title": null, "status": null, "date": null}`, fields: []string{"title", "status", "date"}},
+		{name: "BlankTitle", body: `{"title": "  "}`, fields: []string{"title"}},
+		{name: "InvalidStatus", body: `{"status": "DONE"}`, fields: []string{"status"}},
+		{name: "InvalidAssignee", body: `{"assignee_id": "me"}`, fields: []string{"assignee_id"}},
+		{name: "InvalidTag", body: `{"tags": ["no spaces"]}`, fields: []string{"tags"}},
+		{name: "SetImage", body: `{"image": "photo.png"}`, fields: []string{"image"}},
+		{name: "SetImageID", body: `{"image_id": "550e8400-e29b-41d4-a716-446655440000"}`},
+		{name: "ClearImageID", body: `{"image_id": null}`},
+		{name: "InvalidImageID", body: `{"image_id": "photo.png"}`, fields: []string{"image_id"}},
+		{name: "ImageIDWithImage", body: `{"image": null, "image_id": "550e8400-e29b-41d4-a716-446655440000"}`, fields: []string{"image_id"}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var req entities.PatchTaskRequest
+			assert.NoError(t, json.Unmarshal([]byte(tc.body), &req))
+
+			got := ValidatePatchTaskInput(req)
+
+			if tc.fields == nil {
+				assert.Nil(t, got)
+				return
+			}
+			var fields []string
+			for _, e := range got.([]FieldError) {
+				fields = append(fields, e["field"])
+			}
+			assert.Equal(t, tc.fields, fields)
+		})
+	}
+}
+
+func TestPatchField(t *testing.T) {
+	var req entities.PatchTaskRequest
+	assert.NoError(t, json.Unmarshal([]byte(`{"title": "Task", "description": null}`), &req))
+
+	assert.Equal(t, entities.PatchField[string]{Set: true, Value: "Task"}, req.Title)
+	assert.Equal(t, entities.PatchField[string]{Set: true, Null: true}, req.Description)
+	assert.False(t, req.Status.Set)
+}
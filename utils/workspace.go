@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+)
+
+// WithWorkspace returns a copy of ctx scoped to the workspace and the caller's role in it
+func WithWorkspace(ctx context.Context, workspaceId string, role constants.WorkspaceRole) context.Context {
+	ctx = context.WithValue(ctx, constants.WorkspaceIDKey, workspaceId)
+	return context.WithValue(ctx, constants.WorkspaceRoleKey, role)
+}
+
+// WorkspaceFromContext returns the workspace the request is scoped to.
+// ok is false for personal requests made without a workspace.
+func WorkspaceFromContext(ctx context.Context) (workspaceId string, role constants.WorkspaceRole, ok bool) {
+	workspaceId, ok = ctx.Value(constants.WorkspaceIDKey).(string)
+	if !ok || workspaceId == "" {
+		return "", "", false
+	}
+
+	role, _ = ctx.Value(constants.WorkspaceRoleKey).(constants.WorkspaceRole)
+	return workspaceId, role, true
+}
+
+// NewInvitationToken returns a random URL-safe invitation token and its hash.
+// Only the hash is stored; the token is handed to the invitee once.
+func NewInvitationToken() (token string, hash string, err error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+
+	token = base64.RawURLEncoding.EncodeToString(buf)
+	return token, HashInvitationToken(token), nil
+}
+
+// HashInvitationToken returns the hex SHA-256 of an invitation token
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
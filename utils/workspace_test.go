@@ -0,0 +1,33 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	constants "github.com/guncv/tech-exam-software-engineering/constant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkspaceFromContext(t *testing.T) {
+	_, _, ok := WorkspaceFromContext(context.Background())
+	assert.False(t, ok)
+
+	ctx := WithWorkspace(context.Background(), "ws-1", constants.WorkspaceRoleAdmin)
+	workspaceId, role, ok := WorkspaceFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "ws-1", workspaceId)
+	assert.Equal(t, constants.WorkspaceRoleAdmin, role)
+}
+
+func TestNewInvitationToken(t *testing.T) {
+	token, hash, err := NewInvitationToken()
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.Len(t, hash, 64)
+	assert.Equal(t, hash, HashInvitationToken(token))
+
+	other, _, err := NewInvitationToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, other)
+}